@@ -5,7 +5,9 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/event"
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
 	"github.com/hashicorp/go-rootcerts"
 )
 
@@ -61,17 +64,32 @@ const (
 type API struct {
 	store   *event.Store
 	drivers *driver.Drivers
+	deps    *templates.DependencyTracker
+	conf    *config.Config
 	port    int
+	address string
 	version string
 	srv     *http.Server
 	tls     *config.CTSTLSConfig
+	socket  *config.UnixSocketConfig
 }
 
 type APIConfig struct {
-	Store   *event.Store
-	Drivers *driver.Drivers
-	Port    int
-	TLS     *config.CTSTLSConfig
+	Store        *event.Store
+	Drivers      *driver.Drivers
+	Dependencies *templates.DependencyTracker
+	Port         int
+	TLS          *config.CTSTLSConfig
+
+	// Config is the configuration of CTS, which is served with sensitive
+	// information redacted by the debug endpoint
+	Config *config.Config
+
+	// Address is the address of a Unix domain socket for the API to listen
+	// on, e.g. unix:///var/run/cts.sock. When configured, the API only listens
+	// on a TCP port if Port is also configured.
+	Address    string
+	UnixSocket *config.UnixSocketConfig
 }
 
 // NewAPI create a new API object
@@ -82,8 +100,12 @@ func NewAPI(conf *APIConfig) (*API, error) {
 		port:    conf.Port,
 		drivers: conf.Drivers,
 		store:   conf.Store,
+		deps:    conf.Dependencies,
+		conf:    conf.Config,
+		address: conf.Address,
 		version: defaultAPIVersion,
 		tls:     conf.TLS,
+		socket:  conf.UnixSocket,
 	}
 
 	if conf.Store == nil {
@@ -108,6 +130,18 @@ func NewAPI(conf *APIConfig) (*API, error) {
 	mux.Handle(fmt.Sprintf("/%s/%s", defaultAPIVersion, taskStatusPath),
 		withLogging(newTaskStatusHandler(api.store, api.drivers, defaultAPIVersion)))
 
+	// retrieve dependencies watched
+	mux.Handle(fmt.Sprintf("/%s/%s", defaultAPIVersion, dependenciesPath),
+		withLogging(newDependenciesHandler(api.deps, api.drivers, defaultAPIVersion)))
+
+	// retrieve the OpenAPI specification
+	mux.Handle(fmt.Sprintf("/%s/%s", defaultAPIVersion, openAPIPath),
+		withLogging(newOpenAPIHandler(defaultAPIVersion)))
+
+	// retrieve debug information
+	mux.Handle(fmt.Sprintf("/%s/%s/", defaultAPIVersion, debugPath),
+		withLogging(newDebugHandler(api.conf, api.drivers, defaultAPIVersion)))
+
 	// crud task
 	mux.Handle(fmt.Sprintf("/%s/%s/", defaultAPIVersion, taskPath),
 		withLogging(newTaskHandler(api.store, api.drivers, defaultAPIVersion)))
@@ -164,23 +198,106 @@ func (api *API) Serve(ctx context.Context) error {
 		}
 	}()
 
-	logger.Info("starting server", "port", api.port)
-	var err error
-	if config.BoolVal(api.tls.Enabled) {
-		err = api.srv.ListenAndServeTLS(*api.tls.Cert, *api.tls.Key)
-	} else {
-		err = api.srv.ListenAndServe()
-	}
-	if err != nil && err != http.ErrServerClosed {
-		logger.Error("error serving api", "port", api.port, "error", err)
+	listeners, err := api.listeners()
+	if err != nil {
+		logger.Error("error listening for api", "error", err)
 		return err
 	}
 
+	errCh := make(chan error, len(listeners))
+	for _, l := range listeners {
+		go func(l net.Listener) {
+			errCh <- api.serve(l)
+		}(l)
+	}
+
+	for range listeners {
+		err := <-errCh
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("error serving api", "port", api.port,
+				"address", api.address, "error", err)
+			api.srv.Close()
+			return err
+		}
+	}
+
 	// wait for shutdown
 	wg.Wait()
 	return ctx.Err()
 }
 
+// listeners returns the listeners for the server. The server listens on a
+// TCP port unless only a Unix domain socket is configured.
+func (api *API) listeners() ([]net.Listener, error) {
+	logger := logging.Global().Named(logSystemName)
+	var listeners []net.Listener
+
+	path, isSocket := config.UnixSocketPath(api.address)
+	if isSocket {
+		l, err := listenUnixSocket(path, api.socket.FileMode())
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("starting server", "address", api.address)
+		listeners = append(listeners, l)
+	}
+
+	if !isSocket || api.port != 0 {
+		l, err := net.Listen("tcp", api.srv.Addr)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, err
+		}
+		logger.Info("starting server", "port", api.port)
+		listeners = append(listeners, l)
+	}
+
+	return listeners, nil
+}
+
+// serve serves requests on the listener. TLS is only used for TCP listeners,
+// access to a Unix domain socket is managed by its file permissions.
+func (api *API) serve(l net.Listener) error {
+	if _, ok := l.(*net.UnixListener); ok {
+		return api.srv.Serve(l)
+	}
+	if config.BoolVal(api.tls.Enabled) {
+		return api.srv.ServeTLS(l, *api.tls.Cert, *api.tls.Key)
+	}
+	return api.srv.Serve(l)
+}
+
+// listenUnixSocket listens on the Unix domain socket at the path and sets the
+// file permissions of the socket. A stale socket left behind by a previous
+// process is removed.
+func listenUnixSocket(path string, mode os.FileMode) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("cannot listen on Unix domain socket %s: "+
+				"file exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("error removing existing Unix domain "+
+				"socket %s: %s", path, err)
+		}
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("error setting permissions of Unix domain "+
+			"socket %s: %s", path, err)
+	}
+
+	return l, nil
+}
+
 // jsonResponse adds the return response for handlers. Returns if json encode
 // errored. Option to check error or add responses to jsonResponse test to
 // test json encoding
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -127,6 +128,62 @@ func TestServe_context_cancel(t *testing.T) {
 	}
 }
 
+func TestServe_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "cts.sock")
+	address := "unix://" + path
+	api, err := NewAPI(&APIConfig{
+		Address:    address,
+		UnixSocket: &config.UnixSocketConfig{Mode: config.String("0660")},
+	})
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- api.Serve(ctx)
+	}()
+
+	client, err := NewClient(&ClientConfig{Port: config.DefaultPort, Addr: address}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, address, client.FullAddress())
+	require.NoError(t, client.WaitForAPI(3*time.Second))
+
+	_, err = client.Status().Overall()
+	assert.NoError(t, err)
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), fi.Mode().Perm())
+
+	// socket is removed on shutdown
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not exit properly from cancelling context")
+	}
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestServe_UnixSocketNotSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cts.sock")
+	require.NoError(t, ioutil.WriteFile(path, []byte("file"), 0600))
+
+	api, err := NewAPI(&APIConfig{Address: "unix://" + path})
+	require.NoError(t, err)
+
+	err = api.Serve(context.Background())
+	assert.Error(t, err)
+}
+
 func TestServeWithTLS(t *testing.T) {
 	t.Parallel()
 	rootCert := "../testutils/certs/localhost_cert.pem"
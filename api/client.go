@@ -1,9 +1,12 @@
 package api
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -20,12 +23,17 @@ import (
 const (
 	httpScheme  = "http"
 	httpsScheme = "https"
+	unixScheme  = "unix"
+
+	// unixSocketHost is the host used in request URLs when connecting over a
+	// Unix domain socket. The host is ignored when dialing the socket.
+	unixSocketHost = "localhost"
 
 	DefaultAddress   = "http://localhost:8558"
 	DefaultSSLVerify = true
 
 	// Environment Variables
-	EnvAddress = "CTS_ADDRESS" // The address of the CTS daemon, supports http or https by specifying as part of the address (e.g. https://localhost:8558)
+	EnvAddress = "CTS_ADDRESS" // The address of the CTS daemon, supports http, https or unix by specifying as part of the address (e.g. https://localhost:8558, unix:///var/run/cts.sock)
 
 	// TLS Environment Variables
 	EnvTLSCACert     = "CTS_CACERT"      // Path to a directory of CA certificates to use for TLS when communicating with Consul-Terraform-Sync
@@ -44,6 +52,7 @@ type httpClient interface {
 type Client struct {
 	port    int // remain for backwards compatibility but prefer addr
 	addr    string
+	socket  string
 	version string
 	scheme  string
 	http    httpClient
@@ -70,6 +79,7 @@ type TLSConfig struct {
 type addressComposite struct {
 	scheme  string
 	address string
+	socket  string // path of the Unix domain socket, if any
 }
 
 // DefaultClientConfig returns a default configuration for the client
@@ -113,27 +123,35 @@ func DefaultClientConfig() *ClientConfig {
 
 // NewClient returns a client to make api requests
 func NewClient(c *ClientConfig, httpClient httpClient) (*Client, error) {
+	// Determine the scheme and address without scheme based on the address passed in
+	ac, err := parseAddress(c.Addr)
+	if err != nil {
+		return nil, err
+	}
+
 	if httpClient == nil {
 		tlsConfig, err := setupTLSConfig(c)
 		if err != nil {
 			return nil, err
 		}
+		transport := &http.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+		if ac.socket != "" {
+			var d net.Dialer
+			transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return d.DialContext(ctx, "unix", ac.socket)
+			}
+		}
 		httpClient = &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: tlsConfig,
-			},
+			Transport: transport,
 		}
 	}
 
-	// Determine the scheme and address without scheme based on the address passed in
-	ac, err := parseAddress(c.Addr)
-	if err != nil {
-		return nil, err
-	}
-
 	return &Client{
 		port:    c.Port,
 		addr:    ac.address,
+		socket:  ac.socket,
 		version: defaultAPIVersion,
 		scheme:  ac.scheme,
 		http:    httpClient,
@@ -177,6 +195,9 @@ func (c *Client) Port() int {
 }
 
 func (c *Client) FullAddress() string {
+	if c.socket != "" {
+		return fmt.Sprintf("%s://%s", unixScheme, c.socket)
+	}
 	return fmt.Sprintf("%s://%s", c.scheme, c.addr)
 }
 
@@ -278,6 +299,7 @@ func (c *Client) request(method, path, query, body string) (*http.Response, erro
 // QueryParam sets query parameters for the api client
 type QueryParam struct {
 	IncludeEvents bool
+	IncludeLogs   bool
 	Status        string
 	Run           string
 }
@@ -289,6 +311,9 @@ func (q *QueryParam) Encode() string {
 	if q.IncludeEvents {
 		val.Set("include", "events") // refactor this out?
 	}
+	if q.IncludeLogs {
+		val.Set("include", "logs")
+	}
 	if q.Status != "" {
 		val.Set("status", q.Status)
 	}
@@ -356,6 +381,34 @@ func (s *Status) Task(name string, q *QueryParam) (map[string]TaskStatus, error)
 	return taskStatuses, nil
 }
 
+// Dependencies can be used to query the dependencies endpoint
+type Dependencies struct {
+	c *Client
+}
+
+// Dependencies returns a handle to the dependencies endpoint
+func (c *Client) Dependencies() *Dependencies {
+	return &Dependencies{c}
+}
+
+// List is used to query for the dependencies watched by CTS
+func (d *Dependencies) List() (DependenciesResponse, error) {
+	var deps DependenciesResponse
+
+	resp, err := d.c.request(http.MethodGet, dependenciesPath, "", "")
+	if err != nil {
+		return deps, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&deps); err != nil {
+		return deps, err
+	}
+
+	return deps, nil
+}
+
 // Task can be used to query the task endpoints
 type Task struct {
 	c *Client
@@ -393,6 +446,75 @@ func (t *Task) Update(name string, config UpdateTaskConfig, q *QueryParam) (Upda
 	return plan, nil
 }
 
+// Debug can be used to query the debug endpoints
+type Debug struct {
+	c *Client
+}
+
+// Debug returns a handle to the debug endpoints
+func (c *Client) Debug() *Debug {
+	return &Debug{c}
+}
+
+// Config is used to query for the effective configuration of CTS with
+// sensitive information redacted
+func (d *Debug) Config() (DebugConfigResponse, error) {
+	var conf DebugConfigResponse
+
+	path := fmt.Sprintf("%s/%s", debugPath, debugConfigPath)
+	resp, err := d.c.request(http.MethodGet, path, "", "")
+	if err != nil {
+		return conf, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&conf); err != nil {
+		return conf, err
+	}
+
+	return conf, nil
+}
+
+// Profile is used to query for a runtime profile of CTS. See DebugProfiles for
+// the supported profiles.
+func (d *Debug) Profile(name string) ([]byte, error) {
+	path := fmt.Sprintf("%s/%s/%s", debugPath, debugPprofPath, name)
+	resp, err := d.c.request(http.MethodGet, path, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// TaskFiles is used to query for the files generated for a task. The
+// Terraform log is included when q.IncludeLogs is set.
+//
+// q: nil if no query parameters
+func (d *Debug) TaskFiles(name string, q *QueryParam) (DebugTaskFilesResponse, error) {
+	var files DebugTaskFilesResponse
+
+	if q == nil {
+		q = &QueryParam{}
+	}
+
+	path := fmt.Sprintf("%s/%s/%s/%s", debugPath, debugTasksPath, name, debugFilesPath)
+	resp, err := d.c.request(http.MethodGet, path, q.Encode(), "")
+	if err != nil {
+		return files, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&files); err != nil {
+		return files, err
+	}
+
+	return files, nil
+}
+
 func parseAddress(addr string) (addressComposite, error) {
 	ac := addressComposite{}
 	ac.scheme = httpScheme
@@ -403,6 +525,14 @@ func parseAddress(addr string) (addressComposite, error) {
 			ac.scheme = httpScheme
 		case httpsScheme:
 			ac.scheme = httpsScheme
+		case unixScheme:
+			if parts[1] == "" {
+				return addressComposite{}, fmt.Errorf("missing path of the Unix "+
+					"domain socket: %s", addr)
+			}
+			ac.address = unixSocketHost
+			ac.socket = parts[1]
+			return ac, nil
 		default:
 			return addressComposite{}, fmt.Errorf("unknown protocol scheme: %s", parts[0])
 		}
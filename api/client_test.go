@@ -69,3 +69,61 @@ func TestDefaultClientConfig_OnlyDefaults(t *testing.T) {
 	assert.Equal(t, clientKey, config.TLSConfig.ClientKey)
 	assert.Equal(t, DefaultSSLVerify, config.TLSConfig.SSLVerify)
 }
+
+func TestParseAddress(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		addr     string
+		expected addressComposite
+		isValid  bool
+	}{
+		{
+			"http",
+			"http://localhost:8558",
+			addressComposite{scheme: httpScheme, address: "localhost:8558"},
+			true,
+		},
+		{
+			"https",
+			"https://127.0.0.1:8558",
+			addressComposite{scheme: httpsScheme, address: "127.0.0.1:8558"},
+			true,
+		},
+		{
+			"unix",
+			"unix:///var/run/cts.sock",
+			addressComposite{
+				scheme:  httpScheme,
+				address: unixSocketHost,
+				socket:  "/var/run/cts.sock",
+			},
+			true,
+		},
+		{
+			"unix missing path",
+			"unix://",
+			addressComposite{},
+			false,
+		},
+		{
+			"unknown scheme",
+			"tcp://localhost:8558",
+			addressComposite{},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ac, err := parseAddress(tc.addr)
+			if !tc.isValid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ac)
+		})
+	}
+}
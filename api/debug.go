@@ -0,0 +1,255 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+)
+
+const (
+	debugPath          = "debug"
+	debugSubsystemName = "debug"
+
+	debugConfigPath = "config"
+	debugPprofPath  = "pprof"
+	debugTasksPath  = "tasks"
+	debugFilesPath  = "files"
+
+	terraformLogFilename = "terraform.log"
+)
+
+// DebugProfiles are the names of the runtime profiles served by the debug
+// endpoint
+var DebugProfiles = []string{"goroutine", "heap"}
+
+// DebugConfigResponse is the response for the debug config endpoint
+type DebugConfigResponse struct {
+	// Config is the effective configuration of CTS. Sensitive information is
+	// redacted.
+	Config string `json:"config"`
+}
+
+// DebugTaskFilesResponse is the response for the debug task files endpoint
+type DebugTaskFilesResponse struct {
+	// Files is a map of the names of the files generated for the task to their
+	// content
+	Files map[string]string `json:"files"`
+}
+
+// debugHandler handles the debug endpoints
+type debugHandler struct {
+	conf    *config.Config
+	drivers *driver.Drivers
+	version string
+}
+
+// newDebugHandler returns a new debug handler
+func newDebugHandler(conf *config.Config, drivers *driver.Drivers,
+	version string) *debugHandler {
+	return &debugHandler{
+		conf:    conf,
+		drivers: drivers,
+		version: version,
+	}
+}
+
+// ServeHTTP serves the debug endpoints used to troubleshoot CTS:
+//   - /debug/config returns the effective configuration
+//   - /debug/pprof/{profile} returns a runtime profile
+//   - /debug/tasks/{task_name}/files returns the files generated for a task,
+//     and the Terraform log with ?include=logs
+func (h *debugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context()).Named(debugSubsystemName)
+	logger.Trace("requesting debug information", "url_path", r.URL.Path)
+
+	if r.Method != http.MethodGet {
+		err := fmt.Errorf("'%s' in an unsupported method. The debug API "+
+			"currently supports the method(s): '%s'", r.Method, http.MethodGet)
+		logger.Trace("unsupported method: %s", err)
+		jsonErrorResponse(r.Context(), w, http.StatusMethodNotAllowed, err)
+		return
+	}
+
+	prefix := fmt.Sprintf("/%s/%s/", h.version, debugPath)
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == debugConfigPath:
+		h.config(w, r)
+	case len(parts) == 2 && parts[0] == debugPprofPath:
+		h.profile(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == debugTasksPath && parts[2] == debugFilesPath:
+		h.taskFiles(w, r, parts[1])
+	default:
+		err := fmt.Errorf("unsupported path '%s'. request must be format "+
+			"'%s%s', '%s%s/{profile}', or '%s%s/{task-name}/%s'", r.URL.Path,
+			prefix, debugConfigPath, prefix, debugPprofPath, prefix,
+			debugTasksPath, debugFilesPath)
+		logger.Trace("unsupported path", "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusNotFound, err)
+	}
+}
+
+// config writes the effective configuration with sensitive information
+// redacted
+func (h *debugHandler) config(w http.ResponseWriter, r *http.Request) {
+	var resp DebugConfigResponse
+	if h.conf != nil {
+		resp.Config = h.conf.GoString()
+	}
+
+	if err := jsonResponse(w, http.StatusOK, resp); err != nil {
+		logging.FromContext(r.Context()).Named(debugSubsystemName).Error(
+			"error, could not generate json response", "error", err)
+	}
+}
+
+// profile writes the runtime profile in the protobuf format expected by
+// `go tool pprof`
+func (h *debugHandler) profile(w http.ResponseWriter, r *http.Request, name string) {
+	var p *pprof.Profile
+	for _, n := range DebugProfiles {
+		if n == name {
+			p = pprof.Lookup(name)
+		}
+	}
+	if p == nil {
+		err := fmt.Errorf("unsupported profile '%s'. supported profiles: %s",
+			name, strings.Join(DebugProfiles, ", "))
+		jsonErrorResponse(r.Context(), w, http.StatusNotFound, err)
+		return
+	}
+
+	if name == "heap" {
+		// collect garbage to report up-to-date statistics
+		runtime.GC()
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s.prof"`, name))
+	if err := p.WriteTo(w, 0); err != nil {
+		logging.FromContext(r.Context()).Named(debugSubsystemName).Error(
+			"error writing profile", "profile", name, "error", err)
+	}
+}
+
+// taskFiles writes the files generated for the task in its working directory.
+// Sensitive values are redacted from the rendered input variables, including
+// the whole value of variables declared as sensitive, and the backend
+// configuration is redacted from the root module. Files that only contain
+// provider configuration are not included. The Terraform log is not
+// redacted and can contain sensitive values from the plan, so it is only
+// included when requested with ?include=logs.
+func (h *debugHandler) taskFiles(w http.ResponseWriter, r *http.Request, taskName string) {
+	logger := logging.FromContext(r.Context()).Named(debugSubsystemName)
+
+	logs, err := includeLogs(r)
+	if err != nil {
+		logger.Trace("bad include parameter", "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	d, ok := h.drivers.Get(taskName)
+	if !ok {
+		err := fmt.Errorf("a task with the name '%s' does not exist or has not "+
+			"been initialized yet", taskName)
+		logger.Trace("task not found", "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusNotFound, err)
+		return
+	}
+
+	resp := DebugTaskFilesResponse{Files: make(map[string]string)}
+	dir := d.Task().WorkingDir()
+
+	// the variables file is read before the input variables so that the
+	// values of the variables declared as sensitive are redacted
+	names := []string{tftmpl.RootFilename, tftmpl.VarsFilename,
+		tftmpl.TFVarsFilename}
+	if logs {
+		names = append(names, terraformLogFilename)
+	}
+
+	var sensitive []string
+	for _, name := range names {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			logger.Error("error reading task file", "task_name", taskName,
+				"file", name, "error", err)
+			jsonErrorResponse(r.Context(), w, http.StatusInternalServerError, err)
+			return
+		}
+
+		switch name {
+		case tftmpl.RootFilename:
+			content, err = tftmpl.RedactBackend(content)
+			if err != nil {
+				err = fmt.Errorf("unable to redact %s: %s", name, err)
+				logger.Error("error redacting task file", "task_name", taskName,
+					"error", err)
+				jsonErrorResponse(r.Context(), w, http.StatusInternalServerError, err)
+				return
+			}
+		case tftmpl.VarsFilename:
+			sensitive, err = tftmpl.SensitiveVariables(content)
+			if err != nil {
+				err = fmt.Errorf("unable to parse %s: %s", name, err)
+				logger.Error("error parsing task file", "task_name", taskName,
+					"error", err)
+				jsonErrorResponse(r.Context(), w, http.StatusInternalServerError, err)
+				return
+			}
+		case tftmpl.TFVarsFilename:
+			content, err = tftmpl.RedactTFVars(content, sensitive)
+			if err != nil {
+				err = fmt.Errorf("unable to redact %s: %s", name, err)
+				logger.Error("error redacting task file", "task_name", taskName,
+					"error", err)
+				jsonErrorResponse(r.Context(), w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+		resp.Files[name] = string(content)
+	}
+
+	if err := jsonResponse(w, http.StatusOK, resp); err != nil {
+		logger.Error("error, could not generate json response", "error", err)
+	}
+}
+
+// includeLogs determines whether or not to include the Terraform log in the
+// task files payload
+func includeLogs(r *http.Request) (bool, error) {
+	// `?include=logs` parameter
+	const includeKey = "include"
+	const includeValue = "logs"
+
+	keys, ok := r.URL.Query()[includeKey]
+	if !ok {
+		return false, nil
+	}
+
+	if len(keys) != 1 {
+		return false, fmt.Errorf("cannot support more than one include "+
+			"parameter, got include values: %v", keys)
+	}
+
+	if keys[0] != includeValue {
+		return false, fmt.Errorf("unsupported ?include parameter value. only "+
+			"supporting 'include=logs' but got 'include=%s'", keys[0])
+	}
+
+	return true, nil
+}
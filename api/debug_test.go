@@ -0,0 +1,235 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebug_New(t *testing.T) {
+	h := newDebugHandler(nil, driver.NewDrivers(), "v1")
+	assert.Equal(t, "v1", h.version)
+}
+
+func TestDebug_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "debug")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mainTF := "terraform {\n  backend \"consul\" {\n    access_token = \"%s\"\n  }\n}\n\nmodule \"task_a\" {}\n"
+	files := map[string]string{
+		"main.tf":          fmt.Sprintf(mainTF, "backend-token"),
+		"terraform.tfvars": "services = {}\ntoken    = \"abc\"\n",
+		"providers.tfvars": "local = {}\n",
+		"terraform.log":    "[DEBUG] token = abc\n",
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	task, err := driver.NewTask(driver.TaskConfig{Name: "task_a", WorkingDir: dir})
+	require.NoError(t, err)
+	d := new(mocks.Driver)
+	d.On("Task").Return(task)
+	drivers := driver.NewDrivers()
+	drivers.Add("task_a", d)
+
+	conf := config.DefaultConfig()
+	conf.Consul.Token = config.String("secret")
+	conf.Driver = &config.DriverConfig{Terraform: &config.TerraformConfig{
+		Backend: map[string]interface{}{
+			"s3": map[string]interface{}{
+				"access_key": "AKIAEXAMPLE",
+				"secret_key": "s3-secret-key",
+			},
+		},
+	}}
+	handler := newDebugHandler(conf, drivers, "v1")
+
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		statusCode int
+		expected   interface{}
+	}{
+		{
+			"config",
+			http.MethodGet,
+			"/v1/debug/config",
+			http.StatusOK,
+			DebugConfigResponse{Config: conf.GoString()},
+		},
+		{
+			"task files",
+			http.MethodGet,
+			"/v1/debug/tasks/task_a/files",
+			http.StatusOK,
+			DebugTaskFilesResponse{Files: map[string]string{
+				"main.tf":          fmt.Sprintf(mainTF, "(redacted)"),
+				"terraform.tfvars": "services = {}\ntoken    = \"(redacted)\"\n",
+			}},
+		},
+		{
+			"task files include logs",
+			http.MethodGet,
+			"/v1/debug/tasks/task_a/files?include=logs",
+			http.StatusOK,
+			DebugTaskFilesResponse{Files: map[string]string{
+				"main.tf":          fmt.Sprintf(mainTF, "(redacted)"),
+				"terraform.tfvars": "services = {}\ntoken    = \"(redacted)\"\n",
+				"terraform.log":    "[DEBUG] token = abc\n",
+			}},
+		},
+		{
+			"task files unsupported include",
+			http.MethodGet,
+			"/v1/debug/tasks/task_a/files?include=events",
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"task not found",
+			http.MethodGet,
+			"/v1/debug/tasks/dne/files",
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"profile",
+			http.MethodGet,
+			"/v1/debug/pprof/goroutine",
+			http.StatusOK,
+			nil,
+		},
+		{
+			"unsupported profile",
+			http.MethodGet,
+			"/v1/debug/pprof/cpu",
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"unsupported path",
+			http.MethodGet,
+			"/v1/debug/tasks",
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"method not allowed",
+			http.MethodPost,
+			"/v1/debug/config",
+			http.StatusMethodNotAllowed,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tc.statusCode, resp.Code)
+			switch expected := tc.expected.(type) {
+			case DebugConfigResponse:
+				var actual DebugConfigResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+				assert.Equal(t, expected, actual)
+				assert.NotContains(t, actual.Config, "secret")
+				assert.NotContains(t, actual.Config, "AKIAEXAMPLE")
+			case DebugTaskFilesResponse:
+				var actual DebugTaskFilesResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+				assert.Equal(t, expected, actual)
+				assert.NotContains(t, actual.Files["main.tf"], "backend-token")
+			}
+		})
+	}
+}
+
+func TestDebug_TaskFiles_VaultSecret(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "debug")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// generate and render the root module of a task with a vault-secret
+	// condition
+	err = tftmpl.InitRootModule(&tftmpl.RootModuleInputData{
+		Task:     tftmpl.Task{Name: "task_a", Source: "./module"},
+		Services: []tftmpl.Service{{Name: "web"}},
+		Condition: &tftmpl.VaultSecretCondition{
+			VaultSecretMonitor: tftmpl.VaultSecretMonitor{
+				Paths: []string{"pki/issue/web"},
+			},
+		},
+		Path:      dir,
+		FilePerms: 0644,
+	})
+	require.NoError(t, err)
+
+	tmplContent, err := ioutil.ReadFile(filepath.Join(dir, tftmpl.TFVarsTmplFilename))
+	require.NoError(t, err)
+	fixture := &tmplfunc.Fixture{
+		VaultSecrets: map[string]map[string]interface{}{
+			"pki/issue/web": {
+				"certificate": "-----BEGIN CERTIFICATE-----",
+				"private_key": "supersecretkeymaterial",
+				"key":         "supersecretkeymaterial",
+			},
+		},
+	}
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents:     string(tmplContent),
+		FuncMapMerge: tmplfunc.HCLMap(nil),
+	})
+	content, err := tmpl.Execute(fixture.Recaller())
+	require.NoError(t, err)
+	require.Contains(t, string(content), "supersecretkeymaterial")
+	err = ioutil.WriteFile(filepath.Join(dir, tftmpl.TFVarsFilename), content, 0644)
+	require.NoError(t, err)
+
+	task, err := driver.NewTask(driver.TaskConfig{Name: "task_a", WorkingDir: dir})
+	require.NoError(t, err)
+	d := new(mocks.Driver)
+	d.On("Task").Return(task)
+	drivers := driver.NewDrivers()
+	drivers.Add("task_a", d)
+	handler := newDebugHandler(config.DefaultConfig(), drivers, "v1")
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/debug/tasks/task_a/files", nil)
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var actual DebugTaskFilesResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+	tfvars := actual.Files[tftmpl.TFVarsFilename]
+	assert.Contains(t, tfvars, `vault_secrets = "(redacted)"`)
+	assert.Contains(t, tfvars, "services = {")
+	assert.NotContains(t, tfvars, "supersecretkeymaterial")
+	assert.NotContains(t, tfvars, "BEGIN CERTIFICATE")
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+)
+
+const (
+	dependenciesPath          = "dependencies"
+	dependenciesSubsystemName = "dependencies"
+)
+
+// DependenciesResponse is the response for the dependencies endpoint. It
+// contains all dependencies watched by CTS and a breakdown per task.
+type DependenciesResponse struct {
+	Total        int                         `json:"total"`
+	Dependencies []Dependency                `json:"dependencies"`
+	Tasks        map[string]TaskDependencies `json:"tasks"`
+}
+
+// Dependency is the status of a dependency watched by CTS
+type Dependency struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Tasks       []string   `json:"tasks"`
+	LastIndex   uint64     `json:"last_index"`
+	LastUpdated *time.Time `json:"last_updated,omitempty"`
+	LastFetched *time.Time `json:"last_fetched,omitempty"`
+	ErrorCount  int        `json:"error_count"`
+	LastError   string     `json:"last_error,omitempty"`
+}
+
+// TaskDependencies summarizes the dependencies watched for a task
+type TaskDependencies struct {
+	DependencyCount int      `json:"dependency_count"`
+	ErrorCount      int      `json:"error_count"`
+	Dependencies    []string `json:"dependencies"`
+}
+
+// dependenciesHandler handles the dependencies endpoint
+type dependenciesHandler struct {
+	deps    *templates.DependencyTracker
+	drivers *driver.Drivers
+	version string
+}
+
+// newDependenciesHandler returns a new dependencies handler
+func newDependenciesHandler(deps *templates.DependencyTracker, drivers *driver.Drivers,
+	version string) *dependenciesHandler {
+	return &dependenciesHandler{
+		deps:    deps,
+		drivers: drivers,
+		version: version,
+	}
+}
+
+// ServeHTTP serves the dependencies endpoint which returns the dependencies
+// watched by CTS and the tasks that reference them
+func (h *dependenciesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context()).Named(dependenciesSubsystemName)
+	logger.Trace("requesting dependencies", "url_path", r.URL.Path)
+	switch r.Method {
+	case http.MethodGet:
+		err := jsonResponse(w, http.StatusOK, h.dependencies())
+		if err != nil {
+			logger.Error("error, could not generate json error response", "error", err)
+		}
+	default:
+		err := fmt.Errorf("'%s' in an unsupported method. The dependencies API "+
+			"currently supports the method(s): '%s'", r.Method, http.MethodGet)
+		logger.Trace("unsupported method: %s", err)
+		jsonErrorResponse(r.Context(), w, http.StatusMethodNotAllowed, err)
+	}
+}
+
+// dependencies builds the response by mapping the templates referencing each
+// dependency to the tasks that own the templates
+func (h *dependenciesHandler) dependencies() DependenciesResponse {
+	resp := DependenciesResponse{
+		Dependencies: []Dependency{},
+		Tasks:        make(map[string]TaskDependencies),
+	}
+
+	tmplToTasks := make(map[string][]string)
+	for taskName, d := range h.drivers.Map() {
+		resp.Tasks[taskName] = TaskDependencies{Dependencies: []string{}}
+		for _, tmplID := range d.TemplateIDs() {
+			tmplToTasks[tmplID] = append(tmplToTasks[tmplID], taskName)
+		}
+	}
+
+	if h.deps == nil {
+		return resp
+	}
+
+	for _, status := range h.deps.Dependencies() {
+		dep := Dependency{
+			ID:         status.ID,
+			Type:       status.Type,
+			Tasks:      []string{},
+			LastIndex:  status.LastIndex,
+			ErrorCount: status.ErrorCount,
+			LastError:  status.LastError,
+		}
+		if !status.LastUpdated.IsZero() {
+			lastUpdated := status.LastUpdated
+			dep.LastUpdated = &lastUpdated
+		}
+		if !status.LastFetched.IsZero() {
+			lastFetched := status.LastFetched
+			dep.LastFetched = &lastFetched
+		}
+
+		for _, tmplID := range status.TemplateIDs {
+			for _, taskName := range tmplToTasks[tmplID] {
+				dep.Tasks = append(dep.Tasks, taskName)
+
+				taskDeps := resp.Tasks[taskName]
+				taskDeps.DependencyCount++
+				taskDeps.ErrorCount += status.ErrorCount
+				taskDeps.Dependencies = append(taskDeps.Dependencies, status.ID)
+				resp.Tasks[taskName] = taskDeps
+			}
+		}
+		sort.Strings(dep.Tasks)
+
+		resp.Dependencies = append(resp.Dependencies, dep)
+	}
+	resp.Total = len(resp.Dependencies)
+
+	return resp
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencies_New(t *testing.T) {
+	h := newDependenciesHandler(nil, driver.NewDrivers(), "v1")
+	assert.Equal(t, "v1", h.version)
+}
+
+func TestDependencies_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	// set up tracker with dependencies referenced by templates
+	w := new(mocksTmpl.Watcher)
+	var recalled []dep.Dependency
+	w.On("Recaller", mock.Anything).Return(hcat.Recaller(
+		func(d dep.Dependency) (interface{}, bool) {
+			recalled = append(recalled, d)
+			return nil, false
+		}))
+	w.On("Watching", mock.Anything).Return(true)
+	tracker := templates.NewDependencyTracker(w)
+
+	tmplA := new(mocksTmpl.Template)
+	tmplA.On("ID").Return("tmpl_a")
+	tmplB := new(mocksTmpl.Template)
+	tmplB.On("ID").Return("tmpl_b")
+
+	shared := &testDependency{id: "catalog.services", err: errors.New("timeout")}
+	tracker.Recaller(tmplA)(shared)
+	tracker.Recaller(tmplA)(&testDependency{id: "health.service(api)"})
+	tracker.Recaller(tmplB)(shared)
+	recalled[0].Fetch(nil)
+
+	drivers := driver.NewDrivers()
+	drivers.Add("task_a", createDriverWithTemplates(t, "task_a", "tmpl_a"))
+	drivers.Add("task_b", createDriverWithTemplates(t, "task_b", "tmpl_b"))
+	drivers.Add("task_c", createDriverWithTemplates(t, "task_c"))
+
+	cases := []struct {
+		name       string
+		method     string
+		tracker    *templates.DependencyTracker
+		statusCode int
+		expected   DependenciesResponse
+	}{
+		{
+			"happy path",
+			http.MethodGet,
+			tracker,
+			http.StatusOK,
+			DependenciesResponse{
+				Total: 2,
+				Dependencies: []Dependency{
+					{
+						ID:         "catalog.services",
+						Type:       templates.DependencyTypeOther,
+						Tasks:      []string{"task_a", "task_b"},
+						ErrorCount: 1,
+						LastError:  "timeout",
+					},
+					{
+						ID:    "health.service(api)",
+						Type:  templates.DependencyTypeOther,
+						Tasks: []string{"task_a"},
+					},
+				},
+				Tasks: map[string]TaskDependencies{
+					"task_a": {
+						DependencyCount: 2,
+						ErrorCount:      1,
+						Dependencies:    []string{"catalog.services", "health.service(api)"},
+					},
+					"task_b": {
+						DependencyCount: 1,
+						ErrorCount:      1,
+						Dependencies:    []string{"catalog.services"},
+					},
+					"task_c": {Dependencies: []string{}},
+				},
+			},
+		},
+		{
+			"no tracker",
+			http.MethodGet,
+			nil,
+			http.StatusOK,
+			DependenciesResponse{
+				Dependencies: []Dependency{},
+				Tasks: map[string]TaskDependencies{
+					"task_a": {Dependencies: []string{}},
+					"task_b": {Dependencies: []string{}},
+					"task_c": {Dependencies: []string{}},
+				},
+			},
+		},
+		{
+			"method not allowed",
+			http.MethodPatch,
+			tracker,
+			http.StatusMethodNotAllowed,
+			DependenciesResponse{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newDependenciesHandler(tc.tracker, drivers, "v1")
+			req, err := http.NewRequest(tc.method, "/v1/dependencies", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tc.statusCode, resp.Code)
+			if tc.statusCode != http.StatusOK {
+				return
+			}
+
+			var actual DependenciesResponse
+			err = json.NewDecoder(resp.Body).Decode(&actual)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func createDriverWithTemplates(tb testing.TB, taskName string, tmplIDs ...string) driver.Driver {
+	task, err := driver.NewTask(driver.TaskConfig{Name: taskName, Enabled: true})
+	require.NoError(tb, err)
+	d := new(mocks.Driver)
+	d.On("Task").Return(task)
+	if tmplIDs == nil {
+		tmplIDs = []string{}
+	}
+	d.On("TemplateIDs").Return(tmplIDs)
+	return d
+}
+
+type testDependency struct {
+	id  string
+	err error
+}
+
+func (d *testDependency) Fetch(dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	return nil, nil, d.err
+}
+
+func (d *testDependency) String() string { return d.id }
+
+func (d *testDependency) Stop() {}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+const (
+	openAPIPath          = "openapi.json"
+	openAPISubsystemName = "openapi"
+)
+
+// openAPISpec is the OpenAPI 3 specification of the API. The specification
+// must be updated when endpoints or their request and response types change.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// openAPIHandler handles the OpenAPI specification endpoint
+type openAPIHandler struct {
+	version string
+}
+
+// newOpenAPIHandler returns a new OpenAPI specification handler
+func newOpenAPIHandler(version string) *openAPIHandler {
+	return &openAPIHandler{
+		version: version,
+	}
+}
+
+// ServeHTTP serves the OpenAPI specification of the API
+func (h *openAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context()).Named(openAPISubsystemName)
+	logger.Trace("requesting openapi specification", "url_path", r.URL.Path)
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(openAPISpec); err != nil {
+			logger.Error("error, could not write openapi specification", "error", err)
+		}
+	default:
+		err := fmt.Errorf("'%s' in an unsupported method. The OpenAPI endpoint "+
+			"currently supports the method(s): '%s'", r.Method, http.MethodGet)
+		logger.Trace("unsupported method: %s", err)
+		jsonErrorResponse(r.Context(), w, http.StatusMethodNotAllowed, err)
+	}
+}
@@ -0,0 +1,496 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/event"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// openAPIDoc is the subset of an OpenAPI 3 document used to test the API
+// against the specification
+type openAPIDoc struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components struct {
+		Parameters map[string]*openAPIParameter `json:"parameters"`
+		Responses  map[string]*openAPIResponse  `json:"responses"`
+		Schemas    map[string]*openAPISchema    `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	Parameters  []*openAPIParameter         `json:"parameters"`
+	RequestBody *openAPIResponse            `json:"requestBody"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref    string         `json:"$ref"`
+	Name   string         `json:"name"`
+	In     string         `json:"in"`
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Ref     string `json:"$ref"`
+	Content map[string]struct {
+		Schema *openAPISchema `json:"schema"`
+	} `json:"content"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref"`
+	Type                 string                    `json:"type"`
+	Nullable             bool                      `json:"nullable"`
+	Required             []string                  `json:"required"`
+	Properties           map[string]*openAPISchema `json:"properties"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties"`
+	Items                *openAPISchema            `json:"items"`
+	Enum                 []interface{}             `json:"enum"`
+}
+
+func TestOpenAPI_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		method     string
+		statusCode int
+	}{
+		{
+			"happy path",
+			http.MethodGet,
+			http.StatusOK,
+		},
+		{
+			"method not allowed",
+			http.MethodPatch,
+			http.StatusMethodNotAllowed,
+		},
+	}
+
+	handler := newOpenAPIHandler("v1")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, "/v1/openapi.json", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tc.statusCode, resp.Code)
+			if tc.statusCode != http.StatusOK {
+				return
+			}
+
+			var doc openAPIDoc
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+			assert.True(t, strings.HasPrefix(doc.OpenAPI, "3."))
+			assert.NotEmpty(t, doc.Paths)
+		})
+	}
+}
+
+// TestOpenAPI_Types checks that the schemas in the specification match the
+// JSON encoding of the API types
+func TestOpenAPI_Types(t *testing.T) {
+	t.Parallel()
+
+	doc := loadOpenAPIDoc(t)
+
+	cases := map[string]interface{}{
+		"OverallStatus":          OverallStatus{},
+		"TaskSummary":            TaskSummary{},
+		"StatusSummary":          StatusSummary{},
+		"EnabledSummary":         EnabledSummary{},
+		"TaskStatus":             TaskStatus{},
+		"Event":                  event.Event{},
+		"EventError":             event.Error{},
+		"EventConfig":            event.Config{},
+		"EventCaller":            event.Caller{},
+		"UpdateTaskConfig":       UpdateTaskConfig{},
+		"UpdateTaskResponse":     UpdateTaskResponse{},
+		"InspectPlan":            driver.InspectPlan{},
+		"TriggerTaskResponse":    TriggerTaskResponse{},
+		"DependenciesResponse":   DependenciesResponse{},
+		"Dependency":             Dependency{},
+		"TaskDependencies":       TaskDependencies{},
+		"DebugConfigResponse":    DebugConfigResponse{},
+		"DebugTaskFilesResponse": DebugTaskFilesResponse{},
+		"ErrorResponse":          ErrorResponse{},
+		"ErrorObject":            ErrorObject{},
+	}
+
+	for name, v := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, ok := doc.Components.Schemas[name]
+			require.True(t, ok, "schema %s is missing from the specification", name)
+
+			props, required := jsonFields(reflect.TypeOf(v))
+			var specProps []string
+			for p := range s.Properties {
+				specProps = append(specProps, p)
+			}
+			sort.Strings(specProps)
+			assert.Equal(t, props, specProps, "properties do not match")
+
+			specRequired := append([]string{}, s.Required...)
+			sort.Strings(specRequired)
+			if len(specRequired) == 0 {
+				specRequired = []string{}
+			}
+			assert.Equal(t, required, specRequired, "required properties do not match")
+		})
+	}
+}
+
+// TestOpenAPI_Handlers checks that the responses of the live handlers match
+// the specification for each operation
+func TestOpenAPI_Handlers(t *testing.T) {
+	t.Parallel()
+
+	doc := loadOpenAPIDoc(t)
+	srv := httptest.NewServer(newOpenAPITestHandler(t))
+	defer srv.Close()
+
+	methods := []string{http.MethodGet, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete}
+
+	for path, ops := range doc.Paths {
+		for _, method := range methods {
+			op, ok := ops[strings.ToLower(method)]
+			if !ok {
+				// unsupported methods respond with an error
+				t.Run(fmt.Sprintf("%s %s", method, path), func(t *testing.T) {
+					u := srv.URL + testPath(path, "task_a")
+					resp := doRequest(t, method, u, "")
+					defer resp.Body.Close()
+					assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+					validateResponse(t, doc, resp,
+						doc.Components.Responses["MethodNotAllowed"])
+				})
+				continue
+			}
+
+			for _, q := range operationQueries(doc, op) {
+				u := srv.URL + testPath(path, "task_a")
+				if q != "" {
+					u += "?" + q
+				}
+				t.Run(fmt.Sprintf("%s %s?%s", method, path, q), func(t *testing.T) {
+					var body string
+					if op.RequestBody != nil {
+						s := op.RequestBody.Content["application/json"].Schema
+						b, err := json.Marshal(exampleValue(doc, s))
+						require.NoError(t, err)
+						body = string(b)
+					}
+
+					resp := doRequest(t, method, u, body)
+					defer resp.Body.Close()
+					require.Equal(t, http.StatusOK, resp.StatusCode)
+					validateResponse(t, doc, resp, op.Responses["200"])
+				})
+			}
+
+			if strings.Contains(path, "{task_name}") {
+				t.Run(fmt.Sprintf("%s %s not found", method, path), func(t *testing.T) {
+					u := srv.URL + testPath(path, "dne")
+					resp := doRequest(t, method, u, `{"enabled": true}`)
+					defer resp.Body.Close()
+					require.Equal(t, http.StatusNotFound, resp.StatusCode)
+					validateResponse(t, doc, resp, op.Responses["404"])
+				})
+			}
+		}
+	}
+}
+
+// newOpenAPITestHandler returns the handler of the API with data for each
+// type of response
+func newOpenAPITestHandler(t *testing.T) http.Handler {
+	store := event.NewStore()
+	for i, success := range []bool{false, true} {
+		ev, err := event.NewEvent("task_a", &event.Config{
+			Providers: []string{"local"},
+			Services:  []string{"api"},
+			Source:    "./module",
+		})
+		require.NoError(t, err)
+		ev.Start()
+		if success {
+			ev.End(nil)
+		} else {
+			ev.End(fmt.Errorf("error %d", i))
+		}
+		require.NoError(t, store.Add(*ev))
+	}
+
+	task, err := driver.NewTask(driver.TaskConfig{
+		Name:      "task_a",
+		Enabled:   true,
+		Providers: driver.NewTerraformProviderBlocks(nil),
+		Condition: &config.WebhookConditionConfig{},
+	})
+	require.NoError(t, err)
+	d := new(mocks.Driver)
+	d.On("Task").Return(task)
+	d.On("TemplateIDs").Return([]string{"tmpl_a"})
+	d.On("UpdateTask", mock.Anything, mock.Anything).
+		Return(driver.InspectPlan{ChangesPresent: true, Plan: "plan"}, nil)
+	d.On("TriggerTask", mock.Anything, mock.Anything).Return(nil)
+	drivers := driver.NewDrivers()
+	drivers.Add("task_a", d)
+
+	w := new(mocksTmpl.Watcher)
+	w.On("Recaller", mock.Anything).Return(hcat.Recaller(
+		func(d dep.Dependency) (interface{}, bool) {
+			d.Fetch(nil)
+			return nil, false
+		}))
+	w.On("Watching", mock.Anything).Return(true)
+	deps := templates.NewDependencyTracker(w)
+	tmpl := new(mocksTmpl.Template)
+	tmpl.On("ID").Return("tmpl_a")
+	deps.Recaller(tmpl)(&testDependency{id: "catalog.services", err: errors.New("timeout")})
+
+	api, err := NewAPI(&APIConfig{
+		Store:        store,
+		Drivers:      drivers,
+		Dependencies: deps,
+		Config:       config.DefaultConfig(),
+	})
+	require.NoError(t, err)
+	return api.srv.Handler
+}
+
+// testPath returns the path of an operation with the path parameters set
+func testPath(path, taskName string) string {
+	return strings.NewReplacer("{task_name}", taskName,
+		"{profile}", "goroutine").Replace(path)
+}
+
+func loadOpenAPIDoc(t *testing.T) *openAPIDoc {
+	var doc openAPIDoc
+	require.NoError(t, json.Unmarshal(openAPISpec, &doc))
+	return &doc
+}
+
+// operationQueries returns the query strings to request an operation with:
+// no query parameters and each enum value of each query parameter
+func operationQueries(doc *openAPIDoc, op *openAPIOperation) []string {
+	queries := []string{""}
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			p = doc.Components.Parameters[refName(p.Ref)]
+		}
+		if p.In != "query" || p.Schema == nil {
+			continue
+		}
+		for _, e := range p.Schema.Enum {
+			queries = append(queries, url.Values{p.Name: {fmt.Sprint(e)}}.Encode())
+		}
+	}
+	return queries
+}
+
+func doRequest(t *testing.T, method, u, body string) *http.Response {
+	req, err := http.NewRequest(method, u, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// validateResponse checks that the response is documented and the body of
+// the response matches the schema
+func validateResponse(t *testing.T, doc *openAPIDoc, resp *http.Response,
+	specResp *openAPIResponse) {
+
+	require.NotNil(t, specResp, "response %d is not documented", resp.StatusCode)
+	if specResp.Ref != "" {
+		specResp = doc.Components.Responses[refName(specResp.Ref)]
+	}
+
+	if _, ok := specResp.Content["application/octet-stream"]; ok {
+		assert.Equal(t, "application/octet-stream", resp.Header.Get("Content-Type"))
+		return
+	}
+
+	content, ok := specResp.Content["application/json"]
+	require.True(t, ok)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var body interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.NoError(t, validateSchema(doc, content.Schema, body, "body"))
+}
+
+// validateSchema is a minimal JSON schema validator for the subset of
+// schemas used by the specification
+func validateSchema(doc *openAPIDoc, s *openAPISchema, v interface{}, path string) error {
+	if s.Ref != "" {
+		return validateSchema(doc, doc.Components.Schemas[refName(s.Ref)], v, path)
+	}
+
+	if v == nil {
+		if s.Nullable {
+			return nil
+		}
+		return fmt.Errorf("%s: unexpected null", path)
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if e == v {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, v, s.Enum)
+		}
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, v)
+		}
+		for _, r := range s.Required {
+			if _, ok := obj[r]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, r)
+			}
+		}
+		for k, val := range obj {
+			p := fmt.Sprintf("%s.%s", path, k)
+			if ps, ok := s.Properties[k]; ok {
+				if err := validateSchema(doc, ps, val, p); err != nil {
+					return err
+				}
+			} else if s.AdditionalProperties != nil {
+				if err := validateSchema(doc, s.AdditionalProperties, val, p); err != nil {
+					return err
+				}
+			} else if s.Properties != nil {
+				return fmt.Errorf("%s: undocumented property", p)
+			}
+		}
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, v)
+		}
+		for i, val := range arr {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			if err := validateSchema(doc, s.Items, val, p); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, v)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, v)
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s: expected integer, got %v", path, v)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported schema type %q", path, s.Type)
+	}
+
+	return nil
+}
+
+// exampleValue returns an example value for the schema
+func exampleValue(doc *openAPIDoc, s *openAPISchema) interface{} {
+	if s.Ref != "" {
+		return exampleValue(doc, doc.Components.Schemas[refName(s.Ref)])
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+
+	switch s.Type {
+	case "object":
+		obj := make(map[string]interface{})
+		for k, ps := range s.Properties {
+			obj[k] = exampleValue(doc, ps)
+		}
+		return obj
+	case "array":
+		return []interface{}{exampleValue(doc, s.Items)}
+	case "boolean":
+		return true
+	case "integer":
+		return 1
+	default:
+		return "example"
+	}
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// jsonFields returns the sorted JSON field names of the struct type and the
+// names of the fields that are not omitted when empty
+func jsonFields(typ reflect.Type) ([]string, []string) {
+	fields := []string{}
+	required := []string{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, name)
+
+		omitEmpty := false
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				omitEmpty = true
+			}
+		}
+		if !omitEmpty {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(fields)
+	sort.Strings(required)
+	return fields, required
+}
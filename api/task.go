@@ -44,6 +44,21 @@ func (h *taskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	logger := logging.FromContext(r.Context())
 	logger.Trace("requesting tasks", "url_path", r.URL.Path)
 
+	// /v1/tasks/{task_name}/trigger
+	prefix := fmt.Sprintf("/%s/%s/", h.version, taskPath)
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if len(parts) == 2 && parts[1] == triggerPath {
+		if r.Method != http.MethodPost {
+			err := fmt.Errorf("'%s' in an unsupported method. The task trigger "+
+				"API currently supports the method(s): '%s'", r.Method, http.MethodPost)
+			logger.Trace("unsupported method", "error", err)
+			jsonErrorResponse(r.Context(), w, http.StatusMethodNotAllowed, err)
+			return
+		}
+		h.triggerTask(w, r, parts[0])
+		return
+	}
+
 	switch r.Method {
 	case http.MethodPatch:
 		h.updateTask(w, r)
@@ -58,7 +73,7 @@ func (h *taskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // UpdateTaskConfig contains the fields available for patch updating a task.
 // Not all task configuration is available for update
 type UpdateTaskConfig struct {
-	Enabled *bool `mapstructure:"enabled"`
+	Enabled *bool `mapstructure:"enabled" json:"enabled,omitempty"`
 }
 
 type UpdateTaskResponse struct {
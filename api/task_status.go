@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/event"
@@ -17,13 +18,14 @@ const (
 
 // TaskStatus is the status for a single task
 type TaskStatus struct {
-	TaskName  string        `json:"task_name"`
-	Status    string        `json:"status"`
-	Enabled   bool          `json:"enabled"`
-	Providers []string      `json:"providers"`
-	Services  []string      `json:"services"`
-	EventsURL string        `json:"events_url"`
-	Events    []event.Event `json:"events,omitempty"`
+	TaskName    string        `json:"task_name"`
+	Status      string        `json:"status"`
+	Enabled     bool          `json:"enabled"`
+	Providers   []string      `json:"providers"`
+	Services    []string      `json:"services"`
+	EventsURL   string        `json:"events_url"`
+	Events      []event.Event `json:"events,omitempty"`
+	NextRunTime *time.Time    `json:"next_run_time,omitempty"`
 }
 
 // taskStatusHandler handles the task status endpoint
@@ -157,12 +159,13 @@ func makeTaskStatus(events []event.Event, task *driver.Task,
 
 	taskName := task.Name()
 	return TaskStatus{
-		TaskName:  taskName,
-		Status:    successToStatus(successes),
-		Enabled:   task.IsEnabled(),
-		Providers: mapKeyToArray(uniqProviders),
-		Services:  mapKeyToArray(uniqServices),
-		EventsURL: makeEventsURL(events, version, taskName),
+		TaskName:    taskName,
+		Status:      successToStatus(successes),
+		Enabled:     task.IsEnabled(),
+		Providers:   mapKeyToArray(uniqProviders),
+		Services:    mapKeyToArray(uniqServices),
+		EventsURL:   makeEventsURL(events, version, taskName),
+		NextRunTime: nextRunTime(task),
 	}
 }
 
@@ -176,7 +179,22 @@ func makeTaskStatusUnknown(task *driver.Task) TaskStatus {
 		Providers: task.ProviderNames(),
 		Services:  task.ServiceNames(),
 		EventsURL: "",
+
+		NextRunTime: nextRunTime(task),
+	}
+}
+
+// nextRunTime returns the next run time of a scheduled task. Returns nil for
+// dynamic tasks and scheduled tasks without a known next run.
+func nextRunTime(task *driver.Task) *time.Time {
+	if !task.IsScheduled() {
+		return nil
+	}
+	next := task.NextRunTime()
+	if next.IsZero() {
+		return nil
 	}
+	return &next
 }
 
 // mapKeyToArray returns an array of map keys
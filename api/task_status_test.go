@@ -6,7 +6,9 @@ import (
 	"net/http/httptest"
 	"sort"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/event"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/driver"
@@ -323,6 +325,17 @@ func TestTaskStatus_MakeStatus(t *testing.T) {
 	disabledTask, err := driver.NewTask(driver.TaskConfig{Name: "test_task", Enabled: false})
 	require.NoError(t, err)
 
+	nextRunTime := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	scheduledTask, err := driver.NewTask(driver.TaskConfig{
+		Name:    "test_task",
+		Enabled: true,
+		Condition: &config.ScheduleConditionConfig{
+			Cron: config.String("0 0 12 * * * *"),
+		},
+	})
+	require.NoError(t, err)
+	scheduledTask.SetNextRunTime(nextRunTime)
+
 	cases := []struct {
 		name     string
 		events   []event.Event
@@ -419,6 +432,28 @@ func TestTaskStatus_MakeStatus(t *testing.T) {
 				EventsURL: "/v1/status/tasks/test_task?include=events",
 			},
 		},
+		{
+			"scheduled task",
+			[]event.Event{
+				event.Event{
+					Success: true,
+					Config: &event.Config{
+						Providers: []string{"local"},
+						Services:  []string{"api"},
+					},
+				},
+			},
+			scheduledTask,
+			TaskStatus{
+				TaskName:    "test_task",
+				Enabled:     true,
+				Status:      StatusSuccessful,
+				Providers:   []string{"local"},
+				Services:    []string{"api"},
+				EventsURL:   "/v1/status/tasks/test_task?include=events",
+				NextRunTime: &nextRunTime,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/event"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/pkg/errors"
+)
+
+const (
+	triggerTaskSubsystemName = "triggertask"
+	triggerPath              = "trigger"
+
+	// WebhookSignatureHeader is the header of a trigger request with the
+	// HMAC-SHA256 signature of the payload. The value has the format
+	// 'sha256=<hex digest>' and is required if the webhook condition of the
+	// task is configured with a secret.
+	WebhookSignatureHeader = "X-CTS-Signature"
+
+	webhookSignaturePrefix = "sha256="
+
+	// maxTriggerBodySize is the maximum size of the payload of a trigger
+	// request
+	maxTriggerBodySize = 1 << 20 // 1 MiB
+)
+
+// TriggerTaskResponse is the response for the trigger task endpoint
+type TriggerTaskResponse struct {
+	// Event is the event of the task run for the trigger request
+	Event event.Event `json:"event"`
+}
+
+// triggerTask runs a task with a webhook condition with the JSON payload of
+// the request
+func (h *taskHandler) triggerTask(w http.ResponseWriter, r *http.Request, taskName string) {
+	logger := logging.FromContext(r.Context()).Named(triggerTaskSubsystemName)
+
+	d, ok := h.drivers.Get(taskName)
+	if !ok {
+		err := fmt.Errorf("a task with the name '%s' does not exist or has not "+
+			"been initialized yet", taskName)
+		logger.Trace("task not found", "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusNotFound, err)
+		return
+	}
+
+	task := d.Task()
+	cond, ok := task.Condition().(*config.WebhookConditionConfig)
+	if !ok {
+		err := fmt.Errorf("task '%s' cannot be triggered. only tasks with a "+
+			"webhook condition can be triggered", taskName)
+		logger.Trace("bad request", "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !task.IsEnabled() {
+		err := fmt.Errorf("task '%s' is disabled and cannot be triggered", taskName)
+		logger.Trace("bad request", "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTriggerBodySize)
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		// MaxBytesReader errors once the limit is read and the body has more
+		if len(body) >= maxTriggerBodySize {
+			err = fmt.Errorf("request body is larger than the limit of %d bytes",
+				maxTriggerBodySize)
+			logger.Trace("request body too large", "task_name", taskName, "error", err)
+			jsonErrorResponse(r.Context(), w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		logger.Trace("unable to read request body from trigger", "task_name", taskName, "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if secret := config.StringVal(cond.Secret); secret != "" {
+		if err := verifyWebhookSignature(secret, body, r.Header.Get(WebhookSignatureHeader)); err != nil {
+			logger.Trace("unauthorized trigger request", "task_name", taskName, "error", err)
+			jsonErrorResponse(r.Context(), w, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	if err := validateWebhookPayload(body, cond.Schema); err != nil {
+		logger.Trace("invalid webhook payload", "task_name", taskName, "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.drivers.SetActive(taskName)
+	defer h.drivers.SetInactive(taskName)
+
+	ev, err := event.NewEvent(taskName, &event.Config{
+		Providers: task.ProviderNames(),
+		Services:  task.ServiceNames(),
+		Source:    task.Source(),
+	})
+	if err != nil {
+		err = errors.Wrap(err, fmt.Sprintf("error creating task trigger "+
+			"event for %q", taskName))
+		logger.Error("error creating new event", "error", err)
+		jsonErrorResponse(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+	ev.Caller = &event.Caller{
+		Address:   r.RemoteAddr,
+		UserAgent: r.UserAgent(),
+	}
+
+	logger.Info("triggering task", "task_name", taskName,
+		"caller_address", ev.Caller.Address)
+	ev.Start()
+	storedErr := d.TriggerTask(r.Context(), body)
+	ev.End(storedErr)
+	logger.Trace("adding event", "event", ev.GoString())
+	if err := h.store.Add(*ev); err != nil {
+		// only log error since the task was triggered by now
+		logger.Error("error storing event", "event", ev.GoString(), "error", err)
+	}
+
+	if storedErr != nil {
+		logger.Trace("error while triggering task", "task_name", taskName, "error", storedErr)
+		jsonErrorResponse(r.Context(), w, http.StatusInternalServerError, storedErr)
+		return
+	}
+
+	if err = jsonResponse(w, http.StatusOK, TriggerTaskResponse{Event: *ev}); err != nil {
+		logger.Error("error, could not generate json response", "error", err)
+	}
+}
+
+// verifyWebhookSignature verifies that the signature header value is the
+// HMAC-SHA256 signature of the payload using the secret
+func verifyWebhookSignature(secret string, payload []byte, signature string) error {
+	if signature == "" {
+		return fmt.Errorf("missing %s header. the task requires trigger "+
+			"requests to be signed", WebhookSignatureHeader)
+	}
+
+	if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		return fmt.Errorf("invalid %s header. expected format '%s<hex digest>'",
+			WebhookSignatureHeader, webhookSignaturePrefix)
+	}
+
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+	if err != nil {
+		return fmt.Errorf("invalid %s header. signature must be hex encoded",
+			WebhookSignatureHeader)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return fmt.Errorf("signature of the payload does not match the %s header",
+			WebhookSignatureHeader)
+	}
+
+	return nil
+}
+
+// validateWebhookPayload validates that the payload is a JSON object. If a
+// schema is configured, the payload must contain each field of the schema
+// with the field's JSON type and no other fields.
+func validateWebhookPayload(payload []byte, schema map[string]string) error {
+	var obj map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(payload))
+	d.UseNumber()
+	if err := d.Decode(&obj); err != nil || obj == nil {
+		return fmt.Errorf("webhook payload must be a JSON object")
+	}
+
+	if len(schema) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(obj))
+	for k := range obj {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	for _, k := range fields {
+		if _, ok := schema[k]; !ok {
+			return fmt.Errorf("webhook payload field '%s' is not in the schema "+
+				"of the webhook condition", k)
+		}
+	}
+
+	fields = make([]string, 0, len(schema))
+	for k := range schema {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	for _, k := range fields {
+		v, ok := obj[k]
+		if !ok {
+			return fmt.Errorf("webhook payload is missing required field '%s'", k)
+		}
+
+		valid := false
+		switch schema[k] {
+		case "string":
+			_, valid = v.(string)
+		case "number":
+			_, valid = v.(json.Number)
+		case "bool":
+			_, valid = v.(bool)
+		case "list":
+			_, valid = v.([]interface{})
+		case "object":
+			_, valid = v.(map[string]interface{})
+		}
+		if !valid {
+			return fmt.Errorf("webhook payload field '%s' must be of type %s",
+				k, schema[k])
+		}
+	}
+
+	return nil
+}
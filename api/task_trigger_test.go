@@ -0,0 +1,387 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/event"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_triggerTask(t *testing.T) {
+	t.Parallel()
+
+	secret := "s3cr3t"
+	payload := `{"commit": "abc123"}`
+
+	// sizedPayload returns a JSON payload of the size in bytes
+	sizedPayload := func(size int) string {
+		prefix, suffix := `{"data": "`, `"}`
+		return prefix + strings.Repeat("a", size-len(prefix)-len(suffix)) + suffix
+	}
+
+	cases := []struct {
+		name        string
+		path        string
+		method      string
+		condition   config.ConditionConfig
+		enabled     bool
+		body        string
+		signature   string
+		triggerErr  error
+		statusCode  int
+		expectEvent bool
+	}{
+		{
+			"happy path",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{},
+			true,
+			payload,
+			"",
+			nil,
+			http.StatusOK,
+			true,
+		},
+		{
+			"happy path: signed",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{Secret: config.String(secret)},
+			true,
+			payload,
+			testSignature(secret, payload),
+			nil,
+			http.StatusOK,
+			true,
+		},
+		{
+			"unsupported method",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPatch,
+			&config.WebhookConditionConfig{},
+			true,
+			payload,
+			"",
+			nil,
+			http.StatusMethodNotAllowed,
+			false,
+		},
+		{
+			"task not found",
+			"/v1/tasks/task_b/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{},
+			true,
+			payload,
+			"",
+			nil,
+			http.StatusNotFound,
+			false,
+		},
+		{
+			"not a webhook task",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.ScheduleConditionConfig{Cron: config.String("* * * * *")},
+			true,
+			payload,
+			"",
+			nil,
+			http.StatusBadRequest,
+			false,
+		},
+		{
+			"disabled task",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{},
+			false,
+			payload,
+			"",
+			nil,
+			http.StatusBadRequest,
+			false,
+		},
+		{
+			"missing signature",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{Secret: config.String(secret)},
+			true,
+			payload,
+			"",
+			nil,
+			http.StatusUnauthorized,
+			false,
+		},
+		{
+			"invalid signature",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{Secret: config.String(secret)},
+			true,
+			payload,
+			testSignature("wrong", payload),
+			nil,
+			http.StatusUnauthorized,
+			false,
+		},
+		{
+			"invalid payload",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{
+				Schema: map[string]string{"commit": "number"},
+			},
+			true,
+			payload,
+			"",
+			nil,
+			http.StatusBadRequest,
+			false,
+		},
+		{
+			"payload at size limit",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{},
+			true,
+			sizedPayload(maxTriggerBodySize),
+			"",
+			nil,
+			http.StatusOK,
+			true,
+		},
+		{
+			"payload too large",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{},
+			true,
+			sizedPayload(maxTriggerBodySize + 1),
+			"",
+			nil,
+			http.StatusRequestEntityTooLarge,
+			false,
+		},
+		{
+			"error when triggering task",
+			"/v1/tasks/task_a/trigger",
+			http.MethodPost,
+			&config.WebhookConditionConfig{},
+			true,
+			payload,
+			"",
+			errors.New("error triggering task"),
+			http.StatusInternalServerError,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task, err := driver.NewTask(driver.TaskConfig{
+				Name:      "task_a",
+				Enabled:   tc.enabled,
+				Condition: tc.condition,
+			})
+			require.NoError(t, err)
+
+			drivers := driver.NewDrivers()
+			d := new(mocks.Driver)
+			d.On("Task").Return(task)
+			d.On("TriggerTask", mock.Anything, []byte(tc.body)).
+				Return(tc.triggerErr).Once()
+			drivers.Add("task_a", d)
+
+			store := event.NewStore()
+			handler := newTaskHandler(store, drivers, "v1")
+
+			req, err := http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("User-Agent", "test-agent")
+			if tc.signature != "" {
+				req.Header.Set(WebhookSignatureHeader, tc.signature)
+			}
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+			require.Equal(t, tc.statusCode, resp.Code)
+
+			events := store.Read("task_a")["task_a"]
+			if !tc.expectEvent {
+				d.AssertNotCalled(t, "TriggerTask", mock.Anything, mock.Anything)
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1, "expected one event")
+			ev := events[0]
+			require.NotNil(t, ev.Caller)
+			assert.Equal(t, "test-agent", ev.Caller.UserAgent)
+			assert.Equal(t, tc.triggerErr == nil, ev.Success)
+
+			if tc.statusCode == http.StatusOK {
+				var actual TriggerTaskResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+				assert.Equal(t, ev.ID, actual.Event.ID)
+				assert.Equal(t, ev.Caller, actual.Event.Caller)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	secret := "s3cr3t"
+	payload := []byte(`{"commit": "abc123"}`)
+
+	cases := []struct {
+		name      string
+		signature string
+		expectErr bool
+	}{
+		{
+			"valid",
+			testSignature(secret, string(payload)),
+			false,
+		},
+		{
+			"missing",
+			"",
+			true,
+		},
+		{
+			"missing prefix",
+			strings.TrimPrefix(testSignature(secret, string(payload)), "sha256="),
+			true,
+		},
+		{
+			"not hex",
+			"sha256=xyz",
+			true,
+		},
+		{
+			"wrong secret",
+			testSignature("wrong", string(payload)),
+			true,
+		},
+		{
+			"different payload",
+			testSignature(secret, `{"commit": "def456"}`),
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyWebhookSignature(secret, payload, tc.signature)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookPayload(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]string{
+		"commit":  "string",
+		"count":   "number",
+		"dry_run": "bool",
+		"files":   "list",
+		"meta":    "object",
+	}
+
+	cases := []struct {
+		name      string
+		payload   string
+		schema    map[string]string
+		expectErr string
+	}{
+		{
+			"no schema",
+			`{"anything": [1, 2]}`,
+			nil,
+			"",
+		},
+		{
+			"schema",
+			`{"commit": "abc", "count": 2, "dry_run": false, "files": ["a"], "meta": {"k": "v"}}`,
+			schema,
+			"",
+		},
+		{
+			"not an object",
+			`["abc"]`,
+			nil,
+			"must be a JSON object",
+		},
+		{
+			"null",
+			`null`,
+			nil,
+			"must be a JSON object",
+		},
+		{
+			"invalid json",
+			`{"commit":`,
+			nil,
+			"must be a JSON object",
+		},
+		{
+			"missing field",
+			`{"commit": "abc", "count": 2, "dry_run": false, "files": ["a"]}`,
+			schema,
+			"missing required field 'meta'",
+		},
+		{
+			"extra field",
+			`{"commit": "abc", "count": 2, "dry_run": false, "files": ["a"], "meta": {}, "x": 1}`,
+			schema,
+			"field 'x' is not in the schema",
+		},
+		{
+			"wrong type",
+			`{"commit": 1, "count": 2, "dry_run": false, "files": ["a"], "meta": {}}`,
+			schema,
+			"field 'commit' must be of type string",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateWebhookPayload([]byte(tc.payload), tc.schema)
+			if tc.expectErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectErr)
+		})
+	}
+}
+
+// testSignature returns the signature header value of the payload
+func testSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
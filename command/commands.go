@@ -22,12 +22,27 @@ func Commands() map[string]cli.CommandFactory {
 	}
 
 	all := map[string]cli.CommandFactory{
+		"config validate": func() (cli.Command, error) {
+			return newConfigValidateCommand(m), nil
+		},
+		"debug": func() (cli.Command, error) {
+			return newDebugCommand(m), nil
+		},
+		"generate": func() (cli.Command, error) {
+			return newGenerateCommand(m), nil
+		},
 		"task disable": func() (cli.Command, error) {
 			return newTaskDisableCommand(m), nil
 		},
 		"task enable": func() (cli.Command, error) {
 			return newTaskEnableCommand(m), nil
 		},
+		"task list": func() (cli.Command, error) {
+			return newTaskListCommand(m), nil
+		},
+		"task status": func() (cli.Command, error) {
+			return newTaskStatusCommand(m), nil
+		},
 	}
 
 	return all
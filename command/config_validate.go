@@ -0,0 +1,509 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+)
+
+const cmdConfigValidateName = "config validate"
+
+const (
+	// Command line flag names for loading configuration
+	FlagConfigDir  = "config-dir"
+	FlagConfigFile = "config-file"
+)
+
+// configValidateCommand handles the `config validate` command
+type configValidateCommand struct {
+	meta
+	flags *flag.FlagSet
+
+	configFiles config.FlagAppendSliceValue
+}
+
+func newConfigValidateCommand(m meta) *configValidateCommand {
+	c := &configValidateCommand{meta: m}
+
+	// The command runs offline and does not use the flags to connect to the
+	// CTS daemon, so the default flag set is not used
+	flags := flag.NewFlagSet(cmdConfigValidateName, flag.ContinueOnError)
+	flags.Var(&c.configFiles, FlagConfigDir, "A directory to load files for "+
+		"configuring Sync. Configuration files require an .hcl or .json "+
+		"file extension in order to specify their format. This option can be "+
+		"specified multiple times to load different directories.")
+	flags.Var(&c.configFiles, FlagConfigFile, "A file to load for configuring "+
+		"Sync. Configuration file requires an .hcl or .json extension in order "+
+		"to specify their format. This option can be specified multiple times "+
+		"to load different configuration files.")
+
+	flags.SetOutput(ioutil.Discard)
+	flags.VisitAll(func(f *flag.Flag) {
+		option := fmt.Sprintf("  %s %s\n    %s\n", f.Name, f.Value, f.Usage)
+		c.helpOptions = append(c.helpOptions, option)
+	})
+
+	c.meta.flags = flags
+	c.flags = flags
+	return c
+}
+
+// Name returns the subcommand
+func (c *configValidateCommand) Name() string {
+	return cmdConfigValidateName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *configValidateCommand) Help() string {
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync config validate [options]
+
+  Config Validate is used to validate configuration files without running the
+  daemon. In addition to validating the configuration values, it checks that
+  local module sources exist and declare the input variables that CTS passes
+  to them, and that the providers used by tasks are configured. All errors are
+  reported along with the file and line they were found at.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync config validate -config-dir=./config
+  ==> Error: configuration is invalid, found 2 error(s)
+      config/tasks.hcl:1: task "web": source for the task is required
+      config/tasks.hcl:9: task "db": provider "aws.east" is not configured by a terraform_provider block
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *configValidateCommand) Synopsis() string {
+	return "Validates configuration files."
+}
+
+// Run runs the command
+func (c *configValidateCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if len(args) > 0 {
+		c.UI.Error("Error: this command does not accept arguments: [options]")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		c.UI.Output("All flags are required to appear before positional arguments if set\n")
+		return ExitCodeRequiredFlagsError
+	}
+
+	if len(c.configFiles) == 0 {
+		c.UI.Error(fmt.Sprintf("Error: config file(s) required, use -%s or "+
+			"-%s flag options", FlagConfigDir, FlagConfigFile))
+		return ExitCodeRequiredFlagsError
+	}
+
+	// Errors are reported by the command, so logs from loading the
+	// configuration are discarded to avoid duplicate output
+	if err := logging.Setup(&logging.Config{
+		Level:  "ERR",
+		Writer: ioutil.Discard,
+	}); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to setup logging: %s", err))
+		return ExitCodeError
+	}
+
+	v := &configValidator{}
+	v.validate(c.configFiles)
+
+	for _, w := range v.warnings {
+		c.UI.Output(fmt.Sprintf("Warning: %s", w))
+	}
+
+	if len(v.errs) != 0 {
+		c.UI.Error(fmt.Sprintf("Error: configuration is invalid, found %d "+
+			"error(s)", len(v.errs)))
+		for _, err := range v.errs {
+			c.UI.Output(err.String())
+		}
+		return ExitCodeConfigError
+	}
+
+	c.UI.Info("Configuration is valid!")
+	return ExitCodeOK
+}
+
+// validationError is an error found in the configuration and where it was
+// found, if known
+type validationError struct {
+	pos config.Position
+	msg string
+}
+
+func (e validationError) String() string {
+	if e.pos.Filename == "" {
+		return e.msg
+	}
+	return fmt.Sprintf("%s: %s", e.pos, e.msg)
+}
+
+// configValidator validates configuration files and collects all of the
+// errors instead of stopping at the first one
+type configValidator struct {
+	errs     []validationError
+	warnings []validationError
+
+	// positions of the named blocks, keyed by <block type>.<name>. Names can
+	// be duplicated, so the positions are in the order they are defined.
+	positions map[string][]config.Position
+
+	// reported tracks the error messages that have already been reported
+	reported map[string]bool
+}
+
+func (v *configValidator) validate(paths []string) {
+	v.positions = make(map[string][]config.Position)
+	v.reported = make(map[string]bool)
+
+	files, err := config.Files(paths)
+	if err != nil {
+		v.addError(config.Position{}, "", err)
+		return
+	}
+	if len(files) == 0 {
+		v.addError(config.Position{}, "", fmt.Errorf("no configuration files found"))
+		return
+	}
+
+	// Decode each file individually so that decoding errors are reported for
+	// every file with the file name
+	for _, f := range files {
+		if _, err := config.BuildConfig([]string{f}); err != nil {
+			v.addError(config.Position{Filename: f}, "", err)
+			continue
+		}
+
+		// Block positions are best effort. Errors are reported without the
+		// line if the blocks cannot be located.
+		blocks, err := config.ParseBlocks(f)
+		if err != nil {
+			continue
+		}
+		for _, b := range blocks {
+			key := blockKey(b.Type, b.Name)
+			v.positions[key] = append(v.positions[key], b.Position)
+		}
+	}
+	if len(v.errs) != 0 {
+		return
+	}
+
+	conf, err := config.BuildConfig(files)
+	if err != nil {
+		v.addError(config.Position{}, "", err)
+		return
+	}
+	conf.Finalize()
+
+	v.validateTasks(conf)
+	v.validateServices(conf)
+	v.validateProviders(conf)
+
+	if err := conf.Driver.Validate(); err != nil {
+		v.addError(config.Position{}, "", err)
+	}
+	if err := conf.BufferPeriod.Validate(); err != nil {
+		v.addError(config.Position{}, "", err)
+	}
+	if err := conf.TLS.Validate(); err != nil {
+		v.addError(config.Position{}, "", err)
+	}
+	if err := conf.UnixSocket.Validate(); err != nil {
+		v.addError(config.Position{}, "", err)
+	}
+
+	// Validate the configuration as a whole to catch any remaining errors
+	// that are not checked individually above
+	if err := conf.Validate(); err != nil && !v.reported[err.Error()] {
+		v.addError(config.Position{}, "", err)
+	}
+}
+
+// validateTasks validates each task and its module
+func (v *configValidator) validateTasks(conf *config.Config) {
+	if conf.Tasks.Len() == 0 {
+		v.addError(config.Position{}, "", fmt.Errorf("missing tasks configuration"))
+		return
+	}
+
+	providers := make(map[string]bool)
+	for _, p := range *conf.TerraformProviders {
+		providers[p.ID()] = true
+	}
+
+	occurrences := make(map[string]int)
+	for _, t := range *conf.Tasks {
+		name := config.StringVal(t.Name)
+		pos := v.position(config.TaskBlockType, name, occurrences[name])
+		prefix := fmt.Sprintf("task %q", name)
+		occurrences[name]++
+
+		if occurrences[name] > 1 {
+			v.addError(pos, "", fmt.Errorf("duplicate task name: %s", name))
+		}
+
+		if err := t.Validate(); err != nil {
+			v.addError(pos, prefix, err)
+		}
+
+		for _, id := range t.Providers {
+			if providers[id] {
+				continue
+			}
+			if strings.Contains(id, ".") {
+				v.addError(pos, prefix, fmt.Errorf("provider %q is not configured "+
+					"by a terraform_provider block", id))
+				continue
+			}
+			// Providers without a terraform_provider block are supported and
+			// use the provider's default configuration
+			v.addWarning(pos, prefix, fmt.Errorf("provider %q is not configured "+
+				"by a terraform_provider block and will use the default "+
+				"provider configuration", id))
+		}
+
+		v.validateModule(pos, prefix, t)
+	}
+}
+
+// validateModule checks that a local module source exists and declares the
+// input variables that CTS passes to the module
+func (v *configValidator) validateModule(pos config.Position, prefix string,
+	t *config.TaskConfig) {
+
+	// required is the input variables passed to the module mapped to the
+	// reason they are passed
+	required := map[string]string{
+		"services": "all modules",
+	}
+
+	requiredConditionVariables(t.Condition, required)
+
+	if t.SourceInputs != nil {
+		for _, si := range *t.SourceInputs {
+			switch si.(type) {
+			case *config.ConsulKVSourceInputConfig:
+				required["consul_kv"] = "the consul-kv source_input"
+			case *config.NodesSourceInputConfig:
+				required["nodes"] = "the nodes source_input"
+			case *config.IntentionsSourceInputConfig:
+				required["intentions"] = "the intentions source_input"
+			case *config.ConfigEntriesSourceInputConfig:
+				required["config_entries"] = "the config-entries source_input"
+			case *config.VaultSecretSourceInputConfig:
+				required[tftmpl.VaultSecretsVariable] = "the vault-secret source_input"
+			case *config.FileSourceInputConfig:
+				required[tftmpl.FilesVariable] = "the file source_input"
+			}
+		}
+	}
+
+	for _, vf := range t.VarFiles {
+		variables, err := tftmpl.LoadModuleVariables(vf)
+		if err != nil {
+			v.addError(pos, prefix, fmt.Errorf("unable to load variable_files "+
+				"%q: %s", vf, err))
+			continue
+		}
+		for name := range variables {
+			required[name] = fmt.Sprintf("variable_files %q", vf)
+		}
+	}
+
+	dir, ok := localModuleDir(config.StringVal(t.Source), config.StringVal(t.WorkingDir))
+	if !ok {
+		// Only local modules can be validated without downloading the module
+		return
+	}
+	if dir == "" {
+		v.addError(pos, prefix, fmt.Errorf("local module source %q does not "+
+			"exist", config.StringVal(t.Source)))
+		return
+	}
+
+	declared, err := tftmpl.LoadModuleInputVariables(dir)
+	if err != nil {
+		v.addError(pos, prefix, fmt.Errorf("unable to load variables of module "+
+			"%q: %s", config.StringVal(t.Source), err))
+		return
+	}
+
+	isDeclared := make(map[string]bool, len(declared))
+	for _, name := range declared {
+		isDeclared[name] = true
+	}
+
+	names := make([]string, 0, len(required))
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if isDeclared[name] {
+			continue
+		}
+		v.addError(pos, prefix, fmt.Errorf("module %q does not declare the "+
+			"input variable %q required by %s", config.StringVal(t.Source), name,
+			required[name]))
+	}
+}
+
+// requiredConditionVariables adds the input variables passed to the module for
+// a condition, including the conditions nested within an any or all condition
+func requiredConditionVariables(c config.ConditionConfig, required map[string]string) {
+	switch cond := c.(type) {
+	case *config.CatalogServicesConditionConfig:
+		if config.BoolVal(cond.SourceIncludesVar) {
+			required["catalog_services"] = "the catalog-services condition " +
+				"with source_includes_var"
+		}
+	case *config.ConsulKVConditionConfig:
+		if config.BoolVal(cond.SourceIncludesVar) {
+			required["consul_kv"] = "the consul-kv condition with source_includes_var"
+		}
+	case *config.NodesConditionConfig:
+		if config.BoolVal(cond.SourceIncludesVar) {
+			required["nodes"] = "the nodes condition with source_includes_var"
+		}
+	case *config.IntentionsConditionConfig:
+		if config.BoolVal(cond.SourceIncludesVar) {
+			required["intentions"] = "the intentions condition with " +
+				"source_includes_var"
+		}
+	case *config.ConfigEntriesConditionConfig:
+		if config.BoolVal(cond.SourceIncludesVar) {
+			required["config_entries"] = "the config-entries condition with " +
+				"source_includes_var"
+		}
+	case *config.WebhookConditionConfig:
+		required[tftmpl.WebhookPayloadVariable] = "the webhook condition"
+	case *config.ConsulEventConditionConfig:
+		required[tftmpl.ConsulEventVariable] = "the consul-event condition"
+	case *config.VaultSecretConditionConfig:
+		required[tftmpl.VaultSecretsVariable] = "the vault-secret condition"
+	case *config.FileConditionConfig:
+		required[tftmpl.FilesVariable] = "the file condition"
+	case *config.AnyConditionConfig:
+		for _, nested := range cond.Conditions {
+			requiredConditionVariables(nested, required)
+		}
+	case *config.AllConditionConfig:
+		for _, nested := range cond.Conditions {
+			requiredConditionVariables(nested, required)
+		}
+	}
+}
+
+// validateServices validates each service
+func (v *configValidator) validateServices(conf *config.Config) {
+	occurrences := make(map[string]int)
+	for _, s := range *conf.Services {
+		id := config.StringVal(s.Name)
+		if s.ID != nil {
+			id = *s.ID
+		}
+		pos := v.position(config.ServiceBlockType, id, occurrences[id])
+		occurrences[id]++
+
+		if occurrences[id] > 1 {
+			v.addError(pos, "", fmt.Errorf("unique service IDs are required: %s", id))
+		}
+
+		if err := s.Validate(); err != nil {
+			v.addError(pos, fmt.Sprintf("service %q", id), err)
+		}
+	}
+}
+
+// validateProviders validates each terraform_provider
+func (v *configValidator) validateProviders(conf *config.Config) {
+	occurrences := make(map[string]int)
+	for _, p := range *conf.TerraformProviders {
+		id := p.ID()
+		pos := v.position(config.ProviderBlockType, id, occurrences[id])
+		occurrences[id]++
+
+		if occurrences[id] > 1 {
+			v.addError(pos, "", fmt.Errorf("duplicate provider configuration: %s", id))
+		}
+
+		if err := p.Validate(); err != nil {
+			v.addError(pos, fmt.Sprintf("terraform_provider %q", id), err)
+		}
+	}
+}
+
+// position returns the position of the nth block with the type and name
+func (v *configValidator) position(blockType, name string, n int) config.Position {
+	positions := v.positions[blockKey(blockType, name)]
+	if n < len(positions) {
+		return positions[n]
+	}
+	return config.Position{}
+}
+
+func (v *configValidator) addError(pos config.Position, prefix string, err error) {
+	v.reported[err.Error()] = true
+	v.errs = append(v.errs, newValidationError(pos, prefix, err))
+}
+
+func (v *configValidator) addWarning(pos config.Position, prefix string, err error) {
+	v.warnings = append(v.warnings, newValidationError(pos, prefix, err))
+}
+
+func newValidationError(pos config.Position, prefix string, err error) validationError {
+	msg := err.Error()
+	if prefix != "" {
+		msg = fmt.Sprintf("%s: %s", prefix, msg)
+	}
+	return validationError{pos: pos, msg: msg}
+}
+
+func blockKey(blockType, name string) string {
+	return fmt.Sprintf("%s.%s", blockType, name)
+}
+
+// localModuleDir returns the directory of a local module source and true if
+// the source is a local path. Relative paths are resolved the same way as
+// Terraform, relative to the task's working directory, falling back to the
+// current directory. An empty directory is returned if the module does not
+// exist.
+func localModuleDir(source, workingDir string) (string, bool) {
+	var candidates []string
+	switch {
+	case filepath.IsAbs(source):
+		candidates = []string{source}
+	case strings.HasPrefix(source, "./"), strings.HasPrefix(source, "../"):
+		candidates = []string{filepath.Join(workingDir, source), source}
+	default:
+		return "", false
+	}
+
+	for _, dir := range candidates {
+		if stat, err := os.Stat(dir); err == nil && stat.IsDir() {
+			return dir, true
+		}
+	}
+	return "", true
+}
@@ -0,0 +1,417 @@
+package command
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/version"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+const cmdDebugName = "debug"
+
+const (
+	// Command line flag names for capturing debug information
+	FlagDuration    = "duration"
+	FlagInterval    = "interval"
+	FlagOutput      = "output"
+	FlagIncludeLogs = "include-logs"
+
+	defaultDebugDuration = 2 * time.Minute
+	defaultDebugInterval = 30 * time.Second
+
+	// debugTimeFormat is the format of the directories of each capture within
+	// the bundle. Colons are not used so that the bundle can be extracted on
+	// all platforms.
+	debugTimeFormat = "2006-01-02T15-04-05Z"
+)
+
+// debugCommand handles the `debug` command
+type debugCommand struct {
+	meta
+	flags *flag.FlagSet
+
+	duration    *time.Duration
+	interval    *time.Duration
+	output      *string
+	includeLogs *bool
+}
+
+func newDebugCommand(m meta) *debugCommand {
+	flags := m.defaultFlagSet(cmdDebugName)
+	c := &debugCommand{
+		meta:  m,
+		flags: flags,
+		duration: flags.Duration(FlagDuration, defaultDebugDuration, "The "+
+			"duration to capture debug information over. The status, "+
+			"dependencies, and profiles of CTS are captured at each interval."),
+		interval: flags.Duration(FlagInterval, defaultDebugInterval, "The "+
+			"interval between captures of the status, dependencies, and "+
+			"profiles of CTS."),
+		output: flags.String(FlagOutput, "", "The path of the gzipped tar "+
+			"archive to write the debug bundle to. Defaults to "+
+			"consul-terraform-sync-debug-<timestamp>.tar.gz in the current "+
+			"directory."),
+		includeLogs: flags.Bool(FlagIncludeLogs, false, "Include the "+
+			"terraform.log of each task in the bundle when the Terraform log "+
+			"is persisted. The log is not redacted and can contain sensitive "+
+			"values, so it is excluded by default."),
+	}
+
+	for _, name := range []string{FlagDuration, FlagInterval, FlagOutput,
+		FlagIncludeLogs} {
+		f := flags.Lookup(name)
+		option := fmt.Sprintf("  %s %s\n    %s\n", f.Name, f.Value, f.Usage)
+		c.meta.helpOptions = append(c.meta.helpOptions, option)
+	}
+
+	return c
+}
+
+// Name returns the subcommand
+func (c *debugCommand) Name() string {
+	return cmdDebugName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *debugCommand) Help() string {
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync debug [options]
+
+  Debug captures information from a running CTS daemon to troubleshoot issues
+  and writes it to a gzipped tar archive that can be shared in support cases.
+  The bundle contains:
+
+    - the effective configuration, with sensitive information redacted
+    - the files generated for each task: main.tf, variables.tf, and the
+      rendered terraform.tfvars with sensitive values redacted. terraform.log
+      is not redacted and is only included with -include-logs when the
+      Terraform log is persisted
+    - the overall status, task statuses with events, watched dependencies,
+      and goroutine and heap profiles at each interval over the duration
+
+  Capturing can be stopped early with an interrupt, in which case the bundle
+  contains the information captured so far.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync debug -duration=2m -output=bundle.tar.gz
+  ==> Capturing debug information from CTS
+      Duration: 2m0s
+      Interval: 30s
+      Output:   bundle.tar.gz
+  ==> Saved debug bundle to bundle.tar.gz
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *debugCommand) Synopsis() string {
+	return "Captures debug information from CTS for troubleshooting."
+}
+
+// Run runs the command
+func (c *debugCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if len(args) > 0 {
+		c.UI.Error("Error: this command does not accept arguments: [options]")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		c.UI.Output("All flags are required to appear before positional arguments if set\n")
+		return ExitCodeRequiredFlagsError
+	}
+
+	if *c.interval < time.Second {
+		c.UI.Error(fmt.Sprintf("Error: -%s must be at least 1s", FlagInterval))
+		return ExitCodeRequiredFlagsError
+	}
+	if *c.duration < 0 {
+		c.UI.Error(fmt.Sprintf("Error: -%s cannot be negative", FlagDuration))
+		return ExitCodeRequiredFlagsError
+	}
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error("Error: unable to create client")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	// Check that CTS is reachable before creating the bundle
+	if _, err := client.Status().Overall(); err != nil {
+		c.UI.Error("Error: unable to connect to CTS")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	start := time.Now().UTC()
+	output := *c.output
+	if output == "" {
+		output = fmt.Sprintf("consul-terraform-sync-debug-%s.tar.gz",
+			start.Format(debugTimeFormat))
+	}
+
+	b, err := newDebugBundle(output)
+	if err != nil {
+		c.UI.Error("Error: unable to create debug bundle")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	c.UI.Info("Capturing debug information from CTS")
+	c.UI.Output(fmt.Sprintf("Duration: %s", *c.duration))
+	c.UI.Output(fmt.Sprintf("Interval: %s", *c.interval))
+	c.UI.Output(fmt.Sprintf("Output:   %s", output))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+
+	d := &debugCapture{client: client, bundle: b, includeLogs: *c.includeLogs}
+	d.captureStatic()
+	d.captureIntervals(ctx, *c.duration, *c.interval)
+	d.writeIndex(start, *c.duration, *c.interval)
+
+	if err := b.Close(); err != nil {
+		c.UI.Error("Error: unable to write debug bundle")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	for _, err := range d.errs {
+		c.UI.Output(fmt.Sprintf("Warning: %s", err))
+	}
+	c.UI.Info(fmt.Sprintf("Saved debug bundle to %s", output))
+	return ExitCodeOK
+}
+
+// debugCapture captures debug information from CTS to a bundle. Errors
+// capturing information are collected so that the rest of the information
+// can still be captured.
+type debugCapture struct {
+	client *api.Client
+	bundle *debugBundle
+	errs   []string
+
+	// includeLogs is whether to capture the Terraform log of each task
+	includeLogs bool
+}
+
+// captureStatic captures the information that is captured once: the
+// configuration and the files of each task
+func (d *debugCapture) captureStatic() {
+	conf, err := d.client.Debug().Config()
+	if err != nil {
+		d.addError("config", err)
+	} else {
+		d.add("config.txt", []byte(conf.Config))
+	}
+
+	statuses, err := d.client.Status().Task("", nil)
+	if err != nil {
+		d.addError("task files", err)
+		return
+	}
+
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		files, err := d.client.Debug().TaskFiles(name,
+			&api.QueryParam{IncludeLogs: d.includeLogs})
+		if err != nil {
+			d.addError(fmt.Sprintf("files of task %s", name), err)
+			continue
+		}
+
+		fileNames := make([]string, 0, len(files.Files))
+		for f := range files.Files {
+			fileNames = append(fileNames, f)
+		}
+		sort.Strings(fileNames)
+		for _, f := range fileNames {
+			d.add(path.Join("tasks", name, f), []byte(files.Files[f]))
+		}
+	}
+}
+
+// captureIntervals captures the information that changes over time at each
+// interval until the duration elapses or the context is cancelled
+func (d *debugCapture) captureIntervals(ctx context.Context, duration, interval time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	d.captureInterval()
+	for {
+		select {
+		case <-ticker.C:
+			d.captureInterval()
+		case <-timer.C:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// captureInterval captures the status, dependencies, and profiles of CTS to
+// a directory named by the time of the capture
+func (d *debugCapture) captureInterval() {
+	dir := time.Now().UTC().Format(debugTimeFormat)
+
+	if overall, err := d.client.Status().Overall(); err != nil {
+		d.addError(path.Join(dir, "status"), err)
+	} else {
+		d.addJSON(path.Join(dir, "status.json"), overall)
+	}
+
+	q := &api.QueryParam{IncludeEvents: true}
+	if statuses, err := d.client.Status().Task("", q); err != nil {
+		d.addError(path.Join(dir, "task status"), err)
+	} else {
+		d.addJSON(path.Join(dir, "task_status.json"), statuses)
+	}
+
+	if deps, err := d.client.Dependencies().List(); err != nil {
+		d.addError(path.Join(dir, "dependencies"), err)
+	} else {
+		d.addJSON(path.Join(dir, "dependencies.json"), deps)
+	}
+
+	for _, name := range api.DebugProfiles {
+		if profile, err := d.client.Debug().Profile(name); err != nil {
+			d.addError(path.Join(dir, name+" profile"), err)
+		} else {
+			d.add(path.Join(dir, name+".prof"), profile)
+		}
+	}
+}
+
+// writeIndex writes a summary of the capture, including any errors
+func (d *debugCapture) writeIndex(start time.Time, duration, interval time.Duration) {
+	errs := d.errs
+	if errs == nil {
+		errs = []string{}
+	}
+	d.addJSON("index.json", struct {
+		Version  string   `json:"version"`
+		Start    string   `json:"start"`
+		Duration string   `json:"duration"`
+		Interval string   `json:"interval"`
+		Errors   []string `json:"errors"`
+	}{
+		Version:  version.GetHumanVersion(),
+		Start:    start.Format(time.RFC3339),
+		Duration: duration.String(),
+		Interval: interval.String(),
+		Errors:   errs,
+	})
+}
+
+func (d *debugCapture) add(name string, content []byte) {
+	if err := d.bundle.Add(name, content); err != nil {
+		d.addError(name, err)
+	}
+}
+
+func (d *debugCapture) addJSON(name string, v interface{}) {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		d.addError(name, err)
+		return
+	}
+	d.add(name, content)
+}
+
+func (d *debugCapture) addError(what string, err error) {
+	d.errs = append(d.errs, fmt.Sprintf("unable to capture %s: %s", what, err))
+}
+
+// debugBundle is a gzipped tar archive of debug information. Files are added
+// to a directory named after the archive.
+type debugBundle struct {
+	root string
+	file *os.File
+	gz   *gzip.Writer
+	tar  *tar.Writer
+}
+
+// newDebugBundle creates the archive at the path
+func newDebugBundle(p string) (*debugBundle, error) {
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(f)
+	root := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(p), ".gz"), ".tar")
+	return &debugBundle{
+		root: root,
+		file: f,
+		gz:   gz,
+		tar:  tar.NewWriter(gz),
+	}, nil
+}
+
+// Add adds a file to the archive
+func (b *debugBundle) Add(name string, content []byte) error {
+	err := b.tar.WriteHeader(&tar.Header{
+		Name:    path.Join(b.root, name),
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = b.tar.Write(content)
+	return err
+}
+
+// Close flushes and closes the archive
+func (b *debugBundle) Close() error {
+	if err := b.tar.Close(); err != nil {
+		b.file.Close()
+		return err
+	}
+	if err := b.gz.Close(); err != nil {
+		b.file.Close()
+		return err
+	}
+	return b.file.Close()
+}
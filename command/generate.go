@@ -0,0 +1,175 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/controller"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+const cmdGenerateName = "generate"
+
+// generateCommand handles the `generate` command
+type generateCommand struct {
+	meta
+	flags *flag.FlagSet
+
+	configFiles     config.FlagAppendSliceValue
+	taskName        *string
+	servicesFixture *string
+	outDir          *string
+}
+
+func newGenerateCommand(m meta) *generateCommand {
+	c := &generateCommand{meta: m}
+
+	// The command runs offline and does not use the flags to connect to the
+	// CTS daemon, so the default flag set is not used
+	flags := flag.NewFlagSet(cmdGenerateName, flag.ContinueOnError)
+	flags.Var(&c.configFiles, FlagConfigDir, "A directory to load files for "+
+		"configuring Sync. Configuration files require an .hcl or .json "+
+		"file extension in order to specify their format. This option can be "+
+		"specified multiple times to load different directories.")
+	flags.Var(&c.configFiles, FlagConfigFile, "A file to load for configuring "+
+		"Sync. Configuration file requires an .hcl or .json extension in order "+
+		"to specify their format. This option can be specified multiple times "+
+		"to load different configuration files.")
+	c.taskName = flags.String("task", "", "[Required] The name of the task "+
+		"to generate the root module for.")
+	c.servicesFixture = flags.String("services-fixture", "", "[Required] A "+
+		"JSON file of Consul data used to render the input variables of the "+
+		"task instead of querying Consul. The file can contain health service "+
+		"instances \"services\", a map of service names to tags "+
+		"\"catalog_services\", a map of key paths to values \"consul_kv\", "+
+		"catalog nodes \"nodes\", service intentions \"intentions\", "+
+		"config entries \"config_entries\", Consul user events "+
+		"\"consul_events\", a map of prepared query names to the service "+
+		"instances of their result \"prepared_queries\", a map of Vault "+
+		"paths to the data of their secrets \"vault_secrets\", and a map of "+
+		"local file paths to their contents \"files\".")
+	c.outDir = flags.String("out", "", "[Required] The directory to write the "+
+		"generated root module to.")
+
+	flags.SetOutput(ioutil.Discard)
+	flags.VisitAll(func(f *flag.Flag) {
+		option := fmt.Sprintf("  %s %s\n    %s\n", f.Name, f.Value, f.Usage)
+		c.helpOptions = append(c.helpOptions, option)
+	})
+
+	c.meta.flags = flags
+	c.flags = flags
+	return c
+}
+
+// Name returns the subcommand
+func (c *generateCommand) Name() string {
+	return cmdGenerateName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *generateCommand) Help() string {
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync generate [options]
+
+  Generate writes the root module of a task to a directory without running
+  the daemon or connecting to Consul. The input variables of the task's module
+  are rendered from a JSON fixture of Consul data, which allows the generated
+  terraform.tfvars file to be inspected or tested with Terraform. Dynamic
+  provider configuration is not evaluated.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync generate -config-file=config.hcl -task=web \
+      -services-fixture=services.json -out=./web
+  ==> Generated root module for task "web" in ./web
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *generateCommand) Synopsis() string {
+	return "Generates the root module of a task from fixture data."
+}
+
+// Run runs the command
+func (c *generateCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if len(args) > 0 {
+		c.UI.Error("Error: this command does not accept arguments: [options]")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		c.UI.Output("All flags are required to appear before positional arguments if set\n")
+		return ExitCodeRequiredFlagsError
+	}
+
+	if len(c.configFiles) == 0 {
+		c.UI.Error(fmt.Sprintf("Error: config file(s) required, use -%s or "+
+			"-%s flag options", FlagConfigDir, FlagConfigFile))
+		return ExitCodeRequiredFlagsError
+	}
+	for _, f := range []struct{ name, value string }{
+		{"task", *c.taskName},
+		{"services-fixture", *c.servicesFixture},
+		{"out", *c.outDir},
+	} {
+		if f.value == "" {
+			c.UI.Error(fmt.Sprintf("Error: -%s flag is required", f.name))
+			return ExitCodeRequiredFlagsError
+		}
+	}
+
+	if err := logging.Setup(&logging.Config{
+		Level:  "ERR",
+		Writer: ioutil.Discard,
+	}); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to setup logging: %s", err))
+		return ExitCodeError
+	}
+
+	conf, err := config.BuildConfig(c.configFiles)
+	if err != nil {
+		c.UI.Error("Error: unable to load configuration")
+		c.UI.Output(wordwrap.WrapString(err.Error(), width))
+		return ExitCodeConfigError
+	}
+	conf.Finalize()
+	if err := conf.Validate(); err != nil {
+		c.UI.Error("Error: configuration is invalid")
+		c.UI.Output(wordwrap.WrapString(err.Error(), width))
+		return ExitCodeConfigError
+	}
+
+	fixture, err := tmplfunc.LoadFixture(*c.servicesFixture)
+	if err != nil {
+		c.UI.Error("Error: unable to load fixture")
+		c.UI.Output(wordwrap.WrapString(err.Error(), width))
+		return ExitCodeError
+	}
+
+	err = controller.GenerateTask(conf, *c.taskName, *c.outDir, fixture)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to generate root module for "+
+			"task %q", *c.taskName))
+		c.UI.Output(wordwrap.WrapString(err.Error(), width))
+		return ExitCodeError
+	}
+
+	c.UI.Info(fmt.Sprintf("Generated root module for task %q in %s",
+		*c.taskName, *c.outDir))
+	return ExitCodeOK
+}
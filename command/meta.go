@@ -57,7 +57,9 @@ func (m *meta) defaultFlagSet(name string) *flag.FlagSet {
 		"address or DNS address, but it must also include the port. This can "+
 		"also be specified via the %s environment variable. The "+
 		"default value is %s. The scheme can also be set to "+
-		"HTTPS by including https in the provided address (eg. https://127.0.0.1:8558)", api.EnvAddress, api.DefaultAddress))
+		"HTTPS by including https in the provided address (eg. https://127.0.0.1:8558) "+
+		"or to a Unix domain socket by including unix and the path of the socket "+
+		"(eg. unix:///var/run/cts.sock)", api.EnvAddress, api.DefaultAddress))
 
 	// Initialize TLS flags
 	m.tls.caPath = m.flags.String(FlagCAPath, "", fmt.Sprintf("Path to a directory of CA certificates to use for TLS when communicating with Consul-Terraform-Sync. "+
@@ -191,3 +193,13 @@ func (m meta) isFlagParsedAndFound(name string) bool {
 	})
 	return found
 }
+
+// rawOutput outputs the message without the UI prefix, e.g. for output that
+// is meant to be parsed
+func (m *meta) rawOutput(msg string) {
+	if ui, ok := m.UI.(*cli.PrefixedUi); ok {
+		ui.Ui.Output(msg)
+		return
+	}
+	m.UI.Output(msg)
+}
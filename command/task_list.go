@@ -0,0 +1,173 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+const cmdTaskListName = "task list"
+
+// taskListCommand handles the `task list` command
+type taskListCommand struct {
+	meta
+	flags *flag.FlagSet
+
+	statusFlags taskStatusFlags
+}
+
+func newTaskListCommand(m meta) *taskListCommand {
+	flags := m.defaultFlagSet(cmdTaskListName)
+	statusFlags := m.taskStatusFlagSet(flags)
+	return &taskListCommand{
+		meta:        m,
+		flags:       flags,
+		statusFlags: statusFlags,
+	}
+}
+
+// Name returns the subcommand
+func (c *taskListCommand) Name() string {
+	return cmdTaskListName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskListCommand) Help() string {
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task list [options]
+
+  Task List is used to list all tasks with a summary of their status.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task list
+      NAME      ENABLED  STATUS      LAST RUN              PROVIDERS  SERVICES
+      my_task   true     successful  2021-11-10T14:40:19Z  local      api, web
+      new_task  false    unknown     -                     local      db
+
+      Total: 2 (successful: 1, errored: 0, critical: 0, unknown: 1)
+      Enabled: 1, Disabled: 1
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskListCommand) Synopsis() string {
+	return "Lists tasks and their status."
+}
+
+// Run runs the command
+func (c *taskListCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if len(args) > 0 {
+		c.UI.Error("Error: this command does not accept arguments: [options]")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		c.UI.Output("All flags are required to appear before positional arguments if set\n")
+		return ExitCodeRequiredFlagsError
+	}
+
+	if err := c.statusFlags.validate(); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: %s", err))
+		return ExitCodeRequiredFlagsError
+	}
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error("Error: unable to create client")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	statuses, err := c.statusFlags.taskStatuses(client, "")
+	if err != nil {
+		c.UI.Error("Error: unable to list tasks")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if *c.statusFlags.format == formatJSON {
+		if err := c.meta.outputJSON(c.statusFlags.jsonStatuses(statuses)); err != nil {
+			c.UI.Error(fmt.Sprintf("Error: unable to output tasks: %s", err))
+			return ExitCodeError
+		}
+		return ExitCodeOK
+	}
+
+	if len(statuses) == 0 {
+		c.UI.Output("No tasks found")
+	} else {
+		rows := [][]string{{"NAME", "ENABLED", "STATUS", "LAST RUN", "PROVIDERS", "SERVICES"}}
+		for _, name := range sortedTaskNames(statuses) {
+			status := statuses[name]
+			rows = append(rows, []string{
+				name,
+				fmt.Sprintf("%t", status.Enabled),
+				status.Status,
+				lastRun(status),
+				joinValues(status.Providers),
+				joinValues(status.Services),
+			})
+		}
+		c.outputTable(rows)
+	}
+
+	if *c.statusFlags.includeEvents {
+		for _, name := range sortedTaskNames(statuses) {
+			c.UI.Output("")
+			c.UI.Output(fmt.Sprintf("Events for '%s':", name))
+			c.outputEvents(statuses[name])
+		}
+	}
+
+	// summarize the listed tasks so that the summary matches the -status filter
+	summary := taskSummary(statuses)
+	c.UI.Output("")
+	c.UI.Output(fmt.Sprintf("Total: %d (successful: %d, errored: %d, critical: %d, unknown: %d)",
+		summary.Enabled.True+summary.Enabled.False, summary.Status.Successful,
+		summary.Status.Errored, summary.Status.Critical, summary.Status.Unknown))
+	c.UI.Output(fmt.Sprintf("Enabled: %d, Disabled: %d", summary.Enabled.True,
+		summary.Enabled.False))
+
+	return ExitCodeOK
+}
+
+// taskSummary counts the statuses and whether the tasks are enabled
+func taskSummary(statuses map[string]api.TaskStatus) api.TaskSummary {
+	var summary api.TaskSummary
+	for _, status := range statuses {
+		switch status.Status {
+		case api.StatusSuccessful:
+			summary.Status.Successful++
+		case api.StatusErrored:
+			summary.Status.Errored++
+		case api.StatusCritical:
+			summary.Status.Critical++
+		case api.StatusUnknown:
+			summary.Status.Unknown++
+		}
+
+		if status.Enabled {
+			summary.Enabled.True++
+		} else {
+			summary.Enabled.False++
+		}
+	}
+	return summary
+}
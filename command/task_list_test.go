@@ -0,0 +1,91 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskListCommand_Run_StatusFilter(t *testing.T) {
+	t.Parallel()
+
+	statuses := map[string]api.TaskStatus{
+		"task_a": {TaskName: "task_a", Status: api.StatusSuccessful, Enabled: true},
+		"task_b": {TaskName: "task_b", Status: api.StatusCritical, Enabled: true},
+		"task_c": {TaskName: "task_c", Status: api.StatusCritical, Enabled: false},
+		"task_d": {TaskName: "task_d", Status: api.StatusUnknown, Enabled: false},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/status/tasks" {
+			http.NotFound(w, r)
+			return
+		}
+		filter := r.URL.Query().Get("status")
+		resp := make(map[string]api.TaskStatus)
+		for name, s := range statuses {
+			if filter == "" || s.Status == filter {
+				resp[name] = s
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		name     string
+		status   string
+		total    string
+		enabled  string
+		excluded []string
+	}{
+		{
+			"no filter",
+			"",
+			"Total: 4 (successful: 1, errored: 0, critical: 2, unknown: 1)",
+			"Enabled: 2, Disabled: 2",
+			nil,
+		},
+		{
+			"filter drops tasks",
+			api.StatusCritical,
+			"Total: 2 (successful: 0, errored: 0, critical: 2, unknown: 0)",
+			"Enabled: 1, Disabled: 1",
+			[]string{"task_a", "task_d"},
+		},
+		{
+			"filter drops all tasks",
+			api.StatusErrored,
+			"Total: 0 (successful: 0, errored: 0, critical: 0, unknown: 0)",
+			"Enabled: 0, Disabled: 0",
+			[]string{"task_a", "task_b", "task_c", "task_d"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := newTaskListCommand(meta{UI: ui})
+
+			args := []string{fmt.Sprintf("-%s=%s", FlagHTTPAddr, srv.URL)}
+			if tc.status != "" {
+				args = append(args, fmt.Sprintf("-%s=%s", FlagStatus, tc.status))
+			}
+			require.Equal(t, ExitCodeOK, cmd.Run(args), ui.ErrorWriter.String())
+
+			output := ui.OutputWriter.String()
+			assert.Contains(t, output, tc.total)
+			assert.Contains(t, output, tc.enabled)
+			for _, name := range tc.excluded {
+				assert.NotContains(t, output, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+const cmdTaskStatusName = "task status"
+
+const (
+	// Command line flag names for querying task statuses
+	FlagFormat        = "format"
+	FlagIncludeEvents = "include-events"
+	FlagStatus        = "status"
+
+	formatTable = "table"
+	formatJSON  = "json"
+
+	// noValue is displayed in tables for empty values
+	noValue = "-"
+)
+
+// taskStatusFlags are the flags shared by commands that query task statuses
+type taskStatusFlags struct {
+	format        *string
+	includeEvents *bool
+	status        *string
+}
+
+// taskStatusCommand handles the `task status` command
+type taskStatusCommand struct {
+	meta
+	flags *flag.FlagSet
+
+	statusFlags taskStatusFlags
+}
+
+func newTaskStatusCommand(m meta) *taskStatusCommand {
+	flags := m.defaultFlagSet(cmdTaskStatusName)
+	statusFlags := m.taskStatusFlagSet(flags)
+	return &taskStatusCommand{
+		meta:        m,
+		flags:       flags,
+		statusFlags: statusFlags,
+	}
+}
+
+// Name returns the subcommand
+func (c *taskStatusCommand) Name() string {
+	return cmdTaskStatusName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskStatusCommand) Help() string {
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task status [options] [task name]
+
+  Task Status is used to view the status of a task, or of all tasks when no
+  task name is provided.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task status -include-events my_task
+      Task Name:   my_task
+      Status:      successful
+      Enabled:     true
+      Last Run:    2021-11-10T14:40:19Z
+      Providers:   local
+      Services:    api, web
+      Events URL:  /v1/status/tasks/my_task?include=events
+
+      Events:
+      ID                                    SUCCESS  START TIME            END TIME              ERROR
+      ac6ab9c3-6c0c-4c47-fd1c-2a1ae1d8d7fc  true     2021-11-10T14:40:11Z  2021-11-10T14:40:19Z  -
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskStatusCommand) Synopsis() string {
+	return "Displays the status of tasks."
+}
+
+// Run runs the command
+func (c *taskStatusCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if len(args) > 1 {
+		c.UI.Error("Error: this command accepts at most one argument: [options] [task name]")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		c.UI.Output("All flags are required to appear before positional arguments if set\n")
+		return ExitCodeRequiredFlagsError
+	}
+
+	var taskName string
+	if len(args) == 1 {
+		taskName = args[0]
+	}
+
+	if err := c.statusFlags.validate(); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: %s", err))
+		return ExitCodeRequiredFlagsError
+	}
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error("Error: unable to create client")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	statuses, err := c.statusFlags.taskStatuses(client, taskName)
+	if err != nil {
+		c.UI.Error("Error: unable to retrieve task status")
+		msg := wordwrap.WrapString(err.Error(), width)
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if *c.statusFlags.format == formatJSON {
+		if err := c.meta.outputJSON(c.statusFlags.jsonStatuses(statuses)); err != nil {
+			c.UI.Error(fmt.Sprintf("Error: unable to output task status: %s", err))
+			return ExitCodeError
+		}
+		return ExitCodeOK
+	}
+
+	if len(statuses) == 0 {
+		c.UI.Output("No tasks found")
+		return ExitCodeOK
+	}
+
+	for i, name := range sortedTaskNames(statuses) {
+		if i > 0 {
+			c.UI.Output("")
+		}
+		c.outputTaskStatus(statuses[name])
+	}
+
+	return ExitCodeOK
+}
+
+// outputTaskStatus outputs the details of a task status
+func (c *taskStatusCommand) outputTaskStatus(status api.TaskStatus) {
+	eventsURL := status.EventsURL
+	if eventsURL == "" {
+		eventsURL = noValue
+	}
+
+	c.outputTable([][]string{
+		{"Task Name:", status.TaskName},
+		{"Status:", status.Status},
+		{"Enabled:", fmt.Sprintf("%t", status.Enabled)},
+		{"Last Run:", lastRun(status)},
+		{"Providers:", joinValues(status.Providers)},
+		{"Services:", joinValues(status.Services)},
+		{"Events URL:", eventsURL},
+	})
+
+	if *c.statusFlags.includeEvents {
+		c.UI.Output("")
+		c.UI.Output("Events:")
+		c.outputEvents(status)
+	}
+}
+
+// taskStatusFlagSet adds the flags to query task statuses to the flag set
+func (m *meta) taskStatusFlagSet(flags *flag.FlagSet) taskStatusFlags {
+	sf := taskStatusFlags{
+		format: flags.String(FlagFormat, formatTable, fmt.Sprintf("The output "+
+			"format of the task statuses. Supported values are %q and %q.",
+			formatTable, formatJSON)),
+		includeEvents: flags.Bool(FlagIncludeEvents, false, "Include the "+
+			"recent events of the tasks in the output."),
+		status: flags.String(FlagStatus, "", fmt.Sprintf("Only output tasks "+
+			"with the status. Supported values are %q, %q, %q and %q.",
+			api.StatusSuccessful, api.StatusErrored, api.StatusCritical,
+			api.StatusUnknown)),
+	}
+
+	for _, name := range []string{FlagFormat, FlagIncludeEvents, FlagStatus} {
+		f := flags.Lookup(name)
+		option := fmt.Sprintf("  %s %s\n    %s\n", f.Name, f.Value, f.Usage)
+		m.helpOptions = append(m.helpOptions, option)
+	}
+
+	return sf
+}
+
+// validate validates the values of the flags
+func (sf taskStatusFlags) validate() error {
+	switch *sf.format {
+	case formatTable, formatJSON:
+	default:
+		return fmt.Errorf("unsupported -%s value %q. Supported values are "+
+			"%q and %q", FlagFormat, *sf.format, formatTable, formatJSON)
+	}
+
+	switch strings.ToLower(*sf.status) {
+	case "", api.StatusSuccessful, api.StatusErrored, api.StatusCritical,
+		api.StatusUnknown:
+	default:
+		return fmt.Errorf("unsupported -%s value %q. Supported values are "+
+			"%q, %q, %q and %q", FlagStatus, *sf.status, api.StatusSuccessful,
+			api.StatusErrored, api.StatusCritical, api.StatusUnknown)
+	}
+
+	return nil
+}
+
+// taskStatuses requests the task statuses. Events are always requested to
+// determine when tasks last ran.
+func (sf taskStatusFlags) taskStatuses(client *api.Client, taskName string) (
+	map[string]api.TaskStatus, error) {
+
+	return client.Status().Task(taskName, &api.QueryParam{
+		IncludeEvents: true,
+		Status:        strings.ToLower(*sf.status),
+	})
+}
+
+// jsonStatuses returns the task statuses for JSON output. Events are only
+// included if requested.
+func (sf taskStatusFlags) jsonStatuses(statuses map[string]api.TaskStatus) map[string]api.TaskStatus {
+	if *sf.includeEvents {
+		return statuses
+	}
+
+	out := make(map[string]api.TaskStatus, len(statuses))
+	for name, status := range statuses {
+		status.Events = nil
+		out[name] = status
+	}
+	return out
+}
+
+// outputJSON outputs the value as indented JSON without the UI prefix so that
+// the output can be parsed
+func (m *meta) outputJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	m.rawOutput(string(b))
+	return nil
+}
+
+// outputTable outputs the rows aligned as columns
+func (m *meta) outputTable(rows [][]string) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		m.UI.Output(strings.TrimRight(line, " "))
+	}
+}
+
+// outputEvents outputs the events of a task as a table
+func (m *meta) outputEvents(status api.TaskStatus) {
+	if len(status.Events) == 0 {
+		m.UI.Output("No events found")
+		return
+	}
+
+	rows := [][]string{{"ID", "SUCCESS", "START TIME", "END TIME", "ERROR"}}
+	for _, e := range status.Events {
+		errMsg := noValue
+		if e.EventError != nil {
+			errMsg = e.EventError.Message
+		}
+		rows = append(rows, []string{
+			e.ID,
+			fmt.Sprintf("%t", e.Success),
+			formatTime(e.StartTime),
+			formatTime(e.EndTime),
+			errMsg,
+		})
+	}
+	m.outputTable(rows)
+}
+
+// lastRun returns when the task last completed based on the most recent event
+func lastRun(status api.TaskStatus) string {
+	if len(status.Events) == 0 {
+		return noValue
+	}
+	return formatTime(status.Events[0].EndTime)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return noValue
+	}
+	return t.Format(time.RFC3339)
+}
+
+func joinValues(values []string) string {
+	if len(values) == 0 {
+		return noValue
+	}
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+func sortedTaskNames(statuses map[string]api.TaskStatus) []string {
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
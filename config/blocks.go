@@ -0,0 +1,244 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	hcl2 "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// Types of the named configuration blocks that can be located
+	TaskBlockType     = "task"
+	ServiceBlockType  = "service"
+	ProviderBlockType = "terraform_provider"
+)
+
+// Position is the location of a configuration block within a file
+type Position struct {
+	Filename string
+	Line     int
+}
+
+// String returns the position formatted as <filename>:<line>. The line is
+// omitted when it is unknown.
+func (p Position) String() string {
+	if p.Line == 0 {
+		return p.Filename
+	}
+	return fmt.Sprintf("%s:%d", p.Filename, p.Line)
+}
+
+// Block identifies a named task, service, or terraform_provider configuration
+// block and where it is defined. The name of a task is the task name, the
+// name of a service is its ID or its name when the ID is not set, and the name
+// of a terraform_provider is its label or <label>.<alias> when aliased.
+type Block struct {
+	Type     string
+	Name     string
+	Position Position
+}
+
+// Files returns the configuration files for the paths, which can be files or
+// directories. Files are filtered the same way as when building the
+// configuration: directories are not walked recursively, and empty files and
+// files with unsupported extensions are skipped.
+func Files(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if stat.Mode().IsRegular() {
+			if stat.Size() != 0 && supportedFormat(fileFormat(path)) {
+				files = append(files, path)
+			}
+			continue
+		}
+
+		if !stat.Mode().IsDir() {
+			return nil, fmt.Errorf("unknown filetype %q: %s", stat.Mode().String(), path)
+		}
+
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			if info.IsDir() || !supportedFormat(fileFormat(info.Name())) {
+				continue
+			}
+			files = append(files, filepath.Join(path, info.Name()))
+		}
+	}
+
+	return files, nil
+}
+
+// ParseBlocks parses the configuration file and returns the named blocks in
+// the order they are defined.
+func ParseBlocks(path string) ([]Block, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if fileFormat(path) == "json" {
+		return parseJSONBlocks(content, path)
+	}
+	return parseHCLBlocks(content, path)
+}
+
+// parseHCLBlocks parses the named blocks of HCL configuration
+func parseHCLBlocks(content []byte, path string) ([]Block, error) {
+	file, err := hcl.ParseBytes(content)
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected configuration format: %s", path)
+	}
+
+	var blocks []Block
+	for _, item := range list.Items {
+		obj, ok := item.Val.(*ast.ObjectType)
+		if !ok {
+			continue
+		}
+
+		block := Block{
+			Type: item.Keys[0].Token.Value().(string),
+			Position: Position{
+				Filename: path,
+				Line:     item.Pos().Line,
+			},
+		}
+
+		switch block.Type {
+		case TaskBlockType:
+			block.Name = hclAttribute(obj, "name")
+		case ServiceBlockType:
+			block.Name = hclAttribute(obj, "id")
+			if block.Name == "" {
+				block.Name = hclAttribute(obj, "name")
+			}
+		case ProviderBlockType:
+			if len(item.Keys) < 2 {
+				continue
+			}
+			block.Name = item.Keys[1].Token.Value().(string)
+			if alias := hclAttribute(obj, "alias"); alias != "" {
+				block.Name = fmt.Sprintf("%s.%s", block.Name, alias)
+			}
+		default:
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// hclAttribute returns the value of a string attribute of an HCL object. An
+// empty string is returned if the attribute is not set or is not a string.
+func hclAttribute(obj *ast.ObjectType, name string) string {
+	for _, item := range obj.List.Filter(name).Items {
+		lit, ok := item.Val.(*ast.LiteralType)
+		if !ok {
+			continue
+		}
+		if v, ok := lit.Token.Value().(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseJSONBlocks parses the named blocks of JSON configuration. The HCL v1
+// JSON parser does not track positions, so the HCL v2 parser is used instead.
+func parseJSONBlocks(content []byte, path string) ([]Block, error) {
+	file, diags := hclparse.NewParser().ParseJSON(content, path)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	bodyContent, _, diags := file.Body.PartialContent(&hcl2.BodySchema{
+		Blocks: []hcl2.BlockHeaderSchema{
+			{Type: TaskBlockType},
+			{Type: ServiceBlockType},
+			{Type: ProviderBlockType, LabelNames: []string{"name"}},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	blocks := make([]Block, 0, len(bodyContent.Blocks))
+	for _, b := range bodyContent.Blocks {
+		// The definition range of blocks within a JSON array is the array, so
+		// the line of the attribute or label that names the block is used.
+		block := Block{
+			Type: b.Type,
+			Position: Position{
+				Filename: path,
+				Line:     b.DefRange.Start.Line,
+			},
+		}
+
+		var line int
+		switch b.Type {
+		case TaskBlockType:
+			block.Name, line = jsonAttribute(b.Body, "name")
+		case ServiceBlockType:
+			block.Name, line = jsonAttribute(b.Body, "id")
+			if block.Name == "" {
+				block.Name, line = jsonAttribute(b.Body, "name")
+			}
+		case ProviderBlockType:
+			block.Name = b.Labels[0]
+			line = b.LabelRanges[0].Start.Line
+			if alias, _ := jsonAttribute(b.Body, "alias"); alias != "" {
+				block.Name = fmt.Sprintf("%s.%s", block.Name, alias)
+			}
+		}
+		if line != 0 {
+			block.Position.Line = line
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// jsonAttribute returns the value and line of a string attribute of a JSON
+// block body. An empty string is returned if the attribute is not set or is
+// not a string.
+func jsonAttribute(body hcl2.Body, name string) (string, int) {
+	content, _, diags := body.PartialContent(&hcl2.BodySchema{
+		Attributes: []hcl2.AttributeSchema{{Name: name}},
+	})
+	if diags.HasErrors() {
+		return "", 0
+	}
+
+	attr, ok := content.Attributes[name]
+	if !ok {
+		return "", 0
+	}
+
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return "", 0
+	}
+	return val.AsString(), attr.NameRange.Start.Line
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("directory", func(t *testing.T) {
+		files, err := Files([]string{"testdata/simple"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join("testdata", "simple", "a.hcl"),
+			filepath.Join("testdata", "simple", "b.hcl"),
+		}, files)
+	})
+
+	t.Run("files", func(t *testing.T) {
+		files, err := Files([]string{"testdata/long.hcl", "testdata/long.json"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"testdata/long.hcl", "testdata/long.json"}, files)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := Files([]string{"testdata/dne.hcl"})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseBlocks(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		path     string
+		expected []Block
+	}{
+		{
+			"hcl",
+			"testdata/long.hcl",
+			[]Block{
+				{ServiceBlockType, "serviceA", Position{"testdata/long.hcl", 72}},
+				{ServiceBlockType, "serviceB", Position{"testdata/long.hcl", 77}},
+				{ProviderBlockType, "X", Position{"testdata/long.hcl", 83}},
+				{TaskBlockType, "task", Position{"testdata/long.hcl", 85}},
+			},
+		},
+		{
+			"json",
+			"testdata/long.json",
+			[]Block{
+				{ServiceBlockType, "serviceA", Position{"testdata/long.json", 72}},
+				{ServiceBlockType, "serviceB", Position{"testdata/long.json", 76}},
+				{ProviderBlockType, "X", Position{"testdata/long.json", 83}},
+				{TaskBlockType, "task", Position{"testdata/long.json", 88}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			blocks, err := ParseBlocks(tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, blocks)
+		})
+	}
+}
+
+func TestPosition_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "config.hcl:3", Position{"config.hcl", 3}.String())
+	assert.Equal(t, "config.hcl", Position{Filename: "config.hcl"}.String())
+}
@@ -3,11 +3,8 @@ package config
 import (
 	"fmt"
 	"reflect"
-	"sort"
-	"strings"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
-	"github.com/hashicorp/consul/lib/decode"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -22,7 +19,14 @@ type ConditionConfig interface {
 func DefaultConditionConfig() ConditionConfig {
 	return &ServicesConditionConfig{
 		ServicesMonitorConfig{
-			Regexp: String(""),
+			Regexp:      String(""),
+			Datacenter:  String(""),
+			Datacenters: []string{},
+			Namespace:   String(""),
+			Partition:   String(""),
+			Peer:        String(""),
+			NodeMeta:    map[string]string{},
+			Filter:      String(""),
 		},
 	}
 }
@@ -68,10 +72,50 @@ func conditionToTypeFunc() mapstructure.DecodeHookFunc {
 			var config ConsulKVConditionConfig
 			return decodeConditionToType(c, &config)
 		}
+		if c, ok := conditions[nodesType]; ok {
+			var config NodesConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[intentionsType]; ok {
+			var config IntentionsConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[configEntriesType]; ok {
+			var config ConfigEntriesConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[preparedQueryType]; ok {
+			var config PreparedQueryConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[vaultSecretType]; ok {
+			var config VaultSecretConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[fileType]; ok {
+			var config FileConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[consulEventType]; ok {
+			var config ConsulEventConditionConfig
+			return decodeConditionToType(c, &config)
+		}
 		if c, ok := conditions[scheduleType]; ok {
 			var config ScheduleConditionConfig
 			return decodeConditionToType(c, &config)
 		}
+		if c, ok := conditions[webhookType]; ok {
+			var config WebhookConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[anyType]; ok {
+			var config AnyConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[allType]; ok {
+			var config AllConditionConfig
+			return decodeConditionToType(c, &config)
+		}
 
 		return nil, fmt.Errorf("unsupported condition type: %v", data)
 	}
@@ -85,7 +129,10 @@ func decodeConditionToType(data interface{}, monitor MonitorConfig) (MonitorConf
 	logger := logging.Global().Named(logSystemName)
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			decode.HookWeakDecodeFromSlice,
+			// decodes nested conditions of any and all conditions
+			conditionToTypeFunc(),
+			hookWeakDecodeFromSlice,
+			mapstructure.StringToTimeDurationHookFunc(),
 		),
 		WeaklyTypedInput: true,
 		ErrorUnused:      false,
@@ -103,8 +150,7 @@ func decodeConditionToType(data interface{}, monitor MonitorConfig) (MonitorConf
 	}
 
 	if len(md.Unused) > 0 {
-		sort.Strings(md.Unused)
-		err := fmt.Errorf("invalid keys: %s", strings.Join(md.Unused, ", "))
+		err := invalidKeysError(md.Unused)
 		logger.Error("monitor invalid keys", "error", err)
 		return nil, err
 	}
@@ -29,6 +29,8 @@ func TestCatalogServicesConditionConfig_Copy(t *testing.T) {
 					SourceIncludesVar: Bool(true),
 					Datacenter:        String("dc2"),
 					Namespace:         String("ns2"),
+					Partition:         String("ap2"),
+					Peer:              String("peer2"),
 					NodeMeta: map[string]string{
 						"key1": "value1",
 						"key2": "value2",
@@ -156,6 +158,18 @@ func TestCatalogServicesConditionConfig_Merge(t *testing.T) {
 			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Datacenter: String("same")}},
 			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Datacenter: String("same")}},
 		},
+		{
+			"partition_overrides",
+			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Partition: String("same")}},
+			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Partition: String("different")}},
+			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Partition: String("different")}},
+		},
+		{
+			"peer_overrides",
+			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Peer: String("same")}},
+			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Peer: String("different")}},
+			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Peer: String("different")}},
+		},
 		{
 			"namespace_overrides",
 			&CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Namespace: String("same")}},
@@ -238,6 +252,8 @@ func TestCatalogServicesConditionConfig_Finalize(t *testing.T) {
 					SourceIncludesVar: Bool(false),
 					Datacenter:        String(""),
 					Namespace:         String(""),
+					Partition:         String(""),
+					Peer:              String(""),
 					NodeMeta:          map[string]string{},
 				},
 			},
@@ -252,6 +268,8 @@ func TestCatalogServicesConditionConfig_Finalize(t *testing.T) {
 					SourceIncludesVar: Bool(false),
 					Datacenter:        String(""),
 					Namespace:         String(""),
+					Partition:         String(""),
+					Peer:              String(""),
 					NodeMeta:          map[string]string{},
 				},
 			},
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	anyType = "any"
+	allType = "all"
+)
+
+var (
+	_ ConditionConfig = (*AnyConditionConfig)(nil)
+	_ ConditionConfig = (*AllConditionConfig)(nil)
+)
+
+// CompositeConditionConfig configures the nested conditions of a composite
+// condition configuration block. It is embedded by the 'any' and 'all'
+// condition types, which define how the results of the nested conditions are
+// combined to trigger a task.
+type CompositeConditionConfig struct {
+	Conditions []ConditionConfig `mapstructure:"condition"`
+}
+
+// AnyConditionConfig configures a condition configuration block of type
+// 'any'. An any condition is triggered when any of its nested conditions are
+// triggered.
+type AnyConditionConfig struct {
+	CompositeConditionConfig `mapstructure:",squash"`
+}
+
+// AllConditionConfig configures a condition configuration block of type
+// 'all'. An all condition is triggered once each of its nested conditions
+// have been triggered since the task last ran. When a nested schedule
+// condition is configured, the task runs on schedule only if the other nested
+// conditions have been triggered.
+type AllConditionConfig struct {
+	CompositeConditionConfig `mapstructure:",squash"`
+}
+
+// FindScheduleCondition returns the schedule condition that determines when a
+// task is run, which is either the condition itself or a schedule condition
+// nested within an all condition. Returns false if the task is not scheduled.
+func FindScheduleCondition(c ConditionConfig) (*ScheduleConditionConfig, bool) {
+	switch v := c.(type) {
+	case *ScheduleConditionConfig:
+		return v, v != nil
+	case *AllConditionConfig:
+		if v == nil {
+			return nil, false
+		}
+		for _, nested := range v.Conditions {
+			if s, ok := nested.(*ScheduleConditionConfig); ok && s != nil {
+				return s, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *AnyConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &AnyConditionConfig{c.CompositeConditionConfig.copy()}
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Nested conditions are appended.
+func (c *AnyConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*AnyConditionConfig)
+	if !ok {
+		return c.Copy()
+	}
+
+	return &AnyConditionConfig{c.CompositeConditionConfig.merge(o2.CompositeConditionConfig)}
+}
+
+// Finalize ensures there no nil pointers.
+func (c *AnyConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	c.CompositeConditionConfig.finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *AnyConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	for _, nested := range c.Conditions {
+		if _, ok := nested.(*ScheduleConditionConfig); ok {
+			return fmt.Errorf("a schedule condition is not supported within " +
+				"an any condition. consider an all condition instead")
+		}
+	}
+
+	return c.CompositeConditionConfig.validate(anyType)
+}
+
+// GoString defines the printable version of this struct.
+func (c *AnyConditionConfig) GoString() string {
+	if c == nil {
+		return "(*AnyConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&AnyConditionConfig{%s}",
+		c.CompositeConditionConfig.goString())
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *AllConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &AllConditionConfig{c.CompositeConditionConfig.copy()}
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Nested conditions are appended.
+func (c *AllConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*AllConditionConfig)
+	if !ok {
+		return c.Copy()
+	}
+
+	return &AllConditionConfig{c.CompositeConditionConfig.merge(o2.CompositeConditionConfig)}
+}
+
+// Finalize ensures there no nil pointers.
+func (c *AllConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	c.CompositeConditionConfig.finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *AllConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.CompositeConditionConfig.validate(allType)
+}
+
+// GoString defines the printable version of this struct.
+func (c *AllConditionConfig) GoString() string {
+	if c == nil {
+		return "(*AllConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&AllConditionConfig{%s}",
+		c.CompositeConditionConfig.goString())
+}
+
+func (c CompositeConditionConfig) copy() CompositeConditionConfig {
+	var o CompositeConditionConfig
+	if c.Conditions != nil {
+		o.Conditions = make([]ConditionConfig, 0, len(c.Conditions))
+		for _, nested := range c.Conditions {
+			if isConditionNil(nested) {
+				o.Conditions = append(o.Conditions, nil)
+				continue
+			}
+			o.Conditions = append(o.Conditions, nested.Copy())
+		}
+	}
+	return o
+}
+
+func (c CompositeConditionConfig) merge(o CompositeConditionConfig) CompositeConditionConfig {
+	r := c.copy()
+	r.Conditions = append(r.Conditions, o.copy().Conditions...)
+	return r
+}
+
+func (c *CompositeConditionConfig) finalize(services []string) {
+	if c.Conditions == nil {
+		c.Conditions = []ConditionConfig{}
+	}
+
+	for _, nested := range c.Conditions {
+		if !isConditionNil(nested) {
+			nested.Finalize(services)
+		}
+	}
+}
+
+func (c CompositeConditionConfig) validate(compositeType string) error {
+	if len(c.Conditions) == 0 {
+		return fmt.Errorf("%s condition requires at least one nested "+
+			"condition to be configured", compositeType)
+	}
+
+	seen := make(map[string]bool)
+	providesServices := false
+	for _, nested := range c.Conditions {
+		if isConditionNil(nested) {
+			return fmt.Errorf("%s condition contains an empty nested "+
+				"condition", compositeType)
+		}
+
+		switch nested.(type) {
+		case *AnyConditionConfig, *AllConditionConfig:
+			return fmt.Errorf("%s condition does not support nesting any or "+
+				"all conditions", compositeType)
+		case *WebhookConditionConfig:
+			return fmt.Errorf("%s condition does not support nesting a "+
+				"webhook condition", compositeType)
+		}
+
+		// services and prepared-query conditions both provide the services
+		// variable, so only one of them can be nested
+		switch nested.(type) {
+		case *ServicesConditionConfig, *PreparedQueryConditionConfig:
+			if providesServices {
+				return fmt.Errorf("%s condition does not support nesting both "+
+					"a services and a prepared-query condition", compositeType)
+			}
+			providesServices = true
+		}
+
+		// each condition type provides its own module input variable, so a
+		// type can only be nested once
+		t := fmt.Sprintf("%T", nested)
+		if seen[t] {
+			return fmt.Errorf("%s condition does not support more than one "+
+				"nested condition of the same type: %s", compositeType,
+				strings.TrimPrefix(t, "*config."))
+		}
+		seen[t] = true
+
+		if err := nested.Validate(); err != nil {
+			return fmt.Errorf("invalid nested condition for %s condition: %s",
+				compositeType, err)
+		}
+	}
+
+	return nil
+}
+
+func (c CompositeConditionConfig) goString() string {
+	conditions := make([]string, len(c.Conditions))
+	for i, nested := range c.Conditions {
+		if isConditionNil(nested) {
+			conditions[i] = "<nil>"
+			continue
+		}
+		conditions[i] = nested.GoString()
+	}
+
+	return fmt.Sprintf("Conditions:[%s]", strings.Join(conditions, ", "))
+}
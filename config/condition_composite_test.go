@@ -0,0 +1,388 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    ConditionConfig
+	}{
+		{
+			"nil_any",
+			(*AnyConditionConfig)(nil),
+		},
+		{
+			"empty_any",
+			&AnyConditionConfig{},
+		},
+		{
+			"fully_configured_any",
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("^web")}},
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+				},
+			}},
+		},
+		{
+			"fully_configured_all",
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ScheduleConditionConfig{Cron: String("* * * * * * *")},
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+				},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if isConditionNil(tc.a) {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+				return
+			}
+			assert.Equal(t, tc.a, r)
+
+			// nested conditions are deep copies
+			switch v := r.(type) {
+			case *AnyConditionConfig:
+				for i, nested := range v.Conditions {
+					assert.NotSame(t, tc.a.(*AnyConditionConfig).Conditions[i], nested)
+				}
+			case *AllConditionConfig:
+				for i, nested := range v.Conditions {
+					assert.NotSame(t, tc.a.(*AllConditionConfig).Conditions[i], nested)
+				}
+			}
+		})
+	}
+}
+
+func TestCompositeConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	services := &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("^web")}}
+	kv := &ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}}
+
+	cases := []struct {
+		name string
+		a    ConditionConfig
+		b    ConditionConfig
+		r    ConditionConfig
+	}{
+		{
+			"nil_a",
+			(*AnyConditionConfig)(nil),
+			&AnyConditionConfig{},
+			&AnyConditionConfig{},
+		},
+		{
+			"nil_b",
+			&AllConditionConfig{},
+			(*AllConditionConfig)(nil),
+			&AllConditionConfig{},
+		},
+		{
+			"nil_both",
+			(*AnyConditionConfig)(nil),
+			(*AnyConditionConfig)(nil),
+			nil,
+		},
+		{
+			"conditions_append_any",
+			&AnyConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{services}}},
+			&AnyConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{kv}}},
+			&AnyConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{services, kv}}},
+		},
+		{
+			"conditions_append_all",
+			&AllConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{services}}},
+			&AllConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{kv}}},
+			&AllConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{services, kv}}},
+		},
+		{
+			"different_types",
+			&AllConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{services}}},
+			&AnyConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{kv}}},
+			&AllConditionConfig{CompositeConditionConfig{Conditions: []ConditionConfig{services}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestCompositeConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+		c := &AnyConditionConfig{}
+		c.Finalize([]string{"api"})
+		assert.Equal(t, &AnyConditionConfig{CompositeConditionConfig{
+			Conditions: []ConditionConfig{},
+		}}, c)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		c := &AllConditionConfig{CompositeConditionConfig{
+			Conditions: []ConditionConfig{
+				&ScheduleConditionConfig{},
+				&NodesConditionConfig{},
+			},
+		}}
+		c.Finalize([]string{"api"})
+
+		expectedSchedule := &ScheduleConditionConfig{}
+		expectedSchedule.Finalize([]string{"api"})
+		expected := &NodesConditionConfig{}
+		expected.Finalize([]string{"api"})
+		assert.Equal(t, &AllConditionConfig{CompositeConditionConfig{
+			Conditions: []ConditionConfig{
+				expectedSchedule,
+				expected,
+			},
+		}}, c)
+	})
+}
+
+func TestCompositeConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         ConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			(*AnyConditionConfig)(nil),
+		},
+		{
+			"happy_path_any",
+			false,
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("")}},
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+				},
+			}},
+		},
+		{
+			"happy_path_all_schedule",
+			false,
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ScheduleConditionConfig{Cron: String("* * * * * * *")},
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+				},
+			}},
+		},
+		{
+			"no_conditions",
+			true,
+			&AllConditionConfig{},
+		},
+		{
+			"nil_nested_condition",
+			true,
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{nil},
+			}},
+		},
+		{
+			"nested_composite",
+			true,
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&AllConditionConfig{CompositeConditionConfig{
+						Conditions: []ConditionConfig{&NodesConditionConfig{}},
+					}},
+				},
+			}},
+		},
+		{
+			"duplicate_type",
+			true,
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("a")}},
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("b")}},
+				},
+			}},
+		},
+		{
+			"services_and_prepared_query",
+			true,
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("^web")}},
+					&PreparedQueryConditionConfig{PreparedQueryMonitorConfig{Name: String("web-failover")}},
+				},
+			}},
+		},
+		{
+			"schedule_in_any",
+			true,
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ScheduleConditionConfig{Cron: String("* * * * * * *")},
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+				},
+			}},
+		},
+		{
+			"webhook_nested",
+			true,
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&WebhookConditionConfig{},
+					&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+				},
+			}},
+		},
+		{
+			"invalid_nested_condition",
+			true,
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ScheduleConditionConfig{Cron: String("invalid")},
+				},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCompositeConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        ConditionConfig
+		expected string
+	}{
+		{
+			"nil_any",
+			(*AnyConditionConfig)(nil),
+			"(*AnyConditionConfig)(nil)",
+		},
+		{
+			"nil_all",
+			(*AllConditionConfig)(nil),
+			"(*AllConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ScheduleConditionConfig{Cron: String("* * * * * * *")},
+					&NodesConditionConfig{
+						NodesMonitorConfig: NodesMonitorConfig{
+							Datacenter: String("dc2"),
+							NodeMeta:   map[string]string{},
+							Filter:     String(""),
+						},
+						SourceIncludesVar: Bool(false),
+					},
+				},
+			}},
+			"&AllConditionConfig{Conditions:[" +
+				"&ScheduleConditionConfig{Cron:* * * * * * *, Timezone:, Jitter:0s, " +
+				"SkipIfRunning:false, CatchUp:false}, " +
+				"&NodesConditionConfig{SourceIncludesVar:false, " +
+				"&NodesMonitorConfig{Datacenter:dc2, Partition:, Peer:, NodeMeta:map[], Filter:}}" +
+				"]}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
+
+func TestFindScheduleCondition(t *testing.T) {
+	t.Parallel()
+
+	schedule := &ScheduleConditionConfig{Cron: String("* * * * * * *")}
+
+	cases := []struct {
+		name     string
+		c        ConditionConfig
+		expected *ScheduleConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"schedule",
+			schedule,
+			schedule,
+		},
+		{
+			"services",
+			&ServicesConditionConfig{},
+			nil,
+		},
+		{
+			"all_with_schedule",
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{&NodesConditionConfig{}, schedule},
+			}},
+			schedule,
+		},
+		{
+			"all_without_schedule",
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{&NodesConditionConfig{}},
+			}},
+			nil,
+		},
+		{
+			"any",
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{schedule},
+			}},
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, ok := FindScheduleCondition(tc.c)
+			assert.Equal(t, tc.expected != nil, ok)
+			assert.Equal(t, tc.expected, s)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*ConfigEntriesConditionConfig)(nil)
+
+// ConfigEntriesConditionConfig configures a condition configuration block
+// of type 'config-entries'. A config-entries condition is triggered by changes
+// that occur to Consul config entries of the configured kinds.
+type ConfigEntriesConditionConfig struct {
+	ConfigEntriesMonitorConfig `mapstructure:",squash"`
+	SourceIncludesVar          *bool `mapstructure:"source_includes_var"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConfigEntriesConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ConfigEntriesConditionConfig
+	o.SourceIncludesVar = BoolCopy(c.SourceIncludesVar)
+
+	m, ok := c.ConfigEntriesMonitorConfig.Copy().(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	o.ConfigEntriesMonitorConfig = *m
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *ConfigEntriesConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*ConfigEntriesConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	r2 := r.(*ConfigEntriesConditionConfig)
+	if o2.SourceIncludesVar != nil {
+		r2.SourceIncludesVar = BoolCopy(o2.SourceIncludesVar)
+	}
+
+	mm, ok := c.ConfigEntriesMonitorConfig.Merge(&o2.ConfigEntriesMonitorConfig).(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+	r2.ConfigEntriesMonitorConfig = *mm
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *ConfigEntriesConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.SourceIncludesVar == nil {
+		c.SourceIncludesVar = Bool(false)
+	}
+
+	c.ConfigEntriesMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *ConfigEntriesConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.ConfigEntriesMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *ConfigEntriesConditionConfig) GoString() string {
+	if c == nil {
+		return "(*ConfigEntriesConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ConfigEntriesConditionConfig{"+
+		"SourceIncludesVar:%v, "+
+		"%s"+
+		"}",
+		BoolVal(c.SourceIncludesVar),
+		c.ConfigEntriesMonitorConfig.GoString(),
+	)
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigEntriesConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds:      []string{"service-defaults", "service-router"},
+					Regexp:     String("^web"),
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+					Partition:  String("ap2"),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesConditionConfig
+		b    *ConfigEntriesConditionConfig
+		r    *ConfigEntriesConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"nil_b",
+			&ConfigEntriesConditionConfig{},
+			nil,
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"kinds_merge",
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+				Kinds: []string{"service-defaults"}}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+				Kinds: []string{"service-router"}}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+				Kinds: []string{"service-defaults", "service-router"}}},
+		},
+		{
+			"regexp_overrides",
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("a")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("b")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("b")}},
+		},
+		{
+			"namespace_empty_one",
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Namespace: String("ns")}},
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Namespace: String("ns")}},
+		},
+		{
+			"source_includes_var_overrides",
+			&ConfigEntriesConditionConfig{SourceIncludesVar: Bool(true)},
+			&ConfigEntriesConditionConfig{SourceIncludesVar: Bool(false)},
+			&ConfigEntriesConditionConfig{SourceIncludesVar: Bool(false)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		s    []string
+		i    *ConfigEntriesConditionConfig
+		r    *ConfigEntriesConditionConfig
+	}{
+		{
+			"empty",
+			[]string{"api"},
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds:      []string{},
+					Regexp:     String(""),
+					Datacenter: String(""),
+					Namespace:  String(""),
+					Partition:  String(""),
+				},
+				SourceIncludesVar: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize(tc.s)
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *ConfigEntriesConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds:  []string{"service-defaults", "service-intentions"},
+					Regexp: String("^web"),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+		},
+		{
+			"missing_kinds",
+			true,
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"unsupported_kind",
+			true,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds: []string{"mesh"},
+				},
+			},
+		},
+		{
+			"duplicate_kind",
+			true,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds: []string{"service-defaults", "service-defaults"},
+				},
+			},
+		},
+		{
+			"invalid_regexp",
+			true,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds:  []string{"service-defaults"},
+					Regexp: String("*"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        ConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			(*ConfigEntriesConditionConfig)(nil),
+			"(*ConfigEntriesConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds:      []string{"service-defaults", "service-router"},
+					Regexp:     String("^web"),
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+					Partition:  String("ap2"),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+			"&ConfigEntriesConditionConfig{SourceIncludesVar:true, " +
+				"&ConfigEntriesMonitorConfig{Kinds:[service-defaults service-router], " +
+				"Regexp:^web, Datacenter:dc2, Namespace:ns2, Partition:ap2}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tc.i.GoString()
+			assert.Equal(t, tc.expected, a, fmt.Sprintf("%s", a))
+		})
+	}
+}
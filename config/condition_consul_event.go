@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+)
+
+const consulEventType = "consul-event"
+
+var _ ConditionConfig = (*ConsulEventConditionConfig)(nil)
+
+// ConsulEventConditionConfig configures a condition configuration block of
+// type 'consul-event'. A consul-event condition is triggered by each new Consul
+// user event of the configured name, e.g. fired by `consul event -name=<name>`.
+// The latest event is provided to the module as the consul_event variable.
+type ConsulEventConditionConfig struct {
+	// Name is the name of the Consul user events to watch
+	Name *string `mapstructure:"name"`
+
+	// Datacenter is the datacenter of the Consul user events
+	Datacenter *string `mapstructure:"datacenter"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConsulEventConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ConsulEventConditionConfig
+	o.Name = StringCopy(c.Name)
+	o.Datacenter = StringCopy(c.Datacenter)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *ConsulEventConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*ConsulEventConditionConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*ConsulEventConditionConfig)
+
+	if o2.Name != nil {
+		r2.Name = StringCopy(o2.Name)
+	}
+
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *ConsulEventConditionConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Name == nil {
+		c.Name = String("")
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *ConsulEventConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if StringVal(c.Name) == "" {
+		return fmt.Errorf("name is required for consul-event condition")
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *ConsulEventConditionConfig) GoString() string {
+	if c == nil {
+		return "(*ConsulEventConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ConsulEventConditionConfig{"+
+		"Name:%s, "+
+		"Datacenter:%s"+
+		"}",
+		StringVal(c.Name),
+		StringVal(c.Datacenter),
+	)
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsulEventConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConsulEventConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ConsulEventConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&ConsulEventConditionConfig{
+				Name:       String("deploy"),
+				Datacenter: String("dc2"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestConsulEventConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConsulEventConditionConfig
+		b    *ConsulEventConditionConfig
+		r    *ConsulEventConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ConsulEventConditionConfig{},
+			&ConsulEventConditionConfig{},
+		},
+		{
+			"nil_b",
+			&ConsulEventConditionConfig{},
+			nil,
+			&ConsulEventConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ConsulEventConditionConfig{},
+			&ConsulEventConditionConfig{},
+			&ConsulEventConditionConfig{},
+		},
+		{
+			"name_overrides",
+			&ConsulEventConditionConfig{Name: String("same")},
+			&ConsulEventConditionConfig{Name: String("different")},
+			&ConsulEventConditionConfig{Name: String("different")},
+		},
+		{
+			"name_empty_one",
+			&ConsulEventConditionConfig{Name: String("same")},
+			&ConsulEventConditionConfig{},
+			&ConsulEventConditionConfig{Name: String("same")},
+		},
+		{
+			"datacenter_overrides",
+			&ConsulEventConditionConfig{Datacenter: String("same")},
+			&ConsulEventConditionConfig{Datacenter: String("different")},
+			&ConsulEventConditionConfig{Datacenter: String("different")},
+		},
+		{
+			"datacenter_empty_one",
+			&ConsulEventConditionConfig{Datacenter: String("same")},
+			&ConsulEventConditionConfig{},
+			&ConsulEventConditionConfig{Datacenter: String("same")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestConsulEventConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *ConsulEventConditionConfig
+		r    *ConsulEventConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ConsulEventConditionConfig{},
+			&ConsulEventConditionConfig{
+				Name:       String(""),
+				Datacenter: String(""),
+			},
+		},
+		{
+			"fully_configured",
+			&ConsulEventConditionConfig{
+				Name:       String("deploy"),
+				Datacenter: String("dc2"),
+			},
+			&ConsulEventConditionConfig{
+				Name:       String("deploy"),
+				Datacenter: String("dc2"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize([]string{})
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestConsulEventConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *ConsulEventConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&ConsulEventConditionConfig{
+				Name:       String("deploy"),
+				Datacenter: String(""),
+			},
+		},
+		{
+			"missing_name",
+			true,
+			&ConsulEventConditionConfig{
+				Datacenter: String("dc2"),
+			},
+		},
+		{
+			"empty_name",
+			true,
+			&ConsulEventConditionConfig{
+				Name: String(""),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsulEventConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *ConsulEventConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"(*ConsulEventConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&ConsulEventConditionConfig{
+				Name:       String("deploy"),
+				Datacenter: String("dc2"),
+			},
+			"&ConsulEventConditionConfig{Name:deploy, Datacenter:dc2}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
@@ -12,6 +12,14 @@ var _ ConditionConfig = (*ConsulKVConditionConfig)(nil)
 type ConsulKVConditionConfig struct {
 	ConsulKVMonitorConfig `mapstructure:",squash"`
 	SourceIncludesVar     *bool `mapstructure:"source_includes_var"`
+
+	// Value is the value that the key needs to be set to for the condition
+	// to be met. The condition is triggered only when the key changes to the
+	// value, and is no longer met once the key changes to another value or
+	// is deleted. Value is compared with the raw value of the key and can
+	// only be configured when recurse is false. When unset, any change to
+	// the key triggers the condition.
+	Value *string `mapstructure:"value"`
 }
 
 // Copy returns a deep copy of this configuration.
@@ -22,6 +30,7 @@ func (c *ConsulKVConditionConfig) Copy() MonitorConfig {
 
 	var o ConsulKVConditionConfig
 	o.SourceIncludesVar = BoolCopy(c.SourceIncludesVar)
+	o.Value = StringCopy(c.Value)
 
 	m, ok := c.ConsulKVMonitorConfig.Copy().(*ConsulKVMonitorConfig)
 	if !ok {
@@ -58,6 +67,10 @@ func (c *ConsulKVConditionConfig) Merge(o MonitorConfig) MonitorConfig {
 		r2.SourceIncludesVar = BoolCopy(o2.SourceIncludesVar)
 	}
 
+	if o2.Value != nil {
+		r2.Value = StringCopy(o2.Value)
+	}
+
 	mm, ok := c.ConsulKVMonitorConfig.Merge(&o2.ConsulKVMonitorConfig).(*ConsulKVMonitorConfig)
 	if !ok {
 		return nil
@@ -67,7 +80,8 @@ func (c *ConsulKVConditionConfig) Merge(o MonitorConfig) MonitorConfig {
 	return r2
 }
 
-// Finalize ensures there no nil pointers.
+// Finalize ensures there no nil pointers. Value is left nil when unset since
+// an empty value is a valid value to match.
 func (c *ConsulKVConditionConfig) Finalize(consulkv []string) {
 	if c == nil { // config not required, return early
 		return
@@ -87,7 +101,16 @@ func (c *ConsulKVConditionConfig) Validate() error {
 		return nil
 	}
 
-	return c.ConsulKVMonitorConfig.Validate()
+	if err := c.ConsulKVMonitorConfig.Validate(); err != nil {
+		return err
+	}
+
+	if c.Value != nil && BoolVal(c.Recurse) {
+		return fmt.Errorf("value cannot be configured for a consul-kv " +
+			"condition with recurse set to true")
+	}
+
+	return nil
 }
 
 // GoString defines the printable version of this struct.
@@ -98,9 +121,11 @@ func (c *ConsulKVConditionConfig) GoString() string {
 
 	return fmt.Sprintf("&ConsulKVConditionConfig{"+
 		"SourceIncludesVar:%v, "+
+		"Value:%s, "+
 		"%s"+
 		"}",
 		BoolVal(c.SourceIncludesVar),
+		StringVal(c.Value),
 		c.ConsulKVMonitorConfig.GoString(),
 	)
 }
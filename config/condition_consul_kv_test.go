@@ -29,8 +29,10 @@ func TestConsulKVConditionConfig_Copy(t *testing.T) {
 					Recurse:    Bool(true),
 					Datacenter: String("dc2"),
 					Namespace:  String("ns2"),
+					Decode:     String("json"),
 				},
 				SourceIncludesVar: Bool(true),
+				Value:             String("true"),
 			},
 		},
 	}
@@ -153,6 +155,30 @@ func TestConsulKVConditionConfig_Merge(t *testing.T) {
 			&ConsulKVConditionConfig{SourceIncludesVar: Bool(true)},
 			&ConsulKVConditionConfig{SourceIncludesVar: Bool(true)},
 		},
+		{
+			"value_overrides",
+			&ConsulKVConditionConfig{Value: String("same")},
+			&ConsulKVConditionConfig{Value: String("different")},
+			&ConsulKVConditionConfig{Value: String("different")},
+		},
+		{
+			"value_empty_one",
+			&ConsulKVConditionConfig{Value: String("same")},
+			&ConsulKVConditionConfig{},
+			&ConsulKVConditionConfig{Value: String("same")},
+		},
+		{
+			"value_empty_two",
+			&ConsulKVConditionConfig{},
+			&ConsulKVConditionConfig{Value: String("same")},
+			&ConsulKVConditionConfig{Value: String("same")},
+		},
+		{
+			"value_empty_string",
+			&ConsulKVConditionConfig{Value: String("same")},
+			&ConsulKVConditionConfig{Value: String("")},
+			&ConsulKVConditionConfig{Value: String("")},
+		},
 		{
 			"datacenter_overrides",
 			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
@@ -201,6 +227,18 @@ func TestConsulKVConditionConfig_Merge(t *testing.T) {
 			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
 			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
 		},
+		{
+			"decode_overrides",
+			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Decode: String("json")}},
+			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Decode: String("yaml")}},
+			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Decode: String("yaml")}},
+		},
+		{
+			"decode_empty_one",
+			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Decode: String("hcl")}},
+			&ConsulKVConditionConfig{},
+			&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Decode: String("hcl")}},
+		},
 	}
 
 	for _, tc := range cases {
@@ -235,6 +273,7 @@ func TestConsulKVConditionConfig_Finalize(t *testing.T) {
 					Recurse:    Bool(false),
 					Datacenter: String(""),
 					Namespace:  String(""),
+					Decode:     String(""),
 				},
 				SourceIncludesVar: Bool(false),
 			},
@@ -249,6 +288,7 @@ func TestConsulKVConditionConfig_Finalize(t *testing.T) {
 					Recurse:    Bool(false),
 					Datacenter: String(""),
 					Namespace:  String(""),
+					Decode:     String(""),
 				},
 				SourceIncludesVar: Bool(false),
 			},
@@ -289,6 +329,48 @@ func TestConsulKVConditionConfig_Validate(t *testing.T) {
 			true,
 			&ConsulKVConditionConfig{},
 		},
+		{
+			"decode",
+			false,
+			&ConsulKVConditionConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:   String("key-path"),
+					Decode: String("hcl"),
+				},
+			},
+		},
+		{
+			"value",
+			false,
+			&ConsulKVConditionConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:    String("key-path"),
+					Recurse: Bool(false),
+				},
+				Value: String("true"),
+			},
+		},
+		{
+			"value_with_recurse",
+			true,
+			&ConsulKVConditionConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:    String("key-path"),
+					Recurse: Bool(true),
+				},
+				Value: String("true"),
+			},
+		},
+		{
+			"invalid_decode",
+			true,
+			&ConsulKVConditionConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:   String("key-path"),
+					Decode: String("xml"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
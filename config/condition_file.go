@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*FileConditionConfig)(nil)
+
+// FileConditionConfig configures a condition configuration block of
+// type 'file'. A file condition is triggered by changes to the contents of
+// the local files matching the configured paths. The parsed files are always
+// provided to the module as the files variable.
+type FileConditionConfig struct {
+	FileMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *FileConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	m, ok := c.FileMonitorConfig.Copy().(*FileMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &FileConditionConfig{
+		FileMonitorConfig: *m,
+	}
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *FileConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*FileConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.FileMonitorConfig.Merge(&o2.FileMonitorConfig).(*FileMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &FileConditionConfig{
+		FileMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there no nil pointers.
+func (c *FileConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	c.FileMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *FileConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.FileMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *FileConditionConfig) GoString() string {
+	if c == nil {
+		return "(*FileConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&FileConditionConfig{"+
+		"%s"+
+		"}",
+		c.FileMonitorConfig.GoString(),
+	)
+}
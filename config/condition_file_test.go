@@ -0,0 +1,280 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *FileConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&FileConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths:  []string{"inventory/*.yaml"},
+					Format: String("yaml"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestFileConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *FileConditionConfig
+		b    *FileConditionConfig
+		r    *FileConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&FileConditionConfig{},
+			&FileConditionConfig{},
+		},
+		{
+			"nil_b",
+			&FileConditionConfig{},
+			nil,
+			&FileConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&FileConditionConfig{},
+			&FileConditionConfig{},
+			&FileConditionConfig{},
+		},
+		{
+			"paths_merge",
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Paths: []string{"a.json"}}},
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Paths: []string{"b.json"}}},
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Paths: []string{"a.json", "b.json"}}},
+		},
+		{
+			"format_overrides",
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Format: String("json")}},
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Format: String("hcl")}},
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Format: String("hcl")}},
+		},
+		{
+			"format_empty_one",
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Format: String("json")}},
+			&FileConditionConfig{},
+			&FileConditionConfig{FileMonitorConfig: FileMonitorConfig{Format: String("json")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestFileConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		s    []string
+		i    *FileConditionConfig
+		r    *FileConditionConfig
+	}{
+		{
+			"nil",
+			[]string{},
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			[]string{"api"},
+			&FileConditionConfig{},
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths:  []string{},
+					Format: String(""),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize(tc.s)
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestFileConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *FileConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths: []string{"inventory/*.json", "/etc/app/config.yml", "hosts.hcl"},
+				},
+			},
+		},
+		{
+			"happy_path_format",
+			false,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths:  []string{"inventory/*"},
+					Format: String("yaml"),
+				},
+			},
+		},
+		{
+			"missing_paths",
+			true,
+			&FileConditionConfig{},
+		},
+		{
+			"empty_path",
+			true,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths: []string{"a.json", " "},
+				},
+			},
+		},
+		{
+			"duplicate_path",
+			true,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths: []string{"inventory/a.json", "inventory/../inventory/a.json"},
+				},
+			},
+		},
+		{
+			"invalid_pattern",
+			true,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths: []string{"inventory/[a.json"},
+				},
+			},
+		},
+		{
+			"unknown_extension",
+			true,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths: []string{"inventory/*"},
+				},
+			},
+		},
+		{
+			"invalid_format",
+			true,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths:  []string{"inventory.xml"},
+					Format: String("xml"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFileConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        ConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			(*FileConditionConfig)(nil),
+			"(*FileConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths:  []string{"inventory/*.yaml"},
+					Format: String("yaml"),
+				},
+			},
+			"&FileConditionConfig{&FileMonitorConfig{" +
+				"Paths:[inventory/*.yaml], Format:yaml}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tc.i.GoString()
+			assert.Equal(t, tc.expected, a, fmt.Sprintf("%s", a))
+		})
+	}
+}
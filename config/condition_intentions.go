@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*IntentionsConditionConfig)(nil)
+
+// IntentionsConditionConfig configures a condition configuration block
+// of type 'intentions'. An intentions condition is triggered when Consul
+// service intentions are created, changed, or deleted.
+type IntentionsConditionConfig struct {
+	IntentionsMonitorConfig `mapstructure:",squash"`
+	SourceIncludesVar       *bool `mapstructure:"source_includes_var"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *IntentionsConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o IntentionsConditionConfig
+	o.SourceIncludesVar = BoolCopy(c.SourceIncludesVar)
+
+	m, ok := c.IntentionsMonitorConfig.Copy().(*IntentionsMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	o.IntentionsMonitorConfig = *m
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *IntentionsConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*IntentionsConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	r2 := r.(*IntentionsConditionConfig)
+	if o2.SourceIncludesVar != nil {
+		r2.SourceIncludesVar = BoolCopy(o2.SourceIncludesVar)
+	}
+
+	mm, ok := c.IntentionsMonitorConfig.Merge(&o2.IntentionsMonitorConfig).(*IntentionsMonitorConfig)
+	if !ok {
+		return nil
+	}
+	r2.IntentionsMonitorConfig = *mm
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *IntentionsConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.SourceIncludesVar == nil {
+		c.SourceIncludesVar = Bool(false)
+	}
+
+	c.IntentionsMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *IntentionsConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.IntentionsMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *IntentionsConditionConfig) GoString() string {
+	if c == nil {
+		return "(*IntentionsConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&IntentionsConditionConfig{"+
+		"SourceIncludesVar:%v, "+
+		"%s"+
+		"}",
+		BoolVal(c.SourceIncludesVar),
+		c.IntentionsMonitorConfig.GoString(),
+	)
+}
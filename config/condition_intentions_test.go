@@ -0,0 +1,226 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntentionsConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *IntentionsConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&IntentionsConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					SourceRegexp:      String("^web$"),
+					DestinationRegexp: String("^api$"),
+					Datacenter:        String("dc2"),
+					Namespace:         String("ns2"),
+					Partition:         String("ap2"),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *IntentionsConditionConfig
+		b    *IntentionsConditionConfig
+		r    *IntentionsConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{},
+		},
+		{
+			"nil_b",
+			&IntentionsConditionConfig{},
+			nil,
+			&IntentionsConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"regexp_overrides",
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{
+				SourceRegexp: String("web"), DestinationRegexp: String("api")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{
+				SourceRegexp: String("db")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{
+				SourceRegexp: String("db"), DestinationRegexp: String("api")}},
+		},
+		{
+			"datacenter_namespace_overrides",
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{
+				Datacenter: String("dc1"), Namespace: String("ns1")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{
+				Datacenter: String("dc2"), Namespace: String("ns2")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{
+				Datacenter: String("dc2"), Namespace: String("ns2")}},
+		},
+		{
+			"source_includes_var_overrides",
+			&IntentionsConditionConfig{SourceIncludesVar: Bool(false)},
+			&IntentionsConditionConfig{SourceIncludesVar: Bool(true)},
+			&IntentionsConditionConfig{SourceIncludesVar: Bool(true)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	c := &IntentionsConditionConfig{}
+	c.Finalize([]string{"api"})
+	assert.Equal(t, &IntentionsConditionConfig{
+		IntentionsMonitorConfig: IntentionsMonitorConfig{
+			SourceRegexp:      String(""),
+			DestinationRegexp: String(""),
+			Datacenter:        String(""),
+			Namespace:         String(""),
+			Partition:         String(""),
+		},
+		SourceIncludesVar: Bool(false),
+	}, c)
+}
+
+func TestIntentionsConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *IntentionsConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					SourceRegexp:      String("^web$"),
+					DestinationRegexp: String(".*"),
+				},
+			},
+		},
+		{
+			"invalid_source_regexp",
+			true,
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					SourceRegexp: String("*"),
+				},
+			},
+		},
+		{
+			"invalid_destination_regexp",
+			true,
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					DestinationRegexp: String("*"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIntentionsConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *IntentionsConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"(*IntentionsConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					SourceRegexp:      String("^web$"),
+					DestinationRegexp: String("^api$"),
+					Datacenter:        String("dc2"),
+					Namespace:         String("ns2"),
+					Partition:         String("ap2"),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+			"&IntentionsConditionConfig{SourceIncludesVar:true, " +
+				"&IntentionsMonitorConfig{SourceRegexp:^web$, " +
+				"DestinationRegexp:^api$, Datacenter:dc2, Namespace:ns2, Partition:ap2}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
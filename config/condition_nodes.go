@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*NodesConditionConfig)(nil)
+
+// NodesConditionConfig configures a condition configuration block
+// of type 'nodes'. A nodes condition is triggered by changes that occur
+// to the nodes registered in the Consul catalog.
+type NodesConditionConfig struct {
+	NodesMonitorConfig `mapstructure:",squash"`
+	SourceIncludesVar  *bool `mapstructure:"source_includes_var"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *NodesConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o NodesConditionConfig
+	o.SourceIncludesVar = BoolCopy(c.SourceIncludesVar)
+
+	m, ok := c.NodesMonitorConfig.Copy().(*NodesMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	o.NodesMonitorConfig = *m
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *NodesConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*NodesConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	r2 := r.(*NodesConditionConfig)
+	if o2.SourceIncludesVar != nil {
+		r2.SourceIncludesVar = BoolCopy(o2.SourceIncludesVar)
+	}
+
+	mm, ok := c.NodesMonitorConfig.Merge(&o2.NodesMonitorConfig).(*NodesMonitorConfig)
+	if !ok {
+		return nil
+	}
+	r2.NodesMonitorConfig = *mm
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *NodesConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.SourceIncludesVar == nil {
+		c.SourceIncludesVar = Bool(false)
+	}
+
+	c.NodesMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *NodesConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.NodesMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *NodesConditionConfig) GoString() string {
+	if c == nil {
+		return "(*NodesConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&NodesConditionConfig{"+
+		"SourceIncludesVar:%v, "+
+		"%s"+
+		"}",
+		BoolVal(c.SourceIncludesVar),
+		c.NodesMonitorConfig.GoString(),
+	)
+}
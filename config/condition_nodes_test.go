@@ -0,0 +1,260 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodesConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *NodesConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&NodesConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					Partition:  String("ap2"),
+					Peer:       String("peer2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Node != \"web\""),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestNodesConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *NodesConditionConfig
+		b    *NodesConditionConfig
+		r    *NodesConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&NodesConditionConfig{},
+			&NodesConditionConfig{},
+		},
+		{
+			"nil_b",
+			&NodesConditionConfig{},
+			nil,
+			&NodesConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&NodesConditionConfig{},
+			&NodesConditionConfig{},
+			&NodesConditionConfig{},
+		},
+		{
+			"datacenter_overrides",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("dc1")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("dc2")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("dc2")}},
+		},
+		{
+			"partition_overrides",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Partition: String("ap1")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Partition: String("ap2")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Partition: String("ap2")}},
+		},
+		{
+			"peer_overrides",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Peer: String("peer1")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Peer: String("peer2")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Peer: String("peer2")}},
+		},
+		{
+			"filter_empty_one",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("Node == \"a\"")}},
+			&NodesConditionConfig{},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("Node == \"a\"")}},
+		},
+		{
+			"node_meta_merges",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"foo": "bar", "baz": "a"}}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"baz": "b"}}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"foo": "bar", "baz": "b"}}},
+		},
+		{
+			"source_includes_var_overrides",
+			&NodesConditionConfig{SourceIncludesVar: Bool(true)},
+			&NodesConditionConfig{SourceIncludesVar: Bool(false)},
+			&NodesConditionConfig{SourceIncludesVar: Bool(false)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestNodesConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		s    []string
+		i    *NodesConditionConfig
+		r    *NodesConditionConfig
+	}{
+		{
+			"empty",
+			[]string{"api"},
+			&NodesConditionConfig{},
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String(""),
+					Partition:  String(""),
+					Peer:       String(""),
+					NodeMeta:   map[string]string{},
+					Filter:     String(""),
+				},
+				SourceIncludesVar: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize(tc.s)
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestNodesConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *NodesConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Meta.env == \"prod\""),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+		},
+		{
+			"invalid_filter",
+			true,
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Filter: String("Node =="),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodesConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        ConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			(*NodesConditionConfig)(nil),
+			"(*NodesConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					Partition:  String("ap2"),
+					Peer:       String("peer2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Node != \"web\""),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+			"&NodesConditionConfig{SourceIncludesVar:true, " +
+				"&NodesMonitorConfig{Datacenter:dc2, Partition:ap2, Peer:peer2, " +
+				"NodeMeta:map[key:value], " +
+				"Filter:Node != \"web\"}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tc.i.GoString()
+			assert.Equal(t, tc.expected, a, fmt.Sprintf("%s", a))
+		})
+	}
+}
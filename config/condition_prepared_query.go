@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*PreparedQueryConditionConfig)(nil)
+
+// PreparedQueryConditionConfig configures a condition configuration block of
+// type 'prepared-query'. A prepared-query condition is triggered by changes
+// that occur to the service instances of the prepared query result. The
+// service instances are always provided to the module as the services
+// variable in place of the task's services.
+type PreparedQueryConditionConfig struct {
+	PreparedQueryMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *PreparedQueryConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	m, ok := c.PreparedQueryMonitorConfig.Copy().(*PreparedQueryMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &PreparedQueryConditionConfig{
+		PreparedQueryMonitorConfig: *m,
+	}
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *PreparedQueryConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*PreparedQueryConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.PreparedQueryMonitorConfig.Merge(&o2.PreparedQueryMonitorConfig).(*PreparedQueryMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &PreparedQueryConditionConfig{
+		PreparedQueryMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there no nil pointers.
+func (c *PreparedQueryConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	c.PreparedQueryMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *PreparedQueryConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.PreparedQueryMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *PreparedQueryConditionConfig) GoString() string {
+	if c == nil {
+		return "(*PreparedQueryConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&PreparedQueryConditionConfig{"+
+		"%s"+
+		"}",
+		c.PreparedQueryMonitorConfig.GoString(),
+	)
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreparedQueryConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *PreparedQueryConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&PreparedQueryConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&PreparedQueryConditionConfig{
+				PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{
+					Name:       String("web-failover"),
+					Datacenter: String("dc2"),
+					Near:       String("_agent"),
+					Limit:      Int(3),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestPreparedQueryConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *PreparedQueryConditionConfig
+		b    *PreparedQueryConditionConfig
+		r    *PreparedQueryConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&PreparedQueryConditionConfig{},
+			&PreparedQueryConditionConfig{},
+		},
+		{
+			"nil_b",
+			&PreparedQueryConditionConfig{},
+			nil,
+			&PreparedQueryConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&PreparedQueryConditionConfig{},
+			&PreparedQueryConditionConfig{},
+			&PreparedQueryConditionConfig{},
+		},
+		{
+			"name_overrides",
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Name: String("a")}},
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Name: String("b")}},
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Name: String("b")}},
+		},
+		{
+			"near_empty_one",
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Near: String("_agent")}},
+			&PreparedQueryConditionConfig{},
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Near: String("_agent")}},
+		},
+		{
+			"limit_overrides",
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Limit: Int(3)}},
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Limit: Int(0)}},
+			&PreparedQueryConditionConfig{PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{Limit: Int(0)}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestPreparedQueryConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		s    []string
+		i    *PreparedQueryConditionConfig
+		r    *PreparedQueryConditionConfig
+	}{
+		{
+			"nil",
+			[]string{},
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			[]string{"api"},
+			&PreparedQueryConditionConfig{},
+			&PreparedQueryConditionConfig{
+				PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{
+					Name:       String(""),
+					Datacenter: String(""),
+					Near:       String(""),
+					Limit:      Int(0),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize(tc.s)
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestPreparedQueryConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *PreparedQueryConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&PreparedQueryConditionConfig{
+				PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{
+					Name:  String("web-failover"),
+					Near:  String("_agent"),
+					Limit: Int(3),
+				},
+			},
+		},
+		{
+			"missing_name",
+			true,
+			&PreparedQueryConditionConfig{},
+		},
+		{
+			"negative_limit",
+			true,
+			&PreparedQueryConditionConfig{
+				PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{
+					Name:  String("web-failover"),
+					Limit: Int(-1),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPreparedQueryConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        ConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			(*PreparedQueryConditionConfig)(nil),
+			"(*PreparedQueryConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&PreparedQueryConditionConfig{
+				PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{
+					Name:       String("web-failover"),
+					Datacenter: String("dc2"),
+					Near:       String("_agent"),
+					Limit:      Int(3),
+				},
+			},
+			"&PreparedQueryConditionConfig{&PreparedQueryMonitorConfig{" +
+				"Name:web-failover, Datacenter:dc2, Near:_agent, Limit:3}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tc.i.GoString()
+			assert.Equal(t, tc.expected, a, fmt.Sprintf("%s", a))
+		})
+	}
+}
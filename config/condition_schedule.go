@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/cronexpr"
 )
@@ -14,6 +15,24 @@ var _ ConditionConfig = (*ScheduleConditionConfig)(nil)
 // 'schedule'. A schedule condition is triggered by a configured cron schedule
 type ScheduleConditionConfig struct {
 	Cron *string `mapstructure:"cron"`
+
+	// Timezone is the IANA time zone name, e.g. "America/New_York", in which
+	// the cron schedule is evaluated. Defaults to the local time zone of the
+	// host.
+	Timezone *string `mapstructure:"timezone"`
+
+	// Jitter is the maximum random delay added to each scheduled run, which
+	// spreads out tasks with the same schedule. Disabled by default.
+	Jitter *time.Duration `mapstructure:"jitter"`
+
+	// SkipIfRunning configures whether a scheduled run is skipped when the
+	// task is already running. When false, the task runs once the running
+	// task completes. Defaults to true.
+	SkipIfRunning *bool `mapstructure:"skip_if_running"`
+
+	// CatchUp configures whether the task runs on startup when scheduled runs
+	// were missed while Sync was not running. Defaults to false.
+	CatchUp *bool `mapstructure:"catch_up"`
 }
 
 // Copy returns a deep copy of this configuration.
@@ -24,6 +43,10 @@ func (c *ScheduleConditionConfig) Copy() MonitorConfig {
 
 	var o ScheduleConditionConfig
 	o.Cron = StringCopy(c.Cron)
+	o.Timezone = StringCopy(c.Timezone)
+	o.Jitter = TimeDurationCopy(c.Jitter)
+	o.SkipIfRunning = BoolCopy(c.SkipIfRunning)
+	o.CatchUp = BoolCopy(c.CatchUp)
 
 	return &o
 }
@@ -54,6 +77,22 @@ func (c *ScheduleConditionConfig) Merge(o MonitorConfig) MonitorConfig {
 		r2.Cron = StringCopy(o2.Cron)
 	}
 
+	if o2.Timezone != nil {
+		r2.Timezone = StringCopy(o2.Timezone)
+	}
+
+	if o2.Jitter != nil {
+		r2.Jitter = TimeDurationCopy(o2.Jitter)
+	}
+
+	if o2.SkipIfRunning != nil {
+		r2.SkipIfRunning = BoolCopy(o2.SkipIfRunning)
+	}
+
+	if o2.CatchUp != nil {
+		r2.CatchUp = BoolCopy(o2.CatchUp)
+	}
+
 	return r2
 }
 
@@ -66,6 +105,22 @@ func (c *ScheduleConditionConfig) Finalize([]string) {
 	if c.Cron == nil {
 		c.Cron = String("")
 	}
+
+	if c.Timezone == nil {
+		c.Timezone = String("")
+	}
+
+	if c.Jitter == nil {
+		c.Jitter = TimeDuration(0)
+	}
+
+	if c.SkipIfRunning == nil {
+		c.SkipIfRunning = Bool(true)
+	}
+
+	if c.CatchUp == nil {
+		c.CatchUp = Bool(false)
+	}
 }
 
 // Validate validates the values and required options. This method is recommended
@@ -85,9 +140,28 @@ func (c *ScheduleConditionConfig) Validate() error {
 			StringVal(c.Cron), err, "https://github.com/hashicorp/cronexpr")
 	}
 
+	if _, err := c.Location(); err != nil {
+		return fmt.Errorf("unable to load schedule condition's timezone %q: %s",
+			StringVal(c.Timezone), err)
+	}
+
+	if c.Jitter != nil && *c.Jitter < 0 {
+		return fmt.Errorf("schedule condition's jitter must be a positive "+
+			"duration: %s", c.Jitter.String())
+	}
+
 	return nil
 }
 
+// Location returns the time zone location to evaluate the cron schedule in.
+// The local time zone is returned when the timezone is not configured.
+func (c *ScheduleConditionConfig) Location() (*time.Location, error) {
+	if c == nil || StringVal(c.Timezone) == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(*c.Timezone)
+}
+
 // GoString defines the printable version of this struct.
 func (c *ScheduleConditionConfig) GoString() string {
 	if c == nil {
@@ -96,7 +170,15 @@ func (c *ScheduleConditionConfig) GoString() string {
 
 	return fmt.Sprintf("&ScheduleConditionConfig{"+
 		"Cron:%s, "+
+		"Timezone:%s, "+
+		"Jitter:%s, "+
+		"SkipIfRunning:%t, "+
+		"CatchUp:%t"+
 		"}",
 		StringVal(c.Cron),
+		StringVal(c.Timezone),
+		TimeDurationVal(c.Jitter),
+		BoolVal(c.SkipIfRunning),
+		BoolVal(c.CatchUp),
 	)
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,7 +25,11 @@ func TestScheduleConditionConfig_Copy(t *testing.T) {
 		{
 			"fully_configured",
 			&ScheduleConditionConfig{
-				Cron: String("* * * * * * *"),
+				Cron:          String("* * * * * * *"),
+				Timezone:      String("UTC"),
+				Jitter:        TimeDuration(30 * time.Second),
+				SkipIfRunning: Bool(false),
+				CatchUp:       Bool(true),
 			},
 		},
 	}
@@ -99,6 +104,36 @@ func TestScheduleConditionConfig_Merge(t *testing.T) {
 			&ScheduleConditionConfig{Cron: String("same")},
 			&ScheduleConditionConfig{Cron: String("same")},
 		},
+		{
+			"timezone_overrides",
+			&ScheduleConditionConfig{Timezone: String("UTC")},
+			&ScheduleConditionConfig{Timezone: String("Europe/Paris")},
+			&ScheduleConditionConfig{Timezone: String("Europe/Paris")},
+		},
+		{
+			"jitter_overrides",
+			&ScheduleConditionConfig{Jitter: TimeDuration(time.Second)},
+			&ScheduleConditionConfig{Jitter: TimeDuration(time.Minute)},
+			&ScheduleConditionConfig{Jitter: TimeDuration(time.Minute)},
+		},
+		{
+			"jitter_empty_two",
+			&ScheduleConditionConfig{Jitter: TimeDuration(time.Second)},
+			&ScheduleConditionConfig{},
+			&ScheduleConditionConfig{Jitter: TimeDuration(time.Second)},
+		},
+		{
+			"skip_if_running_overrides",
+			&ScheduleConditionConfig{SkipIfRunning: Bool(true)},
+			&ScheduleConditionConfig{SkipIfRunning: Bool(false)},
+			&ScheduleConditionConfig{SkipIfRunning: Bool(false)},
+		},
+		{
+			"catch_up_overrides",
+			&ScheduleConditionConfig{CatchUp: Bool(false)},
+			&ScheduleConditionConfig{CatchUp: Bool(true)},
+			&ScheduleConditionConfig{CatchUp: Bool(true)},
+		},
 	}
 
 	for _, tc := range cases {
@@ -134,7 +169,11 @@ func TestScheduleConditionConfig_Finalize(t *testing.T) {
 			[]string{},
 			&ScheduleConditionConfig{},
 			&ScheduleConditionConfig{
-				Cron: String(""),
+				Cron:          String(""),
+				Timezone:      String(""),
+				Jitter:        TimeDuration(0),
+				SkipIfRunning: Bool(true),
+				CatchUp:       Bool(false),
 			},
 		},
 		{
@@ -144,7 +183,29 @@ func TestScheduleConditionConfig_Finalize(t *testing.T) {
 				Cron: String("* * * * *"),
 			},
 			&ScheduleConditionConfig{
-				Cron: String("* * * * *"),
+				Cron:          String("* * * * *"),
+				Timezone:      String(""),
+				Jitter:        TimeDuration(0),
+				SkipIfRunning: Bool(true),
+				CatchUp:       Bool(false),
+			},
+		},
+		{
+			"fully_configured",
+			[]string{},
+			&ScheduleConditionConfig{
+				Cron:          String("* * * * *"),
+				Timezone:      String("UTC"),
+				Jitter:        TimeDuration(time.Minute),
+				SkipIfRunning: Bool(false),
+				CatchUp:       Bool(true),
+			},
+			&ScheduleConditionConfig{
+				Cron:          String("* * * * *"),
+				Timezone:      String("UTC"),
+				Jitter:        TimeDuration(time.Minute),
+				SkipIfRunning: Bool(false),
+				CatchUp:       Bool(true),
 			},
 		},
 	}
@@ -189,6 +250,31 @@ func TestScheduleConditionConfig_Validate(t *testing.T) {
 				Cron: String("invalid"),
 			},
 		},
+		{
+			"valid_timezone_and_jitter",
+			false,
+			&ScheduleConditionConfig{
+				Cron:     String("* * * * * * *"),
+				Timezone: String("America/New_York"),
+				Jitter:   TimeDuration(30 * time.Second),
+			},
+		},
+		{
+			"invalid_timezone",
+			true,
+			&ScheduleConditionConfig{
+				Cron:     String("* * * * * * *"),
+				Timezone: String("Mars/Olympus_Mons"),
+			},
+		},
+		{
+			"negative_jitter",
+			true,
+			&ScheduleConditionConfig{
+				Cron:   String("* * * * * * *"),
+				Jitter: TimeDuration(-time.Second),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -202,3 +288,22 @@ func TestScheduleConditionConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleConditionConfig_Location(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		c := &ScheduleConditionConfig{}
+		c.Finalize([]string{})
+		loc, err := c.Location()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Local, loc)
+	})
+
+	t.Run("timezone", func(t *testing.T) {
+		c := &ScheduleConditionConfig{Timezone: String("UTC")}
+		loc, err := c.Location()
+		assert.NoError(t, err)
+		assert.Equal(t, "UTC", loc.String())
+	})
+}
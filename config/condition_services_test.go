@@ -25,7 +25,14 @@ func TestServicesConditionConfig_Copy(t *testing.T) {
 			"fully_configured",
 			&ServicesConditionConfig{
 				ServicesMonitorConfig{
-					Regexp: String("^web.*"),
+					Regexp:      String("^web.*"),
+					Datacenter:  String("dc2"),
+					Datacenters: []string{},
+					Namespace:   String("ns2"),
+					Partition:   String("ap2"),
+					Peer:        String("peer2"),
+					NodeMeta:    map[string]string{"key": "value"},
+					Filter:      String("Service.Tags contains \"v1\""),
 				},
 			},
 		},
@@ -101,6 +108,60 @@ func TestServicesConditionConfig_Merge(t *testing.T) {
 			&ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("same")}},
 			&ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("same")}},
 		},
+		{
+			"datacenter_overrides",
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenter: String("same")}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenter: String("different")}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenter: String("different")}},
+		},
+		{
+			"datacenter_empty_one",
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenter: String("same")}},
+			&ServicesConditionConfig{},
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenter: String("same")}},
+		},
+		{
+			"datacenters_merges",
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenters: []string{"dc1"}}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenters: []string{"dc2"}}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Datacenters: []string{"dc1", "dc2"}}},
+		},
+		{
+			"namespace_overrides",
+			&ServicesConditionConfig{ServicesMonitorConfig{Namespace: String("same")}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Namespace: String("different")}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Namespace: String("different")}},
+		},
+		{
+			"namespace_empty_one",
+			&ServicesConditionConfig{ServicesMonitorConfig{Namespace: String("same")}},
+			&ServicesConditionConfig{},
+			&ServicesConditionConfig{ServicesMonitorConfig{Namespace: String("same")}},
+		},
+		{
+			"node_meta_merges",
+			&ServicesConditionConfig{ServicesMonitorConfig{NodeMeta: map[string]string{"foo": "bar"}}},
+			&ServicesConditionConfig{ServicesMonitorConfig{NodeMeta: map[string]string{"baz": "qux"}}},
+			&ServicesConditionConfig{ServicesMonitorConfig{NodeMeta: map[string]string{"foo": "bar", "baz": "qux"}}},
+		},
+		{
+			"node_meta_overrides",
+			&ServicesConditionConfig{ServicesMonitorConfig{NodeMeta: map[string]string{"key": "value"}}},
+			&ServicesConditionConfig{ServicesMonitorConfig{NodeMeta: map[string]string{"key": "new-value"}}},
+			&ServicesConditionConfig{ServicesMonitorConfig{NodeMeta: map[string]string{"key": "new-value"}}},
+		},
+		{
+			"filter_overrides",
+			&ServicesConditionConfig{ServicesMonitorConfig{Filter: String("same")}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Filter: String("different")}},
+			&ServicesConditionConfig{ServicesMonitorConfig{Filter: String("different")}},
+		},
+		{
+			"filter_empty_one",
+			&ServicesConditionConfig{ServicesMonitorConfig{Filter: String("same")}},
+			&ServicesConditionConfig{},
+			&ServicesConditionConfig{ServicesMonitorConfig{Filter: String("same")}},
+		},
 	}
 
 	for _, tc := range cases {
@@ -131,7 +192,14 @@ func TestServicesConditionConfig_Finalize(t *testing.T) {
 			&ServicesConditionConfig{},
 			&ServicesConditionConfig{
 				ServicesMonitorConfig{
-					Regexp: String(""),
+					Regexp:      String(""),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
 			},
 		},
@@ -141,7 +209,14 @@ func TestServicesConditionConfig_Finalize(t *testing.T) {
 			&ServicesConditionConfig{},
 			&ServicesConditionConfig{
 				ServicesMonitorConfig{
-					Regexp: String(""),
+					Regexp:      String(""),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
 			},
 		},
@@ -172,6 +247,22 @@ func TestServicesConditionConfig_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			"happy_path_query_options",
+			false,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp:      String(".*"),
+					Datacenter:  String("dc2"),
+					Datacenters: []string{},
+					Namespace:   String("ns2"),
+					Partition:   String("ap2"),
+					Peer:        String("peer2"),
+					NodeMeta:    map[string]string{"key": "value"},
+					Filter:      String("Service.Tags contains \"v1\""),
+				},
+			},
+		},
 		{
 			"invalid_regexp",
 			true,
@@ -181,6 +272,79 @@ func TestServicesConditionConfig_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid_filter",
+			true,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp: String(".*"),
+					Filter: String("Service.Tags contains"),
+				},
+			},
+		},
+		{
+			"datacenters",
+			false,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp:      String(".*"),
+					Datacenters: []string{"*"},
+				},
+			},
+		},
+		{
+			"datacenter_and_datacenters",
+			true,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp:      String(".*"),
+					Datacenter:  String("dc1"),
+					Datacenters: []string{"dc2"},
+				},
+			},
+		},
+		{
+			"query_options_without_regexp",
+			true,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp:      String(""),
+					Datacenter:  String("dc2"),
+					Datacenters: []string{},
+				},
+			},
+		},
+		{
+			"partition_without_regexp",
+			true,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp:    String(""),
+					Partition: String("ap2"),
+				},
+			},
+		},
+		{
+			"peer_without_regexp",
+			true,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp: String(""),
+					Peer:   String("peer2"),
+				},
+			},
+		},
+		{
+			"peer_and_datacenters",
+			true,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp:      String(".*"),
+					Peer:        String("peer2"),
+					Datacenters: []string{"dc1", "dc2"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +25,8 @@ func TestCondition_DecodeConfig(t *testing.T) {
 					SourceIncludesVar: Bool(true),
 					Datacenter:        String("dc2"),
 					Namespace:         String("ns2"),
+					Partition:         String(""),
+					Peer:              String(""),
 					NodeMeta: map[string]string{
 						"key1": "value1",
 						"key2": "value2",
@@ -57,6 +60,8 @@ task {
 					SourceIncludesVar: Bool(false),
 					Datacenter:        String(""),
 					Namespace:         String(""),
+					Partition:         String(""),
+					Peer:              String(""),
 					NodeMeta:          map[string]string{},
 				},
 			},
@@ -75,7 +80,14 @@ task {
 			false,
 			&ServicesConditionConfig{
 				ServicesMonitorConfig{
-					Regexp: String(""),
+					Regexp:      String(""),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
 			},
 			"config.hcl",
@@ -91,7 +103,47 @@ task {
 			false,
 			&ServicesConditionConfig{
 				ServicesMonitorConfig{
-					Regexp: String(".*"),
+					Regexp:      String(".*"),
+					Datacenter:  String("dc2"),
+					Datacenters: []string{},
+					Namespace:   String("ns2"),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta: map[string]string{
+						"key": "value",
+					},
+					Filter: String("Service.Tags not contains \"canary\""),
+				},
+			},
+			"config.hcl",
+			`
+task {
+	name = "services_condition_task"
+	source = "..."
+	condition "services" {
+		regexp = ".*"
+		datacenter = "dc2"
+		namespace = "ns2"
+		node_meta {
+			key = "value"
+		}
+		filter = "Service.Tags not contains \"canary\""
+	}
+}`,
+		},
+		{
+			"services: datacenters",
+			false,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig{
+					Regexp:      String(".*"),
+					Datacenter:  String(""),
+					Datacenters: []string{"dc1", "dc2"},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
 			},
 			"config.hcl",
@@ -101,6 +153,7 @@ task {
 	source = "..."
 	condition "services" {
 		regexp = ".*"
+		datacenters = ["dc1", "dc2"]
 	}
 }`,
 		},
@@ -109,7 +162,14 @@ task {
 			false,
 			&ServicesConditionConfig{
 				ServicesMonitorConfig{
-					Regexp: String(""),
+					Regexp:      String(""),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
 			},
 			"config.hcl",
@@ -141,7 +201,11 @@ task {
 			"schedule: happy path",
 			false,
 			&ScheduleConditionConfig{
-				Cron: String("* * * * * * *"),
+				Cron:          String("* * * * * * *"),
+				Timezone:      String("America/New_York"),
+				Jitter:        TimeDuration(30 * time.Second),
+				SkipIfRunning: Bool(false),
+				CatchUp:       Bool(true),
 			},
 			"config.hcl",
 			`
@@ -151,6 +215,125 @@ task {
 	services = ["api"]
 	condition "schedule" {
 		cron = "* * * * * * *"
+		timezone = "America/New_York"
+		jitter = "30s"
+		skip_if_running = false
+		catch_up = true
+	}
+}`,
+		},
+		{
+			"webhook: happy path",
+			false,
+			&WebhookConditionConfig{
+				Secret: String("s3cr3t"),
+				Schema: map[string]string{
+					"commit": "string",
+					"files":  "list",
+				},
+			},
+			"config.hcl",
+			`
+task {
+	name = "webhook_condition_task"
+	source = "..."
+	services = ["api"]
+	condition "webhook" {
+		secret = "s3cr3t"
+		schema {
+			commit = "string"
+			files = "list"
+		}
+	}
+}`,
+		},
+		{
+			"consul-event: happy path",
+			false,
+			&ConsulEventConditionConfig{
+				Name:       String("deploy"),
+				Datacenter: String("dc2"),
+			},
+			"config.hcl",
+			`
+task {
+	name = "consul_event_condition_task"
+	source = "..."
+	services = ["api"]
+	condition "consul-event" {
+		name = "deploy"
+		datacenter = "dc2"
+	}
+}`,
+		},
+		{
+			"prepared-query: happy path",
+			false,
+			&PreparedQueryConditionConfig{
+				PreparedQueryMonitorConfig: PreparedQueryMonitorConfig{
+					Name:       String("web-failover"),
+					Datacenter: String("dc2"),
+					Near:       String("_agent"),
+					Limit:      Int(3),
+				},
+			},
+			"config.hcl",
+			`
+task {
+	name = "prepared_query_condition_task"
+	source = "..."
+	condition "prepared-query" {
+		name = "web-failover"
+		datacenter = "dc2"
+		near = "_agent"
+		limit = 3
+	}
+}`,
+		},
+		{
+			"vault-secret: happy path",
+			false,
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"pki/issue/web"},
+					Data:  map[string]string{"common_name": "web.example.com"},
+				},
+			},
+			"config.hcl",
+			`
+vault {
+	address = "vault.example.com"
+}
+
+task {
+	name = "vault_secret_condition_task"
+	source = "..."
+	services = ["api"]
+	condition "vault-secret" {
+		paths = ["pki/issue/web"]
+		data = {
+			common_name = "web.example.com"
+		}
+	}
+}`,
+		},
+		{
+			"file: happy path",
+			false,
+			&FileConditionConfig{
+				FileMonitorConfig: FileMonitorConfig{
+					Paths:  []string{"inventory/*.yaml", "hosts.yml"},
+					Format: String(""),
+				},
+			},
+			"config.hcl",
+			`
+task {
+	name = "file_condition_task"
+	source = "..."
+	services = ["api"]
+	condition "file" {
+		paths = ["inventory/*.yaml", "hosts.yml"]
 	}
 }`,
 		},
@@ -196,6 +379,7 @@ task {
 					Datacenter: String("dc2"),
 					Namespace:  String("ns2"),
 					Recurse:    Bool(true),
+					Decode:     String("json"),
 				},
 				SourceIncludesVar: Bool(true),
 			},
@@ -211,6 +395,191 @@ task {
 		namespace = "ns2"
 		datacenter = "dc2"
 		recurse = true
+		decode = "json"
+	}
+}`,
+		},
+		{
+			"nodes: happy path",
+			false,
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					Partition:  String(""),
+					Peer:       String(""),
+					NodeMeta: map[string]string{
+						"key1": "value1",
+					},
+					Filter: String("Node != \"web\""),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "nodes" {
+		source_includes_var = true
+		datacenter = "dc2"
+		node_meta {
+		  "key1" = "value1"
+		}
+		filter = "Node != \"web\""
+	}
+}`,
+		},
+		{
+			"intentions: happy path",
+			false,
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					SourceRegexp:      String("^web$"),
+					DestinationRegexp: String(""),
+					Datacenter:        String("dc2"),
+					Namespace:         String("ns2"),
+					Partition:         String(""),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "intentions" {
+		source_includes_var = true
+		source_regexp = "^web$"
+		datacenter = "dc2"
+		namespace = "ns2"
+	}
+}`,
+		},
+		{
+			"config-entries: happy path",
+			false,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kinds:      []string{"service-defaults", "service-router"},
+					Regexp:     String("^web"),
+					Datacenter: String(""),
+					Namespace:  String(""),
+					Partition:  String(""),
+				},
+				SourceIncludesVar: Bool(true),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "config-entries" {
+		source_includes_var = true
+		kinds = ["service-defaults", "service-router"]
+		regexp = "^web"
+	}
+}`,
+		},
+		{
+			"any: happy path",
+			false,
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ServicesConditionConfig{ServicesMonitorConfig{
+						Regexp:      String(""),
+						Datacenter:  String(""),
+						Datacenters: []string{},
+						Namespace:   String(""),
+						Partition:   String(""),
+						Peer:        String(""),
+						NodeMeta:    map[string]string{},
+						Filter:      String(""),
+					}},
+					&ConsulKVConditionConfig{
+						ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+							Path:       String("deploy/enabled"),
+							Recurse:    Bool(false),
+							Datacenter: String(""),
+							Namespace:  String(""),
+							Decode:     String(""),
+						},
+						SourceIncludesVar: Bool(true),
+					},
+				},
+			}},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "any" {
+		condition "services" {}
+		condition "consul-kv" {
+			path = "deploy/enabled"
+			source_includes_var = true
+		}
+	}
+}`,
+		},
+		{
+			"all: happy path",
+			false,
+			&AllConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&ScheduleConditionConfig{
+						Cron:          String("* * * * * * *"),
+						Timezone:      String(""),
+						Jitter:        TimeDuration(0),
+						SkipIfRunning: Bool(true),
+						CatchUp:       Bool(false),
+					},
+					&ConsulKVConditionConfig{
+						ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+							Path:       String("deploy/enabled"),
+							Recurse:    Bool(false),
+							Datacenter: String(""),
+							Namespace:  String(""),
+							Decode:     String(""),
+						},
+						SourceIncludesVar: Bool(false),
+					},
+				},
+			}},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "all" {
+		condition "schedule" {
+			cron = "* * * * * * *"
+		}
+		condition "consul-kv" {
+			path = "deploy/enabled"
+		}
+	}
+}`,
+		},
+		{
+			"error: any with invalid nested condition keys",
+			true,
+			nil,
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "any" {
+		condition "consul-kv" {
+			path = "deploy/enabled"
+			nonexistent = true
+		}
 	}
 }`,
 		},
@@ -237,6 +606,8 @@ task {
 					SourceIncludesVar: Bool(true),
 					Datacenter:        String("dc2"),
 					Namespace:         String("ns2"),
+					Partition:         String(""),
+					Peer:              String(""),
 					NodeMeta: map[string]string{
 						"key1": "value1",
 						"key2": "value2",
@@ -266,6 +637,52 @@ task {
 			}
 		  }
 		}
+]}`,
+		},
+		{
+			"json any happy path",
+			false,
+			&AnyConditionConfig{CompositeConditionConfig{
+				Conditions: []ConditionConfig{
+					&NodesConditionConfig{
+						NodesMonitorConfig: NodesMonitorConfig{
+							Datacenter: String(""),
+							Partition:  String(""),
+							Peer:       String(""),
+							NodeMeta:   map[string]string{},
+							Filter:     String(""),
+						},
+						SourceIncludesVar: Bool(false),
+					},
+					&IntentionsConditionConfig{
+						IntentionsMonitorConfig: IntentionsMonitorConfig{
+							SourceRegexp:      String(""),
+							DestinationRegexp: String("^api$"),
+							Datacenter:        String(""),
+							Namespace:         String(""),
+							Partition:         String(""),
+						},
+						SourceIncludesVar: Bool(false),
+					},
+				},
+			}},
+			"config.json",
+			`
+{
+	"task": [
+		{
+		  "name": "task",
+		  "services": ["api"],
+		  "source": "Y",
+		  "condition": {
+			"any": {
+			  "condition": [
+				{"nodes": {}},
+				{"intentions": {"destination_regexp": "^api$"}}
+			  ]
+			}
+		  }
+		}
 ]}`,
 		},
 	}
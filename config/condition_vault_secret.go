@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*VaultSecretConditionConfig)(nil)
+
+// VaultSecretConditionConfig configures a condition configuration block of
+// type 'vault-secret'. A vault-secret condition is triggered by changes that
+// occur to the secrets at the configured Vault paths. The secrets are always
+// provided to the module as the sensitive vault_secrets variable.
+type VaultSecretConditionConfig struct {
+	VaultSecretMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *VaultSecretConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	m, ok := c.VaultSecretMonitorConfig.Copy().(*VaultSecretMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &VaultSecretConditionConfig{
+		VaultSecretMonitorConfig: *m,
+	}
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *VaultSecretConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*VaultSecretConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.VaultSecretMonitorConfig.Merge(&o2.VaultSecretMonitorConfig).(*VaultSecretMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &VaultSecretConditionConfig{
+		VaultSecretMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there no nil pointers.
+func (c *VaultSecretConditionConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	c.VaultSecretMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *VaultSecretConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.VaultSecretMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *VaultSecretConditionConfig) GoString() string {
+	if c == nil {
+		return "(*VaultSecretConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&VaultSecretConditionConfig{"+
+		"%s"+
+		"}",
+		c.VaultSecretMonitorConfig.GoString(),
+	)
+}
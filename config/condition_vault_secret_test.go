@@ -0,0 +1,263 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultSecretConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *VaultSecretConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&VaultSecretConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"pki/issue/web"},
+					Data:  map[string]string{"common_name": "web.example.com"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestVaultSecretConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *VaultSecretConditionConfig
+		b    *VaultSecretConditionConfig
+		r    *VaultSecretConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&VaultSecretConditionConfig{},
+			&VaultSecretConditionConfig{},
+		},
+		{
+			"nil_b",
+			&VaultSecretConditionConfig{},
+			nil,
+			&VaultSecretConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&VaultSecretConditionConfig{},
+			&VaultSecretConditionConfig{},
+			&VaultSecretConditionConfig{},
+		},
+		{
+			"paths_merge",
+			&VaultSecretConditionConfig{VaultSecretMonitorConfig: VaultSecretMonitorConfig{Paths: []string{"kv/data/a"}}},
+			&VaultSecretConditionConfig{VaultSecretMonitorConfig: VaultSecretMonitorConfig{Paths: []string{"kv/data/b"}}},
+			&VaultSecretConditionConfig{VaultSecretMonitorConfig: VaultSecretMonitorConfig{Paths: []string{"kv/data/a", "kv/data/b"}}},
+		},
+		{
+			"data_merge",
+			&VaultSecretConditionConfig{VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+				Data: map[string]string{"common_name": "a.example.com", "ttl": "24h"}}},
+			&VaultSecretConditionConfig{VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+				Data: map[string]string{"common_name": "b.example.com"}}},
+			&VaultSecretConditionConfig{VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+				Data: map[string]string{"common_name": "b.example.com", "ttl": "24h"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestVaultSecretConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		s    []string
+		i    *VaultSecretConditionConfig
+		r    *VaultSecretConditionConfig
+	}{
+		{
+			"nil",
+			[]string{},
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			[]string{"api"},
+			&VaultSecretConditionConfig{},
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{},
+					Data:  map[string]string{},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize(tc.s)
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestVaultSecretConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *VaultSecretConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app?version=2", "kv/data/db"},
+				},
+			},
+		},
+		{
+			"happy_path_data",
+			false,
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"pki/issue/web"},
+					Data:  map[string]string{"common_name": "web.example.com"},
+				},
+			},
+		},
+		{
+			"missing_paths",
+			true,
+			&VaultSecretConditionConfig{},
+		},
+		{
+			"empty_path",
+			true,
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app", " / "},
+				},
+			},
+		},
+		{
+			"duplicate_path",
+			true,
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app", "/kv/data/app/"},
+				},
+			},
+		},
+		{
+			"empty_data_key",
+			true,
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"pki/issue/web"},
+					Data:  map[string]string{"": "web.example.com"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVaultSecretConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        ConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			(*VaultSecretConditionConfig)(nil),
+			"(*VaultSecretConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: VaultSecretMonitorConfig{
+					Paths: []string{"pki/issue/web"},
+					Data: map[string]string{
+						"ttl":         "24h",
+						"common_name": "web.example.com",
+					},
+				},
+			},
+			"&VaultSecretConditionConfig{&VaultSecretMonitorConfig{" +
+				"Paths:[pki/issue/web], " +
+				"Data:map[common_name:(redacted) ttl:(redacted)]}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tc.i.GoString()
+			assert.Equal(t, tc.expected, a, fmt.Sprintf("%s", a))
+		})
+	}
+}
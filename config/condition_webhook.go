@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const webhookType = "webhook"
+
+// WebhookSchemaTypes are the JSON types supported for the fields of a webhook
+// condition's payload schema
+var WebhookSchemaTypes = []string{"string", "number", "bool", "list", "object"}
+
+var _ ConditionConfig = (*WebhookConditionConfig)(nil)
+
+// WebhookConditionConfig configures a condition configuration block of type
+// 'webhook'. A webhook condition is triggered by requests to the task's trigger
+// API endpoint. The JSON payload of the request is provided to the module as
+// the webhook_payload variable.
+type WebhookConditionConfig struct {
+	// Secret is the key to verify the HMAC-SHA256 signature of the payload of
+	// trigger requests. Requests are not required to be signed if unset.
+	Secret *string `mapstructure:"secret"`
+
+	// Schema maps the fields that are required in the payload to their JSON
+	// type. The payload can only contain the configured fields if set.
+	Schema map[string]string `mapstructure:"schema"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *WebhookConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o WebhookConditionConfig
+	o.Secret = StringCopy(c.Secret)
+
+	if c.Schema != nil {
+		o.Schema = make(map[string]string)
+		for k, v := range c.Schema {
+			o.Schema[k] = v
+		}
+	}
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *WebhookConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*WebhookConditionConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*WebhookConditionConfig)
+
+	if o2.Secret != nil {
+		r2.Secret = StringCopy(o2.Secret)
+	}
+
+	for k, v := range o2.Schema {
+		if r2.Schema == nil {
+			r2.Schema = make(map[string]string)
+		}
+		r2.Schema[k] = v
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *WebhookConditionConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Secret == nil {
+		c.Secret = String("")
+	}
+
+	if c.Schema == nil {
+		c.Schema = make(map[string]string)
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *WebhookConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	for field, t := range c.Schema {
+		if field == "" {
+			return fmt.Errorf("webhook condition schema field names cannot be empty")
+		}
+
+		supported := false
+		for _, st := range WebhookSchemaTypes {
+			if t == st {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("unsupported type %q for webhook condition schema "+
+				"field %q. supported types: %s", t, field,
+				strings.Join(WebhookSchemaTypes, ", "))
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+// Sensitive information is redacted.
+func (c *WebhookConditionConfig) GoString() string {
+	if c == nil {
+		return "(*WebhookConditionConfig)(nil)"
+	}
+
+	fields := make([]string, 0, len(c.Schema))
+	for k := range c.Schema {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	schema := make([]string, len(fields))
+	for i, k := range fields {
+		schema[i] = fmt.Sprintf("%s:%s", k, c.Schema[k])
+	}
+
+	return fmt.Sprintf("&WebhookConditionConfig{"+
+		"Secret:%s, "+
+		"Schema:map[%s]"+
+		"}",
+		sensitiveGoString(c.Secret),
+		strings.Join(schema, " "),
+	)
+}
@@ -0,0 +1,255 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *WebhookConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&WebhookConditionConfig{},
+		},
+		{
+			"fully_configured",
+			&WebhookConditionConfig{
+				Secret: String("secret"),
+				Schema: map[string]string{"commit": "string"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestWebhookConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *WebhookConditionConfig
+		b    *WebhookConditionConfig
+		r    *WebhookConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{},
+		},
+		{
+			"nil_b",
+			&WebhookConditionConfig{},
+			nil,
+			&WebhookConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{},
+		},
+		{
+			"secret_overrides",
+			&WebhookConditionConfig{Secret: String("same")},
+			&WebhookConditionConfig{Secret: String("different")},
+			&WebhookConditionConfig{Secret: String("different")},
+		},
+		{
+			"secret_empty_one",
+			&WebhookConditionConfig{Secret: String("same")},
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{Secret: String("same")},
+		},
+		{
+			"schema_merges",
+			&WebhookConditionConfig{Schema: map[string]string{
+				"commit": "string",
+				"files":  "list",
+			}},
+			&WebhookConditionConfig{Schema: map[string]string{
+				"files":  "object",
+				"branch": "string",
+			}},
+			&WebhookConditionConfig{Schema: map[string]string{
+				"commit": "string",
+				"files":  "object",
+				"branch": "string",
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestWebhookConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *WebhookConditionConfig
+		r    *WebhookConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{
+				Secret: String(""),
+				Schema: map[string]string{},
+			},
+		},
+		{
+			"fully_configured",
+			&WebhookConditionConfig{
+				Secret: String("secret"),
+				Schema: map[string]string{"commit": "string"},
+			},
+			&WebhookConditionConfig{
+				Secret: String("secret"),
+				Schema: map[string]string{"commit": "string"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize([]string{})
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestWebhookConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *WebhookConditionConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"empty",
+			false,
+			&WebhookConditionConfig{},
+		},
+		{
+			"valid_schema",
+			false,
+			&WebhookConditionConfig{
+				Schema: map[string]string{
+					"commit":  "string",
+					"count":   "number",
+					"dry_run": "bool",
+					"files":   "list",
+					"meta":    "object",
+				},
+			},
+		},
+		{
+			"unsupported_schema_type",
+			true,
+			&WebhookConditionConfig{
+				Schema: map[string]string{"commit": "integer"},
+			},
+		},
+		{
+			"empty_schema_field",
+			true,
+			&WebhookConditionConfig{
+				Schema: map[string]string{"": "string"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *WebhookConditionConfig
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"(*WebhookConditionConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&WebhookConditionConfig{
+				Secret: String("secret"),
+				Schema: map[string]string{
+					"files":  "list",
+					"commit": "string",
+				},
+			},
+			"&WebhookConditionConfig{Secret:(redacted), " +
+				"Schema:map[commit:string files:list]}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
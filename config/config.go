@@ -11,7 +11,6 @@ import (
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
-	"github.com/hashicorp/consul/lib/decode"
 	"github.com/hashicorp/hcl"
 	"github.com/mitchellh/mapstructure"
 )
@@ -36,6 +35,7 @@ type Config struct {
 	LogLevel   *string `mapstructure:"log_level"`
 	ClientType *string `mapstructure:"client_type"`
 	Port       *int    `mapstructure:"port"`
+	Address    *string `mapstructure:"address"`
 	WorkingDir *string `mapstructure:"working_dir"`
 
 	Syslog             *SyslogConfig             `mapstructure:"syslog"`
@@ -47,6 +47,7 @@ type Config struct {
 	TerraformProviders *TerraformProviderConfigs `mapstructure:"terraform_provider"`
 	BufferPeriod       *BufferPeriodConfig       `mapstructure:"buffer_period"`
 	TLS                *CTSTLSConfig             `mapstructure:"tls"`
+	UnixSocket         *UnixSocketConfig         `mapstructure:"unix_socket"`
 }
 
 // BuildConfig builds a new Config object from the default configuration and
@@ -79,7 +80,6 @@ func DefaultConfig() *Config {
 	return &Config{
 		LogLevel:           String(DefaultLogLevel),
 		Syslog:             DefaultSyslogConfig(),
-		Port:               Int(DefaultPort),
 		Consul:             consul,
 		Driver:             DefaultDriverConfig(),
 		Tasks:              DefaultTaskConfigs(),
@@ -87,6 +87,7 @@ func DefaultConfig() *Config {
 		TerraformProviders: DefaultTerraformProviderConfigs(),
 		BufferPeriod:       DefaultBufferPeriodConfig(),
 		TLS:                DefaultCTSTLSConfig(),
+		UnixSocket:         DefaultUnixSocketConfig(),
 	}
 }
 
@@ -101,6 +102,7 @@ func (c *Config) Copy() *Config {
 		LogLevel:           StringCopy(c.LogLevel),
 		Syslog:             c.Syslog.Copy(),
 		Port:               IntCopy(c.Port),
+		Address:            StringCopy(c.Address),
 		WorkingDir:         StringCopy(c.WorkingDir),
 		Consul:             c.Consul.Copy(),
 		Vault:              c.Vault.Copy(),
@@ -110,6 +112,7 @@ func (c *Config) Copy() *Config {
 		TerraformProviders: c.TerraformProviders.Copy(),
 		BufferPeriod:       c.BufferPeriod.Copy(),
 		TLS:                c.TLS.Copy(),
+		UnixSocket:         c.UnixSocket.Copy(),
 	}
 }
 
@@ -139,6 +142,10 @@ func (c *Config) Merge(o *Config) *Config {
 		r.Port = IntCopy(o.Port)
 	}
 
+	if o.Address != nil {
+		r.Address = StringCopy(o.Address)
+	}
+
 	if o.WorkingDir != nil {
 		r.WorkingDir = StringCopy(o.WorkingDir)
 	}
@@ -179,6 +186,10 @@ func (c *Config) Merge(o *Config) *Config {
 		r.TLS = r.TLS.Merge(o.TLS)
 	}
 
+	if o.UnixSocket != nil {
+		r.UnixSocket = r.UnixSocket.Merge(o.UnixSocket)
+	}
+
 	return r
 }
 
@@ -188,8 +199,18 @@ func (c *Config) Finalize() {
 		return
 	}
 
+	if c.Address == nil {
+		c.Address = String("")
+	}
+
 	if c.Port == nil {
-		c.Port = Int(DefaultPort)
+		if _, ok := UnixSocketPath(*c.Address); ok {
+			// Only listen on the Unix domain socket unless the port is
+			// explicitly configured
+			c.Port = Int(0)
+		} else {
+			c.Port = Int(DefaultPort)
+		}
 	}
 
 	if c.ClientType == nil {
@@ -256,6 +277,11 @@ func (c *Config) Finalize() {
 		c.TLS = DefaultCTSTLSConfig()
 	}
 	c.TLS.Finalize()
+
+	if c.UnixSocket == nil {
+		c.UnixSocket = DefaultUnixSocketConfig()
+	}
+	c.UnixSocket.Finalize()
 }
 
 // Validate validates the values and nested values of the configuration struct
@@ -292,10 +318,43 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.validateVaultSecrets(); err != nil {
+		return err
+	}
+
 	if err := c.TLS.Validate(); err != nil {
 		return err
 	}
 
+	if err := c.validateAddress(); err != nil {
+		return err
+	}
+
+	if err := c.UnixSocket.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateAddress checks that the address for the api server is a Unix domain
+// socket, which is the only supported address at this time
+func (c *Config) validateAddress() error {
+	if !StringPresent(c.Address) {
+		return nil
+	}
+
+	path, ok := UnixSocketPath(*c.Address)
+	if !ok {
+		return fmt.Errorf("address %q is not supported. The address must be "+
+			"a Unix domain socket e.g. unix:///var/run/cts.sock. Use the port "+
+			"field to configure the TCP port", *c.Address)
+	}
+	if path == "" {
+		return fmt.Errorf("address %q is missing the path of the Unix "+
+			"domain socket", *c.Address)
+	}
+
 	return nil
 }
 
@@ -340,6 +399,7 @@ func (c *Config) GoString() string {
 	return fmt.Sprintf("&Config{"+
 		"LogLevel:%s, "+
 		"Port:%d, "+
+		"Address:%s, "+
 		"WorkingDir:%s, "+
 		"Syslog:%s, "+
 		"Consul:%s, "+
@@ -349,10 +409,12 @@ func (c *Config) GoString() string {
 		"Services:%s, "+
 		"TerraformProviders:%s, "+
 		"BufferPeriod:%s,"+
-		"TLS:%s"+
+		"TLS:%s, "+
+		"UnixSocket:%s"+
 		"}",
 		StringVal(c.LogLevel),
 		IntVal(c.Port),
+		StringVal(c.Address),
 		StringVal(c.WorkingDir),
 		c.Syslog.GoString(),
 		c.Consul.GoString(),
@@ -363,6 +425,7 @@ func (c *Config) GoString() string {
 		c.TerraformProviders.GoString(),
 		c.BufferPeriod.GoString(),
 		c.TLS.GoString(),
+		c.UnixSocket.GoString(),
 	)
 }
 
@@ -379,8 +442,14 @@ func (c *Config) validateDynamicConfigs() error {
 
 	// Dynamic configuration is only supported for terraform_provider blocks.
 	// Provider blocks are redacted, so using the stringified version of the
-	// config to check for templates used elsewhere.
-	if hcltmpl.ContainsDynamicTemplate(c.GoString()) {
+	// config to check for templates used elsewhere. The backend is redacted
+	// as well, so it is checked separately.
+	var backend map[string]interface{}
+	if c.Driver != nil && c.Driver.Terraform != nil {
+		backend = c.Driver.Terraform.Backend
+	}
+	if hcltmpl.ContainsDynamicTemplate(c.GoString()) ||
+		hcltmpl.ContainsDynamicTemplate(fmt.Sprint(backend)) {
 		return fmt.Errorf("dynamic configuration using template syntax is only supported " +
 			"for terraform_provider blocks")
 	}
@@ -388,6 +457,24 @@ func (c *Config) validateDynamicConfigs() error {
 	return nil
 }
 
+// validateVaultSecrets verifies that Vault is configured if a task watches
+// Vault secrets with a vault-secret condition or source_input.
+func (c *Config) validateVaultSecrets() error {
+	if c.Tasks == nil || (c.Vault != nil && BoolVal(c.Vault.Enabled)) {
+		return nil
+	}
+
+	for _, t := range *c.Tasks {
+		if t.watchesVaultSecrets() {
+			return fmt.Errorf("task %q watches Vault secrets with a vault-secret "+
+				"condition or source_input: missing Vault configuration",
+				StringVal(t.Name))
+		}
+	}
+
+	return nil
+}
+
 // decodeConfig attempts to decode bytes based on the provided format and
 // returns the resulting Config struct.
 func decodeConfig(content []byte, file string) (*Config, error) {
@@ -404,16 +491,14 @@ func decodeConfig(content []byte, file string) (*Config, error) {
 			conditionToTypeFunc(),
 			sourceInputToTypeFunc(),
 			mapstructure.StringToTimeDurationHookFunc(),
-			decode.HookTranslateKeys,
 		)
 	case "hcl":
 		err = hcl.Decode(&raw, string(content))
 		decodeHook = mapstructure.ComposeDecodeHookFunc(
 			conditionToTypeFunc(),
 			sourceInputToTypeFunc(),
-			decode.HookWeakDecodeFromSlice,
-			mapstructure.StringToTimeDurationHookFunc(),
-			decode.HookTranslateKeys)
+			hookWeakDecodeFromSlice,
+			mapstructure.StringToTimeDurationHookFunc())
 	default:
 		return nil, fmt.Errorf("invalid format: %s", format)
 	}
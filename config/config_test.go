@@ -25,6 +25,7 @@ var (
 	longConfig = Config{
 		LogLevel:   String("ERR"),
 		Port:       Int(8502),
+		Address:    String("unix:///var/run/cts.sock"),
 		WorkingDir: String("working"),
 		Syslog: &SyslogConfig{
 			Enabled: Bool(true),
@@ -63,6 +64,9 @@ var (
 			VerifyIncoming: Bool(true),
 			CACert:         String("../testutils/certs/consul_cert.pem"),
 		},
+		UnixSocket: &UnixSocketConfig{
+			Mode: String("0660"),
+		},
 		Driver: &DriverConfig{
 			Terraform: &TerraformConfig{
 				Log:  Bool(true),
@@ -106,13 +110,21 @@ var (
 						SourceIncludesVar: Bool(true),
 						Datacenter:        String("dc2"),
 						Namespace:         String("ns2"),
+						Partition:         String("ap2"),
+						Peer:              String("peer2"),
 						NodeMeta: map[string]string{
 							"key1": "value1",
 							"key2": "value2",
 						},
 					},
 				},
-				SourceInput: DefaultSourceInputConfig(),
+				SourceInputs: &SourceInputConfigs{
+					&ServicesSourceInputConfig{
+						ServicesMonitorConfig{
+							Regexp: String(""),
+						},
+					},
+				},
 			},
 		},
 		TerraformProviders: &TerraformProviderConfigs{{
@@ -320,13 +332,19 @@ func TestConfig_Finalize(t *testing.T) {
 	(*expected.Tasks)[0].BufferPeriod.Min = TimeDuration(20 * time.Second)
 	(*expected.Tasks)[0].BufferPeriod.Max = TimeDuration(60 * time.Second)
 	(*expected.Tasks)[0].WorkingDir = String("working/task")
+	(*expected.Tasks)[0].IncludeStatuses = []string{}
+	(*expected.Tasks)[0].TriggerOn = []string{}
+	(*expected.Tasks)[0].ServicesProtocol = Int(0)
+	(*(*expected.Tasks)[0].SourceInputs)[0].Finalize([]string{})
 	(*expected.Services)[0].ID = String("serviceA")
 	(*expected.Services)[0].Namespace = String("")
 	(*expected.Services)[0].Datacenter = String("")
+	(*expected.Services)[0].Datacenters = []string{}
 	(*expected.Services)[0].Filter = String("")
 	(*expected.Services)[0].CTSUserDefinedMeta = map[string]string{}
 	(*expected.Services)[1].ID = String("serviceB")
 	(*expected.Services)[1].Datacenter = String("")
+	(*expected.Services)[1].Datacenters = []string{}
 	(*expected.Services)[1].Filter = String("")
 	(*expected.Services)[1].CTSUserDefinedMeta = map[string]string{}
 
@@ -355,17 +373,39 @@ func TestConfig_Validate(t *testing.T) {
 	// valid case with multiple tasks w/ different providers
 	validMultiTask := longConfig.Copy()
 	*validMultiTask.Tasks = append(*validMultiTask.Tasks, &TaskConfig{
-		Description: String("test task1"),
-		Name:        String("task1"),
-		Services:    []string{"serviceD"},
-		Providers:   []string{"Y"},
-		Source:      String("Z"),
-		Condition:   &ServicesConditionConfig{},
-		SourceInput: DefaultSourceInputConfig(),
+		Description:  String("test task1"),
+		Name:         String("task1"),
+		Services:     []string{"serviceD"},
+		Providers:    []string{"Y"},
+		Source:       String("Z"),
+		Condition:    &ServicesConditionConfig{},
+		SourceInputs: DefaultSourceInputConfigs(),
 	})
 	*validMultiTask.TerraformProviders = append(*validMultiTask.TerraformProviders,
 		&TerraformProviderConfig{"Y": map[string]interface{}{}})
 
+	// address other than a Unix domain socket
+	tcpAddress := valid.Copy()
+	tcpAddress.Address = String("localhost:8558")
+
+	missingSocketPath := valid.Copy()
+	missingSocketPath.Address = String("unix://")
+
+	invalidSocketMode := valid.Copy()
+	invalidSocketMode.UnixSocket.Mode = String("rw-rw----")
+
+	// task watching vault secrets without vault configured
+	vaultSecret := valid.Copy()
+	(*vaultSecret.Tasks)[0].Condition = &VaultSecretConditionConfig{
+		VaultSecretMonitorConfig{Paths: []string{"kv/data/app"}}}
+	vaultSecret.Vault = DefaultVaultConfig()
+	vaultSecret.Vault.Address = String("vault.example.com")
+	vaultSecret.Finalize()
+
+	missingVault := vaultSecret.Copy()
+	missingVault.Vault = DefaultVaultConfig()
+	missingVault.Finalize()
+
 	cases := []struct {
 		name    string
 		i       *Config
@@ -395,6 +435,26 @@ func TestConfig_Validate(t *testing.T) {
 			"autocommitting provider reuse error",
 			autoCommit.Copy(),
 			false,
+		}, {
+			"tcp address",
+			tcpAddress,
+			false,
+		}, {
+			"unix address missing path",
+			missingSocketPath,
+			false,
+		}, {
+			"invalid unix socket mode",
+			invalidSocketMode,
+			false,
+		}, {
+			"vault-secret condition",
+			vaultSecret,
+			true,
+		}, {
+			"vault-secret condition missing vault",
+			missingVault,
+			false,
 		},
 	}
 
@@ -482,6 +542,20 @@ func TestConfig_validateDynamicConfig(t *testing.T) {
 				}},
 			},
 			false,
+		}, {
+			"dynamic configs unsupported in backend",
+			Config{
+				Driver: &DriverConfig{
+					Terraform: &TerraformConfig{
+						Backend: map[string]interface{}{
+							"s3": map[string]interface{}{
+								"secret_key": "{{ env \"NOT_SUPPORTED\" }}",
+							},
+						},
+					},
+				},
+			},
+			false,
 		},
 	}
 
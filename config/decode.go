@@ -2,15 +2,24 @@ package config
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/mitchellh/reflectwalk"
 )
 
 var reRepeatedBlock = regexp.MustCompile(`'([^\']+)' expected a map, got 'slice'`)
 
+// scopeKeyBlocks are the blocks that support the Consul admin partition and
+// cluster peer keys. Other blocks that query Consul do not support them.
+var scopeKeyBlocks = map[string]string{
+	"partition": "catalog-services, config-entries, intentions, nodes, and services (with regexp)",
+	"peer":      "catalog-services, nodes, and services (with regexp)",
+}
+
 func processUnusedConfigKeys(md mapstructure.Metadata, file string) error {
 	if len(md.Unused) == 0 {
 		return nil
@@ -28,6 +37,32 @@ func processUnusedConfigKeys(md mapstructure.Metadata, file string) error {
 `, err)
 		}
 	}
+	return unsupportedScopeKeysError(err, md.Unused)
+}
+
+// invalidKeysError returns the error for the unused keys of a condition or
+// module_input block
+func invalidKeysError(unused []string) error {
+	sort.Strings(unused)
+	err := fmt.Errorf("invalid keys: %s", strings.Join(unused, ", "))
+	return unsupportedScopeKeysError(err, unused)
+}
+
+// unsupportedScopeKeysError explains an invalid keys error for the partition
+// and peer keys, which are only supported by some of the blocks that query
+// Consul.
+func unsupportedScopeKeysError(err error, unused []string) error {
+	reported := make(map[string]bool)
+	for _, key := range unused {
+		key = key[strings.LastIndex(key, ".")+1:]
+		blocks, ok := scopeKeyBlocks[key]
+		if !ok || reported[key] {
+			continue
+		}
+		reported[key] = true
+		err = fmt.Errorf("%s: '%s' is only supported by the %s conditions "+
+			"and module inputs", err, key, blocks)
+	}
 	return err
 }
 
@@ -44,3 +79,77 @@ func decodeError(err error) error {
 	}
 	return err
 }
+
+// hookWeakDecodeFromSlice is a mapstructure decode hook for HCL repeated
+// blocks, which are decoded as []map[string]interface{} instead of
+// map[string]interface{}. If the target is not a slice or array, it unpacks
+// the item of a slice of one item. A slice of more items is left unmodified so
+// that mapstructure reports the decode error.
+//
+// If the target is an empty interface, the nested slices of one item are
+// unpacked as well. Decoding the result into a type again requires
+// WeaklyTypedInput to convert single values back into slices.
+func hookWeakDecodeFromSlice(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() == reflect.Slice && (to.Kind() == reflect.Slice || to.Kind() == reflect.Array) {
+		return data, nil
+	}
+
+	switch d := data.(type) {
+	case []map[string]interface{}:
+		switch {
+		case len(d) != 1:
+			return data, nil
+		case to == typeOfEmptyInterface:
+			return unSlice(d[0])
+		default:
+			return d[0], nil
+		}
+
+	// a slice may be decoded as []interface{}, such as from JSON
+	case []interface{}:
+		switch {
+		case len(d) != 1:
+			return data, nil
+		case to == typeOfEmptyInterface:
+			return unSlice(d[0])
+		default:
+			return d[0], nil
+		}
+	}
+	return data, nil
+}
+
+var typeOfEmptyInterface = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// unSlice unpacks the values of a map that are slices of one item
+func unSlice(data interface{}) (interface{}, error) {
+	err := reflectwalk.Walk(data, &unSliceWalker{})
+	return data, err
+}
+
+// unSliceWalker is a reflectwalk.MapWalker that replaces the values of a map
+// that are slices of one item with the item
+type unSliceWalker struct{}
+
+func (u *unSliceWalker) Map(_ reflect.Value) error {
+	return nil
+}
+
+func (u *unSliceWalker) MapElem(m, k, v reflect.Value) error {
+	if !v.IsValid() || v.Kind() != reflect.Interface {
+		return nil
+	}
+
+	v = v.Elem() // unpack the value from the interface{}
+	if v.Kind() != reflect.Slice || v.Len() != 1 {
+		return nil
+	}
+
+	first := v.Index(0)
+	// The value should always be assignable, but check to avoid a panic
+	if !first.Type().AssignableTo(m.Type().Elem()) {
+		return nil
+	}
+	m.SetMapIndex(k, first)
+	return nil
+}
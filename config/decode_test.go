@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,3 +46,44 @@ func TestDecodeError(t *testing.T) {
 		})
 	}
 }
+
+func TestInvalidKeysError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		unused   []string
+		expected string
+	}{
+		{
+			"invalid keys",
+			[]string{"unsupported", "invalid"},
+			"invalid keys: invalid, unsupported",
+		}, {
+			"partition",
+			[]string{"partition"},
+			"invalid keys: partition: 'partition' is only supported by the " +
+				"catalog-services, config-entries, intentions, nodes, and " +
+				"services (with regexp) conditions and module inputs",
+		}, {
+			"peer",
+			[]string{"peer", "invalid"},
+			"invalid keys: invalid, peer: 'peer' is only supported by the " +
+				"catalog-services, nodes, and services (with regexp) conditions " +
+				"and module inputs",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := invalidKeysError(tc.unused)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestProcessUnusedConfigKeys_Partition(t *testing.T) {
+	md := mapstructure.Metadata{Unused: []string{"service[0].partition"}}
+	err := processUnusedConfigKeys(md, "file.hcl")
+	assert.EqualError(t, err, "'file.hcl' has invalid keys: "+
+		"service[0].partition: 'partition' is only supported by the "+
+		"catalog-services, config-entries, intentions, nodes, and services "+
+		"(with regexp) conditions and module inputs")
+}
@@ -28,8 +28,28 @@ func isMonitorNil(c MonitorConfig) bool {
 		result = v == nil
 	case *ConsulKVConditionConfig:
 		result = v == nil
+	case *NodesConditionConfig:
+		result = v == nil
+	case *IntentionsConditionConfig:
+		result = v == nil
+	case *ConfigEntriesConditionConfig:
+		result = v == nil
+	case *PreparedQueryConditionConfig:
+		result = v == nil
+	case *VaultSecretConditionConfig:
+		result = v == nil
+	case *FileConditionConfig:
+		result = v == nil
+	case *ConsulEventConditionConfig:
+		result = v == nil
 	case *ScheduleConditionConfig:
 		result = v == nil
+	case *WebhookConditionConfig:
+		result = v == nil
+	case *AnyConditionConfig:
+		result = v == nil
+	case *AllConditionConfig:
+		result = v == nil
 	case *ServicesSourceInputConfig:
 		result = v == nil
 	default:
@@ -18,6 +18,8 @@ type CatalogServicesMonitorConfig struct {
 	SourceIncludesVar *bool             `mapstructure:"source_includes_var"`
 	Datacenter        *string           `mapstructure:"datacenter"`
 	Namespace         *string           `mapstructure:"namespace"`
+	Partition         *string           `mapstructure:"partition"`
+	Peer              *string           `mapstructure:"peer"`
 	NodeMeta          map[string]string `mapstructure:"node_meta"`
 }
 
@@ -33,6 +35,8 @@ func (c *CatalogServicesMonitorConfig) Copy() MonitorConfig {
 	o.SourceIncludesVar = BoolCopy(c.SourceIncludesVar)
 	o.Datacenter = StringCopy(c.Datacenter)
 	o.Namespace = StringCopy(c.Namespace)
+	o.Partition = StringCopy(c.Partition)
+	o.Peer = StringCopy(c.Peer)
 
 	if c.NodeMeta != nil {
 		o.NodeMeta = make(map[string]string)
@@ -84,6 +88,14 @@ func (c *CatalogServicesMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
 		r2.Namespace = StringCopy(o2.Namespace)
 	}
 
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+
+	if o2.Peer != nil {
+		r2.Peer = StringCopy(o2.Peer)
+	}
+
 	if o2.NodeMeta != nil {
 		if r2.NodeMeta == nil {
 			r2.NodeMeta = make(map[string]string)
@@ -127,6 +139,14 @@ func (c *CatalogServicesMonitorConfig) Finalize(services []string) {
 		c.Namespace = String("")
 	}
 
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+
+	if c.Peer == nil {
+		c.Peer = String("")
+	}
+
 	if c.NodeMeta == nil {
 		c.NodeMeta = make(map[string]string)
 	}
@@ -162,12 +182,16 @@ func (c *CatalogServicesMonitorConfig) GoString() string {
 		"SourceIncludesVar:%v, "+
 		"Datacenter:%v, "+
 		"Namespace:%v, "+
+		"Partition:%v, "+
+		"Peer:%v, "+
 		"NodeMeta:%s"+
 		"}",
 		StringVal(c.Regexp),
 		BoolVal(c.SourceIncludesVar),
 		StringVal(c.Datacenter),
 		StringVal(c.Namespace),
+		StringVal(c.Partition),
+		StringVal(c.Peer),
 		c.NodeMeta,
 	)
 }
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const configEntriesType = "config-entries"
+
+// ConfigEntryKinds are the kinds of Consul config entries that can be
+// monitored
+var ConfigEntryKinds = []string{
+	"service-defaults",
+	"proxy-defaults",
+	"service-router",
+	"service-splitter",
+	"service-resolver",
+	"ingress-gateway",
+	"terminating-gateway",
+	"service-intentions",
+}
+
+var _ MonitorConfig = (*ConfigEntriesMonitorConfig)(nil)
+
+// ConfigEntriesMonitorConfig configures a configuration block adhering to the
+// monitor interface of type 'config-entries'. A config-entries monitor watches
+// for changes that occur to Consul config entries of one or more kinds.
+type ConfigEntriesMonitorConfig struct {
+	Kinds      []string `mapstructure:"kinds"`
+	Regexp     *string  `mapstructure:"regexp"`
+	Datacenter *string  `mapstructure:"datacenter"`
+	Namespace  *string  `mapstructure:"namespace"`
+	Partition  *string  `mapstructure:"partition"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConfigEntriesMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ConfigEntriesMonitorConfig
+	if c.Kinds != nil {
+		o.Kinds = make([]string, 0, len(c.Kinds))
+		o.Kinds = append(o.Kinds, c.Kinds...)
+	}
+	o.Regexp = StringCopy(c.Regexp)
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Namespace = StringCopy(c.Namespace)
+	o.Partition = StringCopy(c.Partition)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *ConfigEntriesMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*ConfigEntriesMonitorConfig)
+
+	r2.Kinds = append(r2.Kinds, o2.Kinds...)
+
+	if o2.Regexp != nil {
+		r2.Regexp = StringCopy(o2.Regexp)
+	}
+
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	if o2.Namespace != nil {
+		r2.Namespace = StringCopy(o2.Namespace)
+	}
+
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *ConfigEntriesMonitorConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Kinds == nil {
+		c.Kinds = []string{}
+	}
+
+	if c.Regexp == nil {
+		c.Regexp = String("")
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Namespace == nil {
+		c.Namespace = String("")
+	}
+
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *ConfigEntriesMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if len(c.Kinds) == 0 {
+		return fmt.Errorf("at least one kind is required for config-entries")
+	}
+
+	seen := make(map[string]bool)
+	for _, kind := range c.Kinds {
+		if !isConfigEntryKind(kind) {
+			return fmt.Errorf("unsupported config entry kind %q for "+
+				"config-entries. supported kinds: %s", kind,
+				strings.Join(ConfigEntryKinds, ", "))
+		}
+		if seen[kind] {
+			return fmt.Errorf("duplicate config entry kind %q for "+
+				"config-entries", kind)
+		}
+		seen[kind] = true
+	}
+
+	if _, err := regexp.Compile(StringVal(c.Regexp)); err != nil {
+		return fmt.Errorf("unable to compile config-entries regexp: %s", err)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *ConfigEntriesMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*ConfigEntriesMonitorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ConfigEntriesMonitorConfig{"+
+		"Kinds:%v, "+
+		"Regexp:%s, "+
+		"Datacenter:%v, "+
+		"Namespace:%v, "+
+		"Partition:%v"+
+		"}",
+		c.Kinds,
+		StringVal(c.Regexp),
+		StringVal(c.Datacenter),
+		StringVal(c.Namespace),
+		StringVal(c.Partition),
+	)
+}
+
+func isConfigEntryKind(kind string) bool {
+	for _, k := range ConfigEntryKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
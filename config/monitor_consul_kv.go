@@ -6,6 +6,10 @@ import (
 
 const consulKVType = "consul-kv"
 
+// consulKVDecodeFormats are the formats of the values of Consul KV pairs that
+// can be configured for decode
+var consulKVDecodeFormats = []string{"json", "yaml", "hcl"}
+
 var _ MonitorConfig = (*ConsulKVMonitorConfig)(nil)
 
 // ConsulKVMonitorConfig configures a configuration block adhering to the monitor interface
@@ -16,6 +20,11 @@ type ConsulKVMonitorConfig struct {
 	Recurse    *bool   `mapstructure:"recurse"`
 	Datacenter *string `mapstructure:"datacenter"`
 	Namespace  *string `mapstructure:"namespace"`
+
+	// Decode is the format to parse the values of the KV pairs as, so that
+	// they are provided to the module as typed objects. One of "json",
+	// "yaml", or "hcl". Values are provided as strings when empty.
+	Decode *string `mapstructure:"decode"`
 }
 
 // Copy returns a deep copy of this configuration.
@@ -29,6 +38,7 @@ func (c *ConsulKVMonitorConfig) Copy() MonitorConfig {
 	o.Recurse = BoolCopy(c.Recurse)
 	o.Datacenter = StringCopy(c.Datacenter)
 	o.Namespace = StringCopy(c.Namespace)
+	o.Decode = StringCopy(c.Decode)
 
 	return &o
 }
@@ -71,6 +81,10 @@ func (c *ConsulKVMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
 		r2.Namespace = StringCopy(o2.Namespace)
 	}
 
+	if o2.Decode != nil {
+		r2.Decode = StringCopy(o2.Decode)
+	}
+
 	return r2
 }
 
@@ -96,6 +110,9 @@ func (c *ConsulKVMonitorConfig) Finalize([]string) {
 		c.Namespace = String("")
 	}
 
+	if c.Decode == nil {
+		c.Decode = String("")
+	}
 }
 
 // Validate validates the values and required options. This method is recommended
@@ -109,6 +126,12 @@ func (c *ConsulKVMonitorConfig) Validate() error {
 		return fmt.Errorf("path is required for consul-kv condition")
 	}
 
+	if decode := StringVal(c.Decode); decode != "" {
+		if err := validateOptions([]string{decode}, consulKVDecodeFormats); err != nil {
+			return fmt.Errorf("invalid decode for consul-kv: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -123,10 +146,12 @@ func (c *ConsulKVMonitorConfig) GoString() string {
 		"Recurse:%v, "+
 		"Datacenter:%v, "+
 		"Namespace:%v, "+
+		"Decode:%v, "+
 		"}",
 		StringVal(c.Path),
 		BoolVal(c.Recurse),
 		StringVal(c.Datacenter),
 		StringVal(c.Namespace),
+		StringVal(c.Decode),
 	)
 }
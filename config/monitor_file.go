@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const fileType = "file"
+
+// fileFormats are the formats that the contents of the files can be parsed as
+var fileFormats = []string{"json", "yaml", "hcl"}
+
+// fileFormatExtensions are the file extensions that the format of the files
+// is inferred from when the format is not configured
+var fileFormatExtensions = map[string]string{
+	".json": "json",
+	".yaml": "yaml",
+	".yml":  "yaml",
+	".hcl":  "hcl",
+}
+
+var _ MonitorConfig = (*FileMonitorConfig)(nil)
+
+// FileMonitorConfig configures a configuration block adhering to the monitor
+// interface of type 'file'. A file monitor watches for changes that occur to
+// the contents of local files, e.g. inventory files generated by other tools.
+type FileMonitorConfig struct {
+	// Paths are the paths or glob patterns of the files. Relative paths are
+	// relative to the working directory of CTS.
+	Paths []string `mapstructure:"paths"`
+
+	// Format is the format to parse the contents of the files as. The format
+	// is inferred from the file extension when not configured.
+	Format *string `mapstructure:"format"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *FileMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o FileMonitorConfig
+	if c.Paths != nil {
+		o.Paths = make([]string, 0, len(c.Paths))
+		o.Paths = append(o.Paths, c.Paths...)
+	}
+
+	o.Format = StringCopy(c.Format)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *FileMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*FileMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*FileMonitorConfig)
+
+	r2.Paths = append(r2.Paths, o2.Paths...)
+
+	if o2.Format != nil {
+		r2.Format = StringCopy(o2.Format)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *FileMonitorConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Paths == nil {
+		c.Paths = []string{}
+	}
+
+	if c.Format == nil {
+		c.Format = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *FileMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if len(c.Paths) == 0 {
+		return fmt.Errorf("at least one path is required for file")
+	}
+
+	format := StringVal(c.Format)
+	if format != "" {
+		if err := validateOptions([]string{format}, fileFormats); err != nil {
+			return fmt.Errorf("invalid format for file: %s", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range c.Paths {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("empty path for file")
+		}
+		p := filepath.Clean(path)
+		if seen[p] {
+			return fmt.Errorf("duplicate path %q for file", path)
+		}
+		seen[p] = true
+
+		if _, err := filepath.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern %q for file: %s", path, err)
+		}
+
+		if format == "" {
+			if _, ok := fileFormatExtensions[filepath.Ext(p)]; !ok {
+				return fmt.Errorf("format is required for file path %q without "+
+					"a .json, .yaml, .yml, or .hcl extension", path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *FileMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*FileMonitorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&FileMonitorConfig{"+
+		"Paths:%v, "+
+		"Format:%s"+
+		"}",
+		c.Paths,
+		StringVal(c.Format),
+	)
+}
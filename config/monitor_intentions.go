@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const intentionsType = "intentions"
+
+var _ MonitorConfig = (*IntentionsMonitorConfig)(nil)
+
+// IntentionsMonitorConfig configures a configuration block adhering to the
+// monitor interface of type 'intentions'. An intentions monitor watches for
+// changes that occur to Consul service intentions.
+type IntentionsMonitorConfig struct {
+	SourceRegexp      *string `mapstructure:"source_regexp"`
+	DestinationRegexp *string `mapstructure:"destination_regexp"`
+	Datacenter        *string `mapstructure:"datacenter"`
+	Namespace         *string `mapstructure:"namespace"`
+	Partition         *string `mapstructure:"partition"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *IntentionsMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o IntentionsMonitorConfig
+	o.SourceRegexp = StringCopy(c.SourceRegexp)
+	o.DestinationRegexp = StringCopy(c.DestinationRegexp)
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Namespace = StringCopy(c.Namespace)
+	o.Partition = StringCopy(c.Partition)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *IntentionsMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*IntentionsMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*IntentionsMonitorConfig)
+
+	if o2.SourceRegexp != nil {
+		r2.SourceRegexp = StringCopy(o2.SourceRegexp)
+	}
+
+	if o2.DestinationRegexp != nil {
+		r2.DestinationRegexp = StringCopy(o2.DestinationRegexp)
+	}
+
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	if o2.Namespace != nil {
+		r2.Namespace = StringCopy(o2.Namespace)
+	}
+
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *IntentionsMonitorConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.SourceRegexp == nil {
+		c.SourceRegexp = String("")
+	}
+
+	if c.DestinationRegexp == nil {
+		c.DestinationRegexp = String("")
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Namespace == nil {
+		c.Namespace = String("")
+	}
+
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *IntentionsMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if _, err := regexp.Compile(StringVal(c.SourceRegexp)); err != nil {
+		return fmt.Errorf("unable to compile intentions source_regexp: %s", err)
+	}
+
+	if _, err := regexp.Compile(StringVal(c.DestinationRegexp)); err != nil {
+		return fmt.Errorf("unable to compile intentions destination_regexp: %s", err)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *IntentionsMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*IntentionsMonitorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&IntentionsMonitorConfig{"+
+		"SourceRegexp:%s, "+
+		"DestinationRegexp:%s, "+
+		"Datacenter:%v, "+
+		"Namespace:%v, "+
+		"Partition:%v"+
+		"}",
+		StringVal(c.SourceRegexp),
+		StringVal(c.DestinationRegexp),
+		StringVal(c.Datacenter),
+		StringVal(c.Namespace),
+		StringVal(c.Partition),
+	)
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-bexpr"
+)
+
+const nodesType = "nodes"
+
+var _ MonitorConfig = (*NodesMonitorConfig)(nil)
+
+// NodesMonitorConfig configures a configuration block adhering to the monitor
+// interface of type 'nodes'. A nodes monitor watches for changes that occur
+// to the nodes registered in the Consul catalog.
+type NodesMonitorConfig struct {
+	Datacenter *string           `mapstructure:"datacenter"`
+	Partition  *string           `mapstructure:"partition"`
+	Peer       *string           `mapstructure:"peer"`
+	NodeMeta   map[string]string `mapstructure:"node_meta"`
+	Filter     *string           `mapstructure:"filter"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *NodesMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o NodesMonitorConfig
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Partition = StringCopy(c.Partition)
+	o.Peer = StringCopy(c.Peer)
+	o.Filter = StringCopy(c.Filter)
+
+	if c.NodeMeta != nil {
+		o.NodeMeta = make(map[string]string)
+		for k, v := range c.NodeMeta {
+			o.NodeMeta[k] = v
+		}
+	}
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *NodesMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*NodesMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*NodesMonitorConfig)
+
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+
+	if o2.Peer != nil {
+		r2.Peer = StringCopy(o2.Peer)
+	}
+
+	if o2.NodeMeta != nil {
+		if r2.NodeMeta == nil {
+			r2.NodeMeta = make(map[string]string)
+		}
+		for k, v := range o2.NodeMeta {
+			r2.NodeMeta[k] = v
+		}
+	}
+
+	if o2.Filter != nil {
+		r2.Filter = StringCopy(o2.Filter)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *NodesMonitorConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+
+	if c.Peer == nil {
+		c.Peer = String("")
+	}
+
+	if c.NodeMeta == nil {
+		c.NodeMeta = make(map[string]string)
+	}
+
+	if c.Filter == nil {
+		c.Filter = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *NodesMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if filter := StringVal(c.Filter); filter != "" {
+		if _, err := bexpr.CreateFilter(filter); err != nil {
+			return fmt.Errorf("invalid filter for nodes: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *NodesMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*NodesMonitorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&NodesMonitorConfig{"+
+		"Datacenter:%v, "+
+		"Partition:%v, "+
+		"Peer:%v, "+
+		"NodeMeta:%s, "+
+		"Filter:%s"+
+		"}",
+		StringVal(c.Datacenter),
+		StringVal(c.Partition),
+		StringVal(c.Peer),
+		c.NodeMeta,
+		StringVal(c.Filter),
+	)
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+)
+
+const preparedQueryType = "prepared-query"
+
+var _ MonitorConfig = (*PreparedQueryMonitorConfig)(nil)
+
+// PreparedQueryMonitorConfig configures a configuration block adhering to the
+// monitor interface of type 'prepared-query'. A prepared-query monitor
+// executes a Consul prepared query and watches for changes that occur to the
+// service instances of the result.
+type PreparedQueryMonitorConfig struct {
+	// Name is the name or ID of the prepared query to execute
+	Name *string `mapstructure:"name"`
+
+	// Datacenter is the datacenter to execute the prepared query in
+	Datacenter *string `mapstructure:"datacenter"`
+
+	// Near sorts the result by the round trip time from the node. The special
+	// value "_agent" uses the node of the agent.
+	Near *string `mapstructure:"near"`
+
+	// Limit is the maximum number of service instances of the result. A limit
+	// of 0 does not limit the result.
+	Limit *int `mapstructure:"limit"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *PreparedQueryMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o PreparedQueryMonitorConfig
+	o.Name = StringCopy(c.Name)
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Near = StringCopy(c.Near)
+	o.Limit = IntCopy(c.Limit)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *PreparedQueryMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*PreparedQueryMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*PreparedQueryMonitorConfig)
+
+	if o2.Name != nil {
+		r2.Name = StringCopy(o2.Name)
+	}
+
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	if o2.Near != nil {
+		r2.Near = StringCopy(o2.Near)
+	}
+
+	if o2.Limit != nil {
+		r2.Limit = IntCopy(o2.Limit)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *PreparedQueryMonitorConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Name == nil {
+		c.Name = String("")
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Near == nil {
+		c.Near = String("")
+	}
+
+	if c.Limit == nil {
+		c.Limit = Int(0)
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *PreparedQueryMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if StringVal(c.Name) == "" {
+		return fmt.Errorf("name is required for prepared-query")
+	}
+
+	if IntVal(c.Limit) < 0 {
+		return fmt.Errorf("limit for prepared-query cannot be negative: %d",
+			IntVal(c.Limit))
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *PreparedQueryMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*PreparedQueryMonitorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&PreparedQueryMonitorConfig{"+
+		"Name:%s, "+
+		"Datacenter:%v, "+
+		"Near:%s, "+
+		"Limit:%d"+
+		"}",
+		StringVal(c.Name),
+		StringVal(c.Datacenter),
+		StringVal(c.Near),
+		IntVal(c.Limit),
+	)
+}
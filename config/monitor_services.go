@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"regexp"
+
+	"github.com/hashicorp/go-bexpr"
 )
 
 const servicesType = "services"
@@ -12,7 +14,37 @@ var _ MonitorConfig = (*ServicesMonitorConfig)(nil)
 // ServicesMonitorConfig configures a configuration block adhering to the monitor interface
 // of type 'services'. A services monitor watches for changes that occur to services.
 type ServicesMonitorConfig struct {
+	// Regexp configures the services to monitor by matching on the service name.
 	Regexp *string `mapstructure:"regexp"`
+
+	// Datacenter is the datacenter the services are deployed in. Only
+	// supported with Regexp.
+	Datacenter *string `mapstructure:"datacenter"`
+
+	// Datacenters are the datacenters to query the services in. The services
+	// of each datacenter are merged. "*" queries all the datacenters known to
+	// Consul. Cannot be configured with Datacenter. Only supported with Regexp.
+	Datacenters []string `mapstructure:"datacenters"`
+
+	// Namespace is the namespace of the services (Consul Enterprise only).
+	// Only supported with Regexp.
+	Namespace *string `mapstructure:"namespace"`
+
+	// Partition is the admin partition of the services (Consul Enterprise
+	// only). Only supported with Regexp.
+	Partition *string `mapstructure:"partition"`
+
+	// Peer is the name of the cluster peer that the services are imported
+	// from. Cannot be configured with Datacenters. Only supported with Regexp.
+	Peer *string `mapstructure:"peer"`
+
+	// NodeMeta filters the services by the metadata of the nodes they are
+	// registered to. Only supported with Regexp.
+	NodeMeta map[string]string `mapstructure:"node_meta"`
+
+	// Filter is a Consul filter expression to filter the services. Only
+	// supported with Regexp.
+	Filter *string `mapstructure:"filter"`
 }
 
 // Copy returns a deep copy of this configuration.
@@ -23,6 +55,23 @@ func (c *ServicesMonitorConfig) Copy() MonitorConfig {
 
 	var o ServicesMonitorConfig
 	o.Regexp = StringCopy(c.Regexp)
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Namespace = StringCopy(c.Namespace)
+	o.Partition = StringCopy(c.Partition)
+	o.Peer = StringCopy(c.Peer)
+	o.Filter = StringCopy(c.Filter)
+
+	if c.Datacenters != nil {
+		o.Datacenters = make([]string, 0, len(c.Datacenters))
+		o.Datacenters = append(o.Datacenters, c.Datacenters...)
+	}
+
+	if c.NodeMeta != nil {
+		o.NodeMeta = make(map[string]string)
+		for k, v := range c.NodeMeta {
+			o.NodeMeta[k] = v
+		}
+	}
 
 	return &o
 }
@@ -55,6 +104,37 @@ func (c *ServicesMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
 		r2.Regexp = StringCopy(o2.Regexp)
 	}
 
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	r2.Datacenters = append(r2.Datacenters, o2.Datacenters...)
+
+	if o2.Namespace != nil {
+		r2.Namespace = StringCopy(o2.Namespace)
+	}
+
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+
+	if o2.Peer != nil {
+		r2.Peer = StringCopy(o2.Peer)
+	}
+
+	if o2.NodeMeta != nil {
+		if r2.NodeMeta == nil {
+			r2.NodeMeta = make(map[string]string)
+		}
+		for k, v := range o2.NodeMeta {
+			r2.NodeMeta[k] = v
+		}
+	}
+
+	if o2.Filter != nil {
+		r2.Filter = StringCopy(o2.Filter)
+	}
+
 	return r2
 }
 
@@ -67,6 +147,34 @@ func (c *ServicesMonitorConfig) Finalize([]string) {
 	if c.Regexp == nil {
 		c.Regexp = String("")
 	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Datacenters == nil {
+		c.Datacenters = []string{}
+	}
+
+	if c.Namespace == nil {
+		c.Namespace = String("")
+	}
+
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+
+	if c.Peer == nil {
+		c.Peer = String("")
+	}
+
+	if c.NodeMeta == nil {
+		c.NodeMeta = make(map[string]string)
+	}
+
+	if c.Filter == nil {
+		c.Filter = String("")
+	}
 }
 
 // Validate validates the values and required options. This method is recommended
@@ -76,9 +184,34 @@ func (c *ServicesMonitorConfig) Validate() error {
 		return nil
 	}
 
-	if c.Regexp != nil && *c.Regexp != "" {
-		if _, err := regexp.Compile(StringVal(c.Regexp)); err != nil {
-			return fmt.Errorf("unable to compile services regexp: %s", err)
+	if c.Regexp == nil || *c.Regexp == "" {
+		// the query options are only used to query services by regexp
+		if StringVal(c.Datacenter) != "" || len(c.Datacenters) > 0 ||
+			StringVal(c.Namespace) != "" || StringVal(c.Partition) != "" ||
+			StringVal(c.Peer) != "" || len(c.NodeMeta) > 0 ||
+			StringVal(c.Filter) != "" {
+			return fmt.Errorf("datacenter, datacenters, namespace, partition, " +
+				"peer, node_meta, and filter are only supported for services " +
+				"with regexp configured")
+		}
+		return nil
+	}
+
+	if _, err := regexp.Compile(StringVal(c.Regexp)); err != nil {
+		return fmt.Errorf("unable to compile services regexp: %s", err)
+	}
+
+	if err := validateDatacenters(c.Datacenter, c.Datacenters); err != nil {
+		return fmt.Errorf("invalid datacenters for services: %s", err)
+	}
+
+	if StringVal(c.Peer) != "" && len(c.Datacenters) > 0 {
+		return fmt.Errorf("peer cannot be configured with datacenters for services")
+	}
+
+	if filter := StringVal(c.Filter); filter != "" {
+		if _, err := bexpr.CreateFilter(filter); err != nil {
+			return fmt.Errorf("invalid filter for services: %s", err)
 		}
 	}
 
@@ -93,7 +226,21 @@ func (c *ServicesMonitorConfig) GoString() string {
 
 	return fmt.Sprintf("&ServicesMonitorConfig{"+
 		"Regexp:%s, "+
+		"Datacenter:%s, "+
+		"Datacenters:%v, "+
+		"Namespace:%s, "+
+		"Partition:%s, "+
+		"Peer:%s, "+
+		"NodeMeta:%s, "+
+		"Filter:%s"+
 		"}",
 		StringVal(c.Regexp),
+		StringVal(c.Datacenter),
+		c.Datacenters,
+		StringVal(c.Namespace),
+		StringVal(c.Partition),
+		StringVal(c.Peer),
+		c.NodeMeta,
+		StringVal(c.Filter),
 	)
 }
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const vaultSecretType = "vault-secret"
+
+var _ MonitorConfig = (*VaultSecretMonitorConfig)(nil)
+
+// VaultSecretMonitorConfig configures a configuration block adhering to the
+// monitor interface of type 'vault-secret'. A vault-secret monitor watches
+// for changes that occur to the secrets at one or more Vault paths, e.g. when
+// a KV v2 secret has a new version or a leased PKI certificate is renewed.
+type VaultSecretMonitorConfig struct {
+	// Paths are the Vault paths of the secrets. A KV v2 path can select a
+	// version of the secret with the query parameter "version".
+	Paths []string `mapstructure:"paths"`
+
+	// Data are the parameters to write to each path, e.g. the common_name to
+	// issue a PKI certificate. The paths are read if no data is configured.
+	Data map[string]string `mapstructure:"data"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *VaultSecretMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o VaultSecretMonitorConfig
+	if c.Paths != nil {
+		o.Paths = make([]string, 0, len(c.Paths))
+		o.Paths = append(o.Paths, c.Paths...)
+	}
+
+	if c.Data != nil {
+		o.Data = make(map[string]string)
+		for k, v := range c.Data {
+			o.Data[k] = v
+		}
+	}
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *VaultSecretMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*VaultSecretMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*VaultSecretMonitorConfig)
+
+	r2.Paths = append(r2.Paths, o2.Paths...)
+
+	for k, v := range o2.Data {
+		if r2.Data == nil {
+			r2.Data = make(map[string]string)
+		}
+		r2.Data[k] = v
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *VaultSecretMonitorConfig) Finalize([]string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Paths == nil {
+		c.Paths = []string{}
+	}
+
+	if c.Data == nil {
+		c.Data = make(map[string]string)
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *VaultSecretMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if len(c.Paths) == 0 {
+		return fmt.Errorf("at least one path is required for vault-secret")
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range c.Paths {
+		p := strings.Trim(strings.TrimSpace(path), "/")
+		if p == "" {
+			return fmt.Errorf("empty path for vault-secret")
+		}
+		if seen[p] {
+			return fmt.Errorf("duplicate path %q for vault-secret", path)
+		}
+		seen[p] = true
+	}
+
+	for k := range c.Data {
+		if strings.TrimSpace(k) == "" {
+			return fmt.Errorf("empty data key for vault-secret")
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+// Sensitive information is redacted.
+func (c *VaultSecretMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*VaultSecretMonitorConfig)(nil)"
+	}
+
+	keys := make([]string, 0, len(c.Data))
+	for k := range c.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := make([]string, len(keys))
+	for i, k := range keys {
+		data[i] = fmt.Sprintf("%s:%s", k, redactMessage)
+	}
+
+	return fmt.Sprintf("&VaultSecretMonitorConfig{"+
+		"Paths:%v, "+
+		"Data:map[%s]"+
+		"}",
+		c.Paths,
+		strings.Join(data, " "),
+	)
+}
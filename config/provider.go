@@ -90,7 +90,7 @@ func (c *TerraformProviderConfigs) Validate() error {
 		}
 
 		// Require providers to be unique by name and alias.
-		id := s.id()
+		id := s.ID()
 		if ok := m[id]; ok {
 			return fmt.Errorf("duplicate provider configuration: %s", id)
 		}
@@ -161,9 +161,9 @@ func (c *TerraformProviderConfig) Validate() error {
 	return nil
 }
 
-// id returns the unique name to represent the provider configuration. If alias is set,
+// ID returns the unique name to represent the provider configuration. If alias is set,
 // the ID is <name>.<alias>. Otherwise, the name is used as the ID.
-func (c *TerraformProviderConfig) id() string {
+func (c *TerraformProviderConfig) ID() string {
 	if c == nil || len(*c) == 0 {
 		return ""
 	}
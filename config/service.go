@@ -5,6 +5,10 @@ import (
 	"strings"
 )
 
+// allDatacenters is the datacenters wildcard to query all the datacenters
+// known to Consul
+const allDatacenters = "*"
+
 // ServiceConfig defines the explicit configuration for Sync to monitor
 // a service. This block may be specified multiple times to configure multiple
 // services.
@@ -12,6 +16,11 @@ type ServiceConfig struct {
 	// Datacenter is the datacenter the service is deployed in.
 	Datacenter *string `mapstricture:"datacenter"`
 
+	// Datacenters are the datacenters to monitor the service in. The service
+	// instances of each datacenter are merged. "*" monitors the service in all
+	// the datacenters known to Consul. Cannot be configured with Datacenter.
+	Datacenters []string `mapstructure:"datacenters"`
+
 	// Description is the human readable text to describe the service.
 	Description *string `mapstructure:"description"`
 
@@ -56,6 +65,11 @@ func (c *ServiceConfig) Copy() *ServiceConfig {
 	o.Namespace = StringCopy(c.Namespace)
 	o.Filter = StringCopy(c.Filter)
 
+	if c.Datacenters != nil {
+		o.Datacenters = make([]string, 0, len(c.Datacenters))
+		o.Datacenters = append(o.Datacenters, c.Datacenters...)
+	}
+
 	if c.CTSUserDefinedMeta != nil {
 		o.CTSUserDefinedMeta = make(map[string]string)
 		for k, v := range c.CTSUserDefinedMeta {
@@ -88,6 +102,8 @@ func (c *ServiceConfig) Merge(o *ServiceConfig) *ServiceConfig {
 		r.Datacenter = StringCopy(o.Datacenter)
 	}
 
+	r.Datacenters = append(r.Datacenters, o.Datacenters...)
+
 	if o.Description != nil {
 		r.Description = StringCopy(o.Description)
 	}
@@ -130,6 +146,10 @@ func (c *ServiceConfig) Finalize() {
 		c.Datacenter = String("")
 	}
 
+	if c.Datacenters == nil {
+		c.Datacenters = []string{}
+	}
+
 	if c.Description == nil {
 		c.Description = String("")
 	}
@@ -165,6 +185,39 @@ func (c *ServiceConfig) Validate() error {
 		return fmt.Errorf("logical name for the Consul service is required")
 	}
 
+	if err := validateDatacenters(c.Datacenter, c.Datacenters); err != nil {
+		return fmt.Errorf("invalid datacenters for service %q: %s", *c.Name, err)
+	}
+
+	return nil
+}
+
+// validateDatacenters validates the datacenters to fan out a query to. The
+// datacenters cannot be configured with a single datacenter, and the wildcard
+// "*" for all datacenters cannot be configured with other datacenters.
+func validateDatacenters(datacenter *string, datacenters []string) error {
+	if len(datacenters) == 0 {
+		return nil
+	}
+
+	if StringVal(datacenter) != "" {
+		return fmt.Errorf("datacenter and datacenters cannot both be configured")
+	}
+
+	seen := make(map[string]bool, len(datacenters))
+	for _, dc := range datacenters {
+		switch {
+		case strings.TrimSpace(dc) == "":
+			return fmt.Errorf("datacenters cannot contain an empty datacenter")
+		case dc == allDatacenters && len(datacenters) > 1:
+			return fmt.Errorf("%q for all datacenters cannot be configured "+
+				"with other datacenters", allDatacenters)
+		case seen[dc]:
+			return fmt.Errorf("datacenter %q is configured more than once", dc)
+		}
+		seen[dc] = true
+	}
+
 	return nil
 }
 
@@ -179,6 +232,7 @@ func (c *ServiceConfig) GoString() string {
 		"Name:%s, "+
 		"Namespace:%s, "+
 		"Datacenter:%s, "+
+		"Datacenters:%v, "+
 		"Filter:%s, "+
 		"Description:%s, "+
 		"CTSUserDefinedMeta:%s"+
@@ -186,6 +240,7 @@ func (c *ServiceConfig) GoString() string {
 		StringVal(c.Name),
 		StringVal(c.Namespace),
 		StringVal(c.Datacenter),
+		c.Datacenters,
 		StringVal(c.Filter),
 		StringVal(c.Description),
 		c.CTSUserDefinedMeta,
@@ -28,6 +28,7 @@ func TestServiceConfig_Copy(t *testing.T) {
 				Description: String("description"),
 				Name:        String("name"),
 				Namespace:   String("namespace"),
+				Datacenters: []string{"dc1", "dc2"},
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
 				},
@@ -124,6 +125,18 @@ func TestServiceConfig_Merge(t *testing.T) {
 			&ServiceConfig{Name: String("name")},
 			&ServiceConfig{Name: String("name")},
 		},
+		{
+			"datacenters_merges",
+			&ServiceConfig{Datacenters: []string{"dc1"}},
+			&ServiceConfig{Datacenters: []string{"dc2"}},
+			&ServiceConfig{Datacenters: []string{"dc1", "dc2"}},
+		},
+		{
+			"datacenters_empty_one",
+			&ServiceConfig{Datacenters: []string{"dc1"}},
+			&ServiceConfig{},
+			&ServiceConfig{Datacenters: []string{"dc1"}},
+		},
 		{
 			"namespace_overrides",
 			&ServiceConfig{Namespace: String("namespace")},
@@ -195,6 +208,7 @@ func TestServiceConfig_Finalize(t *testing.T) {
 			&ServiceConfig{},
 			&ServiceConfig{
 				Datacenter:         String(""),
+				Datacenters:        []string{},
 				Description:        String(""),
 				ID:                 String(""),
 				Name:               String(""),
@@ -210,6 +224,7 @@ func TestServiceConfig_Finalize(t *testing.T) {
 			},
 			&ServiceConfig{
 				Datacenter:         String(""),
+				Datacenters:        []string{},
 				Description:        String(""),
 				ID:                 String("service"),
 				Name:               String("service"),
@@ -258,6 +273,56 @@ func TestServiceConfig_Validate(t *testing.T) {
 			&ServiceConfig{Description: String("description")},
 			false,
 		},
+		{
+			"valid datacenters",
+			&ServiceConfig{
+				Name:        String("task"),
+				Datacenter:  String(""),
+				Datacenters: []string{"dc1", "dc2"},
+			},
+			true,
+		},
+		{
+			"valid all datacenters",
+			&ServiceConfig{
+				Name:        String("task"),
+				Datacenters: []string{"*"},
+			},
+			true,
+		},
+		{
+			"datacenter and datacenters",
+			&ServiceConfig{
+				Name:        String("task"),
+				Datacenter:  String("dc1"),
+				Datacenters: []string{"dc2"},
+			},
+			false,
+		},
+		{
+			"all datacenters with other datacenters",
+			&ServiceConfig{
+				Name:        String("task"),
+				Datacenters: []string{"*", "dc1"},
+			},
+			false,
+		},
+		{
+			"duplicate datacenters",
+			&ServiceConfig{
+				Name:        String("task"),
+				Datacenters: []string{"dc1", "dc1"},
+			},
+			false,
+		},
+		{
+			"empty datacenter in datacenters",
+			&ServiceConfig{
+				Name:        String("task"),
+				Datacenters: []string{""},
+			},
+			false,
+		},
 	}
 
 	for i, tc := range cases {
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
-	"github.com/hashicorp/consul/lib/decode"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -17,39 +16,134 @@ type SourceInputConfig interface {
 	MonitorConfig
 }
 
-// DefaultSourceInputConfig returns the default source_input which is an un-configured
-// 'services' type source_input.
-func DefaultSourceInputConfig() SourceInputConfig {
-	return &ServicesSourceInputConfig{
-		ServicesMonitorConfig{
-			Regexp: String(""),
-		},
+// SourceInputConfigs is a collection of SourceInputConfig. Each source_input
+// configured for a task provides its own input variable to the task source.
+type SourceInputConfigs []SourceInputConfig
+
+// DefaultSourceInputConfigs returns a configuration that is populated with the
+// default values, which is no source_input.
+func DefaultSourceInputConfigs() *SourceInputConfigs {
+	return &SourceInputConfigs{}
+}
+
+// Len is a helper method to get the length of the underlying config list
+func (c *SourceInputConfigs) Len() int {
+	if c == nil {
+		return 0
 	}
+
+	return len(*c)
 }
 
-// isSourceInputEmpty returns true if the provided SourceInputConfig `c` is equal
-// to an empty SourceInputConfig
-func isSourceInputEmpty(c SourceInputConfig) bool {
-	logger := logging.Global().Named(logSystemName)
-	sv, ok := c.(*ServicesSourceInputConfig)
-	if !ok {
-		return false
+// Copy returns a deep copy of this configuration.
+func (c *SourceInputConfigs) Copy() *SourceInputConfigs {
+	if c == nil {
+		return nil
 	}
 
-	// Nil means serviceSourceInput was not set to empty default
-	if sv.Regexp == nil {
-		return false
+	o := make(SourceInputConfigs, c.Len())
+	for i, si := range *c {
+		if isSourceInputNil(si) {
+			continue
+		}
+		o[i] = si.Copy()
+	}
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// A source_input is merged with the source_input of the same type, otherwise
+// it is appended.
+func (c *SourceInputConfigs) Merge(o *SourceInputConfigs) *SourceInputConfigs {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
 	}
 
-	dsv, ok := DefaultSourceInputConfig().(*ServicesSourceInputConfig)
-	if !ok {
-		// This should never happen and would indicate programmer error
-		logger.Error("default config is not of the expected type",
-			"actual_type", fmt.Sprintf("%T", DefaultSourceInputConfig()), "expected_type", "ServicesSourceInputConfig")
-		panic("default config is not of the expected type")
+	if o == nil {
+		return c.Copy()
 	}
 
-	return *sv.Regexp == *dsv.Regexp
+	r := c.Copy()
+
+OUTER:
+	for _, osi := range *o {
+		if isSourceInputNil(osi) {
+			continue
+		}
+		for i, rsi := range *r {
+			if reflect.TypeOf(rsi) == reflect.TypeOf(osi) {
+				(*r)[i] = rsi.Merge(osi)
+				continue OUTER
+			}
+		}
+		*r = append(*r, osi.Copy())
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *SourceInputConfigs) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	for _, si := range *c {
+		if !isSourceInputNil(si) {
+			si.Finalize(services)
+		}
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct.
+// Each source_input type provides its own input variable, so a type can only
+// be configured once.
+func (c *SourceInputConfigs) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	types := make(map[string]bool)
+	for _, si := range *c {
+		if isSourceInputNil(si) {
+			return fmt.Errorf("empty source_input configured")
+		}
+
+		t := fmt.Sprintf("%T", si)
+		if types[t] {
+			return fmt.Errorf("only one source_input of each type can be "+
+				"configured per task: %s", strings.TrimPrefix(t, "*config."))
+		}
+		types[t] = true
+
+		if err := si.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *SourceInputConfigs) GoString() string {
+	if c == nil {
+		return "(*SourceInputConfigs)(nil)"
+	}
+
+	s := make([]string, len(*c))
+	for i, si := range *c {
+		if isSourceInputNil(si) {
+			s[i] = "<nil>"
+			continue
+		}
+		s[i] = si.GoString()
+	}
+
+	return "{" + strings.Join(s, ", ") + "}"
 }
 
 // sourceInputToTypeFunc is a decode hook function to decode a SourceInputConfig
@@ -60,39 +154,79 @@ func sourceInputToTypeFunc() mapstructure.DecodeHookFunc {
 		f reflect.Type,
 		t reflect.Type,
 		data interface{}) (interface{}, error) {
-		// identify if parsing a SourceInputConfig
-		var i SourceInputConfig
-		if t != reflect.TypeOf(&i).Elem() {
+		// identify if parsing SourceInputConfigs
+		if t != reflect.TypeOf(SourceInputConfigs{}) {
 			return data, nil
 		}
 
-		// abstract sourceInputs map out depending on hcl vs. json formatting
-		// data hcl ex: [map[services:[map[regexp:.*]]]]
-		// data json ex: map[services:map[regexp:.*]]
-		var sourceInputs map[string]interface{}
+		// abstract sourceInputs maps out depending on hcl vs. json formatting.
+		// each map contains one or more source_input blocks keyed by type
+		// data hcl ex: [map[services:[map[regexp:.*]]] map[consul-kv:[map[path:key]]]]
+		// data json ex: map[services:map[regexp:.*] consul-kv:map[path:key]]
+		var sourceInputs []map[string]interface{}
 		if hcl, ok := data.([]map[string]interface{}); ok {
-			if len(hcl) != 1 {
-				return nil, fmt.Errorf("expected only one item in hcl "+
-					"sourceInput but got %d: %v", len(hcl), data)
-			}
-			sourceInputs = hcl[0]
+			sourceInputs = hcl
 		}
 		if json, ok := data.(map[string]interface{}); ok {
-			sourceInputs = json
+			sourceInputs = []map[string]interface{}{json}
 		}
-
-		if c, ok := sourceInputs[servicesType]; ok {
-			var config ServicesSourceInputConfig
-			return decodeSourceInputToType(c, &config)
+		if sourceInputs == nil {
+			return nil, fmt.Errorf("unsupported source_input: %v", data)
 		}
 
-		if c, ok := sourceInputs[consulKVType]; ok {
-			var config ConsulKVSourceInputConfig
-			return decodeSourceInputToType(c, &config)
+		var configs SourceInputConfigs
+		for _, m := range sourceInputs {
+			// sort types for json formatting to decode in a consistent order
+			types := make([]string, 0, len(m))
+			for k := range m {
+				types = append(types, k)
+			}
+			sort.Strings(types)
+
+			for _, k := range types {
+				si, err := decodeSourceInputType(k, m[k])
+				if err != nil {
+					return nil, err
+				}
+				configs = append(configs, si)
+			}
 		}
 
-		return nil, fmt.Errorf("unsupported source_input type: %v", data)
+		return configs, nil
+	}
+}
+
+// decodeSourceInputType decodes a source_input block of a type into the
+// implementation structure of the type
+func decodeSourceInputType(t string, data interface{}) (SourceInputConfig, error) {
+	switch t {
+	case servicesType:
+		var config ServicesSourceInputConfig
+		return decodeSourceInputToType(data, &config)
+	case consulKVType:
+		var config ConsulKVSourceInputConfig
+		return decodeSourceInputToType(data, &config)
+	case nodesType:
+		var config NodesSourceInputConfig
+		return decodeSourceInputToType(data, &config)
+	case intentionsType:
+		var config IntentionsSourceInputConfig
+		return decodeSourceInputToType(data, &config)
+	case configEntriesType:
+		var config ConfigEntriesSourceInputConfig
+		return decodeSourceInputToType(data, &config)
+	case preparedQueryType:
+		var config PreparedQuerySourceInputConfig
+		return decodeSourceInputToType(data, &config)
+	case vaultSecretType:
+		var config VaultSecretSourceInputConfig
+		return decodeSourceInputToType(data, &config)
+	case fileType:
+		var config FileSourceInputConfig
+		return decodeSourceInputToType(data, &config)
 	}
+
+	return nil, fmt.Errorf("unsupported source_input type: %s", t)
 }
 
 // decodeSourceInputToType is used by the overall config mapstructure decode hook
@@ -103,7 +237,7 @@ func decodeSourceInputToType(data interface{}, sourceInput SourceInputConfig) (S
 	logger := logging.Global().Named(logSystemName)
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			decode.HookWeakDecodeFromSlice,
+			hookWeakDecodeFromSlice,
 		),
 		WeaklyTypedInput: true,
 		ErrorUnused:      false,
@@ -121,8 +255,7 @@ func decodeSourceInputToType(data interface{}, sourceInput SourceInputConfig) (S
 	}
 
 	if len(md.Unused) > 0 {
-		sort.Strings(md.Unused)
-		err := fmt.Errorf("invalid keys: %s", strings.Join(md.Unused, ", "))
+		err := invalidKeysError(md.Unused)
 		logger.Error("source_input invalid keys", "error", err)
 		return nil, err
 	}
@@ -134,3 +267,15 @@ func decodeSourceInputToType(data interface{}, sourceInput SourceInputConfig) (S
 func isSourceInputNil(si SourceInputConfig) bool {
 	return isMonitorNil(si)
 }
+
+// isSourceInputEmpty returns true if the provided SourceInputConfig `c` is an
+// un-configured 'services' type source_input, which provides no input variable
+func isSourceInputEmpty(c SourceInputConfig) bool {
+	sv, ok := c.(*ServicesSourceInputConfig)
+	if !ok {
+		return false
+	}
+
+	// Nil means serviceSourceInput was not finalized to the empty default
+	return sv.Regexp != nil && *sv.Regexp == ""
+}
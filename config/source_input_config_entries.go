@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ SourceInputConfig = (*ConfigEntriesSourceInputConfig)(nil)
+
+// ConfigEntriesSourceInputConfig configures a source_input configuration block of type
+// 'config-entries'. The Consul config entries will be used as input for the source
+// variables.
+type ConfigEntriesSourceInputConfig struct {
+	ConfigEntriesMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConfigEntriesSourceInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	svc, ok := c.ConfigEntriesMonitorConfig.Copy().(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &ConfigEntriesSourceInputConfig{
+		ConfigEntriesMonitorConfig: *svc,
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *ConfigEntriesSourceInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isSourceInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isSourceInputNil(o) {
+		return c.Copy()
+	}
+
+	scc, ok := o.(*ConfigEntriesSourceInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.ConfigEntriesMonitorConfig.Merge(&scc.ConfigEntriesMonitorConfig).(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &ConfigEntriesSourceInputConfig{
+		ConfigEntriesMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *ConfigEntriesSourceInputConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+	c.ConfigEntriesMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *ConfigEntriesSourceInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.ConfigEntriesMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *ConfigEntriesSourceInputConfig) GoString() string {
+	if c == nil {
+		return "(*ConfigEntriesSourceInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ConfigEntriesSourceInputConfig{"+
+		"%s"+
+		"}",
+		c.ConfigEntriesMonitorConfig.GoString(),
+	)
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigEntriesSourceInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesSourceInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ConfigEntriesSourceInputConfig{},
+		},
+		{
+			"fully_configured",
+			&ConfigEntriesSourceInputConfig{
+				ConfigEntriesMonitorConfig{
+					Kinds:      []string{"service-resolver"},
+					Regexp:     String("^web"),
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+					Partition:  String("ap2"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesSourceInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesSourceInputConfig
+		b    *ConfigEntriesSourceInputConfig
+		r    *ConfigEntriesSourceInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ConfigEntriesSourceInputConfig{},
+			&ConfigEntriesSourceInputConfig{},
+		},
+		{
+			"nil_b",
+			&ConfigEntriesSourceInputConfig{},
+			nil,
+			&ConfigEntriesSourceInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"kinds_merge",
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{
+				Kinds: []string{"service-defaults"}}},
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{
+				Kinds: []string{"proxy-defaults"}}},
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{
+				Kinds: []string{"service-defaults", "proxy-defaults"}}},
+		},
+		{
+			"datacenter_overrides",
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{Datacenter: String("dc1")}},
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{Datacenter: String("dc2")}},
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{Datacenter: String("dc2")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesSourceInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &ConfigEntriesSourceInputConfig{}
+	i.Finalize([]string{})
+	assert.Equal(t, &ConfigEntriesSourceInputConfig{
+		ConfigEntriesMonitorConfig{
+			Kinds:      []string{},
+			Regexp:     String(""),
+			Datacenter: String(""),
+			Namespace:  String(""),
+			Partition:  String(""),
+		},
+	}, i)
+}
+
+func TestConfigEntriesSourceInputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		i         *ConfigEntriesSourceInputConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{
+				Kinds: []string{"ingress-gateway"},
+			}},
+		},
+		{
+			"unsupported_kind",
+			true,
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{
+				Kinds: []string{"unknown"},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesSourceInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *ConfigEntriesSourceInputConfig
+		expected string
+	}{
+		{
+			"configured config-entries source_input",
+			&ConfigEntriesSourceInputConfig{ConfigEntriesMonitorConfig{
+				Kinds:      []string{"service-resolver"},
+				Regexp:     String(""),
+				Datacenter: String("dc"),
+				Namespace:  String(""),
+				Partition:  String("ap2"),
+			}},
+			"&ConfigEntriesSourceInputConfig{" +
+				"&ConfigEntriesMonitorConfig{" +
+				"Kinds:[service-resolver], " +
+				"Regexp:, " +
+				"Datacenter:dc, " +
+				"Namespace:, " +
+				"Partition:ap2" +
+				"}" +
+				"}",
+		},
+		{
+			"nil config-entries source_input",
+			nil,
+			"(*ConfigEntriesSourceInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -212,6 +212,7 @@ func TestConsulKVSourceInputConfig_Finalize(t *testing.T) {
 					Recurse:    Bool(false),
 					Datacenter: String(""),
 					Namespace:  String(""),
+					Decode:     String(""),
 				},
 			},
 		},
@@ -225,6 +226,7 @@ func TestConsulKVSourceInputConfig_Finalize(t *testing.T) {
 					Recurse:    Bool(false),
 					Datacenter: String(""),
 					Namespace:  String(""),
+					Decode:     String(""),
 				},
 			},
 		},
@@ -293,6 +295,7 @@ func TestConsulKVSourceInputConfig_GoString(t *testing.T) {
 					Recurse:    Bool(true),
 					Datacenter: String("dc"),
 					Namespace:  String("ns"),
+					Decode:     String("json"),
 				},
 			},
 			"&ConsulKVSourceInputConfig{" +
@@ -301,6 +304,7 @@ func TestConsulKVSourceInputConfig_GoString(t *testing.T) {
 				"Recurse:true, " +
 				"Datacenter:dc, " +
 				"Namespace:ns, " +
+				"Decode:json, " +
 				"}" +
 				"}",
 		},
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ SourceInputConfig = (*FileSourceInputConfig)(nil)
+
+// FileSourceInputConfig configures a source_input configuration block
+// of type 'file'. The parsed contents of the local files matching the paths
+// will be used as input for the files variable.
+type FileSourceInputConfig struct {
+	FileMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *FileSourceInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	m, ok := c.FileMonitorConfig.Copy().(*FileMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &FileSourceInputConfig{
+		FileMonitorConfig: *m,
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *FileSourceInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isSourceInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isSourceInputNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*FileSourceInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.FileMonitorConfig.Merge(&o2.FileMonitorConfig).(*FileMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &FileSourceInputConfig{
+		FileMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *FileSourceInputConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+	c.FileMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *FileSourceInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.FileMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *FileSourceInputConfig) GoString() string {
+	if c == nil {
+		return "(*FileSourceInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&FileSourceInputConfig{"+
+		"%s"+
+		"}",
+		c.FileMonitorConfig.GoString(),
+	)
+}
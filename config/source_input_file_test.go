@@ -0,0 +1,185 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSourceInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *FileSourceInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&FileSourceInputConfig{},
+		},
+		{
+			"fully_configured",
+			&FileSourceInputConfig{
+				FileMonitorConfig{
+					Paths:  []string{"inventory/*.yaml"},
+					Format: String("yaml"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestFileSourceInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *FileSourceInputConfig
+		b    *FileSourceInputConfig
+		r    *FileSourceInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&FileSourceInputConfig{},
+			&FileSourceInputConfig{},
+		},
+		{
+			"nil_b",
+			&FileSourceInputConfig{},
+			nil,
+			&FileSourceInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"paths_merge",
+			&FileSourceInputConfig{FileMonitorConfig{Paths: []string{"a.json"}}},
+			&FileSourceInputConfig{FileMonitorConfig{Paths: []string{"b.json"}}},
+			&FileSourceInputConfig{FileMonitorConfig{Paths: []string{"a.json", "b.json"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestFileSourceInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &FileSourceInputConfig{}
+	i.Finalize([]string{})
+	assert.Equal(t, &FileSourceInputConfig{
+		FileMonitorConfig{
+			Paths:  []string{},
+			Format: String(""),
+		},
+	}, i)
+}
+
+func TestFileSourceInputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		i         *FileSourceInputConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&FileSourceInputConfig{FileMonitorConfig{
+				Paths: []string{"hosts.json"},
+			}},
+		},
+		{
+			"missing_paths",
+			true,
+			&FileSourceInputConfig{FileMonitorConfig{
+				Paths: []string{},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFileSourceInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *FileSourceInputConfig
+		expected string
+	}{
+		{
+			"configured file source_input",
+			&FileSourceInputConfig{FileMonitorConfig{
+				Paths:  []string{"hosts.json"},
+				Format: String(""),
+			}},
+			"&FileSourceInputConfig{" +
+				"&FileMonitorConfig{" +
+				"Paths:[hosts.json], " +
+				"Format:" +
+				"}" +
+				"}",
+		},
+		{
+			"nil file source_input",
+			nil,
+			"(*FileSourceInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
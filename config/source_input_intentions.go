@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ SourceInputConfig = (*IntentionsSourceInputConfig)(nil)
+
+// IntentionsSourceInputConfig configures a source_input configuration block of type
+// 'intentions'. The Consul service intentions will be used as input for the source
+// variables.
+type IntentionsSourceInputConfig struct {
+	IntentionsMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *IntentionsSourceInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	svc, ok := c.IntentionsMonitorConfig.Copy().(*IntentionsMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &IntentionsSourceInputConfig{
+		IntentionsMonitorConfig: *svc,
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *IntentionsSourceInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isSourceInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isSourceInputNil(o) {
+		return c.Copy()
+	}
+
+	scc, ok := o.(*IntentionsSourceInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.IntentionsMonitorConfig.Merge(&scc.IntentionsMonitorConfig).(*IntentionsMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &IntentionsSourceInputConfig{
+		IntentionsMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *IntentionsSourceInputConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+	c.IntentionsMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *IntentionsSourceInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.IntentionsMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *IntentionsSourceInputConfig) GoString() string {
+	if c == nil {
+		return "(*IntentionsSourceInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&IntentionsSourceInputConfig{"+
+		"%s"+
+		"}",
+		c.IntentionsMonitorConfig.GoString(),
+	)
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntentionsSourceInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *IntentionsSourceInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&IntentionsSourceInputConfig{},
+		},
+		{
+			"fully_configured",
+			&IntentionsSourceInputConfig{
+				IntentionsMonitorConfig{
+					SourceRegexp:      String("^web$"),
+					DestinationRegexp: String("^api$"),
+					Datacenter:        String("dc2"),
+					Namespace:         String("ns2"),
+					Partition:         String("ap2"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsSourceInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *IntentionsSourceInputConfig
+		b    *IntentionsSourceInputConfig
+		r    *IntentionsSourceInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&IntentionsSourceInputConfig{},
+			&IntentionsSourceInputConfig{},
+		},
+		{
+			"nil_b",
+			&IntentionsSourceInputConfig{},
+			nil,
+			&IntentionsSourceInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"happy_path",
+			&IntentionsSourceInputConfig{IntentionsMonitorConfig{
+				SourceRegexp: String("web"),
+				Datacenter:   String("dc1"),
+			}},
+			&IntentionsSourceInputConfig{IntentionsMonitorConfig{
+				DestinationRegexp: String("api"),
+				Datacenter:        String("dc2"),
+			}},
+			&IntentionsSourceInputConfig{IntentionsMonitorConfig{
+				SourceRegexp:      String("web"),
+				DestinationRegexp: String("api"),
+				Datacenter:        String("dc2"),
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsSourceInputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		i         *IntentionsSourceInputConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&IntentionsSourceInputConfig{IntentionsMonitorConfig{
+				SourceRegexp: String("^web$"),
+			}},
+		},
+		{
+			"invalid_regexp",
+			true,
+			&IntentionsSourceInputConfig{IntentionsMonitorConfig{
+				DestinationRegexp: String("*"),
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIntentionsSourceInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *IntentionsSourceInputConfig
+		expected string
+	}{
+		{
+			"configured intentions source_input",
+			&IntentionsSourceInputConfig{IntentionsMonitorConfig{
+				SourceRegexp:      String("web"),
+				DestinationRegexp: String(""),
+				Datacenter:        String("dc"),
+				Namespace:         String("ns"),
+				Partition:         String("ap2"),
+			}},
+			"&IntentionsSourceInputConfig{" +
+				"&IntentionsMonitorConfig{" +
+				"SourceRegexp:web, " +
+				"DestinationRegexp:, " +
+				"Datacenter:dc, " +
+				"Namespace:ns, " +
+				"Partition:ap2" +
+				"}" +
+				"}",
+		},
+		{
+			"nil intentions source_input",
+			nil,
+			"(*IntentionsSourceInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
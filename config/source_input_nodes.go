@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ SourceInputConfig = (*NodesSourceInputConfig)(nil)
+
+// NodesSourceInputConfig configures a source_input configuration block of type
+// 'nodes'. The nodes in the Consul catalog will be used as input for the source
+// variables.
+type NodesSourceInputConfig struct {
+	NodesMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *NodesSourceInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	svc, ok := c.NodesMonitorConfig.Copy().(*NodesMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &NodesSourceInputConfig{
+		NodesMonitorConfig: *svc,
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *NodesSourceInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isSourceInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isSourceInputNil(o) {
+		return c.Copy()
+	}
+
+	scc, ok := o.(*NodesSourceInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.NodesMonitorConfig.Merge(&scc.NodesMonitorConfig).(*NodesMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &NodesSourceInputConfig{
+		NodesMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *NodesSourceInputConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+	c.NodesMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *NodesSourceInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.NodesMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *NodesSourceInputConfig) GoString() string {
+	if c == nil {
+		return "(*NodesSourceInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&NodesSourceInputConfig{"+
+		"%s"+
+		"}",
+		c.NodesMonitorConfig.GoString(),
+	)
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodesSourceInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *NodesSourceInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&NodesSourceInputConfig{},
+		},
+		{
+			"fully_configured",
+			&NodesSourceInputConfig{
+				NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					Partition:  String("ap2"),
+					Peer:       String("peer2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Node != \"web\""),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestNodesSourceInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *NodesSourceInputConfig
+		b    *NodesSourceInputConfig
+		r    *NodesSourceInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&NodesSourceInputConfig{},
+			&NodesSourceInputConfig{},
+		},
+		{
+			"nil_b",
+			&NodesSourceInputConfig{},
+			nil,
+			&NodesSourceInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"happy_path",
+			&NodesSourceInputConfig{NodesMonitorConfig{
+				Datacenter: String("dc1"),
+				NodeMeta:   map[string]string{"foo": "bar"},
+			}},
+			&NodesSourceInputConfig{NodesMonitorConfig{
+				Datacenter: String("dc2"),
+				Filter:     String("Node != \"web\""),
+			}},
+			&NodesSourceInputConfig{NodesMonitorConfig{
+				Datacenter: String("dc2"),
+				NodeMeta:   map[string]string{"foo": "bar"},
+				Filter:     String("Node != \"web\""),
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestNodesSourceInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &NodesSourceInputConfig{}
+	i.Finalize([]string{"api"})
+	assert.Equal(t, &NodesSourceInputConfig{
+		NodesMonitorConfig{
+			Datacenter: String(""),
+			Partition:  String(""),
+			Peer:       String(""),
+			NodeMeta:   map[string]string{},
+			Filter:     String(""),
+		},
+	}, i)
+}
+
+func TestNodesSourceInputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		i         *NodesSourceInputConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&NodesSourceInputConfig{NodesMonitorConfig{
+				Filter: String("Meta.env == \"prod\""),
+			}},
+		},
+		{
+			"invalid_filter",
+			true,
+			&NodesSourceInputConfig{NodesMonitorConfig{
+				Filter: String("Node =="),
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodesSourceInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *NodesSourceInputConfig
+		expected string
+	}{
+		{
+			"configured nodes source_input",
+			&NodesSourceInputConfig{NodesMonitorConfig{
+				Datacenter: String("dc"),
+				Partition:  String("ap2"),
+				Peer:       String("peer2"),
+				NodeMeta:   map[string]string{"key": "value"},
+				Filter:     String(""),
+			}},
+			"&NodesSourceInputConfig{" +
+				"&NodesMonitorConfig{" +
+				"Datacenter:dc, " +
+				"Partition:ap2, " +
+				"Peer:peer2, " +
+				"NodeMeta:map[key:value], " +
+				"Filter:" +
+				"}" +
+				"}",
+		},
+		{
+			"nil nodes source_input",
+			nil,
+			"(*NodesSourceInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ SourceInputConfig = (*PreparedQuerySourceInputConfig)(nil)
+
+// PreparedQuerySourceInputConfig configures a source_input configuration block
+// of type 'prepared-query'. The service instances of the prepared query result
+// will be used as input for the services variable in place of the task's
+// services.
+type PreparedQuerySourceInputConfig struct {
+	PreparedQueryMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *PreparedQuerySourceInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	m, ok := c.PreparedQueryMonitorConfig.Copy().(*PreparedQueryMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &PreparedQuerySourceInputConfig{
+		PreparedQueryMonitorConfig: *m,
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *PreparedQuerySourceInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isSourceInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isSourceInputNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*PreparedQuerySourceInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.PreparedQueryMonitorConfig.Merge(&o2.PreparedQueryMonitorConfig).(*PreparedQueryMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &PreparedQuerySourceInputConfig{
+		PreparedQueryMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *PreparedQuerySourceInputConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+	c.PreparedQueryMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *PreparedQuerySourceInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.PreparedQueryMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *PreparedQuerySourceInputConfig) GoString() string {
+	if c == nil {
+		return "(*PreparedQuerySourceInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&PreparedQuerySourceInputConfig{"+
+		"%s"+
+		"}",
+		c.PreparedQueryMonitorConfig.GoString(),
+	)
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreparedQuerySourceInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *PreparedQuerySourceInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&PreparedQuerySourceInputConfig{},
+		},
+		{
+			"fully_configured",
+			&PreparedQuerySourceInputConfig{
+				PreparedQueryMonitorConfig{
+					Name:       String("web-failover"),
+					Datacenter: String("dc2"),
+					Near:       String("_agent"),
+					Limit:      Int(3),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestPreparedQuerySourceInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *PreparedQuerySourceInputConfig
+		b    *PreparedQuerySourceInputConfig
+		r    *PreparedQuerySourceInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&PreparedQuerySourceInputConfig{},
+			&PreparedQuerySourceInputConfig{},
+		},
+		{
+			"nil_b",
+			&PreparedQuerySourceInputConfig{},
+			nil,
+			&PreparedQuerySourceInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"name_overrides",
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{Name: String("a")}},
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{Name: String("b")}},
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{Name: String("b")}},
+		},
+		{
+			"datacenter_empty_one",
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{Datacenter: String("dc2")}},
+			&PreparedQuerySourceInputConfig{},
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{Datacenter: String("dc2")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestPreparedQuerySourceInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &PreparedQuerySourceInputConfig{}
+	i.Finalize([]string{})
+	assert.Equal(t, &PreparedQuerySourceInputConfig{
+		PreparedQueryMonitorConfig{
+			Name:       String(""),
+			Datacenter: String(""),
+			Near:       String(""),
+			Limit:      Int(0),
+		},
+	}, i)
+}
+
+func TestPreparedQuerySourceInputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		i         *PreparedQuerySourceInputConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{
+				Name: String("web-failover"),
+			}},
+		},
+		{
+			"missing_name",
+			true,
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{
+				Name: String(""),
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPreparedQuerySourceInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *PreparedQuerySourceInputConfig
+		expected string
+	}{
+		{
+			"configured prepared-query source_input",
+			&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{
+				Name:       String("web-failover"),
+				Datacenter: String(""),
+				Near:       String(""),
+				Limit:      Int(0),
+			}},
+			"&PreparedQuerySourceInputConfig{" +
+				"&PreparedQueryMonitorConfig{" +
+				"Name:web-failover, " +
+				"Datacenter:, " +
+				"Near:, " +
+				"Limit:0" +
+				"}" +
+				"}",
+		},
+		{
+			"nil prepared-query source_input",
+			nil,
+			"(*PreparedQuerySourceInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -134,7 +134,14 @@ func TestServicesSourceInputConfig_Finalize(t *testing.T) {
 			&ServicesSourceInputConfig{},
 			&ServicesSourceInputConfig{
 				ServicesMonitorConfig{
-					Regexp: String(""),
+					Regexp:      String(""),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
 			},
 		},
@@ -144,7 +151,14 @@ func TestServicesSourceInputConfig_Finalize(t *testing.T) {
 			&ServicesSourceInputConfig{},
 			&ServicesSourceInputConfig{
 				ServicesMonitorConfig{
-					Regexp: String(""),
+					Regexp:      String(""),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
 			},
 		},
@@ -221,12 +235,26 @@ func TestGoString(t *testing.T) {
 			"configured services source_input",
 			&ServicesSourceInputConfig{
 				ServicesMonitorConfig{
-					Regexp: String("^api$"),
+					Regexp:      String("^api$"),
+					Datacenter:  String("dc2"),
+					Datacenters: []string{},
+					Namespace:   String("ns2"),
+					Partition:   String("ap2"),
+					Peer:        String("peer2"),
+					NodeMeta:    map[string]string{"key": "value"},
+					Filter:      String("Service.Tags contains \"v1\""),
 				},
 			},
 			"&ServicesSourceInputConfig{" +
 				"&ServicesMonitorConfig{" +
 				"Regexp:^api$, " +
+				"Datacenter:dc2, " +
+				"Datacenters:[], " +
+				"Namespace:ns2, " +
+				"Partition:ap2, " +
+				"Peer:peer2, " +
+				"NodeMeta:map[key:value], " +
+				"Filter:Service.Tags contains \"v1\"" +
 				"}" +
 				"}",
 		},
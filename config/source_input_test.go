@@ -15,6 +15,12 @@ task {
 	source = "..."
 	source_input "services" {
 		regexp = ".*"
+		datacenter = "dc2"
+		namespace = "ns2"
+		node_meta {
+			key = "value"
+		}
+		filter = "Service.Tags contains \"v1\""
 	}
 	condition "schedule" {
 		cron = "* * * * * * *"
@@ -44,6 +50,130 @@ task {
 		namespace = "ns2"
 		datacenter = "dc2"
 		recurse = true
+		decode = "yaml"
+	}
+}`
+
+	testSourceInputNodesSuccess = `
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "nodes" {
+		datacenter = "dc2"
+		node_meta {
+		  "key1" = "value1"
+		}
+	}
+}`
+
+	testSourceInputIntentionsSuccess = `
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "intentions" {
+		destination_regexp = "^db$"
+	}
+}`
+
+	testSourceInputConfigEntriesSuccess = `
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "config-entries" {
+		kinds = ["service-resolver"]
+		namespace = "ns2"
+	}
+}`
+
+	testSourceInputPreparedQuerySuccess = `
+task {
+	name = "condition_task"
+	source = "..."
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "prepared-query" {
+		name = "web-failover"
+		limit = 3
+	}
+}`
+
+	testSourceInputVaultSecretSuccess = `
+vault {
+	address = "vault.example.com"
+}
+
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "vault-secret" {
+		paths = ["kv/data/app?version=2"]
+	}
+}`
+
+	testSourceInputFileSuccess = `
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "file" {
+		paths = ["inventory/*"]
+		format = "json"
+	}
+}`
+
+	testSourceInputMultipleSuccess = `
+task {
+	name = "condition_task"
+	source = "..."
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "services" {
+		regexp = "^api$"
+	}
+	source_input "consul-kv" {
+		path = "key-path"
+	}
+}`
+
+	testSourceInputMultipleJSONSuccess = `
+{
+	"task": {
+		"name": "condition_task",
+		"source": "...",
+		"condition": {
+			"schedule": {
+				"cron": "* * * * * * *"
+			}
+		},
+		"source_input": {
+			"services": {
+				"regexp": "^api$"
+			},
+			"consul-kv": {
+				"path": "key-path"
+			}
+		}
 	}
 }`
 
@@ -77,16 +207,24 @@ task {
 	}
 }`
 
+	testSourceInputUnsupportedTypeError = `
+task {
+	name = "condition_task"
+	source = "..."
+	services = ["api"]
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	source_input "nonexistent" {
+	}
+}`
+
 	testFileName = "config.hcl"
 )
 
-func TestSourceInput_DefaultSourceInputConfig(t *testing.T) {
-	e := &ServicesSourceInputConfig{
-		ServicesMonitorConfig{
-			Regexp: String(""),
-		},
-	}
-	a := DefaultSourceInputConfig()
+func TestSourceInput_DefaultSourceInputConfigs(t *testing.T) {
+	e := &SourceInputConfigs{}
+	a := DefaultSourceInputConfigs()
 	require.Equal(t, e, a)
 }
 
@@ -94,46 +232,192 @@ func TestSourceInput_DecodeConfig_Success(t *testing.T) {
 	// Specifically test decoding source_input configs
 	cases := []struct {
 		name     string
-		expected SourceInputConfig
+		expected *SourceInputConfigs
 		filename string
 		config   string
 	}{
 		{
 			name: "services happy path",
-			expected: &ServicesSourceInputConfig{
+			expected: &SourceInputConfigs{&ServicesSourceInputConfig{
 				ServicesMonitorConfig{
-					Regexp: String(".*"),
+					Regexp:      String(".*"),
+					Datacenter:  String("dc2"),
+					Datacenters: []string{},
+					Namespace:   String("ns2"),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{"key": "value"},
+					Filter:      String("Service.Tags contains \"v1\""),
 				},
-			},
+			}},
 			config: testSourceInputServicesSuccess,
 		},
 		{
 			name: "services un-configured",
-			expected: &ServicesSourceInputConfig{
+			expected: &SourceInputConfigs{&ServicesSourceInputConfig{
 				ServicesMonitorConfig{
-					Regexp: String(""),
+					Regexp:      String(""),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
 				},
-			},
+			}},
 			config: testSourceInputServicesUnconfiguredSuccess,
 		},
 		{
 			name: "consul-kv: happy path",
-			expected: &ConsulKVSourceInputConfig{
+			expected: &SourceInputConfigs{&ConsulKVSourceInputConfig{
 				ConsulKVMonitorConfig{
 					Path:       String("key-path"),
 					Datacenter: String("dc2"),
 					Namespace:  String("ns2"),
 					Recurse:    Bool(true),
+					Decode:     String("yaml"),
 				},
-			},
+			}},
 			config: testSourceInputConsulKVSuccess,
 		},
+		{
+			name: "nodes: happy path",
+			expected: &SourceInputConfigs{&NodesSourceInputConfig{
+				NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					Partition:  String(""),
+					Peer:       String(""),
+					NodeMeta:   map[string]string{"key1": "value1"},
+					Filter:     String(""),
+				},
+			}},
+			config: testSourceInputNodesSuccess,
+		},
+		{
+			name: "intentions: happy path",
+			expected: &SourceInputConfigs{&IntentionsSourceInputConfig{
+				IntentionsMonitorConfig{
+					SourceRegexp:      String(""),
+					DestinationRegexp: String("^db$"),
+					Datacenter:        String(""),
+					Namespace:         String(""),
+					Partition:         String(""),
+				},
+			}},
+			config: testSourceInputIntentionsSuccess,
+		},
+		{
+			name: "config-entries: happy path",
+			expected: &SourceInputConfigs{&ConfigEntriesSourceInputConfig{
+				ConfigEntriesMonitorConfig{
+					Kinds:      []string{"service-resolver"},
+					Regexp:     String(""),
+					Datacenter: String(""),
+					Namespace:  String("ns2"),
+					Partition:  String(""),
+				},
+			}},
+			config: testSourceInputConfigEntriesSuccess,
+		},
+		{
+			name: "prepared-query: happy path",
+			expected: &SourceInputConfigs{&PreparedQuerySourceInputConfig{
+				PreparedQueryMonitorConfig{
+					Name:       String("web-failover"),
+					Datacenter: String(""),
+					Near:       String(""),
+					Limit:      Int(3),
+				},
+			}},
+			config: testSourceInputPreparedQuerySuccess,
+		},
+		{
+			name: "vault-secret: happy path",
+			expected: &SourceInputConfigs{&VaultSecretSourceInputConfig{
+				VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app?version=2"},
+					Data:  map[string]string{},
+				},
+			}},
+			config: testSourceInputVaultSecretSuccess,
+		},
+		{
+			name: "file: happy path",
+			expected: &SourceInputConfigs{&FileSourceInputConfig{
+				FileMonitorConfig{
+					Paths:  []string{"inventory/*"},
+					Format: String("json"),
+				},
+			}},
+			config: testSourceInputFileSuccess,
+		},
+		{
+			name: "multiple source_inputs",
+			expected: &SourceInputConfigs{
+				&ServicesSourceInputConfig{
+					ServicesMonitorConfig{
+						Regexp:      String("^api$"),
+						Datacenter:  String(""),
+						Datacenters: []string{},
+						Namespace:   String(""),
+						Partition:   String(""),
+						Peer:        String(""),
+						NodeMeta:    map[string]string{},
+						Filter:      String(""),
+					},
+				},
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{
+						Path:       String("key-path"),
+						Datacenter: String(""),
+						Namespace:  String(""),
+						Decode:     String(""),
+						Recurse:    Bool(false),
+					},
+				},
+			},
+			config: testSourceInputMultipleSuccess,
+		},
+		{
+			name: "multiple source_inputs json",
+			expected: &SourceInputConfigs{
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{
+						Path:       String("key-path"),
+						Datacenter: String(""),
+						Namespace:  String(""),
+						Decode:     String(""),
+						Recurse:    Bool(false),
+					},
+				},
+				&ServicesSourceInputConfig{
+					ServicesMonitorConfig{
+						Regexp:      String("^api$"),
+						Datacenter:  String(""),
+						Datacenters: []string{},
+						Namespace:   String(""),
+						Partition:   String(""),
+						Peer:        String(""),
+						NodeMeta:    map[string]string{},
+						Filter:      String(""),
+					},
+				},
+			},
+			filename: "config.json",
+			config:   testSourceInputMultipleJSONSuccess,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
+			filename := testFileName
+			if tc.filename != "" {
+				filename = tc.filename
+			}
+
 			// replicate decoding process used by cts cli
-			config, err := decodeConfig([]byte(tc.config), testFileName)
+			config, err := decodeConfig([]byte(tc.config), filename)
 			require.NoError(t, err)
 			config.Finalize()
 			err = config.Validate()
@@ -142,7 +426,7 @@ func TestSourceInput_DecodeConfig_Success(t *testing.T) {
 			// confirm source_input decoding
 			tasks := *config.Tasks
 			require.Equal(t, 1, len(tasks))
-			require.Equal(t, tc.expected, tasks[0].SourceInput)
+			require.Equal(t, tc.expected, tasks[0].SourceInputs)
 		})
 	}
 }
@@ -164,6 +448,11 @@ func TestSourceInput_DecodeConfig_Error(t *testing.T) {
 			expected: nil,
 			config:   testSourceInputConsulKVUnsupportedFieldError,
 		},
+		{
+			name:     "unsupported type",
+			expected: nil,
+			config:   testSourceInputUnsupportedTypeError,
+		},
 	}
 
 	for _, tc := range cases {
@@ -174,3 +463,229 @@ func TestSourceInput_DecodeConfig_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceInputConfigs_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *SourceInputConfigs
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&SourceInputConfigs{},
+		},
+		{
+			"multiple",
+			&SourceInputConfigs{
+				&ServicesSourceInputConfig{
+					ServicesMonitorConfig{Regexp: String(".*")},
+				},
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key-path")},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			require.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestSourceInputConfigs_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *SourceInputConfigs
+		b    *SourceInputConfigs
+		r    *SourceInputConfigs
+	}{
+		{
+			"nil_a",
+			nil,
+			&SourceInputConfigs{},
+			&SourceInputConfigs{},
+		},
+		{
+			"nil_b",
+			&SourceInputConfigs{},
+			nil,
+			&SourceInputConfigs{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&SourceInputConfigs{},
+			&SourceInputConfigs{},
+			&SourceInputConfigs{},
+		},
+		{
+			"same_type_merges",
+			&SourceInputConfigs{
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key-path")},
+				},
+			},
+			&SourceInputConfigs{
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Recurse: Bool(true)},
+				},
+			},
+			&SourceInputConfigs{
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{
+						Path:    String("key-path"),
+						Recurse: Bool(true),
+					},
+				},
+			},
+		},
+		{
+			"different_type_appends",
+			&SourceInputConfigs{
+				&ServicesSourceInputConfig{
+					ServicesMonitorConfig{Regexp: String(".*")},
+				},
+			},
+			&SourceInputConfigs{
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key-path")},
+				},
+			},
+			&SourceInputConfigs{
+				&ServicesSourceInputConfig{
+					ServicesMonitorConfig{Regexp: String(".*")},
+				},
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key-path")},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			require.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestSourceInputConfigs_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *SourceInputConfigs
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"empty",
+			&SourceInputConfigs{},
+			true,
+		},
+		{
+			"valid",
+			&SourceInputConfigs{
+				&ServicesSourceInputConfig{
+					ServicesMonitorConfig{Regexp: String(".*")},
+				},
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key-path")},
+				},
+			},
+			true,
+		},
+		{
+			"nil_source_input",
+			&SourceInputConfigs{nil},
+			false,
+		},
+		{
+			"duplicate_type",
+			&SourceInputConfigs{
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key-path")},
+				},
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("other-path")},
+				},
+			},
+			false,
+		},
+		{
+			"invalid_source_input",
+			&SourceInputConfigs{
+				&ConsulKVSourceInputConfig{},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize([]string{})
+			err := tc.i.Validate()
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSourceInputConfigs_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *SourceInputConfigs
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"(*SourceInputConfigs)(nil)",
+		},
+		{
+			"multiple",
+			&SourceInputConfigs{
+				&ServicesSourceInputConfig{
+					ServicesMonitorConfig{Regexp: String(".*")},
+				},
+				&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key-path")},
+				},
+			},
+			"{&ServicesSourceInputConfig{&ServicesMonitorConfig{Regexp:.*, Datacenter:, Datacenters:[], Namespace:, Partition:, Peer:, NodeMeta:map[], Filter:}}, " +
+				"&ConsulKVSourceInputConfig{&ConsulKVMonitorConfig{Path:key-path, " +
+				"Recurse:false, Datacenter:, Namespace:, Decode:, }}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
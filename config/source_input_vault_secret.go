@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ SourceInputConfig = (*VaultSecretSourceInputConfig)(nil)
+
+// VaultSecretSourceInputConfig configures a source_input configuration block
+// of type 'vault-secret'. The secrets at the Vault paths will be used as input
+// for the sensitive vault_secrets variable.
+type VaultSecretSourceInputConfig struct {
+	VaultSecretMonitorConfig `mapstructure:",squash"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *VaultSecretSourceInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	m, ok := c.VaultSecretMonitorConfig.Copy().(*VaultSecretMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &VaultSecretSourceInputConfig{
+		VaultSecretMonitorConfig: *m,
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *VaultSecretSourceInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isSourceInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isSourceInputNil(o) {
+		return c.Copy()
+	}
+
+	o2, ok := o.(*VaultSecretSourceInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.VaultSecretMonitorConfig.Merge(&o2.VaultSecretMonitorConfig).(*VaultSecretMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	return &VaultSecretSourceInputConfig{
+		VaultSecretMonitorConfig: *merged,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *VaultSecretSourceInputConfig) Finalize(services []string) {
+	if c == nil { // config not required, return early
+		return
+	}
+	c.VaultSecretMonitorConfig.Finalize(services)
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *VaultSecretSourceInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.VaultSecretMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *VaultSecretSourceInputConfig) GoString() string {
+	if c == nil {
+		return "(*VaultSecretSourceInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&VaultSecretSourceInputConfig{"+
+		"%s"+
+		"}",
+		c.VaultSecretMonitorConfig.GoString(),
+	)
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultSecretSourceInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *VaultSecretSourceInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&VaultSecretSourceInputConfig{},
+		},
+		{
+			"fully_configured",
+			&VaultSecretSourceInputConfig{
+				VaultSecretMonitorConfig{
+					Paths: []string{"pki/issue/web"},
+					Data:  map[string]string{"common_name": "web.example.com"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestVaultSecretSourceInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *VaultSecretSourceInputConfig
+		b    *VaultSecretSourceInputConfig
+		r    *VaultSecretSourceInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&VaultSecretSourceInputConfig{},
+			&VaultSecretSourceInputConfig{},
+		},
+		{
+			"nil_b",
+			&VaultSecretSourceInputConfig{},
+			nil,
+			&VaultSecretSourceInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"paths_merge",
+			&VaultSecretSourceInputConfig{VaultSecretMonitorConfig{Paths: []string{"kv/data/a"}}},
+			&VaultSecretSourceInputConfig{VaultSecretMonitorConfig{Paths: []string{"kv/data/b"}}},
+			&VaultSecretSourceInputConfig{VaultSecretMonitorConfig{Paths: []string{"kv/data/a", "kv/data/b"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestVaultSecretSourceInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &VaultSecretSourceInputConfig{}
+	i.Finalize([]string{})
+	assert.Equal(t, &VaultSecretSourceInputConfig{
+		VaultSecretMonitorConfig{
+			Paths: []string{},
+			Data:  map[string]string{},
+		},
+	}, i)
+}
+
+func TestVaultSecretSourceInputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		i         *VaultSecretSourceInputConfig
+	}{
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"happy_path",
+			false,
+			&VaultSecretSourceInputConfig{VaultSecretMonitorConfig{
+				Paths: []string{"kv/data/app"},
+			}},
+		},
+		{
+			"missing_paths",
+			true,
+			&VaultSecretSourceInputConfig{VaultSecretMonitorConfig{
+				Paths: []string{},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVaultSecretSourceInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *VaultSecretSourceInputConfig
+		expected string
+	}{
+		{
+			"configured vault-secret source_input",
+			&VaultSecretSourceInputConfig{VaultSecretMonitorConfig{
+				Paths: []string{"kv/data/app"},
+				Data:  map[string]string{},
+			}},
+			"&VaultSecretSourceInputConfig{" +
+				"&VaultSecretMonitorConfig{" +
+				"Paths:[kv/data/app], " +
+				"Data:map[]" +
+				"}" +
+				"}",
+		},
+		{
+			"nil vault-secret source_input",
+			nil,
+			"(*VaultSecretSourceInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
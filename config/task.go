@@ -14,6 +14,20 @@ const (
 	taskSubsystemName = "task"
 )
 
+var (
+	// healthStatuses are the aggregated health statuses of service instances
+	// that can be configured for task.include_statuses
+	healthStatuses = []string{"passing", "warning", "critical", "maintenance"}
+
+	// serviceChanges are the changes to service instances that can be
+	// configured for task.trigger_on
+	serviceChanges = []string{"membership", "address", "port", "tags", "meta", "status"}
+)
+
+// latestServicesProtocol is the latest version of the services variable
+// protocol that can be configured for task.services_protocol
+const latestServicesProtocol = 1
+
 // TaskConfig is the configuration for a Sync task. This block may be
 // specified multiple times to configure multiple tasks.
 type TaskConfig struct {
@@ -39,9 +53,10 @@ type TaskConfig struct {
 	// is the module path (local or remote).
 	Source *string `mapstructure:"source"`
 
-	// SourceInput defines the Consul objects (e.g. services, kv) whose values are
-	// provided as the task source’s input variables
-	SourceInput SourceInputConfig `mapstructure:"source_input"`
+	// SourceInputs defines the Consul objects (e.g. services, kv) whose values
+	// are provided as the task source’s input variables. Each source_input
+	// block provides the input variable of its type.
+	SourceInputs *SourceInputConfigs `mapstructure:"source_input"`
 
 	// VarFiles is a list of paths to files containing variables for the
 	// task. For the Terraform driver, these are files ending in `.tfvars` and
@@ -76,6 +91,26 @@ type TaskConfig struct {
 	// will create a child directory with the task name in the global working
 	// directory.
 	WorkingDir *string `mapstructure:"working_dir"`
+
+	// IncludeStatuses is the list of health statuses of the service instances
+	// provided to the task. Instances with other statuses are excluded from
+	// the services variable and their changes do not trigger the task. By
+	// default, only passing instances of task.services are included, and
+	// instances of services matching a regexp are included with any status.
+	IncludeStatuses []string `mapstructure:"include_statuses"`
+
+	// TriggerOn is the list of changes to service instances that trigger the
+	// task: membership, address, port, tags, meta, and status. Changes to
+	// the other fields are rendered but do not trigger the task. All changes
+	// trigger the task by default.
+	TriggerOn []string `mapstructure:"trigger_on"`
+
+	// ServicesProtocol is the version of the services variable protocol
+	// provided to the task. Version 0 is the default and includes the
+	// aggregated health status of each instance. Version 1 additionally
+	// includes the health checks, weights, tagged addresses, and proxy and
+	// connect information of each instance.
+	ServicesProtocol *int `mapstructure:"services_protocol"`
 }
 
 // TaskConfigs is a collection of TaskConfig
@@ -97,9 +132,7 @@ func (c *TaskConfig) Copy() *TaskConfig {
 
 	o.Source = StringCopy(c.Source)
 
-	if !isSourceInputNil(c.SourceInput) {
-		o.SourceInput = c.SourceInput.Copy()
-	}
+	o.SourceInputs = c.SourceInputs.Copy()
 
 	o.VarFiles = append(o.VarFiles, c.VarFiles...)
 
@@ -119,6 +152,12 @@ func (c *TaskConfig) Copy() *TaskConfig {
 		o.WorkingDir = StringCopy(c.WorkingDir)
 	}
 
+	o.IncludeStatuses = append(o.IncludeStatuses, c.IncludeStatuses...)
+
+	o.TriggerOn = append(o.TriggerOn, c.TriggerOn...)
+
+	o.ServicesProtocol = IntCopy(c.ServicesProtocol)
+
 	return &o
 }
 
@@ -156,13 +195,7 @@ func (c *TaskConfig) Merge(o *TaskConfig) *TaskConfig {
 		r.Source = StringCopy(o.Source)
 	}
 
-	if !isSourceInputNil(o.SourceInput) {
-		if isSourceInputNil(r.SourceInput) {
-			r.SourceInput = o.SourceInput.Copy()
-		} else {
-			r.SourceInput = r.SourceInput.Merge(o.SourceInput)
-		}
-	}
+	r.SourceInputs = r.SourceInputs.Merge(o.SourceInputs)
 
 	r.VarFiles = append(r.VarFiles, o.VarFiles...)
 
@@ -194,6 +227,14 @@ func (c *TaskConfig) Merge(o *TaskConfig) *TaskConfig {
 		r.WorkingDir = StringCopy(o.WorkingDir)
 	}
 
+	r.IncludeStatuses = append(r.IncludeStatuses, o.IncludeStatuses...)
+
+	r.TriggerOn = append(r.TriggerOn, o.TriggerOn...)
+
+	if o.ServicesProtocol != nil {
+		r.ServicesProtocol = IntCopy(o.ServicesProtocol)
+	}
+
 	return r
 }
 
@@ -236,11 +277,14 @@ func (c *TaskConfig) Finalize(globalBp *BufferPeriodConfig, wd string) {
 	}
 
 	bp := globalBp
-	if _, ok := c.Condition.(*ScheduleConditionConfig); ok {
-		// disable buffer_period for schedule condition
+	_, scheduled := FindScheduleCondition(c.Condition)
+	_, webhook := c.Condition.(*WebhookConditionConfig)
+	if scheduled || webhook {
+		// disable buffer_period for schedule and webhook conditions, which
+		// trigger the task on demand
 		if c.BufferPeriod != nil {
 			logging.Global().Named(logSystemName).Named(taskSubsystemName).Warn(
-				"disabling buffer_period for schedule condition. overriding "+
+				"disabling buffer_period for schedule or webhook condition. overriding "+
 					"buffer_period configured for this task",
 				"task_name", StringVal(c.Name), "buffer_period", c.BufferPeriod.GoString())
 		}
@@ -264,14 +308,26 @@ func (c *TaskConfig) Finalize(globalBp *BufferPeriodConfig, wd string) {
 	}
 	c.Condition.Finalize(c.Services)
 
-	if isSourceInputNil(c.SourceInput) {
-		c.SourceInput = DefaultSourceInputConfig()
+	if c.SourceInputs == nil {
+		c.SourceInputs = DefaultSourceInputConfigs()
 	}
-	c.SourceInput.Finalize(c.Services)
+	c.SourceInputs.Finalize(c.Services)
 
 	if c.WorkingDir == nil {
 		c.WorkingDir = String(filepath.Join(wd, *c.Name))
 	}
+
+	if c.IncludeStatuses == nil {
+		c.IncludeStatuses = []string{}
+	}
+
+	if c.TriggerOn == nil {
+		c.TriggerOn = []string{}
+	}
+
+	if c.ServicesProtocol == nil {
+		c.ServicesProtocol = Int(0)
+	}
 }
 
 // Validate validates the values and required options. This method is recommended
@@ -334,9 +390,24 @@ func (c *TaskConfig) Validate() error {
 		}
 	}
 
-	if !isSourceInputNil(c.SourceInput) {
-		if err := c.SourceInput.Validate(); err != nil {
-			return err
+	if err := c.SourceInputs.Validate(); err != nil {
+		return err
+	}
+
+	if err := validateOptions(c.IncludeStatuses, healthStatuses); err != nil {
+		return fmt.Errorf("invalid task.include_statuses for task %q: %s", *c.Name, err)
+	}
+
+	if err := validateOptions(c.TriggerOn, serviceChanges); err != nil {
+		return fmt.Errorf("invalid task.trigger_on for task %q: %s", *c.Name, err)
+	}
+
+	if c.ServicesProtocol != nil {
+		protocol := *c.ServicesProtocol
+		if protocol < 0 || protocol > latestServicesProtocol {
+			return fmt.Errorf("invalid task.services_protocol for task %q: "+
+				"unsupported version %d, must be between 0 and %d",
+				*c.Name, protocol, latestServicesProtocol)
 		}
 	}
 
@@ -362,7 +433,10 @@ func (c *TaskConfig) GoString() string {
 		"BufferPeriod:%s, "+
 		"Enabled:%t, "+
 		"Condition:%v"+
-		"SourceInput:%v"+
+		"SourceInputs:%v, "+
+		"IncludeStatuses:%v, "+
+		"TriggerOn:%v, "+
+		"ServicesProtocol:%d"+
 		"}",
 		StringVal(c.Name),
 		StringVal(c.Description),
@@ -375,7 +449,10 @@ func (c *TaskConfig) GoString() string {
 		c.BufferPeriod.GoString(),
 		BoolVal(c.Enabled),
 		c.Condition.GoString(),
-		c.SourceInput.GoString(),
+		c.SourceInputs.GoString(),
+		c.IncludeStatuses,
+		c.TriggerOn,
+		IntVal(c.ServicesProtocol),
 	)
 }
 
@@ -497,6 +574,13 @@ func FilterTasks(tasks *TaskConfigs, names []string) (*TaskConfigs, error) {
 }
 
 func (c *TaskConfig) validateCondition() error {
+	switch cond := c.Condition.(type) {
+	case *AnyConditionConfig:
+		return c.validateNestedConditions(cond.Conditions)
+	case *AllConditionConfig:
+		return c.validateNestedConditions(cond.Conditions)
+	}
+
 	if len(c.Services) == 0 {
 		if isConditionNil(c.Condition) {
 			return fmt.Errorf("at least one service or a condition must be " +
@@ -517,11 +601,36 @@ func (c *TaskConfig) validateCondition() error {
 		case *ConsulKVConditionConfig:
 			return fmt.Errorf("consul-kv condition requires at least one service to " +
 				"be configured in task.services")
+		case *NodesConditionConfig:
+			return fmt.Errorf("nodes condition requires at least one service to " +
+				"be configured in task.services")
+		case *IntentionsConditionConfig:
+			return fmt.Errorf("intentions condition requires at least one service to " +
+				"be configured in task.services")
+		case *ConfigEntriesConditionConfig:
+			return fmt.Errorf("config-entries condition requires at least one service to " +
+				"be configured in task.services")
+		case *PreparedQueryConditionConfig:
+			// the prepared query result provides the services
+		case *VaultSecretConditionConfig:
+			return fmt.Errorf("vault-secret condition requires at least one service to " +
+				"be configured in task.services")
+		case *FileConditionConfig:
+			return fmt.Errorf("file condition requires at least one service to " +
+				"be configured in task.services")
+		case *ConsulEventConditionConfig:
+			return fmt.Errorf("consul-event condition requires at least one service to " +
+				"be configured in task.services")
 		case *ScheduleConditionConfig:
-			if isSourceInputNil(c.SourceInput) || isSourceInputEmpty(c.SourceInput) {
+			if !c.hasSourceInputs() {
 				return fmt.Errorf("schedule condition requires at least one service to " +
 					"be configured in task.services or a source_input must be provided")
 			}
+		case *WebhookConditionConfig:
+			if !c.hasSourceInputs() {
+				return fmt.Errorf("webhook condition requires at least one service to " +
+					"be configured in task.services or a source_input must be provided")
+			}
 		}
 	} else {
 		switch cond := c.Condition.(type) {
@@ -536,47 +645,279 @@ func (c *TaskConfig) validateCondition() error {
 						"task_name", StringVal(c.Name), "error", err)
 				return err
 			}
+		case *PreparedQueryConditionConfig:
+			return fmt.Errorf("task.services is not allowed if a prepared-query " +
+				"condition is configured")
+		}
+	}
+
+	return nil
+}
+
+// validateNestedConditions validates each condition nested within an any or
+// all condition against the task as if it were the task's condition.
+func (c *TaskConfig) validateNestedConditions(conditions []ConditionConfig) error {
+	for _, nested := range conditions {
+		if _, ok := nested.(*ScheduleConditionConfig); ok && len(conditions) > 1 {
+			// the other nested conditions provide the input for the
+			// scheduled task in place of a source_input
+			continue
+		}
+
+		t := *c
+		t.Condition = nested
+		if err := t.validateCondition(); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// validateOptions validates that the values of a list option are each one of
+// the supported values and are not duplicated
+func validateOptions(values, supported []string) error {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if seen[v] {
+			return fmt.Errorf("duplicate value %q", v)
+		}
+		seen[v] = true
+
+		valid := false
+		for _, s := range supported {
+			if v == s {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("unsupported value %q, must be one of %q",
+				v, supported)
+		}
+	}
+	return nil
+}
+
 func (c *TaskConfig) validateSourceInput() error {
-	// For now only schedule condition allows for source_input, so a condition of type ScheduleConditionConfig
-	// is the only supported type
+	var sourceInputs SourceInputConfigs
+	if c.SourceInputs != nil {
+		sourceInputs = *c.SourceInputs
+	}
+
 	switch c.Condition.(type) {
-	case *ScheduleConditionConfig:
-		if len(c.Services) == 0 {
-			switch si := c.SourceInput.(type) {
-			case *ServicesSourceInputConfig:
-				// source_input "services" follows the same rules as condition "services"
-				if si.Regexp == nil || *si.Regexp == "" {
-					return fmt.Errorf("at least one service is required in task.services " +
-						"or task.source_input.regexp must be configured")
+	case *ScheduleConditionConfig, *WebhookConditionConfig:
+		// a services source_input configured with a regexp or a
+		// prepared-query source_input provides the services in place of
+		// task.services
+		servicesRegexp := false
+		for _, si := range sourceInputs {
+			if providesServices(si) {
+				if servicesRegexp {
+					return fmt.Errorf("only one source_input can provide the " +
+						"services in place of task.services")
 				}
-			case *ConsulKVSourceInputConfig:
-				return fmt.Errorf("consul-kv source_input requires at least one service to " +
-					"be configured in task.services")
+				servicesRegexp = true
 			}
-		} else {
-			switch si := c.SourceInput.(type) {
-			case *ServicesSourceInputConfig:
-				if si.Regexp != nil && *si.Regexp != "" {
-					err := fmt.Errorf("task.services is not allowed if task.source_input.regexp " +
-						"is configured for a services source_input")
-					logging.Global().Named(logSystemName).Named(taskSubsystemName).
-						Error("list of services and service condition regex both "+
-							"provided. If both are needed, consider including the "+
-							"list in the regex or creating separate tasks",
-							"task_name", StringVal(c.Name), "error", err)
-					return err
-				}
+		}
+
+		for _, si := range sourceInputs {
+			if err := c.validateScheduleSourceInput(si, servicesRegexp); err != nil {
+				return err
 			}
 		}
 	default:
-		if !isSourceInputNil(c.SourceInput) && !isSourceInputEmpty(c.SourceInput) {
-			return fmt.Errorf("source_input is only supported when a schedule condition is configured")
+		for _, si := range sourceInputs {
+			if err := c.validateConditionSourceInput(si); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// watchesVaultSecrets returns true if the condition, a nested condition, or a
+// source_input of the task is of type vault-secret
+func (c *TaskConfig) watchesVaultSecrets() bool {
+	conditions := []ConditionConfig{c.Condition}
+	switch cond := c.Condition.(type) {
+	case *AnyConditionConfig:
+		conditions = cond.Conditions
+	case *AllConditionConfig:
+		conditions = cond.Conditions
+	}
+	for _, cond := range conditions {
+		if v, ok := cond.(*VaultSecretConditionConfig); ok && v != nil {
+			return true
+		}
+	}
+
+	if c.SourceInputs != nil {
+		for _, si := range *c.SourceInputs {
+			if v, ok := si.(*VaultSecretSourceInputConfig); ok && v != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// providesServices returns true if the source_input provides the services
+// variable in place of task.services
+func providesServices(sourceInput SourceInputConfig) bool {
+	switch si := sourceInput.(type) {
+	case *ServicesSourceInputConfig:
+		return si != nil && StringVal(si.Regexp) != ""
+	case *PreparedQuerySourceInputConfig:
+		return si != nil
+	}
+	return false
+}
+
+// hasSourceInputs returns true if at least one source_input that provides an
+// input variable is configured for the task
+func (c *TaskConfig) hasSourceInputs() bool {
+	if c.SourceInputs == nil {
+		return false
+	}
+
+	for _, si := range *c.SourceInputs {
+		if !isSourceInputNil(si) && !isSourceInputEmpty(si) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateConditionSourceInput validates a source_input of a task with a
+// condition other than schedule. The source_input only provides data to the
+// task and does not trigger it, so it cannot monitor the same type of Consul
+// objects as the condition.
+func (c *TaskConfig) validateConditionSourceInput(sourceInput SourceInputConfig) error {
+	if isSourceInputNil(sourceInput) || isSourceInputEmpty(sourceInput) {
+		return nil
+	}
+
+	if si, ok := sourceInput.(*ServicesSourceInputConfig); ok {
+		if len(c.Services) > 0 && si.Regexp != nil && *si.Regexp != "" {
+			return fmt.Errorf("task.services is not allowed if task.source_input.regexp " +
+				"is configured for a services source_input")
+		}
+	}
+
+	if _, ok := sourceInput.(*PreparedQuerySourceInputConfig); ok && len(c.Services) > 0 {
+		return fmt.Errorf("task.services is not allowed if a prepared-query " +
+			"source_input is configured")
+	}
+
+	if conditionMonitorsSourceInput(c.Condition, sourceInput) {
+		return fmt.Errorf("source_input cannot be configured with a condition "+
+			"of the same type: %s", strings.TrimPrefix(fmt.Sprintf("%T", sourceInput), "*config."))
+	}
+
+	return nil
+}
+
+// conditionMonitorsSourceInput returns true if the condition, or a condition
+// nested within it, monitors the same type of Consul objects as the source_input
+func conditionMonitorsSourceInput(cond ConditionConfig, sourceInput SourceInputConfig) bool {
+	switch c := cond.(type) {
+	case *AnyConditionConfig:
+		for _, nested := range c.Conditions {
+			if conditionMonitorsSourceInput(nested, sourceInput) {
+				return true
+			}
+		}
+		return false
+	case *AllConditionConfig:
+		for _, nested := range c.Conditions {
+			if conditionMonitorsSourceInput(nested, sourceInput) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var ok bool
+	switch cond.(type) {
+	case *ServicesConditionConfig, *PreparedQueryConditionConfig:
+		// both provide the services variable
+		switch sourceInput.(type) {
+		case *ServicesSourceInputConfig, *PreparedQuerySourceInputConfig:
+			ok = true
+		}
+	case *ConsulKVConditionConfig:
+		_, ok = sourceInput.(*ConsulKVSourceInputConfig)
+	case *NodesConditionConfig:
+		_, ok = sourceInput.(*NodesSourceInputConfig)
+	case *IntentionsConditionConfig:
+		_, ok = sourceInput.(*IntentionsSourceInputConfig)
+	case *ConfigEntriesConditionConfig:
+		_, ok = sourceInput.(*ConfigEntriesSourceInputConfig)
+	case *VaultSecretConditionConfig:
+		_, ok = sourceInput.(*VaultSecretSourceInputConfig)
+	case *FileConditionConfig:
+		_, ok = sourceInput.(*FileSourceInputConfig)
+	}
+	return ok
+}
+
+// validateScheduleSourceInput validates a source_input of a task with a
+// schedule or webhook condition against the services configured for the task
+func (c *TaskConfig) validateScheduleSourceInput(sourceInput SourceInputConfig,
+	servicesRegexp bool) error {
+
+	if len(c.Services) == 0 {
+		switch si := sourceInput.(type) {
+		case *ServicesSourceInputConfig:
+			// source_input "services" follows the same rules as condition "services"
+			if si.Regexp == nil || *si.Regexp == "" {
+				return fmt.Errorf("at least one service is required in task.services " +
+					"or task.source_input.regexp must be configured")
+			}
+		}
+
+		if servicesRegexp {
+			return nil
+		}
+
+		switch sourceInput.(type) {
+		case *ConsulKVSourceInputConfig:
+			return fmt.Errorf("consul-kv source_input requires at least one service to " +
+				"be configured in task.services or a services source_input")
+		case *NodesSourceInputConfig:
+			return fmt.Errorf("nodes source_input requires at least one service to " +
+				"be configured in task.services or a services source_input")
+		case *IntentionsSourceInputConfig:
+			return fmt.Errorf("intentions source_input requires at least one service to " +
+				"be configured in task.services or a services source_input")
+		case *ConfigEntriesSourceInputConfig:
+			return fmt.Errorf("config-entries source_input requires at least one service to " +
+				"be configured in task.services or a services source_input")
+		case *VaultSecretSourceInputConfig:
+			return fmt.Errorf("vault-secret source_input requires at least one service to " +
+				"be configured in task.services or a services source_input")
+		case *FileSourceInputConfig:
+			return fmt.Errorf("file source_input requires at least one service to " +
+				"be configured in task.services or a services source_input")
+		}
+	} else {
+		switch si := sourceInput.(type) {
+		case *ServicesSourceInputConfig:
+			if si.Regexp != nil && *si.Regexp != "" {
+				err := fmt.Errorf("task.services is not allowed if task.source_input.regexp " +
+					"is configured for a services source_input")
+				logging.Global().Named(logSystemName).Named(taskSubsystemName).
+					Error("list of services and service condition regex both "+
+						"provided. If both are needed, consider including the "+
+						"list in the regex or creating separate tasks",
+						"task_name", StringVal(c.Name), "error", err)
+				return err
+			}
+		case *PreparedQuerySourceInputConfig:
+			return fmt.Errorf("task.services is not allowed if a prepared-query " +
+				"source_input is configured")
 		}
 	}
 
@@ -38,12 +38,17 @@ func TestTaskConfig_Copy(t *testing.T) {
 						SourceIncludesVar: Bool(true),
 						Datacenter:        String("dc2"),
 						Namespace:         String("ns2"),
+						Partition:         String("ap2"),
+						Peer:              String("peer2"),
 						NodeMeta: map[string]string{
 							"key": "value",
 						},
 					},
 				},
-				WorkingDir: String("cts-dir"),
+				WorkingDir:       String("cts-dir"),
+				IncludeStatuses:  []string{"passing", "warning"},
+				TriggerOn:        []string{"membership", "address"},
+				ServicesProtocol: Int(1),
 			},
 		},
 	}
@@ -315,29 +320,74 @@ func TestTaskConfig_Merge(t *testing.T) {
 			&TaskConfig{WorkingDir: String("cts-dir")},
 			&TaskConfig{WorkingDir: String("cts-dir")},
 		},
+		{
+			"include_statuses_merges",
+			&TaskConfig{IncludeStatuses: []string{"passing"}},
+			&TaskConfig{IncludeStatuses: []string{"warning"}},
+			&TaskConfig{IncludeStatuses: []string{"passing", "warning"}},
+		},
+		{
+			"include_statuses_empty_one",
+			&TaskConfig{IncludeStatuses: []string{"passing"}},
+			&TaskConfig{},
+			&TaskConfig{IncludeStatuses: []string{"passing"}},
+		},
+		{
+			"trigger_on_merges",
+			&TaskConfig{TriggerOn: []string{"membership"}},
+			&TaskConfig{TriggerOn: []string{"tags"}},
+			&TaskConfig{TriggerOn: []string{"membership", "tags"}},
+		},
+		{
+			"trigger_on_empty_one",
+			&TaskConfig{},
+			&TaskConfig{TriggerOn: []string{"membership"}},
+			&TaskConfig{TriggerOn: []string{"membership"}},
+		},
+		{
+			"services_protocol_overrides",
+			&TaskConfig{ServicesProtocol: Int(0)},
+			&TaskConfig{ServicesProtocol: Int(1)},
+			&TaskConfig{ServicesProtocol: Int(1)},
+		},
+		{
+			"services_protocol_empty_one",
+			&TaskConfig{ServicesProtocol: Int(1)},
+			&TaskConfig{},
+			&TaskConfig{ServicesProtocol: Int(1)},
+		},
 		{
 			"source_input_overrides",
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("")}}},
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("")}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("")}}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("")}}}},
 		},
 		{
 			"source_input_empty_one",
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
 			&TaskConfig{},
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
 		},
 		{
 			"source_input_empty_two",
 			&TaskConfig{},
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
 		},
 		{
 			"source_input_same",
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
-			&TaskConfig{SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+		},
+		{
+			"source_input_appends",
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("path")}}}},
+			&TaskConfig{SourceInputs: &SourceInputConfigs{
+				&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}},
+				&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("path")}},
+			}},
 		},
 	}
 
@@ -359,19 +409,22 @@ func TestTaskConfig_Finalize(t *testing.T) {
 			"empty",
 			&TaskConfig{},
 			&TaskConfig{
-				Description:  String(""),
-				Name:         String(""),
-				Providers:    []string{},
-				Services:     []string{},
-				Source:       String(""),
-				VarFiles:     []string{},
-				Version:      String(""),
-				TFVersion:    String(""),
-				BufferPeriod: DefaultBufferPeriodConfig(),
-				Enabled:      Bool(true),
-				Condition:    DefaultConditionConfig(),
-				WorkingDir:   String("sync-tasks"),
-				SourceInput:  DefaultSourceInputConfig(),
+				Description:      String(""),
+				Name:             String(""),
+				Providers:        []string{},
+				Services:         []string{},
+				Source:           String(""),
+				VarFiles:         []string{},
+				Version:          String(""),
+				TFVersion:        String(""),
+				BufferPeriod:     DefaultBufferPeriodConfig(),
+				Enabled:          Bool(true),
+				Condition:        DefaultConditionConfig(),
+				WorkingDir:       String("sync-tasks"),
+				IncludeStatuses:  []string{},
+				TriggerOn:        []string{},
+				ServicesProtocol: Int(0),
+				SourceInputs:     DefaultSourceInputConfigs(),
 			},
 		},
 		{
@@ -380,19 +433,22 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				Name: String("task"),
 			},
 			&TaskConfig{
-				Description:  String(""),
-				Name:         String("task"),
-				Providers:    []string{},
-				Services:     []string{},
-				Source:       String(""),
-				VarFiles:     []string{},
-				Version:      String(""),
-				TFVersion:    String(""),
-				BufferPeriod: DefaultBufferPeriodConfig(),
-				Enabled:      Bool(true),
-				Condition:    DefaultConditionConfig(),
-				WorkingDir:   String("sync-tasks/task"),
-				SourceInput:  DefaultSourceInputConfig(),
+				Description:      String(""),
+				Name:             String("task"),
+				Providers:        []string{},
+				Services:         []string{},
+				Source:           String(""),
+				VarFiles:         []string{},
+				Version:          String(""),
+				TFVersion:        String(""),
+				BufferPeriod:     DefaultBufferPeriodConfig(),
+				Enabled:          Bool(true),
+				Condition:        DefaultConditionConfig(),
+				WorkingDir:       String("sync-tasks/task"),
+				IncludeStatuses:  []string{},
+				TriggerOn:        []string{},
+				ServicesProtocol: Int(0),
+				SourceInputs:     DefaultSourceInputConfigs(),
 			},
 		},
 		{
@@ -415,18 +471,59 @@ func TestTaskConfig_Finalize(t *testing.T) {
 					Min:     TimeDuration(0 * time.Second),
 					Max:     TimeDuration(0 * time.Second),
 				},
-				Enabled:     Bool(true),
-				Condition:   &ScheduleConditionConfig{String("")},
-				WorkingDir:  String("sync-tasks/task"),
-				SourceInput: DefaultSourceInputConfig(),
+				Enabled:          Bool(true),
+				Condition:        &ScheduleConditionConfig{Cron: String(""), Timezone: String(""), Jitter: TimeDuration(0), SkipIfRunning: Bool(true), CatchUp: Bool(false)},
+				WorkingDir:       String("sync-tasks/task"),
+				IncludeStatuses:  []string{},
+				TriggerOn:        []string{},
+				ServicesProtocol: Int(0),
+				SourceInputs:     DefaultSourceInputConfigs(),
 			},
 		},
 		{
 			"with_services_source_input",
 			&TaskConfig{
+				Name:         String("task"),
+				Condition:    &ScheduleConditionConfig{},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("^api$")}}},
+			},
+			&TaskConfig{
+				Description: String(""),
 				Name:        String("task"),
-				Condition:   &ScheduleConditionConfig{},
-				SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{String("^api$")}},
+				Providers:   []string{},
+				Services:    []string{},
+				Source:      String(""),
+				VarFiles:    []string{},
+				Version:     String(""),
+				TFVersion:   String(""),
+				BufferPeriod: &BufferPeriodConfig{
+					Enabled: Bool(false),
+					Min:     TimeDuration(0 * time.Second),
+					Max:     TimeDuration(0 * time.Second),
+				},
+				Enabled:          Bool(true),
+				Condition:        &ScheduleConditionConfig{Cron: String(""), Timezone: String(""), Jitter: TimeDuration(0), SkipIfRunning: Bool(true), CatchUp: Bool(false)},
+				WorkingDir:       String("sync-tasks/task"),
+				IncludeStatuses:  []string{},
+				TriggerOn:        []string{},
+				ServicesProtocol: Int(0),
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{
+					Regexp:      String("^api$"),
+					Datacenter:  String(""),
+					Datacenters: []string{},
+					Namespace:   String(""),
+					Partition:   String(""),
+					Peer:        String(""),
+					NodeMeta:    map[string]string{},
+					Filter:      String(""),
+				}}},
+			},
+		},
+		{
+			"with_webhook_condition",
+			&TaskConfig{
+				Name:      String("task"),
+				Condition: &WebhookConditionConfig{},
 			},
 			&TaskConfig{
 				Description: String(""),
@@ -442,10 +539,49 @@ func TestTaskConfig_Finalize(t *testing.T) {
 					Min:     TimeDuration(0 * time.Second),
 					Max:     TimeDuration(0 * time.Second),
 				},
-				Enabled:     Bool(true),
-				Condition:   &ScheduleConditionConfig{String("")},
-				WorkingDir:  String("sync-tasks/task"),
-				SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{String("^api$")}},
+				Enabled: Bool(true),
+				Condition: &WebhookConditionConfig{
+					Secret: String(""),
+					Schema: map[string]string{},
+				},
+				WorkingDir:       String("sync-tasks/task"),
+				IncludeStatuses:  []string{},
+				TriggerOn:        []string{},
+				ServicesProtocol: Int(0),
+				SourceInputs:     DefaultSourceInputConfigs(),
+			},
+		},
+		{
+			"with_all_schedule_condition",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &AllConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{&ScheduleConditionConfig{}},
+				}},
+			},
+			&TaskConfig{
+				Description: String(""),
+				Name:        String("task"),
+				Providers:   []string{},
+				Services:    []string{},
+				Source:      String(""),
+				VarFiles:    []string{},
+				Version:     String(""),
+				TFVersion:   String(""),
+				BufferPeriod: &BufferPeriodConfig{
+					Enabled: Bool(false),
+					Min:     TimeDuration(0 * time.Second),
+					Max:     TimeDuration(0 * time.Second),
+				},
+				Enabled: Bool(true),
+				Condition: &AllConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{&ScheduleConditionConfig{Cron: String(""), Timezone: String(""), Jitter: TimeDuration(0), SkipIfRunning: Bool(true), CatchUp: Bool(false)}},
+				}},
+				WorkingDir:       String("sync-tasks/task"),
+				IncludeStatuses:  []string{},
+				TriggerOn:        []string{},
+				ServicesProtocol: Int(0),
+				SourceInputs:     DefaultSourceInputConfigs(),
 			},
 		},
 	}
@@ -488,11 +624,11 @@ func TestTaskConfig_Validate(t *testing.T) {
 		{
 			"valid: missing condition",
 			&TaskConfig{
-				Name:        String("task"),
-				Services:    []string{"serviceA", "serviceB"},
-				Source:      String("source"),
-				Providers:   []string{"providerA", "providerB"},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Services:     []string{"serviceA", "serviceB"},
+				Source:       String("source"),
+				Providers:    []string{"providerA", "providerB"},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			true,
 		},
@@ -507,7 +643,7 @@ func TestTaskConfig_Validate(t *testing.T) {
 						Regexp: String(".*"),
 					},
 				},
-				SourceInput: DefaultSourceInputConfig(),
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			true,
 		},
@@ -522,31 +658,104 @@ func TestTaskConfig_Validate(t *testing.T) {
 						Regexp: String(".*"),
 					},
 				},
-				SourceInput: DefaultSourceInputConfig(),
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			true,
 		},
 		{
 			"valid: service with schedule condition",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Services:    []string{"serviceA", "serviceB"},
-				Condition:   &ScheduleConditionConfig{String("* * * * * * *")},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Source:       String("source"),
+				Services:     []string{"serviceA", "serviceB"},
+				Condition:    &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			true,
 		},
 		{
 			"valid: service with schedule condition and non empty source_input",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &ScheduleConditionConfig{String("* * * * * * *")},
-				SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}},
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
+			},
+			true,
+		},
+		{
+			"valid: include_statuses and trigger_on",
+			&TaskConfig{
+				Name:            String("task"),
+				Services:        []string{"serviceA"},
+				Source:          String("source"),
+				Condition:       DefaultConditionConfig(),
+				SourceInputs:    DefaultSourceInputConfigs(),
+				IncludeStatuses: []string{"passing", "warning"},
+				TriggerOn:       []string{"membership", "address", "port", "tags", "meta", "status"},
+			},
+			true,
+		},
+		{
+			"invalid: include_statuses",
+			&TaskConfig{
+				Name:            String("task"),
+				Services:        []string{"serviceA"},
+				Source:          String("source"),
+				Condition:       DefaultConditionConfig(),
+				SourceInputs:    DefaultSourceInputConfigs(),
+				IncludeStatuses: []string{"healthy"},
+			},
+			false,
+		},
+		{
+			"invalid: trigger_on",
+			&TaskConfig{
+				Name:         String("task"),
+				Services:     []string{"serviceA"},
+				Source:       String("source"),
+				Condition:    DefaultConditionConfig(),
+				SourceInputs: DefaultSourceInputConfigs(),
+				TriggerOn:    []string{"weights"},
+			},
+			false,
+		},
+		{
+			"invalid: duplicate trigger_on",
+			&TaskConfig{
+				Name:         String("task"),
+				Services:     []string{"serviceA"},
+				Source:       String("source"),
+				Condition:    DefaultConditionConfig(),
+				SourceInputs: DefaultSourceInputConfigs(),
+				TriggerOn:    []string{"tags", "tags"},
+			},
+			false,
+		},
+		{
+			"valid: services_protocol",
+			&TaskConfig{
+				Name:             String("task"),
+				Services:         []string{"serviceA"},
+				Source:           String("source"),
+				Condition:        DefaultConditionConfig(),
+				SourceInputs:     DefaultSourceInputConfigs(),
+				ServicesProtocol: Int(1),
 			},
 			true,
 		},
+		{
+			"invalid: services_protocol",
+			&TaskConfig{
+				Name:             String("task"),
+				Services:         []string{"serviceA"},
+				Source:           String("source"),
+				Condition:        DefaultConditionConfig(),
+				SourceInputs:     DefaultSourceInputConfigs(),
+				ServicesProtocol: Int(2),
+			},
+			false,
+		},
 		{
 			"invalid: missing name",
 			&TaskConfig{Services: []string{"service"}, Source: String("source")},
@@ -576,37 +785,96 @@ func TestTaskConfig_Validate(t *testing.T) {
 						Regexp: String(""),
 					},
 				},
-				SourceInput: DefaultSourceInputConfig(),
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
 		{
 			"invalid: missing service with catalog-service condition and no regexp",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &CatalogServicesConditionConfig{},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &CatalogServicesConditionConfig{},
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			false,
+		},
+		{
+			"valid: service with webhook condition",
+			&TaskConfig{
+				Name:      String("task"),
+				Services:  []string{"service"},
+				Source:    String("source"),
+				Condition: &WebhookConditionConfig{},
+			},
+			true,
+		},
+		{
+			"valid: webhook condition with consul-kv source_input",
+			&TaskConfig{
+				Name:         String("task"),
+				Services:     []string{"service"},
+				Source:       String("source"),
+				Condition:    &WebhookConditionConfig{},
+				SourceInputs: &SourceInputConfigs{&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("path")}}},
+			},
+			true,
+		},
+		{
+			"invalid: missing service with webhook condition",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &WebhookConditionConfig{},
+			},
+			false,
+		},
+		{
+			"valid: service with consul-event condition",
+			&TaskConfig{
+				Name:      String("task"),
+				Services:  []string{"service"},
+				Source:    String("source"),
+				Condition: &ConsulEventConditionConfig{Name: String("deploy")},
+			},
+			true,
+		},
+		{
+			"invalid: missing service with consul-event condition",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ConsulEventConditionConfig{Name: String("deploy")},
+			},
+			false,
+		},
+		{
+			"invalid: consul-event condition without name",
+			&TaskConfig{
+				Name:      String("task"),
+				Services:  []string{"service"},
+				Source:    String("source"),
+				Condition: &ConsulEventConditionConfig{},
 			},
 			false,
 		},
 		{
 			"invalid: missing service with schedule condition",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &ScheduleConditionConfig{String("* * * * * * *")},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
 		{
 			"invalid: invalid catalog-service condition (bad regexp)",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Regexp: String("*")}},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Regexp: String("*")}},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
@@ -618,54 +886,54 @@ func TestTaskConfig_Validate(t *testing.T) {
 		{
 			"invalid: unsupported TF version per task",
 			&TaskConfig{
-				Name:        String("task"),
-				Services:    []string{"service"},
-				Source:      String("source"),
-				TFVersion:   String("0.15.0"),
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Services:     []string{"service"},
+				Source:       String("source"),
+				TFVersion:    String("0.15.0"),
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
 		{
 			"invalid: duplicate provider",
 			&TaskConfig{
-				Name:        String("task"),
-				Services:    []string{"serviceA", "serviceB"},
-				Source:      String("source"),
-				Providers:   []string{"providerA", "providerA"},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Services:     []string{"serviceA", "serviceB"},
+				Source:       String("source"),
+				Providers:    []string{"providerA", "providerA"},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
 		{
 			"invalid: duplicate provider with alias",
 			&TaskConfig{
-				Name:        String("task"),
-				Services:    []string{"serviceA", "serviceB"},
-				Source:      String("source"),
-				Providers:   []string{"providerA", "providerA.alias"},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Services:     []string{"serviceA", "serviceB"},
+				Source:       String("source"),
+				Providers:    []string{"providerA", "providerA.alias"},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
 		{
 			"invalid: invalid service condition (bad regexp)",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("*")}},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("*")}},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
 		{
 			"invalid: services and service condition regexp both provided",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Services:    []string{"serviceA", "serviceB"},
-				Condition:   &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("^service.*")}},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Source:       String("source"),
+				Services:     []string{"serviceA", "serviceB"},
+				Condition:    &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("^service.*")}},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
@@ -679,17 +947,51 @@ func TestTaskConfig_Validate(t *testing.T) {
 						Path: String("path"),
 					},
 				},
-				SourceInput: DefaultSourceInputConfig(),
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			false,
+		},
+		{
+			"missing services with nodes condition",
+			&TaskConfig{
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &NodesConditionConfig{},
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			false,
+		},
+		{
+			"missing services with intentions condition",
+			&TaskConfig{
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &IntentionsConditionConfig{},
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			false,
+		},
+		{
+			"missing services with config-entries condition",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &ConfigEntriesConditionConfig{
+					ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+						Kinds: []string{"service-defaults"},
+					},
+				},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
 		{
 			"invalid: schedule condition provided with no services and empty source_input",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &ScheduleConditionConfig{String("* * * * * * *")},
-				SourceInput: DefaultSourceInputConfig(),
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: DefaultSourceInputConfigs(),
 			},
 			false,
 		},
@@ -698,43 +1000,102 @@ func TestTaskConfig_Validate(t *testing.T) {
 			&TaskConfig{
 				Name:      String("task"),
 				Source:    String("source"),
-				Condition: &ScheduleConditionConfig{String("* * * * * * *")},
-				SourceInput: &ServicesSourceInputConfig{
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{
 					ServicesMonitorConfig{
 						Regexp: nil,
 					},
-				},
+				}},
 			},
 			false,
 		},
 		{
 			"invalid: services provided along with services source_input",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Services:    []string{"serviceA", "serviceB"},
-				Condition:   &ScheduleConditionConfig{String("* * * * * * *")},
-				SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}},
+				Name:         String("task"),
+				Source:       String("source"),
+				Services:     []string{"serviceA", "serviceB"},
+				Condition:    &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
 			},
 			false,
 		},
 		{
-			"invalid: configured source_input provided with non schedule condition",
+			"invalid: services source_input provided with services condition",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String(".*")}},
-				SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}},
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String(".*")}},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
+			},
+			false,
+		},
+		{
+			"valid: consul-kv source_input provided with services condition",
+			&TaskConfig{
+				Name:         String("task"),
+				Source:       String("source"),
+				Services:     []string{"api"},
+				Condition:    &ServicesConditionConfig{},
+				SourceInputs: &SourceInputConfigs{&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("path")}}},
+			},
+			true,
+		},
+		{
+			"valid: services source_input provided with catalog-services condition",
+			&TaskConfig{
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Regexp: String(".*")}},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("^api$")}}},
+			},
+			true,
+		},
+		{
+			"invalid: consul-kv source_input provided with consul-kv condition",
+			&TaskConfig{
+				Name:         String("task"),
+				Source:       String("source"),
+				Services:     []string{"api"},
+				Condition:    &ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("path")}},
+				SourceInputs: &SourceInputConfigs{&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("other")}}},
+			},
+			false,
+		},
+		{
+			"invalid: nodes source_input provided with nested nodes condition",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &AnyConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{
+						&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("path")}},
+						&NodesConditionConfig{},
+					},
+				}},
+				SourceInputs: &SourceInputConfigs{&NodesSourceInputConfig{}},
+			},
+			false,
+		},
+		{
+			"invalid: services source_input regex provided with services and non schedule condition",
+			&TaskConfig{
+				Name:         String("task"),
+				Source:       String("source"),
+				Services:     []string{"api"},
+				Condition:    &NodesConditionConfig{},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}},
 			},
 			false,
 		},
 		{
 			"invalid: source_input bad regex",
 			&TaskConfig{
-				Name:        String("task"),
-				Source:      String("source"),
-				Condition:   &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String(".*")}},
-				SourceInput: &ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("*")}},
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &ServicesConditionConfig{ServicesMonitorConfig{Regexp: String(".*")}},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String("*")}}},
 			},
 			false,
 		},
@@ -743,13 +1104,357 @@ func TestTaskConfig_Validate(t *testing.T) {
 			&TaskConfig{
 				Name:      String("task"),
 				Source:    String("source"),
-				Condition: &ScheduleConditionConfig{String("* * * * * * *")},
-				SourceInput: &ConsulKVSourceInputConfig{
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&ConsulKVSourceInputConfig{
 					ConsulKVMonitorConfig{
 						Path: String("path"),
 					},
+				}},
+			},
+			false,
+		},
+		{
+			"valid: consul-kv source_input with services source_input regex",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{
+					&ServicesSourceInputConfig{ServicesMonitorConfig{Regexp: String(".*")}},
+					&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("path")}},
+				},
+			},
+			true,
+		},
+		{
+			"invalid: duplicate source_input types",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Services:  []string{"api"},
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{
+					&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("path")}},
+					&ConsulKVSourceInputConfig{ConsulKVMonitorConfig{Path: String("other")}},
+				},
+			},
+			false,
+		},
+		{
+			"missing services with nodes source_input",
+			&TaskConfig{
+				Name:         String("task"),
+				Source:       String("source"),
+				Condition:    &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&NodesSourceInputConfig{}},
+			},
+			false,
+		},
+		{
+			"valid: any condition",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &AnyConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{
+						&ServicesConditionConfig{},
+						&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+					},
+				}},
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			true,
+		},
+		{
+			"valid: all condition with schedule and services regexp",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &AllConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{
+						&ScheduleConditionConfig{Cron: String("* * * * * * *")},
+						&ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("^api$")}},
+					},
+				}},
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			true,
+		},
+		{
+			"invalid: missing services with nested consul-kv condition",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &AnyConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{
+						&ServicesConditionConfig{ServicesMonitorConfig{Regexp: String("^api$")}},
+						&ConsulKVConditionConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("key")}},
+					},
+				}},
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			false,
+		},
+		{
+			"invalid: all condition with only schedule and no services",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &AllConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{
+						&ScheduleConditionConfig{Cron: String("* * * * * * *")},
+					},
+				}},
+				SourceInputs: DefaultSourceInputConfigs(),
+			},
+			false,
+		},
+		{
+			"invalid: source_input with all condition",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &AllConditionConfig{CompositeConditionConfig{
+					Conditions: []ConditionConfig{
+						&ScheduleConditionConfig{Cron: String("* * * * * * *")},
+						&NodesConditionConfig{},
+					},
+				}},
+				SourceInputs: &SourceInputConfigs{&NodesSourceInputConfig{}},
+			},
+			false,
+		},
+		{
+			"missing services with config-entries source_input",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&ConfigEntriesSourceInputConfig{
+					ConfigEntriesMonitorConfig{
+						Kinds: []string{"service-defaults"},
+					},
+				}},
+			},
+			false,
+		},
+		{
+			"valid: prepared-query condition",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &PreparedQueryConditionConfig{PreparedQueryMonitorConfig{
+					Name: String("web-failover"),
+				}},
+				SourceInputs: &SourceInputConfigs{&ConsulKVSourceInputConfig{
+					ConsulKVMonitorConfig{Path: String("key")},
+				}},
+			},
+			true,
+		},
+		{
+			"invalid: services with prepared-query condition",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &PreparedQueryConditionConfig{PreparedQueryMonitorConfig{
+					Name: String("web-failover"),
+				}},
+			},
+			false,
+		},
+		{
+			"invalid: prepared-query condition with services source_input",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &PreparedQueryConditionConfig{PreparedQueryMonitorConfig{
+					Name: String("web-failover"),
+				}},
+				SourceInputs: &SourceInputConfigs{&ServicesSourceInputConfig{
+					ServicesMonitorConfig{Regexp: String("^api$")},
+				}},
+			},
+			false,
+		},
+		{
+			"valid: prepared-query source_input with consul-kv source_input",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{
+					&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{
+						Name: String("web-failover"),
+					}},
+					&ConsulKVSourceInputConfig{
+						ConsulKVMonitorConfig{Path: String("key")},
+					},
+				},
+			},
+			true,
+		},
+		{
+			"invalid: services with prepared-query source_input",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Services:  []string{"api"},
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&PreparedQuerySourceInputConfig{
+					PreparedQueryMonitorConfig{Name: String("web-failover")},
+				}},
+			},
+			false,
+		},
+		{
+			"invalid: prepared-query and services regexp source_inputs",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{
+					&PreparedQuerySourceInputConfig{PreparedQueryMonitorConfig{
+						Name: String("web-failover"),
+					}},
+					&ServicesSourceInputConfig{
+						ServicesMonitorConfig{Regexp: String("^api$")},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"invalid: services condition with prepared-query source_input",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Services:  []string{"api"},
+				Condition: &ServicesConditionConfig{},
+				SourceInputs: &SourceInputConfigs{&PreparedQuerySourceInputConfig{
+					PreparedQueryMonitorConfig{Name: String("web-failover")},
+				}},
+			},
+			false,
+		},
+		{
+			"valid: vault-secret condition",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &VaultSecretConditionConfig{VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app"},
+				}},
+			},
+			true,
+		},
+		{
+			"invalid: vault-secret condition without services",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &VaultSecretConditionConfig{VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app"},
+				}},
+			},
+			false,
+		},
+		{
+			"valid: vault-secret source_input with services source_input",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{
+					&ServicesSourceInputConfig{
+						ServicesMonitorConfig{Regexp: String("^api$")},
+					},
+					&VaultSecretSourceInputConfig{VaultSecretMonitorConfig{
+						Paths: []string{"kv/data/app"},
+					}},
 				},
 			},
+			true,
+		},
+		{
+			"invalid: vault-secret source_input without services",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&VaultSecretSourceInputConfig{
+					VaultSecretMonitorConfig{Paths: []string{"kv/data/app"}},
+				}},
+			},
+			false,
+		},
+		{
+			"valid: file condition",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &FileConditionConfig{FileMonitorConfig{
+					Paths: []string{"hosts.json"},
+				}},
+			},
+			true,
+		},
+		{
+			"invalid: file condition without services",
+			&TaskConfig{
+				Name:   String("task"),
+				Source: String("source"),
+				Condition: &FileConditionConfig{FileMonitorConfig{
+					Paths: []string{"hosts.json"},
+				}},
+			},
+			false,
+		},
+		{
+			"invalid: file source_input without services",
+			&TaskConfig{
+				Name:      String("task"),
+				Source:    String("source"),
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
+				SourceInputs: &SourceInputConfigs{&FileSourceInputConfig{
+					FileMonitorConfig{Paths: []string{"hosts.json"}},
+				}},
+			},
+			false,
+		},
+		{
+			"invalid: file condition with file source_input",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &FileConditionConfig{FileMonitorConfig{
+					Paths: []string{"hosts.json"},
+				}},
+				SourceInputs: &SourceInputConfigs{&FileSourceInputConfig{
+					FileMonitorConfig{Paths: []string{"racks.json"}},
+				}},
+			},
+			false,
+		},
+		{
+			"invalid: vault-secret condition with vault-secret source_input",
+			&TaskConfig{
+				Name:     String("task"),
+				Source:   String("source"),
+				Services: []string{"api"},
+				Condition: &VaultSecretConditionConfig{VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app"},
+				}},
+				SourceInputs: &SourceInputConfigs{&VaultSecretSourceInputConfig{
+					VaultSecretMonitorConfig{Paths: []string{"kv/data/db"}},
+				}},
+			},
 			false,
 		},
 	}
@@ -780,11 +1485,11 @@ func TestTasksConfig_Validate(t *testing.T) {
 			name: "one task",
 			i: []*TaskConfig{
 				{
-					Name:        String("task"),
-					Services:    []string{"serviceA", "serviceB"},
-					Source:      String("source"),
-					Providers:   []string{"providerA", "providerB"},
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task"),
+					Services:     []string{"serviceA", "serviceB"},
+					Source:       String("source"),
+					Providers:    []string{"providerA", "providerB"},
+					SourceInputs: DefaultSourceInputConfigs(),
 				},
 			},
 			isValid: true,
@@ -792,18 +1497,18 @@ func TestTasksConfig_Validate(t *testing.T) {
 			name: "two tasks",
 			i: []*TaskConfig{
 				{
-					Name:        String("task"),
-					Services:    []string{"serviceA", "serviceB"},
-					Source:      String("source"),
-					Providers:   []string{"providerA", "providerB"},
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task"),
+					Services:     []string{"serviceA", "serviceB"},
+					Source:       String("source"),
+					Providers:    []string{"providerA", "providerB"},
+					SourceInputs: DefaultSourceInputConfigs(),
 				},
 				{
-					Name:        String("task2"),
-					Services:    []string{"serviceC"},
-					Source:      String("sourceC"),
-					Providers:   []string{"providerC"},
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task2"),
+					Services:     []string{"serviceC"},
+					Source:       String("sourceC"),
+					Providers:    []string{"providerC"},
+					SourceInputs: DefaultSourceInputConfigs(),
 				},
 			},
 			isValid: true,
@@ -811,17 +1516,17 @@ func TestTasksConfig_Validate(t *testing.T) {
 			name: "duplicate task names",
 			i: []*TaskConfig{
 				{
-					Name:        String("task"),
-					Services:    []string{"serviceA", "serviceB"},
-					Source:      String("source"),
-					Providers:   []string{"providerA", "providerB"},
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task"),
+					Services:     []string{"serviceA", "serviceB"},
+					Source:       String("source"),
+					Providers:    []string{"providerA", "providerB"},
+					SourceInputs: DefaultSourceInputConfigs(),
 				}, {
-					Name:        String("task"),
-					Services:    []string{"serviceA"},
-					Source:      String("source2"),
-					Providers:   []string{"providerA"},
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task"),
+					Services:     []string{"serviceA"},
+					Source:       String("source2"),
+					Providers:    []string{"providerA"},
+					SourceInputs: DefaultSourceInputConfigs(),
 				},
 			},
 			isValid: false,
@@ -829,11 +1534,11 @@ func TestTasksConfig_Validate(t *testing.T) {
 			name: "one invalid",
 			i: []*TaskConfig{
 				{
-					Name:        String("task"),
-					Services:    []string{"serviceA", "serviceB"},
-					Source:      String("source"),
-					Providers:   []string{"providerA", "providerB"},
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task"),
+					Services:     []string{"serviceA", "serviceB"},
+					Source:       String("source"),
+					Providers:    []string{"providerA", "providerB"},
+					SourceInputs: DefaultSourceInputConfigs(),
 				}, {
 					Name: String("invalid"),
 				},
@@ -843,9 +1548,9 @@ func TestTasksConfig_Validate(t *testing.T) {
 			name: "duplicate provider instances",
 			i: []*TaskConfig{
 				{
-					Name:        String("task"),
-					Providers:   []string{"provider.A", "provider.B"},
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task"),
+					Providers:    []string{"provider.A", "provider.B"},
+					SourceInputs: DefaultSourceInputConfigs(),
 				},
 			},
 			isValid: false,
@@ -853,11 +1558,11 @@ func TestTasksConfig_Validate(t *testing.T) {
 			name: "unsupported TF version per task",
 			i: []*TaskConfig{
 				{
-					Name:        String("task"),
-					Services:    []string{"serviceA", "serviceB"},
-					Source:      String("source"),
-					TFVersion:   String("0.15.0"),
-					SourceInput: DefaultSourceInputConfig(),
+					Name:         String("task"),
+					Services:     []string{"serviceA", "serviceB"},
+					Source:       String("source"),
+					TFVersion:    String("0.15.0"),
+					SourceInputs: DefaultSourceInputConfigs(),
 				},
 			},
 			isValid: false,
@@ -924,7 +1629,7 @@ func TestTaskConfig_FinalizeValidate(t *testing.T) {
 				Name:      String("task_a"),
 				Source:    String("source"),
 				Services:  []string{"serviceA"},
-				Condition: &ScheduleConditionConfig{String("* * * * * * *")},
+				Condition: &ScheduleConditionConfig{Cron: String("* * * * * * *")},
 			},
 			true,
 		},
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -306,6 +307,7 @@ func (c *TerraformConfig) Validate() error {
 }
 
 // GoString defines the printable version of this struct.
+// Sensitive information is redacted.
 func (c *TerraformConfig) GoString() string {
 	if c == nil {
 		return "(*TerraformConfig)(nil)"
@@ -325,11 +327,28 @@ func (c *TerraformConfig) GoString() string {
 		BoolVal(c.PersistLog),
 		StringVal(c.Path),
 		StringVal(c.WorkingDir),
-		c.Backend,
+		backendGoString(c.Backend),
 		c.RequiredProviders,
 	)
 }
 
+// backendGoString returns the printable version of the backend. The backend
+// configuration is completely redacted since backends will have varying
+// arguments containing secrets, e.g. access_token or secret_key.
+func backendGoString(backend map[string]interface{}) string {
+	if backend == nil {
+		return "map[]"
+	}
+
+	names := make([]string, 0, len(backend))
+	for name := range backend {
+		names = append(names, fmt.Sprintf("%s:%s", name, redactMessage))
+	}
+	sort.Strings(names)
+
+	return "map[" + strings.Join(names, " ") + "]"
+}
+
 // IsConsulBackend returns if the Terraform backend is using Consul KV for
 // remote state store.
 func (c *TerraformConfig) IsConsulBackend() bool {
@@ -671,3 +671,46 @@ func TestDefaultTerraformBackend(t *testing.T) {
 		})
 	}
 }
+
+func TestTerraformConfig_GoString(t *testing.T) {
+	cases := []struct {
+		name     string
+		i        *TerraformConfig
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"(*TerraformConfig)(nil)",
+		},
+		{
+			"backend",
+			&TerraformConfig{
+				Version:    String("0.15.0"),
+				Log:        Bool(true),
+				PersistLog: Bool(false),
+				Path:       String("path"),
+				WorkingDir: String("working"),
+				Backend: map[string]interface{}{
+					"s3": map[string]interface{}{
+						"bucket":     "tfstate",
+						"access_key": "AKIAEXAMPLE",
+						"secret_key": "secret123",
+					},
+				},
+				RequiredProviders: map[string]interface{}{},
+			},
+			fmt.Sprintf("&TerraformConfig{Version:0.15.0, Log:true, "+
+				"PersistLog:false, Path:path, WorkingDir:working, "+
+				"Backend:map[s3:%s], RequiredProviders:map[]}", redactMessage),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			assert.Equal(t, tc.expected, actual)
+			assert.NotContains(t, actual, "secret123")
+		})
+	}
+}
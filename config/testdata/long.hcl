@@ -1,5 +1,6 @@
 log_level = "ERR"
 port = 8502
+address = "unix:///var/run/cts.sock"
 working_dir = "working"
 
 syslog {
@@ -20,6 +21,10 @@ tls {
   ca_cert = "../testutils/certs/consul_cert.pem"
 }
 
+unix_socket {
+  mode = "0660"
+}
+
 consul {
   address = "consul-example.com"
   auth {
@@ -87,6 +92,8 @@ task {
     regexp = ".*"
     source_includes_var = true
     namespace = "ns2"
+    partition = "ap2"
+    peer = "peer2"
     datacenter = "dc2"
     node_meta {
       "key1" = "value1"
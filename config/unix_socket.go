@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultUnixSocketMode is the default file permissions of the Unix domain
+	// socket for the api server. Only the user running CTS can connect.
+	DefaultUnixSocketMode = "0600"
+
+	// unixSocketScheme is the scheme of an address for a Unix domain socket,
+	// e.g. unix:///var/run/cts.sock
+	unixSocketScheme = "unix://"
+)
+
+// UnixSocketConfig is the configuration for the Unix domain socket that the
+// api server listens on when the address is configured as unix://<path>
+type UnixSocketConfig struct {
+	// Mode is the file permissions of the socket in octal notation, e.g. "0660"
+	Mode *string `mapstructure:"mode"`
+}
+
+// DefaultUnixSocketConfig returns the default configuration struct.
+func DefaultUnixSocketConfig() *UnixSocketConfig {
+	return &UnixSocketConfig{
+		Mode: String(DefaultUnixSocketMode),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *UnixSocketConfig) Copy() *UnixSocketConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o UnixSocketConfig
+	o.Mode = StringCopy(c.Mode)
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *UnixSocketConfig) Merge(o *UnixSocketConfig) *UnixSocketConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Mode != nil {
+		r.Mode = StringCopy(o.Mode)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *UnixSocketConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Mode == nil || *c.Mode == "" {
+		c.Mode = String(DefaultUnixSocketMode)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *UnixSocketConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Mode != nil {
+		if _, err := parseFileMode(*c.Mode); err != nil {
+			return fmt.Errorf("unix_socket: invalid mode %q: %s", *c.Mode, err)
+		}
+	}
+
+	return nil
+}
+
+// FileMode returns the file permissions for the socket. Returns the default
+// permissions if the mode is not configured.
+func (c *UnixSocketConfig) FileMode() os.FileMode {
+	mode := DefaultUnixSocketMode
+	if c != nil && StringPresent(c.Mode) {
+		mode = *c.Mode
+	}
+
+	m, err := parseFileMode(mode)
+	if err != nil {
+		m, _ = parseFileMode(DefaultUnixSocketMode)
+	}
+	return m
+}
+
+// GoString defines the printable version of this struct.
+func (c *UnixSocketConfig) GoString() string {
+	if c == nil {
+		return "(*UnixSocketConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&UnixSocketConfig{"+
+		"Mode:%s"+
+		"}",
+		StringVal(c.Mode),
+	)
+}
+
+// UnixSocketPath returns the path of the Unix domain socket if the address
+// has the unix:// scheme.
+func UnixSocketPath(address string) (string, bool) {
+	if !strings.HasPrefix(address, unixSocketScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(address, unixSocketScheme), true
+}
+
+// parseFileMode parses file permissions in octal notation
+func parseFileMode(mode string) (os.FileMode, error) {
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("mode must be in octal notation, e.g. \"0600\"")
+	}
+	if m > 0777 {
+		return 0, fmt.Errorf("mode must only include file permission bits")
+	}
+	return os.FileMode(m), nil
+}
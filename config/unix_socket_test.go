@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixSocketConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *UnixSocketConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&UnixSocketConfig{},
+		},
+		{
+			"mode",
+			&UnixSocketConfig{Mode: String("0660")},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestUnixSocketConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *UnixSocketConfig
+		b    *UnixSocketConfig
+		r    *UnixSocketConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&UnixSocketConfig{},
+			&UnixSocketConfig{},
+		},
+		{
+			"nil_b",
+			&UnixSocketConfig{},
+			nil,
+			&UnixSocketConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"mode_overrides",
+			&UnixSocketConfig{Mode: String("0600")},
+			&UnixSocketConfig{Mode: String("0660")},
+			&UnixSocketConfig{Mode: String("0660")},
+		},
+		{
+			"mode_empty_one",
+			&UnixSocketConfig{Mode: String("0600")},
+			&UnixSocketConfig{},
+			&UnixSocketConfig{Mode: String("0600")},
+		},
+		{
+			"mode_empty_two",
+			&UnixSocketConfig{},
+			&UnixSocketConfig{Mode: String("0660")},
+			&UnixSocketConfig{Mode: String("0660")},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestUnixSocketConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *UnixSocketConfig
+		r    *UnixSocketConfig
+	}{
+		{
+			"empty",
+			&UnixSocketConfig{},
+			DefaultUnixSocketConfig(),
+		},
+		{
+			"empty_mode",
+			&UnixSocketConfig{Mode: String("")},
+			DefaultUnixSocketConfig(),
+		},
+		{
+			"mode",
+			&UnixSocketConfig{Mode: String("0660")},
+			&UnixSocketConfig{Mode: String("0660")},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestUnixSocketConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *UnixSocketConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"valid",
+			&UnixSocketConfig{Mode: String("0660")},
+			true,
+		},
+		{
+			"not_octal",
+			&UnixSocketConfig{Mode: String("rw-rw----")},
+			false,
+		},
+		{
+			"not_permission_bits",
+			&UnixSocketConfig{Mode: String("4755")},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUnixSocketConfig_FileMode(t *testing.T) {
+	t.Parallel()
+
+	var c *UnixSocketConfig
+	assert.Equal(t, os.FileMode(0600), c.FileMode())
+
+	c = &UnixSocketConfig{Mode: String("0660")}
+	assert.Equal(t, os.FileMode(0660), c.FileMode())
+}
+
+func TestUnixSocketPath(t *testing.T) {
+	t.Parallel()
+
+	path, ok := UnixSocketPath("unix:///var/run/cts.sock")
+	assert.True(t, ok)
+	assert.Equal(t, "/var/run/cts.sock", path)
+
+	_, ok = UnixSocketPath("http://localhost:8558")
+	assert.False(t, ok)
+}
+
+func TestConfig_Finalize_UnixSocketPort(t *testing.T) {
+	t.Parallel()
+
+	// only listens on the socket when port is not configured
+	c := &Config{Address: String("unix:///var/run/cts.sock")}
+	c.Finalize()
+	assert.Equal(t, 0, *c.Port)
+
+	// listens on both when port is configured
+	c = &Config{Address: String("unix:///var/run/cts.sock"), Port: Int(8558)}
+	c.Finalize()
+	assert.Equal(t, 8558, *c.Port)
+
+	c = &Config{}
+	c.Finalize()
+	assert.Equal(t, DefaultPort, *c.Port)
+}
@@ -48,6 +48,7 @@ type baseController struct {
 	newDriver func(*config.Config, *driver.Task, templates.Watcher) (driver.Driver, error)
 	drivers   *driver.Drivers
 	watcher   templates.Watcher
+	deps      *templates.DependencyTracker
 	resolver  templates.Resolver
 	logger    logging.Logger
 }
@@ -64,12 +65,15 @@ func newBaseController(conf *config.Config) (*baseController, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Track the dependencies of templates for introspection through the API
+	deps := templates.NewDependencyTracker(watcher)
 
 	return &baseController{
 		conf:      conf,
 		newDriver: nd,
 		drivers:   driver.NewDrivers(),
-		watcher:   watcher,
+		watcher:   deps,
+		deps:      deps,
 		resolver:  hcat.NewResolver(),
 		logger:    logger,
 	}, nil
@@ -258,8 +262,12 @@ func newDriverTasks(conf *config.Config, providerConfigs driver.TerraformProvide
 			Version:      *t.Version,
 			BufferPeriod: bp,
 			Condition:    t.Condition,
-			SourceInput:  t.SourceInput,
+			SourceInputs: *t.SourceInputs,
 			WorkingDir:   *t.WorkingDir,
+
+			IncludeStatuses:  t.IncludeStatuses,
+			TriggerOn:        t.TriggerOn,
+			ServicesProtocol: *t.ServicesProtocol,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("error initializing task %s: %s", *t.Name, err)
@@ -279,6 +287,7 @@ func getService(services *config.ServiceConfigs, id string, meta config.Services
 		if *s.ID == id {
 			return driver.Service{
 				Datacenter:      *s.Datacenter,
+				Datacenters:     s.Datacenters,
 				Description:     *s.Description,
 				Name:            *s.Name,
 				Namespace:       *s.Namespace,
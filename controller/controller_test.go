@@ -207,19 +207,25 @@ func TestNewDriverTasks(t *testing.T) {
 				VarFiles: []string{},
 				Condition: &config.ServicesConditionConfig{
 					config.ServicesMonitorConfig{
-						Regexp: config.String(""),
-					},
-				},
-				SourceInput: &config.ServicesSourceInputConfig{
-					config.ServicesMonitorConfig{
-						Regexp: config.String(""),
+						Regexp:      config.String(""),
+						Datacenter:  config.String(""),
+						Datacenters: []string{},
+						Namespace:   config.String(""),
+						Partition:   config.String(""),
+						Peer:        config.String(""),
+						NodeMeta:    map[string]string{},
+						Filter:      config.String(""),
 					},
 				},
+				SourceInputs: config.SourceInputConfigs{},
 				BufferPeriod: &driver.BufferPeriod{
 					Min: 5 * time.Second,
 					Max: 20 * time.Second,
 				},
 				WorkingDir: "sync-tasks/name",
+
+				IncludeStatuses: []string{},
+				TriggerOn:       []string{},
 			})},
 		}, {
 			// Fetches correct provider and required_providers blocks from config
@@ -282,19 +288,25 @@ func TestNewDriverTasks(t *testing.T) {
 				VarFiles: []string{},
 				Condition: &config.ServicesConditionConfig{
 					config.ServicesMonitorConfig{
-						Regexp: config.String(""),
-					},
-				},
-				SourceInput: &config.ServicesSourceInputConfig{
-					config.ServicesMonitorConfig{
-						Regexp: config.String(""),
+						Regexp:      config.String(""),
+						Datacenter:  config.String(""),
+						Datacenters: []string{},
+						Namespace:   config.String(""),
+						Partition:   config.String(""),
+						Peer:        config.String(""),
+						NodeMeta:    map[string]string{},
+						Filter:      config.String(""),
 					},
 				},
+				SourceInputs: config.SourceInputConfigs{},
 				BufferPeriod: &driver.BufferPeriod{
 					Min: 5 * time.Second,
 					Max: 20 * time.Second,
 				},
 				WorkingDir: "sync-tasks/name",
+
+				IncludeStatuses: []string{},
+				TriggerOn:       []string{},
 			})},
 		}, {
 			// Task env is fetched from providers and Consul config when using
@@ -342,19 +354,25 @@ func TestNewDriverTasks(t *testing.T) {
 				VarFiles:     []string{},
 				Condition: &config.ServicesConditionConfig{
 					config.ServicesMonitorConfig{
-						Regexp: config.String(""),
-					},
-				},
-				SourceInput: &config.ServicesSourceInputConfig{
-					config.ServicesMonitorConfig{
-						Regexp: config.String(""),
+						Regexp:      config.String(""),
+						Datacenter:  config.String(""),
+						Datacenters: []string{},
+						Namespace:   config.String(""),
+						Partition:   config.String(""),
+						Peer:        config.String(""),
+						NodeMeta:    map[string]string{},
+						Filter:      config.String(""),
 					},
 				},
+				SourceInputs: config.SourceInputConfigs{},
 				BufferPeriod: &driver.BufferPeriod{
 					Min: 5 * time.Second,
 					Max: 20 * time.Second,
 				},
 				WorkingDir: "sync-tasks/name",
+
+				IncludeStatuses: []string{},
+				TriggerOn:       []string{},
 			})},
 		},
 	}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	goVersion "github.com/hashicorp/go-version"
+)
+
+// GenerateTask generates the root module of a task in the directory with the
+// input variables rendered from the fixture data instead of Consul. Dynamic
+// provider configuration is not evaluated and is written to the root module as
+// is.
+func GenerateTask(conf *config.Config, taskName, dir string, fixture *tmplfunc.Fixture) error {
+	if conf.Driver.Terraform == nil {
+		return fmt.Errorf("unsupported driver")
+	}
+
+	tasks, err := config.FilterTasks(conf.Tasks, []string{taskName})
+	if err != nil {
+		return err
+	}
+	taskConf := *conf
+	taskConf.Tasks = tasks
+
+	providers := make(driver.TerraformProviderBlocks, len(*conf.TerraformProviders))
+	for i, p := range *conf.TerraformProviders {
+		providers[i] = driver.NewTerraformProviderBlock(hcltmpl.NewNamedBlock(*p))
+	}
+
+	driverTasks, err := newDriverTasks(&taskConf, providers)
+	if err != nil {
+		return err
+	}
+
+	tfConf := conf.Driver.Terraform
+	var tfVersion *goVersion.Version
+	if tfConf.Version != nil && *tfConf.Version != "" {
+		tfVersion, err = goVersion.NewVersion(*tfConf.Version)
+		if err != nil {
+			return fmt.Errorf("invalid terraform version %q: %s", *tfConf.Version, err)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+
+	return driver.GenerateRootModule(driver.GenerateConfig{
+		Task:             driverTasks[0],
+		TerraformVersion: tfVersion,
+		Backend:          tfConf.Backend,
+		Dir:              dir,
+		Recaller:         fixture.Recaller(),
+	})
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/event"
 	"github.com/hashicorp/consul-terraform-sync/retry"
-	"github.com/hashicorp/cronexpr"
 )
 
 var (
@@ -110,6 +109,11 @@ func (rw *ReadWrite) runDynamicTasks(ctx context.Context) chan error {
 			continue
 		}
 
+		if d.Task().IsWebhook() {
+			// Webhook tasks are not dynamic and are triggered through the API
+			continue
+		}
+
 		if rw.drivers.IsActive(taskName) {
 			// The driver is currently active with the task, initiated by an ad-hoc run.
 			// There may be updates for other tasks, so we'll continue checking
@@ -146,7 +150,7 @@ func (rw *ReadWrite) runScheduledTask(ctx context.Context, d driver.Driver) erro
 	task := d.Task()
 	taskName := task.Name()
 
-	cond, ok := task.Condition().(*config.ScheduleConditionConfig)
+	cond, ok := config.FindScheduleCondition(task.Condition())
 	if !ok {
 		rw.logger.Error("unexpected condition while running a scheduled "+
 			"condition", taskNameLogKey, taskName, "condition_type",
@@ -155,43 +159,70 @@ func (rw *ReadWrite) runScheduledTask(ctx context.Context, d driver.Driver) erro
 			"condition type %T", task.Condition())
 	}
 
-	expr, err := cronexpr.Parse(*cond.Cron)
+	sched, err := newSchedule(cond)
 	if err != nil {
-		rw.logger.Error("error parsing task cron", taskNameLogKey, taskName,
-			"cron", *cond.Cron, "error", err)
+		rw.logger.Error("error parsing task schedule", taskNameLogKey, taskName,
+			"cron", config.StringVal(cond.Cron), "timezone",
+			config.StringVal(cond.Timezone), "error", err)
 		return err
 	}
 
-	nextTime := expr.Next(time.Now())
-	waitTime := time.Until(nextTime)
+	statePath := scheduleStatePath(task.WorkingDir())
+	now := time.Now()
+	scheduled := sched.next(now)
+	nextTime := scheduled.Add(sched.delay())
+	if sched.catchUp && sched.missed(loadScheduleState(statePath, rw.logger), now) {
+		// A run was missed while the daemon was down, run the task right away
+		rw.logger.Info("catching up on missed scheduled run", taskNameLogKey,
+			taskName)
+		scheduled = now
+		nextTime = now
+	}
+	task.SetNextRunTime(nextTime)
 	rw.logger.Info("scheduled task next run time", taskNameLogKey, taskName,
-		"wait_time", waitTime, "next_runtime", nextTime)
+		"wait_time", time.Until(nextTime), "next_runtime", nextTime)
 
 	for {
 		select {
-		case <-time.After(waitTime):
+		case <-time.After(time.Until(nextTime)):
 			rw.logger.Info("time for scheduled task", taskNameLogKey, taskName)
+			run := true
 			if rw.drivers.IsActive(taskName) {
-				// The driver is currently active with the task, initiated by an ad-hoc run.
-				rw.logger.Trace("task is active", taskNameLogKey, taskName)
-				continue
+				// The driver is currently active with the task, initiated by an
+				// ad-hoc run or another trigger.
+				if sched.skipIfRunning {
+					rw.logger.Info("task is active, skipping scheduled run",
+						taskNameLogKey, taskName)
+					run = false
+				} else {
+					rw.logger.Trace("task is active, waiting to run",
+						taskNameLogKey, taskName)
+					if err := rw.waitForInactive(ctx, taskName); err != nil {
+						rw.logger.Info("stopping scheduled task", taskNameLogKey, taskName)
+						return err
+					}
+				}
 			}
 
-			complete, err := rw.checkApply(ctx, d, true, false)
-			if err != nil {
-				// print error but continue
-				rw.logger.Error("error applying task %q: %s",
-					taskNameLogKey, taskName, "error", err)
-			}
+			if run {
+				complete, err := rw.checkApply(ctx, d, true, false)
+				if err != nil {
+					// print error but continue
+					rw.logger.Error("error applying task %q: %s",
+						taskNameLogKey, taskName, "error", err)
+				}
 
-			if rw.taskNotify != nil && complete {
-				rw.taskNotify <- taskName
+				if rw.taskNotify != nil && complete {
+					rw.taskNotify <- taskName
+				}
 			}
+			saveScheduleState(statePath, scheduled, rw.logger)
 
-			nextTime := expr.Next(time.Now())
-			waitTime = time.Until(nextTime)
+			scheduled = sched.next(time.Now())
+			nextTime = scheduled.Add(sched.delay())
+			task.SetNextRunTime(nextTime)
 			rw.logger.Info("scheduled task next run time", taskNameLogKey, taskName,
-				"wait_time", waitTime, "next_runtime", nextTime)
+				"wait_time", time.Until(nextTime), "next_runtime", nextTime)
 		case <-ctx.Done():
 			rw.logger.Info("stopping scheduled task", taskNameLogKey, taskName)
 			return ctx.Err()
@@ -199,6 +230,21 @@ func (rw *ReadWrite) runScheduledTask(ctx context.Context, d driver.Driver) erro
 	}
 }
 
+// waitForInactive blocks until the driver is no longer active with the task or
+// the context is cancelled.
+func (rw *ReadWrite) waitForInactive(ctx context.Context, taskName string) error {
+	ticker := time.NewTicker(scheduleActivePollInterval)
+	defer ticker.Stop()
+	for rw.drivers.IsActive(taskName) {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 // Once runs the controller in read-write mode making sure each template has
 // been fully rendered and the task run, then it returns.
 func (rw *ReadWrite) Once(ctx context.Context) error {
@@ -241,10 +287,14 @@ func (rw *ReadWrite) Once(ctx context.Context) error {
 // ServeAPI runs the API server for the controller
 func (rw *ReadWrite) ServeAPI(ctx context.Context) error {
 	a, err := api.NewAPI(&api.APIConfig{
-		Store:   rw.store,
-		Drivers: rw.drivers,
-		Port:    config.IntVal(rw.conf.Port),
-		TLS:     rw.conf.TLS},
+		Store:        rw.store,
+		Drivers:      rw.drivers,
+		Dependencies: rw.deps,
+		Config:       rw.conf,
+		Port:         config.IntVal(rw.conf.Port),
+		TLS:          rw.conf.TLS,
+		Address:      config.StringVal(rw.conf.Address),
+		UnixSocket:   rw.conf.UnixSocket},
 	)
 	if err != nil {
 		return err
@@ -401,6 +401,34 @@ func TestReadWrite_runDynamicTasks(t *testing.T) {
 			t.Error(err)
 		}
 	})
+
+	t.Run("skip-webhook-tasks", func(t *testing.T) {
+		controller := ReadWrite{
+			baseController: &baseController{
+				drivers: driver.NewDrivers(),
+			},
+			store: event.NewStore(),
+		}
+
+		taskName := "webhook_task"
+		d := new(mocksD.Driver)
+		task, err := driver.NewTask(driver.TaskConfig{
+			Name:      taskName,
+			Enabled:   true,
+			Condition: &config.WebhookConditionConfig{},
+		})
+		require.NoError(t, err)
+		d.On("Task").Return(task)
+		// no other methods should be called (or mocked)
+		controller.drivers.Add(taskName, d)
+
+		ctx := context.Background()
+		errCh := controller.runDynamicTasks(ctx)
+		err = <-errCh
+		if err != nil {
+			t.Error(err)
+		}
+	})
 }
 
 func TestReadWrite_runScheduledTask(t *testing.T) {
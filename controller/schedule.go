@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/cronexpr"
+)
+
+// scheduleStateFilename is the name of the file in the task's working
+// directory that stores the last scheduled run of the task
+const scheduleStateFilename = "schedule_state.json"
+
+// scheduleActivePollInterval is the interval to check whether a task that is
+// active is complete, for schedules that do not skip runs of active tasks
+var scheduleActivePollInterval = time.Second
+
+// schedule determines the run times of a scheduled task
+type schedule struct {
+	expr          *cronexpr.Expression
+	loc           *time.Location
+	jitter        time.Duration
+	skipIfRunning bool
+	catchUp       bool
+}
+
+// scheduleState is the last run of a scheduled task that is stored in the
+// state file
+type scheduleState struct {
+	LastRun time.Time `json:"last_run"`
+}
+
+// newSchedule creates a schedule from the schedule condition configuration
+func newSchedule(cond *config.ScheduleConditionConfig) (*schedule, error) {
+	expr, err := cronexpr.Parse(config.StringVal(cond.Cron))
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := cond.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedule{
+		expr:          expr,
+		loc:           loc,
+		jitter:        config.TimeDurationVal(cond.Jitter),
+		skipIfRunning: cond.SkipIfRunning == nil || *cond.SkipIfRunning,
+		catchUp:       config.BoolVal(cond.CatchUp),
+	}, nil
+}
+
+// next returns the next time of the cron expression after t, evaluated in the
+// schedule's timezone
+func (s *schedule) next(t time.Time) time.Time {
+	return s.expr.Next(t.In(s.loc))
+}
+
+// delay returns a random delay within the jitter to offset a scheduled run by
+func (s *schedule) delay() time.Duration {
+	if s.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.jitter)))
+}
+
+// missed returns whether a run was scheduled between the last run and now.
+// Always false if there is no last run.
+func (s *schedule) missed(last, now time.Time) bool {
+	if last.IsZero() {
+		return false
+	}
+	next := s.next(last)
+	return !next.IsZero() && next.Before(now)
+}
+
+// loadScheduleState loads the last run of a scheduled task from the state
+// file. Returns the zero time if the state is not available.
+func loadScheduleState(path string, logger logging.Logger) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+
+	var state scheduleState
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return time.Time{}
+	}
+	if err == nil {
+		err = json.Unmarshal(content, &state)
+	}
+	if err != nil {
+		logger.Warn("unable to load last scheduled run. runs missed before "+
+			"the restart will not be caught up", "file_path", path, "error", err)
+		return time.Time{}
+	}
+	return state.LastRun
+}
+
+// saveScheduleState stores the last run of a scheduled task in the state file
+func saveScheduleState(path string, lastRun time.Time, logger logging.Logger) {
+	if path == "" {
+		return
+	}
+
+	content, err := json.Marshal(scheduleState{LastRun: lastRun})
+	if err == nil {
+		err = ioutil.WriteFile(path, content, 0640)
+	}
+	if err != nil {
+		logger.Error("unable to store last scheduled run", "file_path", path,
+			"error", err)
+	}
+}
+
+// scheduleStatePath returns the path of the state file for a scheduled task.
+// Empty if the task does not have a working directory.
+func scheduleStatePath(workingDir string) string {
+	if workingDir == "" {
+		return ""
+	}
+	return filepath.Join(workingDir, scheduleStateFilename)
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSchedule(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		cond      *config.ScheduleConditionConfig
+		expectErr bool
+	}{
+		{
+			"unfinalized defaults",
+			&config.ScheduleConditionConfig{Cron: config.String("* * * * *")},
+			false,
+		},
+		{
+			"invalid cron",
+			&config.ScheduleConditionConfig{Cron: config.String("invalid")},
+			true,
+		},
+		{
+			"invalid timezone",
+			&config.ScheduleConditionConfig{
+				Cron:     config.String("* * * * *"),
+				Timezone: config.String("Mars/Olympus_Mons"),
+			},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := newSchedule(tc.cond)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, s.skipIfRunning)
+			assert.False(t, s.catchUp)
+			assert.Equal(t, time.Local, s.loc)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	s, err := newSchedule(&config.ScheduleConditionConfig{
+		Cron:     config.String("0 0 9 * * * *"),
+		Timezone: config.String("America/New_York"),
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC) // 08:00 in New York
+	next := s.next(now)
+	assert.True(t, next.Equal(time.Date(2021, 6, 1, 13, 0, 0, 0, time.UTC)),
+		"unexpected next time %s", next)
+}
+
+func TestSchedule_Delay(t *testing.T) {
+	t.Parallel()
+
+	s := &schedule{}
+	assert.Equal(t, time.Duration(0), s.delay())
+
+	s.jitter = time.Minute
+	for i := 0; i < 100; i++ {
+		d := s.delay()
+		assert.True(t, d >= 0 && d < time.Minute, "unexpected delay %s", d)
+	}
+}
+
+func TestSchedule_Missed(t *testing.T) {
+	t.Parallel()
+
+	s, err := newSchedule(&config.ScheduleConditionConfig{
+		Cron:     config.String("0 0 * * * * *"),
+		Timezone: config.String("UTC"),
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+	assert.False(t, s.missed(time.Time{}, now))
+	assert.False(t, s.missed(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC), now))
+	assert.True(t, s.missed(time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC), now))
+}
+
+func TestScheduleState(t *testing.T) {
+	t.Parallel()
+
+	logger := logging.NewNullLogger()
+
+	t.Run("no path", func(t *testing.T) {
+		saveScheduleState("", time.Now(), logger)
+		assert.True(t, loadScheduleState("", logger).IsZero())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "schedule-state")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		assert.True(t, loadScheduleState(scheduleStatePath(dir), logger).IsZero())
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "schedule-state")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := scheduleStatePath(dir)
+		assert.Equal(t, filepath.Join(dir, scheduleStateFilename), path)
+
+		lastRun := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+		saveScheduleState(path, lastRun, logger)
+		assert.True(t, lastRun.Equal(loadScheduleState(path, logger)))
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "schedule-state")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := scheduleStatePath(dir)
+		require.NoError(t, ioutil.WriteFile(path, []byte("invalid"), 0640))
+		assert.True(t, loadScheduleState(path, logger).IsZero())
+	})
+}
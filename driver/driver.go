@@ -26,12 +26,20 @@ type Driver interface {
 	// ApplyTask applies change for the task managed by the driver
 	ApplyTask(ctx context.Context) error
 
+	// TriggerTask runs a task with a webhook condition with the payload of the
+	// webhook request
+	TriggerTask(ctx context.Context, payload []byte) error
+
 	// UpdateTask supports updating certain fields of a task
 	UpdateTask(ctx context.Context, task PatchTask) (InspectPlan, error)
 
 	// Task returns the task information of the driver
 	Task() *Task
 
+	// TemplateIDs returns the IDs of the templates the driver registered with
+	// the watcher for the task
+	TemplateIDs() []string
+
 	// Version returns the version of the driver.
 	Version() string
 }
@@ -0,0 +1,83 @@
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	goVersion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcat"
+)
+
+// GenerateConfig configures generating the root module of a task
+type GenerateConfig struct {
+	Task             *Task
+	TerraformVersion *goVersion.Version
+	Backend          map[string]interface{}
+
+	// Dir is the directory to generate the root module in
+	Dir string
+
+	// Recaller looks up the values of the template dependencies used to
+	// render the input variables of the task's module
+	Recaller hcat.Recaller
+}
+
+// GenerateRootModule generates the root module of a task and renders the
+// terraform.tfvars file with the values from the recaller. Unlike
+// initializing a task, Terraform is not run and the template is not
+// registered with a watcher, which allows the root module to be generated
+// without Consul.
+func GenerateRootModule(conf GenerateConfig) error {
+	source, err := absModuleSource(conf.Task.Source())
+	if err != nil {
+		return err
+	}
+
+	input := tftmpl.RootModuleInputData{
+		TerraformVersion: conf.TerraformVersion,
+		Backend:          conf.Backend,
+		Path:             conf.Dir,
+		FilePerms:        filePerms,
+	}
+	conf.Task.configureRootModuleInput(&input)
+	input.Task.Source = source
+
+	if err := tftmpl.InitRootModule(&input); err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(conf.Dir, tftmpl.TFVarsTmplFilename))
+	if err != nil {
+		return err
+	}
+
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents:     string(content),
+		FuncMapMerge: tmplfunc.HCLMap(conf.Task.servicesMeta()),
+	})
+	tfvars, err := tmpl.Execute(conf.Recaller)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(conf.Dir, tftmpl.TFVarsFilename),
+		tfvars, filePerms)
+}
+
+// absModuleSource converts the relative path of a local module source to an
+// absolute path. Other sources are returned as is.
+func absModuleSource(source string) (string, error) {
+	if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return source, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(wd, source), nil
+}
@@ -0,0 +1,60 @@
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRootModule(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "generate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	task, err := NewTask(TaskConfig{
+		Name:     "task",
+		Source:   "./modules/test",
+		Services: []Service{{Name: "web", UserDefinedMeta: map[string]string{"key": "value"}}},
+	})
+	require.NoError(t, err)
+
+	fixture := &tmplfunc.Fixture{
+		Services: []*dep.HealthService{
+			{Node: "node", ID: "web-1", Name: "web", Address: "10.0.0.1", Port: 80},
+		},
+	}
+
+	err = GenerateRootModule(GenerateConfig{
+		Task:     task,
+		Dir:      dir,
+		Recaller: fixture.Recaller(),
+	})
+	require.NoError(t, err)
+
+	for _, f := range []string{tftmpl.RootFilename, tftmpl.VarsFilename,
+		tftmpl.TFVarsTmplFilename, tftmpl.TFVarsFilename} {
+		assert.FileExists(t, filepath.Join(dir, f))
+	}
+
+	mainTF, err := ioutil.ReadFile(filepath.Join(dir, tftmpl.RootFilename))
+	require.NoError(t, err)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Contains(t, string(mainTF), filepath.Join(wd, "modules/test"),
+		"expected relative module source to be converted to an absolute path")
+
+	tfvars, err := ioutil.ReadFile(filepath.Join(dir, tftmpl.TFVarsFilename))
+	require.NoError(t, err)
+	assert.Contains(t, string(tfvars), `"web-1.node" = {`)
+	assert.Contains(t, string(tfvars), `"10.0.0.1"`)
+	assert.Contains(t, string(tfvars), `key = "value"`)
+}
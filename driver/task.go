@@ -12,6 +12,7 @@ import (
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 )
 
 const (
@@ -34,6 +35,7 @@ type PatchTask struct {
 // Service contains service configuration information
 type Service struct {
 	Datacenter      string
+	Datacenters     []string
 	Description     string
 	Name            string
 	Namespace       string
@@ -65,9 +67,16 @@ type Task struct {
 	version      string
 	bufferPeriod *BufferPeriod // nil when disabled
 	condition    config.ConditionConfig
-	sourceInput  config.SourceInputConfig
+	sourceInputs config.SourceInputConfigs
 	workingDir   string
 	logger       logging.Logger
+
+	includeStatuses []string // health statuses of the services to include
+	triggerOn       []string // changes to the services that trigger the task
+
+	servicesProtocol int // version of the service definition protocol
+
+	nextRunTime time.Time // next run of a scheduled task, zero if unknown
 }
 
 type TaskConfig struct {
@@ -83,8 +92,12 @@ type TaskConfig struct {
 	Version      string
 	BufferPeriod *BufferPeriod
 	Condition    config.ConditionConfig
-	SourceInput  config.SourceInputConfig
+	SourceInputs config.SourceInputConfigs
 	WorkingDir   string
+
+	IncludeStatuses  []string
+	TriggerOn        []string
+	ServicesProtocol int
 }
 
 func NewTask(conf TaskConfig) (*Task, error) {
@@ -113,9 +126,14 @@ func NewTask(conf TaskConfig) (*Task, error) {
 		version:      conf.Version,
 		bufferPeriod: conf.BufferPeriod,
 		condition:    conf.Condition,
-		sourceInput:  conf.SourceInput,
+		sourceInputs: conf.SourceInputs,
 		workingDir:   conf.WorkingDir,
 		logger:       logging.Global().Named(logSystemName),
+
+		includeStatuses: conf.IncludeStatuses,
+		triggerOn:       conf.TriggerOn,
+
+		servicesProtocol: conf.ServicesProtocol,
 	}, nil
 }
 
@@ -137,18 +155,26 @@ func (t *Task) Condition() config.ConditionConfig {
 	return t.condition
 }
 
-// SourceInput returns the type of sourceInput for the task to run
-func (t *Task) SourceInput() config.SourceInputConfig {
+// SourceInputs returns the source_inputs for the task to run
+func (t *Task) SourceInputs() config.SourceInputConfigs {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	return t.sourceInput
+	return t.sourceInputs
 }
 
 // IsScheduled returns if the task is a scheduled task or not (a dynamic task)
 func (t *Task) IsScheduled() bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	_, ok := t.condition.(*config.ScheduleConditionConfig)
+	_, ok := config.FindScheduleCondition(t.condition)
+	return ok
+}
+
+// IsWebhook returns if the task is triggered by webhook requests
+func (t *Task) IsWebhook() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.condition.(*config.WebhookConditionConfig)
 	return ok
 }
 
@@ -289,6 +315,45 @@ func (t *Task) WorkingDir() string {
 	return t.workingDir
 }
 
+// IncludeStatuses returns the health statuses of the service instances
+// provided to the task. Empty when not configured.
+func (t *Task) IncludeStatuses() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.includeStatuses
+}
+
+// NextRunTime returns the time of the next run of a scheduled task. It is
+// zero for dynamic tasks and when the next run is not yet known.
+func (t *Task) NextRunTime() time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.nextRunTime
+}
+
+// SetNextRunTime sets the time of the next run of a scheduled task
+func (t *Task) SetNextRunTime(next time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextRunTime = next
+}
+
+// TriggerOn returns the changes to service instances that trigger the task.
+// Empty when all changes trigger the task.
+func (t *Task) TriggerOn() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.triggerOn
+}
+
+// ServicesProtocol returns the version of the service definition protocol of
+// the services variable provided to the task.
+func (t *Task) ServicesProtocol() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.servicesProtocol
+}
+
 func (s Service) Copy() Service {
 	// All other Service attributes are simple types, this sets the meta to a new
 	// copy of the map
@@ -301,6 +366,19 @@ func (s Service) Copy() Service {
 	return cp
 }
 
+// servicesMeta returns the user defined metadata of the task's services
+// keyed by service name.
+func (t *Task) servicesMeta() tmplfunc.ServicesMeta {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	meta := make(tmplfunc.ServicesMeta)
+	for _, s := range t.services {
+		meta[s.Name] = s.UserDefinedMeta
+	}
+	return meta
+}
+
 // configureRootModuleInput sets task values for the module input.
 func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) {
 	t.mu.RLock()
@@ -317,93 +395,280 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) {
 	for i, s := range t.services {
 		input.Services[i] = tftmpl.Service{
 			Datacenter:         s.Datacenter,
+			Datacenters:        s.Datacenters,
 			Description:        s.Description,
 			Name:               s.Name,
 			Namespace:          s.Namespace,
 			Filter:             s.Filter,
+			IncludeStatuses:    t.includeStatuses,
+			Protocol:           t.servicesProtocol,
 			CTSUserDefinedMeta: s.UserDefinedMeta,
 		}
 	}
+	input.ServicesProtocol = t.servicesProtocol
+
+	condition := t.configureCondition(t.condition)
+	t.logger.Trace("condition configured", "source_input_type", fmt.Sprintf("%T", condition))
+	input.Condition = condition
+
+	input.SourceInputs = make([]tftmpl.SourceInput, 0, len(t.sourceInputs))
+	for _, si := range t.sourceInputs {
+		sourceInput := t.configureSourceInput(si)
+		if sourceInput == nil {
+			continue
+		}
+		t.logger.Trace("source_input configured", "source_input_type", fmt.Sprintf("%T", sourceInput))
+		input.SourceInputs = append(input.SourceInputs, sourceInput)
+	}
+
+	input.Providers = t.providers.ProviderBlocks()
+	input.ProviderInfo = make(map[string]interface{})
+	for k, v := range t.providerInfo {
+		input.ProviderInfo[k] = v
+	}
 
-	var condition tftmpl.Condition
-	switch v := t.condition.(type) {
+	input.Variables = make(hcltmpl.Variables)
+	for k, v := range t.variables {
+		input.Variables[k] = v
+	}
+}
+
+// configureCondition returns the module input templating for a condition.
+// Expected to be called while holding the task lock.
+func (t *Task) configureCondition(cond config.ConditionConfig) tftmpl.Condition {
+	switch v := cond.(type) {
 	case *config.CatalogServicesConditionConfig:
-		condition = &tftmpl.CatalogServicesCondition{
+		return &tftmpl.CatalogServicesCondition{
 			CatalogServicesMonitor: tftmpl.CatalogServicesMonitor{
 				Regexp:     *v.Regexp,
 				Datacenter: *v.Datacenter,
 				Namespace:  *v.Namespace,
+				Partition:  *v.Partition,
+				Peer:       *v.Peer,
 				NodeMeta:   v.NodeMeta,
 			},
 			SourceIncludesVar: *v.SourceIncludesVar,
 		}
 	case *config.ServicesConditionConfig:
-		condition = &tftmpl.ServicesCondition{
+		return &tftmpl.ServicesCondition{
 			ServicesMonitor: tftmpl.ServicesMonitor{
-				Regexp: *v.Regexp,
+				Regexp:      *v.Regexp,
+				Datacenter:  *v.Datacenter,
+				Datacenters: v.Datacenters,
+				Namespace:   *v.Namespace,
+				Partition:   *v.Partition,
+				Peer:        *v.Peer,
+				NodeMeta:    v.NodeMeta,
+				Filter:      *v.Filter,
+
+				IncludeStatuses: t.includeStatuses,
+				Protocol:        t.servicesProtocol,
 			},
 			// always set services variable
 			SourceIncludesVar: true,
 		}
 	case *config.ConsulKVConditionConfig:
-		condition = &tftmpl.ConsulKVCondition{
+		return &tftmpl.ConsulKVCondition{
 			ConsulKVMonitor: tftmpl.ConsulKVMonitor{
 				Path:       *v.Path,
 				Datacenter: *v.Datacenter,
 				Recurse:    *v.Recurse,
 				Namespace:  *v.Namespace,
+				Decode:     *v.Decode,
+			},
+			SourceIncludesVar: *v.SourceIncludesVar,
+		}
+	case *config.NodesConditionConfig:
+		return &tftmpl.NodesCondition{
+			NodesMonitor: tftmpl.NodesMonitor{
+				Datacenter: *v.Datacenter,
+				Partition:  *v.Partition,
+				Peer:       *v.Peer,
+				NodeMeta:   v.NodeMeta,
+				Filter:     *v.Filter,
 			},
 			SourceIncludesVar: *v.SourceIncludesVar,
 		}
+	case *config.IntentionsConditionConfig:
+		return &tftmpl.IntentionsCondition{
+			IntentionsMonitor: tftmpl.IntentionsMonitor{
+				SourceRegexp:      *v.SourceRegexp,
+				DestinationRegexp: *v.DestinationRegexp,
+				Datacenter:        *v.Datacenter,
+				Namespace:         *v.Namespace,
+				Partition:         *v.Partition,
+			},
+			SourceIncludesVar: *v.SourceIncludesVar,
+		}
+	case *config.ConfigEntriesConditionConfig:
+		return &tftmpl.ConfigEntriesCondition{
+			ConfigEntriesMonitor: tftmpl.ConfigEntriesMonitor{
+				Kinds:      v.Kinds,
+				Regexp:     *v.Regexp,
+				Datacenter: *v.Datacenter,
+				Namespace:  *v.Namespace,
+				Partition:  *v.Partition,
+			},
+			SourceIncludesVar: *v.SourceIncludesVar,
+		}
+	case *config.PreparedQueryConditionConfig:
+		return &tftmpl.PreparedQueryCondition{
+			PreparedQueryMonitor: tftmpl.PreparedQueryMonitor{
+				Name:       *v.Name,
+				Datacenter: *v.Datacenter,
+				Near:       *v.Near,
+				Limit:      *v.Limit,
+
+				IncludeStatuses: t.includeStatuses,
+				Protocol:        t.servicesProtocol,
+			},
+		}
+	case *config.AnyConditionConfig:
+		return t.configureCompositeCondition(v.Conditions)
+	case *config.AllConditionConfig:
+		return t.configureCompositeCondition(v.Conditions)
 	case *config.ScheduleConditionConfig:
-		condition = &tftmpl.ServicesCondition{
+		return &tftmpl.ServicesCondition{
 			SourceIncludesVar: true,
 		}
+	case *config.WebhookConditionConfig:
+		return &tftmpl.WebhookCondition{
+			Schema: v.Schema,
+		}
+	case *config.ConsulEventConditionConfig:
+		return &tftmpl.ConsulEventCondition{
+			Name:       *v.Name,
+			Datacenter: *v.Datacenter,
+		}
+	case *config.VaultSecretConditionConfig:
+		return &tftmpl.VaultSecretCondition{
+			VaultSecretMonitor: tftmpl.VaultSecretMonitor{
+				Paths: v.Paths,
+				Data:  v.Data,
+			},
+		}
+	case *config.FileConditionConfig:
+		return &tftmpl.FileCondition{
+			FileMonitor: tftmpl.FileMonitor{
+				Paths:  v.Paths,
+				Format: *v.Format,
+			},
+		}
 	default:
 		// expected only for test scenarios
 		t.logger.Warn("task condition config unset. defaulting to services condition",
 			"task_name", t.name)
-		condition = &tftmpl.ServicesCondition{}
+		return &tftmpl.ServicesCondition{}
 	}
-	t.logger.Trace("condition configured", "source_input_type", fmt.Sprintf("%T", condition))
-	input.Condition = condition
+}
 
-	var sourceInput tftmpl.SourceInput
-	switch v := t.sourceInput.(type) {
+// configureCompositeCondition returns the module input templating for the
+// nested conditions of an any or all condition. Nested schedule conditions are
+// skipped since they do not monitor any dependencies.
+func (t *Task) configureCompositeCondition(conditions []config.ConditionConfig) tftmpl.Condition {
+	composite := &tftmpl.CompositeCondition{
+		Conditions: make([]tftmpl.Condition, 0, len(conditions)),
+	}
+	for _, nested := range conditions {
+		if _, ok := nested.(*config.ScheduleConditionConfig); ok {
+			continue
+		}
+		composite.Conditions = append(composite.Conditions, t.configureCondition(nested))
+	}
+	return composite
+}
+
+// configureSourceInput returns the module input templating for a source_input.
+// Expected to be called while holding the task lock.
+func (t *Task) configureSourceInput(si config.SourceInputConfig) tftmpl.SourceInput {
+	switch v := si.(type) {
 	case *config.ServicesSourceInputConfig:
-		sourceInput = &tftmpl.ServicesSourceInput{
+		return &tftmpl.ServicesSourceInput{
 			ServicesMonitor: tftmpl.ServicesMonitor{
-				Regexp: *v.Regexp,
+				Regexp:      *v.Regexp,
+				Datacenter:  *v.Datacenter,
+				Datacenters: v.Datacenters,
+				Namespace:   *v.Namespace,
+				Partition:   *v.Partition,
+				Peer:        *v.Peer,
+				NodeMeta:    v.NodeMeta,
+				Filter:      *v.Filter,
+
+				IncludeStatuses: t.includeStatuses,
+				Protocol:        t.servicesProtocol,
 			},
 		}
 	case *config.ConsulKVSourceInputConfig:
-		sourceInput = &tftmpl.ConsulKVSourceInput{
+		return &tftmpl.ConsulKVSourceInput{
 			ConsulKVMonitor: tftmpl.ConsulKVMonitor{
 				Path:       *v.Path,
 				Datacenter: *v.Datacenter,
 				Recurse:    *v.Recurse,
 				Namespace:  *v.Namespace,
+				Decode:     *v.Decode,
+			},
+		}
+	case *config.NodesSourceInputConfig:
+		return &tftmpl.NodesSourceInput{
+			NodesMonitor: tftmpl.NodesMonitor{
+				Datacenter: *v.Datacenter,
+				Partition:  *v.Partition,
+				Peer:       *v.Peer,
+				NodeMeta:   v.NodeMeta,
+				Filter:     *v.Filter,
+			},
+		}
+	case *config.IntentionsSourceInputConfig:
+		return &tftmpl.IntentionsSourceInput{
+			IntentionsMonitor: tftmpl.IntentionsMonitor{
+				SourceRegexp:      *v.SourceRegexp,
+				DestinationRegexp: *v.DestinationRegexp,
+				Datacenter:        *v.Datacenter,
+				Namespace:         *v.Namespace,
+				Partition:         *v.Partition,
+			},
+		}
+	case *config.ConfigEntriesSourceInputConfig:
+		return &tftmpl.ConfigEntriesSourceInput{
+			ConfigEntriesMonitor: tftmpl.ConfigEntriesMonitor{
+				Kinds:      v.Kinds,
+				Regexp:     *v.Regexp,
+				Datacenter: *v.Datacenter,
+				Namespace:  *v.Namespace,
+				Partition:  *v.Partition,
+			},
+		}
+	case *config.PreparedQuerySourceInputConfig:
+		return &tftmpl.PreparedQuerySourceInput{
+			PreparedQueryMonitor: tftmpl.PreparedQueryMonitor{
+				Name:       *v.Name,
+				Datacenter: *v.Datacenter,
+				Near:       *v.Near,
+				Limit:      *v.Limit,
+
+				IncludeStatuses: t.includeStatuses,
+				Protocol:        t.servicesProtocol,
+			},
+		}
+	case *config.VaultSecretSourceInputConfig:
+		return &tftmpl.VaultSecretSourceInput{
+			VaultSecretMonitor: tftmpl.VaultSecretMonitor{
+				Paths: v.Paths,
+				Data:  v.Data,
+			},
+		}
+	case *config.FileSourceInputConfig:
+		return &tftmpl.FileSourceInput{
+			FileMonitor: tftmpl.FileMonitor{
+				Paths:  v.Paths,
+				Format: *v.Format,
 			},
 		}
 	default:
 		// expected only for test scenarios
-		t.logger.Warn("task source_input config unset. defaulting to services source_input",
-			"task_name", t.name)
-		sourceInput = &tftmpl.ServicesSourceInput{}
-	}
-	t.logger.Trace("source_input configured", "source_input_type", fmt.Sprintf("%T", sourceInput))
-
-	input.SourceInput = sourceInput
-
-	input.Providers = t.providers.ProviderBlocks()
-	input.ProviderInfo = make(map[string]interface{})
-	for k, v := range t.providerInfo {
-		input.ProviderInfo[k] = v
-	}
-
-	input.Variables = make(hcltmpl.Variables)
-	for k, v := range t.variables {
-		input.Variables[k] = v
+		t.logger.Warn("unexpected task source_input config. skipping source_input",
+			"task_name", t.name, "source_input_type", fmt.Sprintf("%T", si))
+		return nil
 	}
 }
 
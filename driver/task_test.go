@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/client"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -122,3 +125,317 @@ func TestTask_ServiceNames(t *testing.T) {
 		})
 	}
 }
+
+func TestTask_IsScheduled(t *testing.T) {
+	t.Parallel()
+
+	schedule := &config.ScheduleConditionConfig{Cron: config.String("* * * * * * *")}
+	kv := &config.ConsulKVConditionConfig{}
+
+	cases := []struct {
+		name      string
+		condition config.ConditionConfig
+		expected  bool
+	}{
+		{
+			"services condition",
+			config.DefaultConditionConfig(),
+			false,
+		},
+		{
+			"schedule condition",
+			schedule,
+			true,
+		},
+		{
+			"all condition with schedule",
+			&config.AllConditionConfig{CompositeConditionConfig: config.CompositeConditionConfig{
+				Conditions: []config.ConditionConfig{schedule, kv},
+			}},
+			true,
+		},
+		{
+			"any condition",
+			&config.AnyConditionConfig{CompositeConditionConfig: config.CompositeConditionConfig{
+				Conditions: []config.ConditionConfig{kv},
+			}},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &Task{condition: tc.condition}
+			assert.Equal(t, tc.expected, task.IsScheduled())
+		})
+	}
+}
+
+func TestTask_IsWebhook(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		condition config.ConditionConfig
+		expected  bool
+	}{
+		{
+			"services condition",
+			config.DefaultConditionConfig(),
+			false,
+		},
+		{
+			"schedule condition",
+			&config.ScheduleConditionConfig{Cron: config.String("* * * * * * *")},
+			false,
+		},
+		{
+			"webhook condition",
+			&config.WebhookConditionConfig{},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &Task{condition: tc.condition}
+			assert.Equal(t, tc.expected, task.IsWebhook())
+		})
+	}
+}
+
+func TestTask_configureCondition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("composite", func(t *testing.T) {
+		task := &Task{}
+		actual := task.configureCondition(&config.AllConditionConfig{
+			CompositeConditionConfig: config.CompositeConditionConfig{
+				Conditions: []config.ConditionConfig{
+					&config.ScheduleConditionConfig{Cron: config.String("* * * * * * *")},
+					&config.ConsulKVConditionConfig{
+						ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+							Path:       config.String("deploy/enabled"),
+							Recurse:    config.Bool(false),
+							Datacenter: config.String(""),
+							Namespace:  config.String(""),
+							Decode:     config.String(""),
+						},
+						SourceIncludesVar: config.Bool(true),
+					},
+				},
+			},
+		})
+
+		// nested schedule condition is skipped
+		expected := &tftmpl.CompositeCondition{
+			Conditions: []tftmpl.Condition{
+				&tftmpl.ConsulKVCondition{
+					ConsulKVMonitor: tftmpl.ConsulKVMonitor{
+						Path: "deploy/enabled",
+					},
+					SourceIncludesVar: true,
+				},
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("webhook", func(t *testing.T) {
+		task := &Task{}
+		actual := task.configureCondition(&config.WebhookConditionConfig{
+			Secret: config.String("secret"),
+			Schema: map[string]string{"commit": "string"},
+		})
+
+		expected := &tftmpl.WebhookCondition{
+			Schema: map[string]string{"commit": "string"},
+		}
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("consul-event", func(t *testing.T) {
+		task := &Task{}
+		actual := task.configureCondition(&config.ConsulEventConditionConfig{
+			Name:       config.String("deploy"),
+			Datacenter: config.String("dc2"),
+		})
+
+		expected := &tftmpl.ConsulEventCondition{
+			Name:       "deploy",
+			Datacenter: "dc2",
+		}
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("prepared-query", func(t *testing.T) {
+		task := &Task{
+			includeStatuses:  []string{"passing"},
+			servicesProtocol: tftmpl.ServicesProtocolV1,
+		}
+		actual := task.configureCondition(&config.PreparedQueryConditionConfig{
+			PreparedQueryMonitorConfig: config.PreparedQueryMonitorConfig{
+				Name:       config.String("web-failover"),
+				Datacenter: config.String(""),
+				Near:       config.String("_agent"),
+				Limit:      config.Int(3),
+			},
+		})
+
+		expected := &tftmpl.PreparedQueryCondition{
+			PreparedQueryMonitor: tftmpl.PreparedQueryMonitor{
+				Name:            "web-failover",
+				Near:            "_agent",
+				Limit:           3,
+				IncludeStatuses: []string{"passing"},
+				Protocol:        tftmpl.ServicesProtocolV1,
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("vault-secret", func(t *testing.T) {
+		task := &Task{}
+		actual := task.configureCondition(&config.VaultSecretConditionConfig{
+			VaultSecretMonitorConfig: config.VaultSecretMonitorConfig{
+				Paths: []string{"pki/issue/web"},
+				Data:  map[string]string{"common_name": "web.example.com"},
+			},
+		})
+
+		expected := &tftmpl.VaultSecretCondition{
+			VaultSecretMonitor: tftmpl.VaultSecretMonitor{
+				Paths: []string{"pki/issue/web"},
+				Data:  map[string]string{"common_name": "web.example.com"},
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("file", func(t *testing.T) {
+		task := &Task{}
+		actual := task.configureCondition(&config.FileConditionConfig{
+			FileMonitorConfig: config.FileMonitorConfig{
+				Paths:  []string{"inventory/*.json"},
+				Format: config.String(""),
+			},
+		})
+
+		expected := &tftmpl.FileCondition{
+			FileMonitor: tftmpl.FileMonitor{
+				Paths: []string{"inventory/*.json"},
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestTask_configureSourceInput(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		si       config.SourceInputConfig
+		expected tftmpl.SourceInput
+	}{
+		{
+			"services",
+			&config.ServicesSourceInputConfig{
+				ServicesMonitorConfig: config.ServicesMonitorConfig{
+					Regexp:     config.String("^api$"),
+					Datacenter: config.String("dc2"),
+					Namespace:  config.String(""),
+					Partition:  config.String("ap1"),
+					Peer:       config.String("peer1"),
+					NodeMeta:   map[string]string{"rack": "a"},
+					Filter:     config.String("Service.Tags contains \"v1\""),
+				},
+			},
+			&tftmpl.ServicesSourceInput{
+				ServicesMonitor: tftmpl.ServicesMonitor{
+					Regexp:     "^api$",
+					Datacenter: "dc2",
+					Partition:  "ap1",
+					Peer:       "peer1",
+					NodeMeta:   map[string]string{"rack": "a"},
+					Filter:     "Service.Tags contains \"v1\"",
+				},
+			},
+		},
+		{
+			"consul-kv",
+			&config.ConsulKVSourceInputConfig{
+				ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+					Path:       config.String("key-path"),
+					Recurse:    config.Bool(true),
+					Datacenter: config.String("dc1"),
+					Namespace:  config.String(""),
+					Decode:     config.String("json"),
+				},
+			},
+			&tftmpl.ConsulKVSourceInput{
+				ConsulKVMonitor: tftmpl.ConsulKVMonitor{
+					Path:       "key-path",
+					Recurse:    true,
+					Datacenter: "dc1",
+					Decode:     "json",
+				},
+			},
+		},
+		{
+			"prepared-query",
+			&config.PreparedQuerySourceInputConfig{
+				PreparedQueryMonitorConfig: config.PreparedQueryMonitorConfig{
+					Name:       config.String("web-failover"),
+					Datacenter: config.String("dc2"),
+					Near:       config.String(""),
+					Limit:      config.Int(0),
+				},
+			},
+			&tftmpl.PreparedQuerySourceInput{
+				PreparedQueryMonitor: tftmpl.PreparedQueryMonitor{
+					Name:       "web-failover",
+					Datacenter: "dc2",
+				},
+			},
+		},
+		{
+			"vault-secret",
+			&config.VaultSecretSourceInputConfig{
+				VaultSecretMonitorConfig: config.VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app?version=2"},
+					Data:  map[string]string{},
+				},
+			},
+			&tftmpl.VaultSecretSourceInput{
+				VaultSecretMonitor: tftmpl.VaultSecretMonitor{
+					Paths: []string{"kv/data/app?version=2"},
+					Data:  map[string]string{},
+				},
+			},
+		},
+		{
+			"file",
+			&config.FileSourceInputConfig{
+				FileMonitorConfig: config.FileMonitorConfig{
+					Paths:  []string{"inventory/hosts"},
+					Format: config.String("yaml"),
+				},
+			},
+			&tftmpl.FileSourceInput{
+				FileMonitor: tftmpl.FileMonitor{
+					Paths:  []string{"inventory/hosts"},
+					Format: "yaml",
+				},
+			},
+		},
+		{
+			"unexpected",
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &Task{logger: logging.NewNullLogger()}
+			actual := task.configureSourceInput(tc.si)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
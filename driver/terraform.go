@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
@@ -55,6 +56,7 @@ type Terraform struct {
 
 	resolver   templates.Resolver
 	template   templates.Template
+	templateID atomic.Value // ID of template, accessible without locking mu
 	watcher    templates.Watcher
 	fileReader func(string) ([]byte, error)
 
@@ -157,6 +159,17 @@ func (tf *Terraform) Task() *Task {
 	return tf.task
 }
 
+// TemplateIDs returns the ID of the template for the task. Returns an empty
+// list if the task template has not been initialized. This does not lock the
+// driver so that it can be called while the task is running.
+func (tf *Terraform) TemplateIDs() []string {
+	id, ok := tf.templateID.Load().(string)
+	if !ok || id == "" {
+		return []string{}
+	}
+	return []string{id}
+}
+
 // InitTask initializes the task by creating the Terraform root module and related
 // files to execute on.
 func (tf *Terraform) InitTask(ctx context.Context) error {
@@ -248,6 +261,42 @@ func (tf *Terraform) ApplyTask(ctx context.Context) error {
 	return tf.applyTask(ctx)
 }
 
+// TriggerTask runs a task with a webhook condition for a webhook request. The
+// payload of the request is written to the root module as the webhook_payload
+// variable and the template is rendered with the latest dependency values
+// before applying the task. The task is applied regardless of whether the
+// dependencies changed since the payload is new.
+func (tf *Terraform) TriggerTask(ctx context.Context, payload []byte) error {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+	taskName := tf.task.Name()
+
+	if !tf.task.IsWebhook() {
+		return fmt.Errorf("task '%s' does not have a webhook condition", taskName)
+	}
+
+	if !tf.task.IsEnabled() {
+		return fmt.Errorf("task '%s' is disabled", taskName)
+	}
+
+	tf.logger.Trace("writing webhook payload", taskNameLogKey, taskName)
+	if err := tftmpl.WriteWebhookPayload(tf.task.WorkingDir(), payload, filePerms); err != nil {
+		return fmt.Errorf("error writing webhook payload for task '%s': %s",
+			taskName, err)
+	}
+
+	re, err := tf.renderTemplate(ctx)
+	if err != nil {
+		return err
+	}
+	if !re.Complete {
+		return fmt.Errorf("task '%s' cannot be triggered until its dependencies "+
+			"are fetched from Consul. try again later", taskName)
+	}
+
+	return tf.applyTask(ctx)
+}
+
 // InspectPlan stores return the information about what
 type InspectPlan struct {
 	ChangesPresent bool   `json:"changes_present"`
@@ -369,17 +418,13 @@ func (tf *Terraform) initTask(ctx context.Context) error {
 	}
 
 	// convert relative paths to absolute paths for local module sources
-	moduleSource := tf.task.source
-	if strings.HasPrefix(moduleSource, "./") || strings.HasPrefix(moduleSource, "../") {
-		wd, err := os.Getwd()
-		if err != nil {
-			tf.logger.Error("unable to retrieve current working directory to determine path to local module",
-				"error", err)
-			return err
-		}
-		moduleSource = filepath.Join(wd, tf.task.source)
-		tf.task.source = moduleSource
+	moduleSource, err := absModuleSource(tf.task.source)
+	if err != nil {
+		tf.logger.Error("unable to retrieve current working directory to determine path to local module",
+			"error", err)
+		return err
 	}
+	tf.task.source = moduleSource
 
 	tf.task.configureRootModuleInput(&input)
 	if err := tftmpl.InitRootModule(&input); err != nil {
@@ -416,6 +461,13 @@ func (tf *Terraform) renderTemplate(ctx context.Context) (hcat.ResolveEvent, err
 
 	// log the task name with each log
 	tnlog := tf.logger.With(taskNameLogKey, taskName)
+
+	// mark the dependency changes that triggered the task before rendering,
+	// so that a change received while rendering is not committed by the run
+	if n, ok := tf.template.(notifier.RunStateNotifier); ok {
+		n.Rendered()
+	}
+
 	result, err := tf.resolver.Run(tf.template, tf.watcher)
 	if err != nil {
 		tnlog.Error("error checking dependency changes for task", "error", err)
@@ -495,6 +547,12 @@ func (tf *Terraform) applyTask(ctx context.Context) error {
 		}
 	}
 
+	// commit the state of the dependency changes that triggered the task
+	// only once the task has been applied successfully
+	if n, ok := tf.template.(notifier.RunStateNotifier); ok {
+		n.Applied()
+	}
+
 	return nil
 }
 
@@ -516,16 +574,11 @@ func (tf *Terraform) initTaskTemplate() error {
 		Perms: filePerms,
 	})
 
-	metaMap := make(tmplfunc.ServicesMeta)
 	services := tf.task.Services()
-	for _, s := range services {
-		metaMap[s.Name] = s.UserDefinedMeta
-	}
-
 	tmpl := hcat.NewTemplate(hcat.TemplateInput{
 		Contents:     string(content),
 		Renderer:     renderer,
-		FuncMapMerge: tmplfunc.HCLMap(metaMap),
+		FuncMapMerge: tmplfunc.HCLMap(tf.task.servicesMeta()),
 	})
 
 	if tf.template != nil {
@@ -543,6 +596,7 @@ func (tf *Terraform) initTaskTemplate() error {
 	}
 
 	tf.setNotifier(tmpl, len(services))
+	tf.templateID.Store(tf.template.ID())
 
 	if !tf.watcher.Watching(tf.template.ID()) {
 		err = tf.watcher.Register(tf.template)
@@ -556,25 +610,165 @@ func (tf *Terraform) initTaskTemplate() error {
 }
 
 func (tf *Terraform) setNotifier(tmpl templates.Template, serviceCount int) {
-	switch tf.task.Condition().(type) {
+	// source_input dependencies only provide data to the task. They are counted
+	// for once-mode but the notifiers suppress notifications on their changes.
+	sourceInputCount := sourceInputDependencyCount(tf.task.SourceInputs())
+	depCount := serviceCount + sourceInputCount
+
+	switch v := tf.task.Condition().(type) {
+	case *config.ServicesConditionConfig:
+		includeStatuses, triggerOn := tf.task.IncludeStatuses(), tf.task.TriggerOn()
+		if sourceInputCount == 0 && len(includeStatuses) == 0 && len(triggerOn) == 0 {
+			// all dependencies are services, so every change notifies
+			tf.template = tmpl
+			return
+		}
+		if config.StringVal(v.Regexp) != "" {
+			depCount++
+		}
+		tf.template = notifier.NewServices(tmpl, depCount, includeStatuses, triggerOn)
+	case *config.PreparedQueryConditionConfig:
+		// the prepared query result is watched as one dependency
+		tf.template = notifier.NewServices(tmpl, depCount+1,
+			tf.task.IncludeStatuses(), tf.task.TriggerOn())
 	case *config.CatalogServicesConditionConfig:
-		tf.template = notifier.NewCatalogServicesRegistration(tmpl, serviceCount)
+		tf.template = notifier.NewCatalogServicesRegistration(tmpl, depCount)
 	case *config.ConsulKVConditionConfig:
-		tf.template = notifier.NewConsulKV(tmpl, serviceCount)
-	case *config.ScheduleConditionConfig:
-		additionalDepCount := 0
-		switch tf.task.SourceInput().(type) {
-		case *config.ConsulKVSourceInputConfig:
-			// If a ConsulKVSourceInputConfig is specified, then we need to add
-			// to the number of dependencies passed to the notifier, since consul-kv adds a dependency
-			additionalDepCount = 1
-		}
-		tf.template = notifier.NewSuppressNotification(tmpl, serviceCount+additionalDepCount)
+		tf.template = notifier.NewConsulKV(tmpl, depCount, v.Value)
+	case *config.NodesConditionConfig:
+		tf.template = notifier.NewNodes(tmpl, depCount)
+	case *config.IntentionsConditionConfig:
+		tf.template = notifier.NewIntentions(tmpl, depCount)
+	case *config.ConfigEntriesConditionConfig:
+		tf.template = notifier.NewConfigEntries(tmpl, depCount, len(v.Kinds))
+	case *config.ConsulEventConditionConfig:
+		tf.template = notifier.NewConsulEvent(tmpl, depCount, tf.task.WorkingDir())
+	case *config.VaultSecretConditionConfig:
+		tf.template = notifier.NewVaultSecret(tmpl, depCount, len(v.Paths))
+	case *config.FileConditionConfig:
+		tf.template = notifier.NewFile(tmpl, depCount)
+	case *config.ScheduleConditionConfig, *config.WebhookConditionConfig:
+		// scheduled and webhook tasks are not triggered by dependency changes
+		tf.template = notifier.NewSuppressNotification(tmpl, depCount)
+	case *config.AnyConditionConfig:
+		tf.template = compositeNotifier(tmpl, v.Conditions, false, depCount, tf.task)
+	case *config.AllConditionConfig:
+		tf.template = compositeNotifier(tmpl, v.Conditions, true, depCount, tf.task)
 	default:
 		tf.template = tmpl
 	}
 }
 
+// sourceInputDependencyCount returns the number of dependencies that the
+// source_inputs add to the template
+func sourceInputDependencyCount(sourceInputs config.SourceInputConfigs) int {
+	count := 0
+	for _, si := range sourceInputs {
+		switch v := si.(type) {
+		case *config.ServicesSourceInputConfig:
+			// A services source_input with a regexp watches the services
+			// matching the regexp as one dependency
+			if config.StringVal(v.Regexp) != "" {
+				count++
+			}
+		case *config.ConsulKVSourceInputConfig, *config.NodesSourceInputConfig,
+			*config.IntentionsSourceInputConfig, *config.PreparedQuerySourceInputConfig,
+			*config.FileSourceInputConfig:
+			// Each consul-kv, nodes, intentions, prepared-query, or file
+			// source_input adds a dependency
+			count++
+		case *config.ConfigEntriesSourceInputConfig:
+			// A config-entries source_input adds a dependency per kind
+			count += len(v.Kinds)
+		case *config.VaultSecretSourceInputConfig:
+			// A vault-secret source_input adds a dependency per path
+			count += len(v.Paths)
+		}
+	}
+	return count
+}
+
+// compositeNotifier creates the notifier for an any or all condition, which
+// combines the notifiers of the nested conditions. The baseCount is the number
+// of dependencies of the services and source_inputs of the task. The task
+// configures the nested services and consul-event notifiers.
+func compositeNotifier(tmpl templates.Template, conditions []config.ConditionConfig,
+	all bool, baseCount int, task *Task) templates.Template {
+
+	// count the dependencies that each nested condition adds to the base
+	depCount := baseCount
+	scheduled := false
+	for _, nested := range conditions {
+		switch v := nested.(type) {
+		case *config.ScheduleConditionConfig:
+			scheduled = true
+		case *config.ServicesConditionConfig:
+			if config.StringVal(v.Regexp) != "" {
+				depCount++
+			}
+		case *config.ConfigEntriesConditionConfig:
+			depCount += len(v.Kinds)
+		case *config.VaultSecretConditionConfig:
+			depCount += len(v.Paths)
+		default:
+			depCount++
+		}
+	}
+
+	var funcs []notifier.ConditionNotifierFunc
+	for _, nested := range conditions {
+		var f notifier.ConditionNotifierFunc
+		switch v := nested.(type) {
+		case *config.ServicesConditionConfig, *config.PreparedQueryConditionConfig:
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewServices(t, depCount, task.IncludeStatuses(),
+					task.TriggerOn())
+			}
+		case *config.CatalogServicesConditionConfig:
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewCatalogServicesRegistration(t, baseCount)
+			}
+		case *config.ConsulKVConditionConfig:
+			value := v.Value
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewConsulKV(t, baseCount, value)
+			}
+		case *config.NodesConditionConfig:
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewNodes(t, baseCount)
+			}
+		case *config.IntentionsConditionConfig:
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewIntentions(t, baseCount)
+			}
+		case *config.ConfigEntriesConditionConfig:
+			kindCount := len(v.Kinds)
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewConfigEntries(t, baseCount, kindCount)
+			}
+		case *config.ConsulEventConditionConfig:
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewConsulEvent(t, baseCount, task.WorkingDir())
+			}
+		case *config.VaultSecretConditionConfig:
+			pathCount := len(v.Paths)
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewVaultSecret(t, baseCount, pathCount)
+			}
+		case *config.FileConditionConfig:
+			f = func(t templates.Template) templates.Template {
+				return notifier.NewFile(t, baseCount)
+			}
+		default:
+			// schedule conditions do not watch dependencies
+			continue
+		}
+		funcs = append(funcs, f)
+	}
+
+	return notifier.NewComposite(tmpl, all, scheduled, depCount, funcs...)
+}
+
 func (tf *Terraform) validateTask(ctx context.Context) error {
 	err := tf.client.Validate(ctx)
 	if err != nil {
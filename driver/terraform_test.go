@@ -3,18 +3,26 @@ package driver
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/handler"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
 	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 	"github.com/hashicorp/consul-terraform-sync/testutils"
 	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -105,6 +113,93 @@ func TestRenderTemplate(t *testing.T) {
 	}
 }
 
+func TestRenderTemplate_ConsulKVValue(t *testing.T) {
+	t.Parallel()
+
+	// Task runs on schedule only while the deploy/enabled key is true
+	task := &Task{
+		name:    "RenderTemplateConsulKVValueTest",
+		enabled: true,
+		logger:  logging.NewNullLogger(),
+		condition: &config.AllConditionConfig{CompositeConditionConfig: config.CompositeConditionConfig{
+			Conditions: []config.ConditionConfig{
+				&config.ScheduleConditionConfig{Cron: config.String("* * * * *")},
+				&config.ConsulKVConditionConfig{
+					ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+						Path:    config.String("deploy/enabled"),
+						Recurse: config.Bool(false),
+					},
+					Value: config.String("true"),
+				},
+			},
+		}},
+	}
+
+	w := new(mocksTmpl.Watcher)
+	w.On("Buffer", mock.Anything).Return(false)
+	w.On("Recaller", mock.Anything).Return(hcat.Recaller(nil))
+	w.On("Complete", mock.Anything).Return(true)
+	w.On("Mark", mock.Anything).Return()
+	w.On("Sweep", mock.Anything).Return()
+
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents: "services = {}",
+		Renderer: hcat.NewFileRenderer(hcat.FileRendererInput{
+			Path: filepath.Join(t.TempDir(), tftmpl.TFVarsFilename),
+		}),
+	})
+	tf := &Terraform{
+		mu:       &sync.RWMutex{},
+		task:     task,
+		resolver: hcat.NewResolver(),
+		watcher:  w,
+		logger:   logging.NewNullLogger(),
+	}
+	tf.setNotifier(tmpl, 1)
+
+	ctx := context.Background()
+	enabled := &dep.KeyPair{Key: "deploy/enabled", Value: "true", Exists: true}
+	disabled := &dep.KeyPair{Key: "deploy/enabled", Value: "false", Exists: true}
+
+	// once-mode renders regardless of the key
+	tf.template.Notify([]*dep.HealthService{})
+	tf.template.Notify(disabled)
+	rendered, err := tf.RenderTemplate(ctx)
+	require.NoError(t, err)
+	assert.True(t, rendered, "once-mode should have rendered")
+
+	// scheduled run with services changes while disabled
+	tf.template.Notify([]*dep.HealthService{})
+	rendered, err = tf.RenderTemplate(ctx)
+	require.NoError(t, err)
+	assert.False(t, rendered, "disabled task should not have rendered")
+
+	// scheduled run after enabling
+	tf.template.Notify(enabled)
+	rendered, err = tf.RenderTemplate(ctx)
+	require.NoError(t, err)
+	assert.True(t, rendered, "enabled task should have rendered")
+
+	// scheduled run with services changes while enabled
+	tf.template.Notify([]*dep.HealthService{})
+	rendered, err = tf.RenderTemplate(ctx)
+	require.NoError(t, err)
+	assert.True(t, rendered, "enabled task should have rendered")
+
+	// scheduled run with services changes while enabled, disabled before
+	// the run
+	tf.template.Notify([]*dep.HealthService{})
+	tf.template.Notify(disabled)
+	rendered, err = tf.RenderTemplate(ctx)
+	require.NoError(t, err)
+	assert.False(t, rendered, "disabled task should not have rendered")
+
+	// scheduled run without changes
+	rendered, err = tf.RenderTemplate(ctx)
+	require.NoError(t, err)
+	assert.False(t, rendered, "task without changes should not have rendered")
+}
+
 func TestApplyTask(t *testing.T) {
 	t.Parallel()
 
@@ -163,6 +258,191 @@ func TestApplyTask(t *testing.T) {
 	}
 }
 
+func TestApplyTask_ConsulEventState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	events := []*tmplfunc.ConsulEvent{{ID: "e1", LTime: 1}}
+	statePath := func(dir string) string {
+		return filepath.Join(dir, notifier.ConsulEventStateFilename)
+	}
+
+	cases := []struct {
+		name        string
+		applyReturn error
+		stored      bool
+	}{
+		{
+			"event is stored after successful apply",
+			nil,
+			true,
+		},
+		{
+			"event is not stored after failed apply",
+			errors.New("apply error"),
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			c := new(mocks.Client)
+			c.On("Apply", ctx).Return(tc.applyReturn).Once()
+
+			tmpl := new(mocksTmpl.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+			n := notifier.NewConsulEvent(tmpl, 0, dir)
+			n.Notify(events)
+			n.Rendered()
+
+			tf := &Terraform{
+				mu:       &sync.RWMutex{},
+				task:     &Task{name: "ApplyTaskTest", enabled: true, logger: logging.NewNullLogger()},
+				client:   c,
+				template: n,
+				logger:   logging.NewNullLogger(),
+			}
+			err := tf.ApplyTask(ctx)
+			assert.Equal(t, tc.applyReturn == nil, err == nil)
+
+			_, err = os.Stat(statePath(dir))
+			assert.Equal(t, tc.stored, err == nil)
+
+			// a restarted notifier is only triggered again by the event if
+			// the task failed to apply
+			restarted := notifier.NewConsulEvent(tmpl, 0, dir)
+			restarted.Notify([]*dep.HealthService{})
+			assert.Equal(t, !tc.stored, restarted.Notify(events))
+		})
+	}
+}
+
+func TestTriggerTask(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		condition   config.ConditionConfig
+		enabled     bool
+		payload     string
+		runResult   hcat.ResolveEvent
+		applyReturn error
+		expectErr   string
+	}{
+		{
+			"happy path",
+			&config.WebhookConditionConfig{},
+			true,
+			`{"commit":"abc123"}`,
+			hcat.ResolveEvent{Complete: true},
+			nil,
+			"",
+		},
+		{
+			"happy path: no dependency changes",
+			&config.WebhookConditionConfig{},
+			true,
+			`{"commit":"abc123"}`,
+			hcat.ResolveEvent{Complete: true, NoChange: true},
+			nil,
+			"",
+		},
+		{
+			"not a webhook task",
+			&config.ScheduleConditionConfig{},
+			true,
+			`{}`,
+			hcat.ResolveEvent{Complete: true},
+			nil,
+			"does not have a webhook condition",
+		},
+		{
+			"disabled task",
+			&config.WebhookConditionConfig{},
+			false,
+			`{}`,
+			hcat.ResolveEvent{Complete: true},
+			nil,
+			"is disabled",
+		},
+		{
+			"invalid payload",
+			&config.WebhookConditionConfig{},
+			true,
+			`{"commit":`,
+			hcat.ResolveEvent{Complete: true},
+			nil,
+			"error writing webhook payload",
+		},
+		{
+			"dependencies not fetched",
+			&config.WebhookConditionConfig{},
+			true,
+			`{}`,
+			hcat.ResolveEvent{Complete: false},
+			nil,
+			"cannot be triggered until its dependencies are fetched",
+		},
+		{
+			"error on apply",
+			&config.WebhookConditionConfig{},
+			true,
+			`{}`,
+			hcat.ResolveEvent{Complete: true},
+			errors.New("apply error"),
+			"apply error",
+		},
+	}
+	ctx := context.Background()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workingDir := "trigger-task-test-" + strings.ReplaceAll(tc.name, " ", "-")
+			deleteTemp := testutils.MakeTempDir(t, workingDir)
+			defer deleteTemp()
+
+			r := new(mocksTmpl.Resolver)
+			r.On("Run", mock.Anything, mock.Anything).Return(tc.runResult, nil)
+
+			tmpl := new(mocksTmpl.Template)
+			tmpl.On("Render", mock.Anything).Return(hcat.RenderResult{}, nil)
+
+			c := new(mocks.Client)
+			c.On("Apply", ctx).Return(tc.applyReturn)
+
+			tf := &Terraform{
+				mu: &sync.RWMutex{},
+				task: &Task{
+					name:       "TriggerTaskTest",
+					enabled:    tc.enabled,
+					condition:  tc.condition,
+					workingDir: workingDir,
+					logger:     logging.NewNullLogger(),
+				},
+				resolver: r,
+				template: tmpl,
+				watcher:  new(mocksTmpl.Watcher),
+				client:   c,
+				logger:   logging.NewNullLogger(),
+			}
+
+			err := tf.TriggerTask(ctx, []byte(tc.payload))
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			c.AssertExpectations(t)
+
+			content, err := ioutil.ReadFile(
+				filepath.Join(workingDir, tftmpl.WebhookPayloadFilename))
+			require.NoError(t, err)
+			assert.Contains(t, string(content), `"commit": "abc123"`)
+		})
+	}
+}
+
 func TestUpdateTask(t *testing.T) {
 	t.Parallel()
 
@@ -765,3 +1045,225 @@ func testHandler(err bool) handler.Handler {
 	h, _ := handler.NewFake(config)
 	return h
 }
+
+func TestSetNotifier(t *testing.T) {
+	t.Parallel()
+
+	kvSourceInput := config.SourceInputConfigs{
+		&config.ConsulKVSourceInputConfig{
+			ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+				Path: config.String("key-path"),
+			},
+		},
+	}
+
+	t.Run("services condition", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tf := &Terraform{task: &Task{
+			condition: &config.ServicesConditionConfig{},
+		}}
+		tf.setNotifier(tmpl, 1)
+		assert.Equal(t, tmpl, tf.template)
+	})
+
+	t.Run("services condition with source_input", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		tf := &Terraform{task: &Task{
+			condition:    &config.ServicesConditionConfig{},
+			sourceInputs: kvSourceInput,
+		}}
+		tf.setNotifier(tmpl, 1)
+		require.IsType(t, &notifier.Services{}, tf.template)
+
+		// complete once-mode with the service and source_input dependencies
+		assert.True(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.True(t, tf.template.Notify(&dep.KeyPair{}))
+
+		// source_input changes do not trigger the task
+		assert.False(t, tf.template.Notify(&dep.KeyPair{}))
+		assert.True(t, tf.template.Notify([]*dep.HealthService{}))
+	})
+
+	t.Run("services condition with trigger_on", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		tf := &Terraform{task: &Task{
+			condition:       &config.ServicesConditionConfig{},
+			includeStatuses: []string{"passing"},
+			triggerOn:       []string{"membership"},
+		}}
+		tf.setNotifier(tmpl, 1)
+		require.IsType(t, &notifier.Services{}, tf.template)
+
+		web := &dep.HealthService{ID: "web-1", Name: "web", Status: "passing"}
+		assert.True(t, tf.template.Notify([]*dep.HealthService{web}))
+
+		// instances that are not included do not trigger the task
+		critical := &dep.HealthService{ID: "web-2", Name: "web", Status: "critical"}
+		assert.False(t, tf.template.Notify([]*dep.HealthService{web, critical}))
+	})
+
+	t.Run("prepared-query condition with source_input", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		tf := &Terraform{task: &Task{
+			condition:    &config.PreparedQueryConditionConfig{},
+			sourceInputs: kvSourceInput,
+		}}
+		tf.setNotifier(tmpl, 0)
+		require.IsType(t, &notifier.Services{}, tf.template)
+
+		// complete once-mode with the prepared query and source_input
+		// dependencies
+		assert.False(t, tf.template.Notify(&dep.KeyPair{}))
+		assert.True(t, tf.template.Notify([]*dep.HealthService{}))
+
+		// changes to the prepared query result trigger the task
+		assert.False(t, tf.template.Notify(&dep.KeyPair{}))
+		assert.True(t, tf.template.Notify([]*dep.HealthService{}))
+	})
+
+	t.Run("vault-secret condition with source_input", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		tf := &Terraform{task: &Task{
+			condition: &config.VaultSecretConditionConfig{
+				VaultSecretMonitorConfig: config.VaultSecretMonitorConfig{
+					Paths: []string{"kv/data/app", "kv/data/db"},
+				},
+			},
+			sourceInputs: kvSourceInput,
+		}}
+		tf.setNotifier(tmpl, 1)
+		require.IsType(t, &notifier.VaultSecret{}, tf.template)
+
+		// complete once-mode with the service, source_input, and secret
+		// dependencies
+		assert.False(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.False(t, tf.template.Notify(&dep.KeyPair{}))
+		assert.True(t, tf.template.Notify(&dep.Secret{}))
+		assert.True(t, tf.template.Notify(&dep.Secret{}))
+
+		// only secret changes trigger the task
+		assert.False(t, tf.template.Notify(&dep.KeyPair{}))
+		assert.False(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.True(t, tf.template.Notify(&dep.Secret{}))
+	})
+
+	t.Run("services condition with vault-secret source_input", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		tf := &Terraform{task: &Task{
+			condition: &config.ServicesConditionConfig{},
+			sourceInputs: config.SourceInputConfigs{
+				&config.VaultSecretSourceInputConfig{
+					VaultSecretMonitorConfig: config.VaultSecretMonitorConfig{
+						Paths: []string{"kv/data/app"},
+					},
+				},
+			},
+		}}
+		tf.setNotifier(tmpl, 1)
+		require.IsType(t, &notifier.Services{}, tf.template)
+
+		// complete once-mode with the service and secret dependencies
+		assert.True(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.True(t, tf.template.Notify(&dep.Secret{}))
+
+		// secret changes of a source_input do not trigger the task
+		assert.False(t, tf.template.Notify(&dep.Secret{}))
+	})
+
+	t.Run("file condition with source_input", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		tf := &Terraform{task: &Task{
+			condition: &config.FileConditionConfig{
+				FileMonitorConfig: config.FileMonitorConfig{
+					Paths: []string{"inventory"},
+				},
+			},
+			sourceInputs: kvSourceInput,
+		}}
+		tf.setNotifier(tmpl, 1)
+		require.IsType(t, &notifier.File{}, tf.template)
+
+		// complete once-mode with the service, source_input, and local files
+		// dependencies
+		assert.False(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.False(t, tf.template.Notify(&dep.KeyPair{}))
+		assert.True(t, tf.template.Notify([]*tmplfunc.LocalFile{}))
+
+		// only changes to the local files trigger the task
+		assert.False(t, tf.template.Notify(&dep.KeyPair{}))
+		assert.False(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.True(t, tf.template.Notify([]*tmplfunc.LocalFile{}))
+	})
+
+	t.Run("services condition with file source_input", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		tf := &Terraform{task: &Task{
+			condition: &config.ServicesConditionConfig{},
+			sourceInputs: config.SourceInputConfigs{
+				&config.FileSourceInputConfig{
+					FileMonitorConfig: config.FileMonitorConfig{
+						Paths: []string{"inventory"},
+					},
+				},
+			},
+		}}
+		tf.setNotifier(tmpl, 1)
+		require.IsType(t, &notifier.Services{}, tf.template)
+
+		// complete once-mode with the service and local files dependencies
+		assert.True(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.True(t, tf.template.Notify([]*tmplfunc.LocalFile{}))
+
+		// changes to the local files of a source_input do not trigger the task
+		assert.False(t, tf.template.Notify([]*tmplfunc.LocalFile{}))
+	})
+
+	t.Run("schedule condition with source_input", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tf := &Terraform{task: &Task{
+			condition:    &config.ScheduleConditionConfig{},
+			sourceInputs: kvSourceInput,
+		}}
+		tf.setNotifier(tmpl, 0)
+		assert.IsType(t, &notifier.SuppressNotification{}, tf.template)
+	})
+	t.Run("webhook condition", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tf := &Terraform{task: &Task{
+			condition: &config.WebhookConditionConfig{},
+		}}
+		tf.setNotifier(tmpl, 1)
+		assert.IsType(t, &notifier.SuppressNotification{}, tf.template)
+	})
+	t.Run("consul-event condition", func(t *testing.T) {
+		tmpl := new(mocksTmpl.Template)
+		tmpl.On("Notify", mock.Anything).Return(true)
+		dir := t.TempDir()
+		tf := &Terraform{task: &Task{
+			condition:  &config.ConsulEventConditionConfig{},
+			workingDir: dir,
+		}}
+		tf.setNotifier(tmpl, 1)
+		require.IsType(t, &notifier.ConsulEvent{}, tf.template)
+
+		// new events trigger the task and the last event is stored in the
+		// working directory of the task once the task is applied
+		events := []*tmplfunc.ConsulEvent{{ID: "e1", LTime: 1}}
+		assert.True(t, tf.template.Notify(events))
+		assert.True(t, tf.template.Notify([]*dep.HealthService{}))
+		assert.False(t, tf.template.Notify(events))
+		assert.NoFileExists(t, filepath.Join(dir, notifier.ConsulEventStateFilename))
+
+		n := tf.template.(*notifier.ConsulEvent)
+		n.Rendered()
+		n.Applied()
+		assert.FileExists(t, filepath.Join(dir, notifier.ConsulEventStateFilename))
+	})
+}
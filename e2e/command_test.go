@@ -5,8 +5,13 @@
 package e2e
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -572,6 +577,72 @@ func TestE2E_DisableTaskCommand(t *testing.T) {
 	}
 }
 
+// TestE2E_TaskStatusCommands tests the CLI to list tasks and view the status
+// of tasks. This test starts up a local Consul server and runs CTS in dev mode.
+func TestE2E_TaskStatusCommands(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestConsulServer(t)
+	defer srv.Stop()
+
+	tempDir := fmt.Sprintf("%s%s", tempDirPrefix, "status_cmd")
+	cts := ctsSetup(t, srv, tempDir, dbTask())
+	port := fmt.Sprintf("-%s=%d", command.FlagPort, cts.Port())
+
+	cases := []struct {
+		name           string
+		subcmd         []string
+		outputContains []string
+	}{
+		{
+			"list",
+			[]string{"task", "list", port},
+			[]string{"NAME", "LAST RUN", dbTaskName, "successful", "Total: 1"},
+		},
+		{
+			"list json",
+			[]string{"task", "list", port, "-format=json"},
+			[]string{fmt.Sprintf(`"task_name": "%s"`, dbTaskName)},
+		},
+		{
+			"list status filter",
+			[]string{"task", "list", port, "-status=critical"},
+			[]string{"No tasks found", "Total: 0", "Enabled: 0, Disabled: 0"},
+		},
+		{
+			"status",
+			[]string{"task", "status", port, "-include-events", dbTaskName},
+			[]string{"Task Name:", dbTaskName, "Events:", "START TIME"},
+		},
+		{
+			"status json",
+			[]string{"task", "status", port, "-format=json", "-include-events", dbTaskName},
+			[]string{`"events": [`},
+		},
+		{
+			"help flag",
+			[]string{"task", "status", "-help"},
+			[]string{"consul-terraform-sync task status [options] [task name]"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := runSubcommand(t, "", tc.subcmd...)
+			assert.NoError(t, err)
+			for _, s := range tc.outputContains {
+				assert.Contains(t, output, s)
+			}
+		})
+	}
+
+	t.Run("non-existing task", func(t *testing.T) {
+		output, err := runSubcommand(t, "", "task", "status", port, "non-existent-task")
+		assert.Error(t, err)
+		assert.Contains(t, output, "does not exist")
+	})
+}
+
 // TestE2E_ReenableTaskTriggers specifically tests the case where an enabled task
 // is disabled and then re-enabled. It confirms that the task triggered as
 // expected once re-enabled.
@@ -618,6 +689,128 @@ func TestE2E_ReenableTaskTriggers(t *testing.T) {
 		"event count did not increment once. task was not triggered as expected")
 }
 
+// TestE2E_DebugCommand tests capturing a debug bundle from a running CTS
+func TestE2E_DebugCommand(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestConsulServer(t)
+	defer srv.Stop()
+
+	tempDir := fmt.Sprintf("%s%s", tempDirPrefix, "debug_cmd")
+	cts := ctsSetup(t, srv, tempDir, dbTask())
+	port := fmt.Sprintf("-%s=%d", command.FlagPort, cts.Port())
+
+	bundlePath := filepath.Join(tempDir, "bundle.tar.gz")
+	output, err := runSubcommand(t, "", "debug", port, "-duration=2s",
+		"-interval=1s", fmt.Sprintf("-output=%s", bundlePath))
+	require.NoError(t, err, output)
+	assert.Contains(t, output, "Saved debug bundle")
+	assert.NotContains(t, output, "Warning")
+
+	f, err := os.Open(bundlePath)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var files []string
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files = append(files, strings.TrimPrefix(hdr.Name, "bundle/"))
+	}
+
+	for _, f := range []string{"index.json", "config.txt",
+		fmt.Sprintf("tasks/%s/main.tf", dbTaskName),
+		fmt.Sprintf("tasks/%s/terraform.tfvars", dbTaskName)} {
+		assert.Contains(t, files, f)
+	}
+	var profiles int
+	for _, f := range files {
+		if strings.HasSuffix(f, "/goroutine.prof") {
+			profiles++
+		}
+	}
+	assert.GreaterOrEqual(t, profiles, 2, "expected a capture at each interval")
+}
+
+// TestE2E_ConfigValidateCommand tests validating configuration files offline
+// with the config validate command
+func TestE2E_ConfigValidateCommand(t *testing.T) {
+	t.Parallel()
+
+	tempDir := fmt.Sprintf("%s%s", tempDirPrefix, "config_validate_cmd")
+	delete := testutils.MakeTempDir(t, tempDir)
+
+	validPath := filepath.Join(tempDir, "valid.hcl")
+	baseConfig(tempDir).appendDBTask().write(t, validPath)
+
+	invalidPath := filepath.Join(tempDir, "invalid.hcl")
+	baseConfig(tempDir).appendModuleTask("missing_module", "./test_modules/dne",
+		`providers = ["local.dne"]`).write(t, invalidPath)
+
+	output, err := runSubcommand(t, "", "config", "validate",
+		fmt.Sprintf("-config-file=%s", validPath))
+	assert.NoError(t, err, output)
+	assert.Contains(t, output, "Configuration is valid")
+
+	output, err = runSubcommand(t, "", "config", "validate",
+		fmt.Sprintf("-config-file=%s", invalidPath))
+	assert.Error(t, err)
+	assert.Contains(t, output, "found 2 error(s)")
+	assert.Contains(t, output, fmt.Sprintf(`%s:25: task "missing_module": `+
+		`provider "local.dne" is not configured`, invalidPath))
+	assert.Contains(t, output, `local module source "./test_modules/dne" does not exist`)
+
+	delete()
+}
+
+// TestE2E_GenerateCommand tests generating the root module of a task offline
+// with fixture data
+func TestE2E_GenerateCommand(t *testing.T) {
+	t.Parallel()
+
+	tempDir := fmt.Sprintf("%s%s", tempDirPrefix, "generate_cmd")
+	delete := testutils.MakeTempDir(t, tempDir)
+
+	configPath := filepath.Join(tempDir, configFile)
+	baseConfig(tempDir).appendDBTask().write(t, configPath)
+
+	fixturePath := filepath.Join(tempDir, "fixture.json")
+	fixture := `{"services": [
+  {"Node": "node", "ID": "api-1", "Name": "api", "Address": "10.0.0.1", "Port": 80},
+  {"Node": "node", "ID": "db-1", "Name": "db", "Address": "10.0.0.2", "Port": 5432}
+]}`
+	err := ioutil.WriteFile(fixturePath, []byte(fixture), 0644)
+	require.NoError(t, err)
+
+	outDir := filepath.Join(tempDir, dbTaskName)
+	output, err := runSubcommand(t, "", "generate",
+		fmt.Sprintf("-config-file=%s", configPath),
+		fmt.Sprintf("-task=%s", dbTaskName),
+		fmt.Sprintf("-services-fixture=%s", fixturePath),
+		fmt.Sprintf("-out=%s", outDir))
+	require.NoError(t, err, output)
+
+	tfvars, err := ioutil.ReadFile(filepath.Join(outDir, "terraform.tfvars"))
+	require.NoError(t, err)
+	assert.Contains(t, string(tfvars), `"api-1.node" = {`)
+	assert.Contains(t, string(tfvars), `"db-1.node" = {`)
+
+	output, err = runSubcommand(t, "", "generate",
+		fmt.Sprintf("-config-file=%s", configPath), "-task=dne",
+		fmt.Sprintf("-services-fixture=%s", fixturePath),
+		fmt.Sprintf("-out=%s", outDir))
+	assert.Error(t, err)
+	assert.Contains(t, output, "task not found: dne")
+
+	delete()
+}
+
 // runSubcommand runs a CTS subcommand and its arguments. If user input is
 // required for subcommand, pass it through 'input' parameter. Function returns
 // the stdout/err output and any error when executing the subcommand.
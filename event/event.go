@@ -25,6 +25,7 @@ type Event struct {
 	TaskName   string    `json:"task_name"`
 	EventError *Error    `json:"error"`
 	Config     *Config   `json:"config"`
+	Caller     *Caller   `json:"caller,omitempty"`
 }
 
 // Error captures an event's error information
@@ -40,6 +41,13 @@ type Config struct {
 	Source    string   `json:"source"`
 }
 
+// Caller captures information on the client of the request that triggered the
+// event through the API e.g. a webhook request
+type Caller struct {
+	Address   string `json:"address"`
+	UserAgent string `json:"user_agent"`
+}
+
 // NewEvent configures a new event with a task name and any relevant information
 // that the task is configured with
 func NewEvent(taskName string, config *Config) (*Event, error) {
@@ -100,7 +108,8 @@ func (e *Event) GoString() string {
 		"StartTime:%s, "+
 		"EndTime:%s, "+
 		"EventError:%s, "+
-		"Config:%s"+
+		"Config:%s, "+
+		"Caller:%s"+
 		"}",
 		e.ID,
 		e.TaskName,
@@ -109,5 +118,6 @@ func (e *Event) GoString() string {
 		e.EndTime,
 		e.EventError,
 		e.Config,
+		e.Caller,
 	)
 }
@@ -216,7 +216,26 @@ func TestEvent_GoString(t *testing.T) {
 			"&Event{ID:123, TaskName:happy, Success:false, " +
 				"StartTime:0001-01-01 00:00:00 +0000 UTC, " +
 				"EndTime:0001-01-01 00:00:00 +0000 UTC, EventError:&{error!}, " +
-				"Config:&{[local] [web api] /my-module}}",
+				"Config:&{[local] [web api] /my-module}, Caller:%!s(*event.Caller=<nil>)}",
+		},
+		{
+			"caller",
+			&Event{
+				ID:       "123",
+				TaskName: "webhook",
+				Success:  true,
+				Config: &Config{
+					Source: "/my-module",
+				},
+				Caller: &Caller{
+					Address:   "127.0.0.1:51234",
+					UserAgent: "curl/7.79.1",
+				},
+			},
+			"&Event{ID:123, TaskName:webhook, Success:true, " +
+				"StartTime:0001-01-01 00:00:00 +0000 UTC, " +
+				"EndTime:0001-01-01 00:00:00 +0000 UTC, EventError:%!s(*event.Error=<nil>), " +
+				"Config:&{[] [] /my-module}, Caller:&{127.0.0.1:51234 curl/7.79.1}}",
 		},
 	}
 
@@ -98,6 +98,36 @@ func (_m *Driver) Task() *driver.Task {
 	return r0
 }
 
+// TemplateIDs provides a mock function with given fields:
+func (_m *Driver) TemplateIDs() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// TriggerTask provides a mock function with given fields: ctx, payload
+func (_m *Driver) TriggerTask(ctx context.Context, payload []byte) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTask provides a mock function with given fields: ctx, task
 func (_m *Driver) UpdateTask(ctx context.Context, task driver.PatchTask) (driver.InspectPlan, error) {
 	ret := _m.Called(ctx, task)
@@ -0,0 +1,284 @@
+package templates
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+)
+
+var _ Watcher = (*DependencyTracker)(nil)
+
+const (
+	// Types of dependencies reported by the DependencyTracker
+	DependencyTypeConsul = "consul"
+	DependencyTypeVault  = "vault"
+	DependencyTypeOther  = "other"
+)
+
+// DependencyStatus is a snapshot of a dependency watched by the watcher
+type DependencyStatus struct {
+	// ID is the unique identifier of the dependency, e.g.
+	// "health.service(web|passing)"
+	ID string
+
+	// Type is the type of upstream the dependency queries
+	Type string
+
+	// TemplateIDs are the IDs of the templates that reference the dependency
+	TemplateIDs []string
+
+	// LastIndex is the last blocking query index returned for the dependency
+	LastIndex uint64
+
+	// LastUpdated is the last time the blocking query index changed. The zero
+	// value means data has not been received yet.
+	LastUpdated time.Time
+
+	// LastFetched is the last time a blocking query successfully returned
+	LastFetched time.Time
+
+	// ErrorCount is the total number of blocking queries that have errored
+	ErrorCount int
+
+	// LastError is the most recent blocking query error
+	LastError string
+}
+
+// DependencyTracker is a Watcher that wraps another Watcher in order to
+// track which templates reference each watched dependency and the results of
+// each dependency's blocking queries. It is used to introspect dependencies
+// that CTS is watching, for example to identify which tasks contribute most
+// to the watcher size (see DepSizeWarning).
+type DependencyTracker struct {
+	Watcher
+
+	mu   sync.RWMutex
+	deps map[string]*trackedStats
+}
+
+// trackedStats holds the dependency information collected over time
+type trackedStats struct {
+	mu sync.Mutex
+
+	depType   string
+	templates map[string]bool // template ID -> in use since last Mark
+	lastIndex uint64
+	updated   time.Time
+	fetched   time.Time
+	errCount  int
+	lastErr   string
+}
+
+// NewDependencyTracker creates a new DependencyTracker that wraps the watcher
+func NewDependencyTracker(w Watcher) *DependencyTracker {
+	return &DependencyTracker{
+		Watcher: w,
+		deps:    make(map[string]*trackedStats),
+	}
+}
+
+// Recaller returns a Recaller that records the dependencies that the
+// notifier (template) recalls before delegating to the wrapped watcher.
+func (t *DependencyTracker) Recaller(n hcat.Notifier) hcat.Recaller {
+	recall := t.Watcher.Recaller(n)
+	return func(d dep.Dependency) (interface{}, bool) {
+		stats := t.stats(d)
+		stats.mu.Lock()
+		stats.templates[n.ID()] = true
+		stats.mu.Unlock()
+		return recall(wrapDependency(d, stats))
+	}
+}
+
+// Mark marks all dependencies referenced by the notifier as not in use
+// before delegating to the wrapped watcher.
+func (t *DependencyTracker) Mark(n hcat.IDer) {
+	t.mu.RLock()
+	for _, stats := range t.deps {
+		stats.mu.Lock()
+		if _, ok := stats.templates[n.ID()]; ok {
+			stats.templates[n.ID()] = false
+		}
+		stats.mu.Unlock()
+	}
+	t.mu.RUnlock()
+
+	t.Watcher.Mark(n)
+}
+
+// Sweep removes the references between the notifier and the dependencies
+// that were not used since Mark. Dependencies no longer referenced by any
+// template are no longer tracked.
+func (t *DependencyTracker) Sweep(n hcat.IDer) {
+	t.Watcher.Sweep(n)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, stats := range t.deps {
+		stats.mu.Lock()
+		if inUse, ok := stats.templates[n.ID()]; ok && !inUse {
+			delete(stats.templates, n.ID())
+		}
+		if len(stats.templates) == 0 {
+			delete(t.deps, id)
+		}
+		stats.mu.Unlock()
+	}
+}
+
+// Dependencies returns the status of all dependencies currently watched
+// sorted by ID.
+func (t *DependencyTracker) Dependencies() []DependencyStatus {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	statuses := make([]DependencyStatus, 0, len(t.deps))
+	for id, stats := range t.deps {
+		if !t.Watcher.Watching(id) {
+			continue
+		}
+
+		stats.mu.Lock()
+		tmplIDs := make([]string, 0, len(stats.templates))
+		for tmplID := range stats.templates {
+			tmplIDs = append(tmplIDs, tmplID)
+		}
+		sort.Strings(tmplIDs)
+
+		statuses = append(statuses, DependencyStatus{
+			ID:          id,
+			Type:        stats.depType,
+			TemplateIDs: tmplIDs,
+			LastIndex:   stats.lastIndex,
+			LastUpdated: stats.updated,
+			LastFetched: stats.fetched,
+			ErrorCount:  stats.errCount,
+			LastError:   stats.lastErr,
+		})
+		stats.mu.Unlock()
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses
+}
+
+// stats returns the stats for the dependency and creates them if this is the
+// first time the dependency is tracked.
+func (t *DependencyTracker) stats(d dep.Dependency) *trackedStats {
+	id := d.String()
+
+	t.mu.RLock()
+	stats, ok := t.deps[id]
+	t.mu.RUnlock()
+	if ok {
+		return stats
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if stats, ok := t.deps[id]; ok {
+		return stats
+	}
+
+	depType := DependencyTypeOther
+	switch d.(type) {
+	case consulType:
+		depType = DependencyTypeConsul
+	case vaultType:
+		depType = DependencyTypeVault
+	}
+	stats = &trackedStats{
+		depType:   depType,
+		templates: make(map[string]bool),
+	}
+	t.deps[id] = stats
+	return stats
+}
+
+// consulType and vaultType mirror the hcat interfaces that denote the type of
+// dependency in order to select a retry function.
+type consulType interface {
+	Consul()
+}
+
+type vaultType interface {
+	Vault()
+}
+
+// trackedDependency wraps a dependency to record the results of each fetch.
+// The wrapper has the same ID as the wrapped dependency so that the hcat
+// cache and views are shared.
+//
+// Note: hcat's internal marker for blocking KV get queries (the `key`
+// template function) cannot be forwarded by a wrapper. CTS templates do not
+// use this template function.
+type trackedDependency struct {
+	dep.Dependency
+	stats *trackedStats
+}
+
+type trackedConsulDependency struct {
+	*trackedDependency
+}
+
+func (trackedConsulDependency) Consul() {}
+
+type trackedVaultDependency struct {
+	*trackedDependency
+}
+
+func (trackedVaultDependency) Vault() {}
+
+// wrapDependency wraps the dependency and preserves its type for hcat
+func wrapDependency(d dep.Dependency, stats *trackedStats) dep.Dependency {
+	td := &trackedDependency{Dependency: d, stats: stats}
+	switch d.(type) {
+	case consulType:
+		return trackedConsulDependency{td}
+	case vaultType:
+		return trackedVaultDependency{td}
+	}
+	return td
+}
+
+// Fetch fetches the wrapped dependency and records the result
+func (d *trackedDependency) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	data, rm, err := d.Dependency.Fetch(clients)
+
+	d.stats.mu.Lock()
+	defer d.stats.mu.Unlock()
+	now := time.Now()
+	switch {
+	case err == dep.ErrStopped:
+	case err != nil:
+		if strings.Contains(err.Error(), context.Canceled.Error()) {
+			// the view was stopped mid-request
+			break
+		}
+		d.stats.errCount++
+		d.stats.lastErr = err.Error()
+	case rm != nil:
+		d.stats.fetched = now
+		if rm.LastIndex != d.stats.lastIndex || d.stats.updated.IsZero() {
+			d.stats.lastIndex = rm.LastIndex
+			d.stats.updated = now
+		}
+	}
+
+	return data, rm, err
+}
+
+// SetOptions passes the query options to the wrapped dependency if it
+// supports blocking queries.
+func (d *trackedDependency) SetOptions(opts hcat.QueryOptions) {
+	if setter, ok := d.Dependency.(hcat.QueryOptionsSetter); ok {
+		setter.SetOptions(opts)
+	}
+}
@@ -0,0 +1,191 @@
+package templates
+
+import (
+	"errors"
+	"testing"
+
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyTracker_Recaller(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		dep          dep.Dependency
+		expectedType string
+	}{
+		{
+			"consul",
+			&testConsulDep{testDep{id: "health.service(api)"}},
+			DependencyTypeConsul,
+		},
+		{
+			"vault",
+			&testVaultDep{testDep{id: "vault.read(secret)"}},
+			DependencyTypeVault,
+		},
+		{
+			"other",
+			&testDep{id: "file(/tmp/file)"},
+			DependencyTypeOther,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker, recalled := newTestTracker()
+			tmpl := newTestTemplate("tmpl")
+
+			tracker.Recaller(tmpl)(tc.dep)
+			require.NotNil(t, *recalled)
+
+			// wrapper preserves the ID and type of the dependency
+			assert.Equal(t, tc.dep.String(), (*recalled).String())
+			switch tc.expectedType {
+			case DependencyTypeConsul:
+				assert.Implements(t, (*consulType)(nil), *recalled)
+			case DependencyTypeVault:
+				assert.Implements(t, (*vaultType)(nil), *recalled)
+			}
+
+			deps := tracker.Dependencies()
+			require.Len(t, deps, 1)
+			assert.Equal(t, tc.dep.String(), deps[0].ID)
+			assert.Equal(t, tc.expectedType, deps[0].Type)
+			assert.Equal(t, []string{"tmpl"}, deps[0].TemplateIDs)
+		})
+	}
+}
+
+func TestDependencyTracker_Fetch(t *testing.T) {
+	t.Parallel()
+
+	tracker, recalled := newTestTracker()
+	tmpl := newTestTemplate("tmpl")
+	d := &testConsulDep{testDep{id: "health.service(api)"}}
+	tracker.Recaller(tmpl)(d)
+
+	// no data yet
+	status := tracker.Dependencies()[0]
+	assert.True(t, status.LastUpdated.IsZero())
+	assert.True(t, status.LastFetched.IsZero())
+
+	// successful fetch updates index
+	d.index = 10
+	_, _, err := (*recalled).Fetch(nil)
+	require.NoError(t, err)
+	status = tracker.Dependencies()[0]
+	assert.Equal(t, uint64(10), status.LastIndex)
+	assert.False(t, status.LastUpdated.IsZero())
+	assert.False(t, status.LastFetched.IsZero())
+	updated := status.LastUpdated
+
+	// same index only updates last fetched
+	_, _, err = (*recalled).Fetch(nil)
+	require.NoError(t, err)
+	status = tracker.Dependencies()[0]
+	assert.Equal(t, updated, status.LastUpdated)
+
+	// errors are counted
+	d.err = errors.New("connection refused")
+	(*recalled).Fetch(nil)
+	(*recalled).Fetch(nil)
+	status = tracker.Dependencies()[0]
+	assert.Equal(t, 2, status.ErrorCount)
+	assert.Equal(t, "connection refused", status.LastError)
+
+	// stopped dependencies are not errors
+	d.err = dep.ErrStopped
+	(*recalled).Fetch(nil)
+	assert.Equal(t, 2, tracker.Dependencies()[0].ErrorCount)
+}
+
+func TestDependencyTracker_MarkSweep(t *testing.T) {
+	t.Parallel()
+
+	tracker, _ := newTestTracker()
+	tmplA := newTestTemplate("tmpl_a")
+	tmplB := newTestTemplate("tmpl_b")
+	depA := &testDep{id: "dep_a"}
+	depB := &testDep{id: "dep_b"}
+
+	tracker.Recaller(tmplA)(depA)
+	tracker.Recaller(tmplA)(depB)
+	tracker.Recaller(tmplB)(depB)
+	require.Len(t, tracker.Dependencies(), 2)
+
+	// tmpl_a no longer references dep_b
+	tracker.Mark(tmplA)
+	tracker.Recaller(tmplA)(depA)
+	tracker.Sweep(tmplA)
+
+	deps := tracker.Dependencies()
+	require.Len(t, deps, 2)
+	assert.Equal(t, []string{"tmpl_a"}, deps[0].TemplateIDs)
+	assert.Equal(t, []string{"tmpl_b"}, deps[1].TemplateIDs)
+
+	// dep_b is no longer referenced by any template
+	tracker.Mark(tmplB)
+	tracker.Sweep(tmplB)
+
+	deps = tracker.Dependencies()
+	require.Len(t, deps, 1)
+	assert.Equal(t, "dep_a", deps[0].ID)
+}
+
+// newTestTracker returns a tracker wrapping a mock watcher and a pointer to
+// the last dependency recalled by the mock watcher
+func newTestTracker() (*DependencyTracker, *dep.Dependency) {
+	var recalled dep.Dependency
+	w := new(mocks.Watcher)
+	w.On("Recaller", mock.Anything).Return(hcat.Recaller(
+		func(d dep.Dependency) (interface{}, bool) {
+			recalled = d
+			return nil, false
+		}))
+	w.On("Mark", mock.Anything).Return()
+	w.On("Sweep", mock.Anything).Return()
+	w.On("Watching", mock.Anything).Return(true)
+	return NewDependencyTracker(w), &recalled
+}
+
+func newTestTemplate(id string) *mocks.Template {
+	tmpl := new(mocks.Template)
+	tmpl.On("ID").Return(id)
+	return tmpl
+}
+
+type testDep struct {
+	id    string
+	index uint64
+	err   error
+}
+
+func (d *testDep) Fetch(dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	if d.err != nil {
+		return nil, nil, d.err
+	}
+	return "data", &dep.ResponseMetadata{LastIndex: d.index}, nil
+}
+
+func (d *testDep) String() string { return d.id }
+
+func (d *testDep) Stop() {}
+
+type testConsulDep struct {
+	testDep
+}
+
+func (*testConsulDep) Consul() {}
+
+type testVaultDep struct {
+	testDep
+}
+
+func (*testVaultDep) Vault() {}
@@ -0,0 +1,72 @@
+package tftmpl
+
+import (
+	"io"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Condition = (*CompositeCondition)(nil)
+)
+
+// CompositeCondition handles appending templating for the any and all run
+// conditions. The templating of each nested condition is combined, and the
+// notifier for the task decides whether the combined result triggers the task.
+type CompositeCondition struct {
+	Conditions []Condition
+}
+
+// ServicesAppended returns true if any of the nested conditions append the
+// services variable
+func (c CompositeCondition) ServicesAppended() bool {
+	for _, nested := range c.Conditions {
+		if nested.ServicesAppended() {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceIncludesVariable returns true if any of the nested conditions include
+// their variable and false otherwise
+func (c CompositeCondition) SourceIncludesVariable() bool {
+	for _, nested := range c.Conditions {
+		if nested.SourceIncludesVariable() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CompositeCondition) appendModuleAttribute(body *hclwrite.Body) {
+	for _, nested := range c.Conditions {
+		if nested.SourceIncludesVariable() {
+			nested.appendModuleAttribute(body)
+		}
+	}
+}
+
+// appendTemplate writes the templates of each nested condition
+func (c CompositeCondition) appendTemplate(w io.Writer) error {
+	for _, nested := range c.Conditions {
+		if err := nested.appendTemplate(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendVariable writes the variables of the nested conditions that include
+// their variable
+func (c CompositeCondition) appendVariable(w io.Writer) error {
+	for _, nested := range c.Conditions {
+		if !nested.SourceIncludesVariable() {
+			continue
+		}
+		if err := nested.appendVariable(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeCondition(t *testing.T) {
+	kv := &ConsulKVCondition{
+		ConsulKVMonitor: ConsulKVMonitor{
+			Path: "deploy/enabled",
+		},
+		SourceIncludesVar: true,
+	}
+	nodes := &NodesCondition{
+		SourceIncludesVar: false,
+	}
+	services := &ServicesCondition{
+		ServicesMonitor: ServicesMonitor{
+			Regexp: "^api$",
+		},
+		SourceIncludesVar: true,
+	}
+
+	t.Run("services appended", func(t *testing.T) {
+		c := &CompositeCondition{Conditions: []Condition{kv, nodes}}
+		assert.False(t, c.ServicesAppended())
+
+		c = &CompositeCondition{Conditions: []Condition{services, kv}}
+		assert.True(t, c.ServicesAppended())
+	})
+
+	t.Run("source includes variable", func(t *testing.T) {
+		c := &CompositeCondition{Conditions: []Condition{nodes}}
+		assert.False(t, c.SourceIncludesVariable())
+
+		c = &CompositeCondition{Conditions: []Condition{nodes, kv}}
+		assert.True(t, c.SourceIncludesVariable())
+	})
+
+	t.Run("append template", func(t *testing.T) {
+		c := &CompositeCondition{Conditions: []Condition{kv, nodes}}
+		w := new(strings.Builder)
+		require.NoError(t, c.appendTemplate(w))
+
+		// templates of all nested conditions are appended in order
+		expected := new(strings.Builder)
+		require.NoError(t, kv.appendTemplate(expected))
+		require.NoError(t, nodes.appendTemplate(expected))
+		assert.Equal(t, expected.String(), w.String())
+	})
+
+	t.Run("append module attribute", func(t *testing.T) {
+		c := &CompositeCondition{Conditions: []Condition{kv, nodes}}
+		f := hclwrite.NewEmptyFile()
+		c.appendModuleAttribute(f.Body())
+
+		content := string(f.Bytes())
+		assert.Contains(t, content, "consul_kv = var.consul_kv")
+		assert.NotContains(t, content, "nodes")
+	})
+}
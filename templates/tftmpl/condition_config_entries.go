@@ -0,0 +1,57 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+var (
+	_ Condition = (*ConfigEntriesCondition)(nil)
+)
+
+// ConfigEntriesCondition handles appending templating for the config-entries
+// run condition
+type ConfigEntriesCondition struct {
+	ConfigEntriesMonitor
+	SourceIncludesVar bool
+}
+
+// SourceIncludesVariable returns true if the variables are to be included
+// and false otherwise
+func (c ConfigEntriesCondition) SourceIncludesVariable() bool {
+	return c.SourceIncludesVar
+}
+
+// appendTemplate writes the template needed for the config-entries condition.
+// If source_includes_var is set to true, the config entries are included as
+// the variable config_entries. Otherwise, an empty template is used to only
+// detect changes.
+func (c ConfigEntriesCondition) appendTemplate(w io.Writer) error {
+	if c.SourceIncludesVariable() {
+		return c.ConfigEntriesMonitor.appendTemplate(w)
+	}
+
+	q := c.hcatQuery()
+	var b strings.Builder
+	for _, kind := range c.Kinds {
+		fmt.Fprintf(&b, configEntriesConditionTmpl, kind, q)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write config-entries empty template", "error", err)
+		return err
+	}
+	return nil
+}
+
+const configEntriesConditionTmpl = `
+{{- with $entries := configEntries "%s" %s}}
+  {{- range $e := $entries }}
+  {{- /* Empty template. Detects changes in config entries */ -}}
+  {{- end}}
+{{- end}}
+`
@@ -0,0 +1,156 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigEntriesCondition_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *ConfigEntriesCondition
+		exp  string
+	}{
+		{
+			"no parameters",
+			&ConfigEntriesCondition{},
+			"",
+		},
+		{
+			"all_parameters",
+			&ConfigEntriesCondition{
+				ConfigEntriesMonitor{
+					Kinds:      []string{"service-defaults"},
+					Regexp:     "^web",
+					Datacenter: "dc2",
+					Namespace:  "ns2",
+					Partition:  "ap2",
+				},
+				false,
+			},
+			`"regexp=^web" "dc=dc2" "ns=ns2" "partition=ap2" `,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestConfigEntriesCondition_appendTemplate(t *testing.T) {
+	testcases := []struct {
+		name string
+		c    *ConfigEntriesCondition
+		exp  string
+	}{
+		{
+			"includes var false",
+			&ConfigEntriesCondition{
+				ConfigEntriesMonitor: ConfigEntriesMonitor{
+					Kinds:  []string{"service-defaults", "service-router"},
+					Regexp: "^web",
+				},
+				SourceIncludesVar: false,
+			},
+			`
+{{- with $entries := configEntries "service-defaults" "regexp=^web" }}
+  {{- range $e := $entries }}
+  {{- /* Empty template. Detects changes in config entries */ -}}
+  {{- end}}
+{{- end}}
+
+{{- with $entries := configEntries "service-router" "regexp=^web" }}
+  {{- range $e := $entries }}
+  {{- /* Empty template. Detects changes in config entries */ -}}
+  {{- end}}
+{{- end}}
+`,
+		},
+		{
+			"includes var true",
+			&ConfigEntriesCondition{
+				ConfigEntriesMonitor: ConfigEntriesMonitor{
+					Kinds: []string{"service-defaults"},
+				},
+				SourceIncludesVar: true,
+			},
+			`
+config_entries = {
+{{- with $entries := configEntries "service-defaults" }}
+  {{- range $e := $entries }}
+  "{{ $e.Kind }}/{{ if $e.Namespace }}{{ $e.Namespace }}/{{ end }}{{ $e.Name }}" = {
+{{ HCLConfigEntry $e | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+}
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			err := tc.c.appendTemplate(w)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, w.String())
+		})
+	}
+}
+
+func TestConfigEntriesCondition_render(t *testing.T) {
+	// Test that the rendered config entries are valid HCL
+	c := &ConfigEntriesCondition{
+		ConfigEntriesMonitor: ConfigEntriesMonitor{
+			Kinds: []string{"service-defaults", "service-router"},
+		},
+		SourceIncludesVar: true,
+	}
+	w := new(strings.Builder)
+	require.NoError(t, c.appendTemplate(w))
+
+	fixture := &tmplfunc.Fixture{
+		ConfigEntries: []*tmplfunc.ConfigEntry{
+			{
+				Kind:   "service-defaults",
+				Name:   "web",
+				Meta:   map[string]string{"team": "a"},
+				Config: `{"Kind":"service-defaults","Name":"web","Protocol":"http"}`,
+			},
+			{
+				Kind:   "service-router",
+				Name:   "web",
+				Config: `{"Kind":"service-router","Name":"web"}`,
+			},
+			{
+				Kind:   "proxy-defaults",
+				Name:   "global",
+				Config: `{"Kind":"proxy-defaults","Name":"global"}`,
+			},
+		},
+	}
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents:     w.String(),
+		FuncMapMerge: tmplfunc.HCLMap(nil),
+	})
+	content, err := tmpl.Execute(fixture.Recaller())
+	require.NoError(t, err)
+
+	_, diags := hclsyntax.ParseConfig(content, "config_entries.tfvars", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	s := string(content)
+	assert.Contains(t, s, `"service-defaults/web"`)
+	assert.Contains(t, s, `"service-router/web"`)
+	assert.NotContains(t, s, "proxy-defaults")
+}
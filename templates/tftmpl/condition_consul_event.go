@@ -0,0 +1,109 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Condition = (*ConsulEventCondition)(nil)
+)
+
+// ConsulEventVariable is the name of the input variable of the latest Consul
+// user event that triggered the task
+const ConsulEventVariable = "consul_event"
+
+// ConsulEventCondition handles appending templating for the consul-event run
+// condition. The latest Consul user event of the configured name is always
+// included as the variable consul_event.
+type ConsulEventCondition struct {
+	Name       string
+	Datacenter string
+}
+
+// ServicesAppended always returns false for consul-event as it doesn't deal
+// with services
+func (c ConsulEventCondition) ServicesAppended() bool {
+	return false
+}
+
+// SourceIncludesVariable always returns true for consul-event since the event
+// is always provided to the module
+func (c ConsulEventCondition) SourceIncludesVariable() bool {
+	return true
+}
+
+func (c ConsulEventCondition) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal(ConsulEventVariable, hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: ConsulEventVariable},
+	})
+}
+
+// appendTemplate writes the template needed to render the latest Consul user
+// event as the variable consul_event, or null if there are no events
+func (c ConsulEventCondition) appendTemplate(w io.Writer) error {
+	q := c.hcatQuery()
+	if _, err := fmt.Fprintf(w, consulEventIncludesVarTmpl, q); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write consul-event template to include variable", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c ConsulEventCondition) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableConsulEvent)
+	return err
+}
+
+func (c ConsulEventCondition) hcatQuery() string {
+	var opts []string
+
+	if c.Name != "" {
+		opts = append(opts, fmt.Sprintf("name=%s", c.Name))
+	}
+
+	if c.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", c.Datacenter))
+	}
+
+	if len(opts) > 0 {
+		return `"` + strings.Join(opts, `" "`) + `"`
+	}
+	return ""
+}
+
+const consulEventIncludesVarTmpl = `
+consul_event = {{ with $e := latestConsulEvent (consulEvents %s) -}}
+{
+{{ HCLConsulEvent $e | indent 2 }}
+}
+{{- else }}null{{ end }}
+`
+
+// variableConsulEvent is required for modules that include a Consul user
+// event. It is versioned to track compatibility between the generated root
+// module and modules that include the event.
+var variableConsulEvent = []byte(`
+# Consul event definition protocol v0
+variable "consul_event" {
+  description = "Latest Consul user event that triggered the task, or null if there are no events"
+  type = object({
+    id             = string
+    name           = string
+    payload        = string
+    node_filter    = string
+    service_filter = string
+    tag_filter     = string
+    version        = number
+    ltime          = number
+  })
+  default = null
+}
+`)
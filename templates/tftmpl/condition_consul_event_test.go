@@ -0,0 +1,107 @@
+package tftmpl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsulEventCondition_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *ConsulEventCondition
+		exp  string
+	}{
+		{
+			"no parameters",
+			&ConsulEventCondition{},
+			"",
+		},
+		{
+			"all_parameters",
+			&ConsulEventCondition{
+				Name:       "deploy",
+				Datacenter: "dc2",
+			},
+			`"name=deploy" "dc=dc2"`,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestConsulEventCondition_appendModuleAttribute(t *testing.T) {
+	f := hclwrite.NewEmptyFile()
+	ConsulEventCondition{}.appendModuleAttribute(f.Body())
+	assert.Equal(t, "consul_event = var.consul_event\n", string(f.Bytes()))
+}
+
+func TestConsulEventCondition_appendTemplate(t *testing.T) {
+	c := &ConsulEventCondition{Name: "deploy"}
+	w := new(bytes.Buffer)
+	require.NoError(t, c.appendTemplate(w))
+	assert.Equal(t, `
+consul_event = {{ with $e := latestConsulEvent (consulEvents "name=deploy") -}}
+{
+{{ HCLConsulEvent $e | indent 2 }}
+}
+{{- else }}null{{ end }}
+`, w.String())
+}
+
+func TestConsulEventCondition_render(t *testing.T) {
+	// Test that the rendered event is valid HCL
+	cases := []struct {
+		name   string
+		events []*tmplfunc.ConsulEvent
+		exp    string
+	}{
+		{
+			"no events",
+			nil,
+			"consul_event = null",
+		},
+		{
+			"latest event",
+			[]*tmplfunc.ConsulEvent{
+				{ID: "2", Name: "deploy", Payload: "v2", LTime: 5},
+				{ID: "1", Name: "deploy", Payload: "v1", LTime: 3},
+				{ID: "3", Name: "restart", LTime: 7},
+			},
+			`payload        = "v2"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &ConsulEventCondition{Name: "deploy"}
+			w := new(strings.Builder)
+			require.NoError(t, c.appendTemplate(w))
+
+			fixture := &tmplfunc.Fixture{ConsulEvents: tc.events}
+			tmpl := hcat.NewTemplate(hcat.TemplateInput{
+				Contents:     w.String(),
+				FuncMapMerge: tmplfunc.HCLMap(nil),
+			})
+			content, err := tmpl.Execute(fixture.Recaller())
+			require.NoError(t, err)
+
+			_, diags := hclsyntax.ParseConfig(content, "consul_event.tfvars", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags.Error())
+			assert.Contains(t, string(content), tc.exp)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package tftmpl
+
+var (
+	_ Condition = (*FileCondition)(nil)
+)
+
+// FileCondition handles appending templating for the file run condition
+type FileCondition struct {
+	FileMonitor
+}
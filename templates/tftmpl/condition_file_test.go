@@ -0,0 +1,100 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCondition_hcatQuery(t *testing.T) {
+	c := &FileCondition{
+		FileMonitor{
+			Paths: []string{"inventory", "racks/*.yaml"},
+		},
+	}
+	assert.Equal(t, `"inventory" "racks/*.yaml"`, c.hcatQuery())
+}
+
+func TestFileCondition_appendModuleAttribute(t *testing.T) {
+	f := hclwrite.NewEmptyFile()
+	FileCondition{}.appendModuleAttribute(f.Body())
+	assert.Equal(t, "files = var.files\n", string(f.Bytes()))
+}
+
+func TestFileCondition_render(t *testing.T) {
+	// Test that the rendered files are valid HCL
+	cases := []struct {
+		name   string
+		format string
+		files  map[string]string
+		exp    []string
+	}{
+		{
+			"no files",
+			"",
+			nil,
+			[]string{"files = {\n}"},
+		},
+		{
+			"inferred formats",
+			"",
+			map[string]string{
+				"inventory/hosts.json": `{"web": ["10.0.0.1", "10.0.0.2"]}`,
+				"inventory/racks.yaml": "rack: a\n",
+				"inventory/empty.hcl":  "",
+			},
+			[]string{
+				`"inventory/empty.hcl" = null`,
+				`"inventory/hosts.json" = {`,
+				`web = ["10.0.0.1", "10.0.0.2"]`,
+				`"inventory/racks.yaml" = {`,
+				`rack = "a"`,
+			},
+		},
+		{
+			"format",
+			"hcl",
+			map[string]string{
+				"inventory/hosts": "web = \"10.0.0.1\"\n",
+			},
+			[]string{
+				`"inventory/hosts" = {`,
+				`web = "10.0.0.1"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &FileCondition{
+				FileMonitor{
+					Paths:  []string{"inventory"},
+					Format: tc.format,
+				},
+			}
+			w := new(strings.Builder)
+			require.NoError(t, c.appendTemplate(w))
+
+			fixture := &tmplfunc.Fixture{Files: tc.files}
+			tmpl := hcat.NewTemplate(hcat.TemplateInput{
+				Contents:     w.String(),
+				FuncMapMerge: tmplfunc.HCLMap(nil),
+			})
+			content, err := tmpl.Execute(fixture.Recaller())
+			require.NoError(t, err)
+
+			_, diags := hclsyntax.ParseConfig(content, "files.tfvars", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags.Error())
+			for _, exp := range tc.exp {
+				assert.Contains(t, string(content), exp)
+			}
+		})
+	}
+}
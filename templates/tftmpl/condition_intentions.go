@@ -0,0 +1,51 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+var (
+	_ Condition = (*IntentionsCondition)(nil)
+)
+
+// IntentionsCondition handles appending templating for the intentions run
+// condition
+type IntentionsCondition struct {
+	IntentionsMonitor
+	SourceIncludesVar bool
+}
+
+// SourceIncludesVariable returns true if the variables are to be included
+// and false otherwise
+func (c IntentionsCondition) SourceIncludesVariable() bool {
+	return c.SourceIncludesVar
+}
+
+// appendTemplate writes the template needed for the intentions condition. If
+// source_includes_var is set to true, the intentions are included as the
+// variable intentions. Otherwise, an empty template is used to only detect
+// changes.
+func (c IntentionsCondition) appendTemplate(w io.Writer) error {
+	if c.SourceIncludesVariable() {
+		return c.IntentionsMonitor.appendTemplate(w)
+	}
+
+	q := c.hcatQuery()
+	if _, err := fmt.Fprintf(w, intentionsConditionTmpl, q); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write intentions empty template", "error", err)
+		return err
+	}
+	return nil
+}
+
+const intentionsConditionTmpl = `
+{{- with $intentions := intentions %s}}
+  {{- range $i := $intentions }}
+  {{- /* Empty template. Detects changes in intentions */ -}}
+  {{- end}}
+{{- end}}
+`
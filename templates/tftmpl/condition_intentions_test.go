@@ -0,0 +1,129 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntentionsCondition_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *IntentionsCondition
+		exp  string
+	}{
+		{
+			"no parameters",
+			&IntentionsCondition{},
+			"",
+		},
+		{
+			"all_parameters",
+			&IntentionsCondition{
+				IntentionsMonitor{
+					SourceRegexp:      "^web$",
+					DestinationRegexp: "^api$",
+					Datacenter:        "dc2",
+					Namespace:         "ns2",
+					Partition:         "ap2",
+				},
+				false,
+			},
+			`"source=^web$" "destination=^api$" "dc=dc2" "ns=ns2" "partition=ap2" `,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestIntentionsCondition_appendTemplate(t *testing.T) {
+	testcases := []struct {
+		name string
+		c    *IntentionsCondition
+		exp  string
+	}{
+		{
+			"includes var false",
+			&IntentionsCondition{
+				IntentionsMonitor: IntentionsMonitor{
+					DestinationRegexp: "^api$",
+				},
+				SourceIncludesVar: false,
+			},
+			`
+{{- with $intentions := intentions "destination=^api$" }}
+  {{- range $i := $intentions }}
+  {{- /* Empty template. Detects changes in intentions */ -}}
+  {{- end}}
+{{- end}}
+`,
+		},
+		{
+			"includes var true",
+			&IntentionsCondition{
+				IntentionsMonitor: IntentionsMonitor{
+					DestinationRegexp: "^api$",
+				},
+				SourceIncludesVar: true,
+			},
+			`
+intentions = [
+{{- with $intentions := intentions "destination=^api$" }}
+  {{- range $i := $intentions }}
+  {
+{{ HCLIntention $i | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+]
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			err := tc.c.appendTemplate(w)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, w.String())
+		})
+	}
+}
+
+func TestIntentionsCondition_render(t *testing.T) {
+	// Test that the rendered intentions are valid HCL
+	c := &IntentionsCondition{SourceIncludesVar: true}
+	w := new(strings.Builder)
+	require.NoError(t, c.appendTemplate(w))
+
+	fixture := &tmplfunc.Fixture{
+		Intentions: []*tmplfunc.Intention{
+			{SourceName: "*", DestinationName: "api", Action: "deny", Precedence: 8},
+			{SourceName: "web", DestinationName: "api", Action: "allow", Precedence: 9},
+		},
+	}
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents:     w.String(),
+		FuncMapMerge: tmplfunc.HCLMap(nil),
+	})
+	content, err := tmpl.Execute(fixture.Recaller())
+	require.NoError(t, err)
+
+	_, diags := hclsyntax.ParseConfig(content, "intentions.tfvars", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	// intentions are ordered by precedence
+	s := string(content)
+	assert.Less(t, strings.Index(s, `"web"`), strings.Index(s, `"*"`))
+}
@@ -0,0 +1,49 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+var (
+	_ Condition = (*NodesCondition)(nil)
+)
+
+// NodesCondition handles appending templating for the nodes run condition
+type NodesCondition struct {
+	NodesMonitor
+	SourceIncludesVar bool
+}
+
+// SourceIncludesVariable returns true if the variables are to be included
+// and false otherwise
+func (c NodesCondition) SourceIncludesVariable() bool {
+	return c.SourceIncludesVar
+}
+
+// appendTemplate writes the template needed for the nodes condition. If
+// source_includes_var is set to true, the nodes are included as the variable
+// nodes. Otherwise, an empty template is used to only detect changes.
+func (c NodesCondition) appendTemplate(w io.Writer) error {
+	if c.SourceIncludesVariable() {
+		return c.NodesMonitor.appendTemplate(w)
+	}
+
+	q := c.hcatQuery()
+	if _, err := fmt.Fprintf(w, nodesConditionTmpl, q); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write nodes empty template", "error", err)
+		return err
+	}
+	return nil
+}
+
+const nodesConditionTmpl = `
+{{- with $nodes := catalogNodes %s}}
+  {{- range $n := $nodes }}
+  {{- /* Empty template. Detects changes in nodes */ -}}
+  {{- end}}
+{{- end}}
+`
@@ -0,0 +1,143 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodesCondition_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *NodesCondition
+		exp  string
+	}{
+		{
+			"no parameters",
+			&NodesCondition{},
+			"",
+		},
+		{
+			"all_parameters",
+			&NodesCondition{
+				NodesMonitor{
+					Datacenter: "dc2",
+					Partition:  "ap2",
+					Peer:       "peer2",
+					NodeMeta: map[string]string{
+						"rack": "a",
+						"env":  "prod",
+					},
+					Filter: `Node != "web"`,
+				},
+				false,
+			},
+			`"dc=dc2" "partition=ap2" "peer=peer2" "node-meta=env:prod" ` +
+				`"node-meta=rack:a" "Node != \"web\"" `,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestNodesCondition_appendTemplate(t *testing.T) {
+	testcases := []struct {
+		name string
+		c    *NodesCondition
+		exp  string
+	}{
+		{
+			"includes var false",
+			&NodesCondition{
+				NodesMonitor: NodesMonitor{
+					Datacenter: "dc1",
+				},
+				SourceIncludesVar: false,
+			},
+			`
+{{- with $nodes := catalogNodes "dc=dc1" }}
+  {{- range $n := $nodes }}
+  {{- /* Empty template. Detects changes in nodes */ -}}
+  {{- end}}
+{{- end}}
+`,
+		},
+		{
+			"includes var true",
+			&NodesCondition{
+				NodesMonitor: NodesMonitor{
+					Datacenter: "dc1",
+				},
+				SourceIncludesVar: true,
+			},
+			`
+nodes = {
+{{- with $nodes := catalogNodes "dc=dc1" }}
+  {{- range $n := $nodes }}
+  "{{ $n.Node }}" = {
+{{ HCLNode $n | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+}
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			err := tc.c.appendTemplate(w)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, w.String())
+		})
+	}
+}
+
+func TestNodesCondition_render(t *testing.T) {
+	// Test that the rendered nodes are valid HCL
+	c := &NodesCondition{
+		NodesMonitor: NodesMonitor{
+			Filter: `Meta.env == "prod"`,
+		},
+		SourceIncludesVar: true,
+	}
+	w := new(strings.Builder)
+	require.NoError(t, c.appendTemplate(w))
+
+	fixture := &tmplfunc.Fixture{
+		Nodes: []*dep.Node{
+			{
+				ID:              "39e5a7f5-2834-e16d-6925-78167c9f50d8",
+				Node:            "worker-01",
+				Address:         "10.0.0.1",
+				Datacenter:      "dc1",
+				TaggedAddresses: map[string]string{"lan": "10.0.0.1"},
+				Meta:            map[string]string{"env": "prod"},
+			},
+		},
+	}
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents:     w.String(),
+		FuncMapMerge: tmplfunc.HCLMap(nil),
+	})
+	content, err := tmpl.Execute(fixture.Recaller())
+	require.NoError(t, err)
+
+	_, diags := hclsyntax.ParseConfig(content, "nodes.tfvars", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.Contains(t, string(content), `"worker-01" = {`)
+	assert.Contains(t, string(content), `"10.0.0.1"`)
+}
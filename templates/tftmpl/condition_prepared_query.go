@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ Condition = (*PreparedQueryCondition)(nil)
+)
+
+// PreparedQueryCondition handles appending templating for the prepared-query
+// run condition
+type PreparedQueryCondition struct {
+	PreparedQueryMonitor
+}
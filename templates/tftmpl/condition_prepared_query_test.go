@@ -0,0 +1,95 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreparedQueryCondition_hcatPipeline(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *PreparedQueryCondition
+		exp  string
+	}{
+		{
+			"name",
+			&PreparedQueryCondition{PreparedQueryMonitor{
+				Name: "web-failover",
+			}},
+			`preparedQuery "name=web-failover" `,
+		},
+		{
+			"all_parameters",
+			&PreparedQueryCondition{PreparedQueryMonitor{
+				Name:       "web-failover",
+				Datacenter: "dc2",
+				Near:       "_agent",
+				Limit:      3,
+			}},
+			`preparedQuery "name=web-failover" "dc=dc2" "near=_agent" "limit=3" `,
+		},
+		{
+			"include_statuses",
+			&PreparedQueryCondition{PreparedQueryMonitor{
+				Name:            "web-failover",
+				IncludeStatuses: []string{"passing"},
+			}},
+			`includeStatuses (preparedQuery "name=web-failover" ) "passing"`,
+		},
+		{
+			"protocol_v1",
+			&PreparedQueryCondition{PreparedQueryMonitor{
+				Name:     "web-failover",
+				Protocol: ServicesProtocolV1,
+			}},
+			`preparedQueryDetails "name=web-failover" `,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatPipeline()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestPreparedQueryCondition_render(t *testing.T) {
+	// Test that the rendered services are valid HCL
+	c := &PreparedQueryCondition{PreparedQueryMonitor{
+		Name:  "web-failover",
+		Limit: 1,
+	}}
+	w := new(strings.Builder)
+	require.NoError(t, c.appendTemplate(w))
+
+	fixture := &tmplfunc.Fixture{
+		PreparedQueries: map[string][]*dep.HealthService{
+			"web-failover": {
+				{Node: "node2", ID: "web-2", Name: "web", NodeDatacenter: "dc2"},
+				{Node: "node1", ID: "web-1", Name: "web", NodeDatacenter: "dc1"},
+			},
+		},
+	}
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents:     w.String(),
+		FuncMapMerge: tmplfunc.HCLMap(nil),
+	})
+	content, err := tmpl.Execute(fixture.Recaller())
+	require.NoError(t, err)
+
+	_, diags := hclsyntax.ParseConfig(content, "services.tfvars", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	s := string(content)
+	assert.Contains(t, s, `"web-2.node2.dc2"`)
+	assert.NotContains(t, s, "web-1")
+}
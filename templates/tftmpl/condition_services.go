@@ -38,7 +38,7 @@ func (c ServicesCondition) appendTemplate(w io.Writer) error {
 		if c.Regexp == "" {
 			return nil
 		}
-		q := c.hcatQuery()
+		q := c.hcatPipeline()
 		_, err = fmt.Fprintf(w, servicesRegexTmpl, q)
 	}
 
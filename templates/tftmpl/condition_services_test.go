@@ -0,0 +1,117 @@
+package tftmpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServicesCondition_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *ServicesCondition
+		exp  string
+	}{
+		{
+			"no parameters",
+			&ServicesCondition{},
+			"",
+		},
+		{
+			"regexp",
+			&ServicesCondition{
+				ServicesMonitor{
+					Regexp: "^web.*",
+				},
+				false,
+			},
+			`"regexp=^web.*" `,
+		},
+		{
+			"all_parameters",
+			&ServicesCondition{
+				ServicesMonitor{
+					Regexp:     "^web.*",
+					Datacenter: "dc2",
+					Namespace:  "ns2",
+					Partition:  "ap2",
+					Peer:       "peer2",
+					NodeMeta: map[string]string{
+						"rack": "a",
+						"env":  "prod",
+					},
+					Filter: `Service.Tags contains "v1"`,
+				},
+				false,
+			},
+			`"regexp=^web.*" "dc=dc2" "ns=ns2" "partition=ap2" "peer=peer2" "node-meta=env:prod" ` +
+				`"node-meta=rack:a" "Service.Tags contains \"v1\"" `,
+		},
+		{
+			"datacenters",
+			&ServicesCondition{
+				ServicesMonitor{
+					Regexp:      "^web.*",
+					Datacenters: []string{"dc1", "dc2"},
+				},
+				false,
+			},
+			`"regexp=^web.*" "dc=dc1" "dc=dc2" `,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestServicesCondition_hcatPipeline(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *ServicesCondition
+		exp  string
+	}{
+		{
+			"regexp",
+			&ServicesCondition{
+				ServicesMonitor{
+					Regexp: "^web.*",
+				},
+				false,
+			},
+			`servicesRegex "regexp=^web.*" `,
+		},
+		{
+			"include statuses",
+			&ServicesCondition{
+				ServicesMonitor{
+					Regexp:          "^web.*",
+					IncludeStatuses: []string{"passing", "warning"},
+				},
+				false,
+			},
+			`includeStatuses (servicesRegex "regexp=^web.*" ) "passing" "warning"`,
+		},
+		{
+			"protocol v1",
+			&ServicesCondition{
+				ServicesMonitor{
+					Regexp:   "^web.*",
+					Protocol: ServicesProtocolV1,
+				},
+				false,
+			},
+			`servicesRegexDetails "regexp=^web.*" `,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatPipeline()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
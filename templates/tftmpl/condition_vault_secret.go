@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ Condition = (*VaultSecretCondition)(nil)
+)
+
+// VaultSecretCondition handles appending templating for the vault-secret
+// run condition
+type VaultSecretCondition struct {
+	VaultSecretMonitor
+}
@@ -0,0 +1,110 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultSecretCondition_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		c    *VaultSecretCondition
+		path string
+		exp  string
+	}{
+		{
+			"read",
+			&VaultSecretCondition{},
+			"kv/data/app?version=2",
+			`"kv/data/app?version=2"`,
+		},
+		{
+			"write",
+			&VaultSecretCondition{
+				VaultSecretMonitor{
+					Data: map[string]string{
+						"ttl":         "24h",
+						"common_name": "web.example.com",
+					},
+				},
+			},
+			"pki/issue/web",
+			`"pki/issue/web" "common_name=web.example.com" "ttl=24h"`,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.c.hcatQuery(tc.path)
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestVaultSecretCondition_appendModuleAttribute(t *testing.T) {
+	f := hclwrite.NewEmptyFile()
+	VaultSecretCondition{}.appendModuleAttribute(f.Body())
+	assert.Equal(t, "vault_secrets = var.vault_secrets\n", string(f.Bytes()))
+}
+
+func TestVaultSecretCondition_render(t *testing.T) {
+	// Test that the rendered secrets are valid HCL
+	cases := []struct {
+		name    string
+		secrets map[string]map[string]interface{}
+		exp     []string
+	}{
+		{
+			"no secrets",
+			nil,
+			[]string{"vault_secrets = {\n}"},
+		},
+		{
+			"secrets",
+			map[string]map[string]interface{}{
+				"kv/data/app": {"data": map[string]interface{}{"password": "s3cr3t"}},
+				"kv/data/db":  {"data": map[string]interface{}{"password": "p4ssw0rd"}},
+			},
+			[]string{
+				`"kv/data/app?version=2" = {`,
+				`password = "s3cr3t"`,
+				`"kv/data/db" = {`,
+				`password = "p4ssw0rd"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &VaultSecretCondition{
+				VaultSecretMonitor{
+					Paths: []string{"kv/data/app?version=2", "kv/data/db"},
+				},
+			}
+			w := new(strings.Builder)
+			require.NoError(t, c.appendTemplate(w))
+
+			fixture := &tmplfunc.Fixture{VaultSecrets: tc.secrets}
+			tmpl := hcat.NewTemplate(hcat.TemplateInput{
+				Contents:     w.String(),
+				FuncMapMerge: tmplfunc.HCLMap(nil),
+			})
+			content, err := tmpl.Execute(fixture.Recaller())
+			require.NoError(t, err)
+
+			_, diags := hclsyntax.ParseConfig(content, "vault_secrets.tfvars", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags.Error())
+			for _, exp := range tc.exp {
+				assert.Contains(t, string(content), exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package tftmpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Condition = (*WebhookCondition)(nil)
+)
+
+// WebhookPayloadVariable is the name of the input variable of the JSON payload
+// of the webhook request that triggered the task.
+const WebhookPayloadVariable = "webhook_payload"
+
+// webhookSchemaTypes maps the JSON types of a webhook payload schema to the
+// Terraform type of the field in the webhook_payload variable.
+var webhookSchemaTypes = map[string]string{
+	"string": "string",
+	"number": "number",
+	"bool":   "bool",
+	"list":   "any",
+	"object": "any",
+}
+
+// WebhookCondition handles appending templating for the webhook run condition.
+// The webhook payload is not monitored from Consul. It is written to the
+// WebhookPayloadFilename file of the root module when the task is triggered.
+type WebhookCondition struct {
+	// Schema maps the fields of the payload to their JSON type
+	Schema map[string]string
+}
+
+// ServicesAppended always returns false for webhook as it doesn't deal with
+// services
+func (c WebhookCondition) ServicesAppended() bool {
+	return false
+}
+
+// SourceIncludesVariable always returns true for webhook since the payload is
+// always provided to the module
+func (c WebhookCondition) SourceIncludesVariable() bool {
+	return true
+}
+
+func (c WebhookCondition) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal(WebhookPayloadVariable, hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: WebhookPayloadVariable},
+	})
+}
+
+// appendTemplate does not write a template since the webhook payload is not
+// rendered from Consul
+func (c WebhookCondition) appendTemplate(io.Writer) error {
+	return nil
+}
+
+// appendVariable writes the webhook_payload variable. The variable is an
+// object of the schema fields if a schema is configured, otherwise any type.
+// The variable defaults to null until the task is triggered by a webhook.
+func (c WebhookCondition) appendVariable(w io.Writer) error {
+	varType := "any"
+	if len(c.Schema) > 0 {
+		fields := make([]string, 0, len(c.Schema))
+		for k := range c.Schema {
+			fields = append(fields, k)
+		}
+		sort.Strings(fields)
+
+		var b bytes.Buffer
+		b.WriteString("object({\n")
+		for _, f := range fields {
+			t, ok := webhookSchemaTypes[c.Schema[f]]
+			if !ok {
+				t = "any"
+			}
+			fmt.Fprintf(&b, "%s = %s\n", f, t)
+		}
+		b.WriteString("})")
+		varType = b.String()
+	}
+
+	content := fmt.Sprintf(variableWebhookPayloadTmpl, varType)
+	_, err := w.Write(hclwrite.Format([]byte(content)))
+	return err
+}
+
+const variableWebhookPayloadTmpl = `
+# Webhook payload definition protocol v0
+variable "webhook_payload" {
+description = "JSON payload of the webhook request that triggered the task"
+type = %s
+default = null
+}
+`
+
+// WriteWebhookPayload writes the JSON payload of a webhook request to the
+// WebhookPayloadFilename file in the root module directory. Terraform loads
+// the file automatically as the value of the webhook_payload variable.
+func WriteWebhookPayload(dir string, payload []byte, perms os.FileMode) error {
+	content, err := json.MarshalIndent(map[string]json.RawMessage{
+		WebhookPayloadVariable: json.RawMessage(payload),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("invalid webhook payload: %s", err)
+	}
+
+	path := filepath.Join(dir, WebhookPayloadFilename)
+	if err := ioutil.WriteFile(path, content, perms); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write webhook payload", "file_path", path, "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package tftmpl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookCondition_appendModuleAttribute(t *testing.T) {
+	f := hclwrite.NewEmptyFile()
+	WebhookCondition{}.appendModuleAttribute(f.Body())
+	assert.Equal(t, "webhook_payload = var.webhook_payload\n", string(f.Bytes()))
+}
+
+func TestWebhookCondition_appendVariable(t *testing.T) {
+	testcases := []struct {
+		name string
+		c    WebhookCondition
+		exp  string
+	}{
+		{
+			"no schema",
+			WebhookCondition{},
+			`
+# Webhook payload definition protocol v0
+variable "webhook_payload" {
+  description = "JSON payload of the webhook request that triggered the task"
+  type        = any
+  default     = null
+}
+`,
+		},
+		{
+			"schema",
+			WebhookCondition{
+				Schema: map[string]string{
+					"commit":  "string",
+					"files":   "list",
+					"dry_run": "bool",
+				},
+			},
+			`
+# Webhook payload definition protocol v0
+variable "webhook_payload" {
+  description = "JSON payload of the webhook request that triggered the task"
+  type = object({
+    commit  = string
+    dry_run = bool
+    files   = any
+  })
+  default = null
+}
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			err := tc.c.appendVariable(w)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, w.String())
+		})
+	}
+}
+
+func TestWriteWebhookPayload(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		err = WriteWebhookPayload(dir, []byte(`{"commit":"abc123"}`), 0640)
+		require.NoError(t, err)
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, WebhookPayloadFilename))
+		require.NoError(t, err)
+		assert.Equal(t, `{
+  "webhook_payload": {
+    "commit": "abc123"
+  }
+}`, string(content))
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		err = WriteWebhookPayload(dir, []byte(`{"commit":`), 0640)
+		assert.Error(t, err)
+		_, err = os.Stat(filepath.Join(dir, WebhookPayloadFilename))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
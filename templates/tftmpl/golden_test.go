@@ -134,15 +134,172 @@ func TestNewFiles(t *testing.T) {
 			Func:   newVariablesTF,
 			Golden: "testdata/consul-kv/variables.tf",
 			Input: RootModuleInputData{
-				SourceInput: &ConsulKVSourceInput{
+				SourceInputs: []SourceInput{&ConsulKVSourceInput{
 					ConsulKVMonitor{
 						Path:       "key-path",
 						Datacenter: "dc1",
 					},
+				}},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition any)",
+			Func:   newVariablesTF,
+			Golden: "testdata/consul-kv/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &CompositeCondition{
+					Conditions: []Condition{
+						&ConsulKVCondition{
+							ConsulKVMonitor{
+								Path:       "key-path",
+								Datacenter: "dc1",
+							},
+							true,
+						},
+						&NodesCondition{
+							NodesMonitor{
+								Datacenter: "dc1",
+							},
+							false,
+						},
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition nodes)",
+			Func:   newVariablesTF,
+			Golden: "testdata/nodes/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &NodesCondition{
+					NodesMonitor{
+						Datacenter: "dc1",
+					},
+					true,
 				},
 				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
 				Task:             task,
 			},
+		}, {
+			Name:   "variables.tf (source_input nodes)",
+			Func:   newVariablesTF,
+			Golden: "testdata/nodes/variables.tf",
+			Input: RootModuleInputData{
+				SourceInputs: []SourceInput{&NodesSourceInput{
+					NodesMonitor{
+						Datacenter: "dc1",
+					},
+				}},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition intentions)",
+			Func:   newVariablesTF,
+			Golden: "testdata/intentions/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &IntentionsCondition{
+					IntentionsMonitor{
+						DestinationRegexp: "^api$",
+					},
+					true,
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (source_input intentions)",
+			Func:   newVariablesTF,
+			Golden: "testdata/intentions/variables.tf",
+			Input: RootModuleInputData{
+				SourceInputs: []SourceInput{&IntentionsSourceInput{
+					IntentionsMonitor{
+						DestinationRegexp: "^api$",
+					},
+				}},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition config-entries)",
+			Func:   newVariablesTF,
+			Golden: "testdata/config-entries/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &ConfigEntriesCondition{
+					ConfigEntriesMonitor{
+						Kinds: []string{"service-defaults"},
+					},
+					true,
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition consul-event)",
+			Func:   newVariablesTF,
+			Golden: "testdata/consul-event/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &ConsulEventCondition{
+					Name: "deploy",
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition vault-secret)",
+			Func:   newVariablesTF,
+			Golden: "testdata/vault-secret/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &VaultSecretCondition{
+					VaultSecretMonitor{
+						Paths: []string{"kv/data/app"},
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition file)",
+			Func:   newVariablesTF,
+			Golden: "testdata/file/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &FileCondition{
+					FileMonitor{
+						Paths: []string{"inventory"},
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (condition webhook)",
+			Func:   newVariablesTF,
+			Golden: "testdata/webhook/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &WebhookCondition{
+					Schema: map[string]string{
+						"commit": "string",
+						"files":  "list",
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (source_input config-entries)",
+			Func:   newVariablesTF,
+			Golden: "testdata/config-entries/variables.tf",
+			Input: RootModuleInputData{
+				SourceInputs: []SourceInput{&ConfigEntriesSourceInput{
+					ConfigEntriesMonitor{
+						Kinds: []string{"service-defaults"},
+					},
+				}},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
 		}, {
 			Name:   "terraform.tfvars.tmpl (services condition)",
 			Func:   newTFVarsTmpl,
@@ -164,6 +321,230 @@ func TestNewFiles(t *testing.T) {
 				Task: task,
 			},
 		},
+		{
+			Name:   "terraform.tfvars.tmpl (services with datacenters)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/services-datacenters/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{},
+				Services: []Service{
+					{
+						Name:        "web",
+						Datacenters: []string{"dc1", "dc2"},
+						Description: "web service",
+					}, {
+						Name:        "api",
+						Datacenters: []string{"*"},
+						Namespace:   "ns2",
+						Description: "api service for web",
+					},
+				},
+				Task: task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (services with include_statuses)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/include-statuses/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{},
+				Services: []Service{
+					{
+						Name:            "web",
+						Description:     "web service",
+						IncludeStatuses: []string{"passing", "warning"},
+					}, {
+						Name:            "api",
+						Datacenters:     []string{"dc1", "dc2"},
+						Filter:          "\"tag\" in Service.Tags",
+						IncludeStatuses: []string{"critical", "maintenance"},
+					},
+				},
+				Task: task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (services condition with include_statuses)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/include-statuses/terraform_condition.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{
+					ServicesMonitor{
+						Regexp:          "^web.*",
+						IncludeStatuses: []string{"passing", "warning"},
+					},
+					true,
+				},
+				Task: task,
+			},
+		},
+		{
+			Name:   "variables.tf (services protocol v1)",
+			Func:   newVariablesTF,
+			Golden: "testdata/services-protocol-v1/variables.tf",
+			Input: RootModuleInputData{
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				ServicesProtocol: ServicesProtocolV1,
+				Task:             task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (services protocol v1)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/services-protocol-v1/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{},
+				Services: []Service{
+					{
+						Name:        "web",
+						Description: "web service",
+						Protocol:    ServicesProtocolV1,
+					}, {
+						Name:            "api",
+						Datacenters:     []string{"dc1", "dc2"},
+						IncludeStatuses: []string{"passing", "warning"},
+						Protocol:        ServicesProtocolV1,
+					},
+				},
+				ServicesProtocol: ServicesProtocolV1,
+				Task:             task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (services condition with services protocol v1)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/services-protocol-v1/terraform_condition.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{
+					ServicesMonitor{
+						Regexp:   "^web.*",
+						Protocol: ServicesProtocolV1,
+					},
+					true,
+				},
+				ServicesProtocol: ServicesProtocolV1,
+				Task:             task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (prepared-query condition)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/prepared-query/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &PreparedQueryCondition{
+					PreparedQueryMonitor{
+						Name:            "web-failover",
+						Near:            "_agent",
+						Limit:           3,
+						IncludeStatuses: []string{"passing"},
+					},
+				},
+				Task: task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (prepared-query source_input)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/prepared-query/terraform_source_input.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{SourceIncludesVar: true},
+				SourceInputs: []SourceInput{
+					&PreparedQuerySourceInput{
+						PreparedQueryMonitor{
+							Name:       "web-failover",
+							Datacenter: "dc2",
+							Protocol:   ServicesProtocolV1,
+						},
+					},
+				},
+				Task: task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (vault-secret condition)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/vault-secret/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &VaultSecretCondition{
+					VaultSecretMonitor{
+						Paths: []string{"kv/data/app?version=2", "kv/data/db"},
+					},
+				},
+				Services: []Service{{Name: "api"}},
+				Task:     task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (vault-secret source_input)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/vault-secret/terraform_source_input.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{SourceIncludesVar: true},
+				SourceInputs: []SourceInput{
+					&VaultSecretSourceInput{
+						VaultSecretMonitor{
+							Paths: []string{"pki/issue/web"},
+							Data: map[string]string{
+								"ttl":         "24h",
+								"common_name": "web.example.com",
+							},
+						},
+					},
+				},
+				Services: []Service{{Name: "api"}},
+				Task:     task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (file condition)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/file/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &FileCondition{
+					FileMonitor{
+						Paths: []string{"inventory", "racks/*.yaml"},
+					},
+				},
+				Services: []Service{{Name: "api"}},
+				Task:     task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (file source_input)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/file/terraform_source_input.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{SourceIncludesVar: true},
+				SourceInputs: []SourceInput{
+					&FileSourceInput{
+						FileMonitor{
+							Paths:  []string{"inventory/hosts"},
+							Format: "hcl",
+						},
+					},
+				},
+				Services: []Service{{Name: "api"}},
+				Task:     task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (services condition with query options)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/services-condition/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{
+					ServicesMonitor{
+						Regexp:     ".*",
+						Datacenter: "dc2",
+						Namespace:  "ns2",
+						NodeMeta:   map[string]string{"rack": "a"},
+						Filter:     `Service.Tags contains "v1"`,
+					},
+					true,
+				},
+				Task: task,
+			},
+		},
 		{
 			Name:   "terraform.tfvars.tmpl (services condition SourceIncludesVar true, empty SourceInput)",
 			Func:   newTFVarsTmpl,
@@ -175,8 +556,8 @@ func TestNewFiles(t *testing.T) {
 					},
 					true,
 				},
-				Task:        task,
-				SourceInput: &ServicesSourceInput{},
+				Task:         task,
+				SourceInputs: []SourceInput{&ServicesSourceInput{}},
 			},
 		},
 		{
@@ -186,11 +567,11 @@ func TestNewFiles(t *testing.T) {
 			Input: RootModuleInputData{
 				Condition: &ServicesCondition{},
 				Task:      task,
-				SourceInput: &ServicesSourceInput{
+				SourceInputs: []SourceInput{&ServicesSourceInput{
 					ServicesMonitor{
 						Regexp: ".*",
 					},
-				},
+				}},
 			},
 		},
 		{
@@ -205,11 +586,11 @@ func TestNewFiles(t *testing.T) {
 					true,
 				},
 				Task: task,
-				SourceInput: &ServicesSourceInput{
+				SourceInputs: []SourceInput{&ServicesSourceInput{
 					ServicesMonitor{
 						Regexp: ".*",
 					},
-				},
+				}},
 			},
 		},
 		{
@@ -230,11 +611,68 @@ func TestNewFiles(t *testing.T) {
 					},
 				},
 				Task: task,
-				SourceInput: &ServicesSourceInput{
+				SourceInputs: []SourceInput{&ServicesSourceInput{
 					ServicesMonitor{
 						Regexp: ".*",
 					},
+				}},
+			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (multiple source_inputs)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/multiple-source-inputs/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{
+					SourceIncludesVar: true,
+				},
+				Task: task,
+				SourceInputs: []SourceInput{
+					&ServicesSourceInput{
+						ServicesMonitor{
+							Regexp: ".*",
+						},
+					},
+					&ConsulKVSourceInput{
+						ConsulKVMonitor{
+							Path:       "key-path",
+							Datacenter: "dc1",
+						},
+					},
+					&NodesSourceInput{
+						NodesMonitor{
+							Datacenter: "dc1",
+						},
+					},
+				},
+			},
+		}, {
+			Name:   "variables.tf (multiple source_inputs)",
+			Func:   newVariablesTF,
+			Golden: "testdata/multiple-source-inputs/variables.tf",
+			Input: RootModuleInputData{
+				Condition: &ServicesCondition{
+					SourceIncludesVar: true,
+				},
+				SourceInputs: []SourceInput{
+					&ServicesSourceInput{
+						ServicesMonitor{
+							Regexp: ".*",
+						},
+					},
+					&ConsulKVSourceInput{
+						ConsulKVMonitor{
+							Path:       "key-path",
+							Datacenter: "dc1",
+						},
+					},
+					&NodesSourceInput{
+						NodesMonitor{
+							Datacenter: "dc1",
+						},
+					},
 				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
 			},
 		}, {
 			Name:   "terraform.tfvars.tmpl (catalog-services condition)",
@@ -464,12 +902,12 @@ func TestNewFiles(t *testing.T) {
 			Func:   newTFVarsTmpl,
 			Golden: "testdata/consul-kv/terraform_includes_vars.tfvars.tmpl",
 			Input: RootModuleInputData{
-				SourceInput: &ConsulKVSourceInput{
+				SourceInputs: []SourceInput{&ConsulKVSourceInput{
 					ConsulKVMonitor{
 						Path:       "key-path",
 						Datacenter: "dc1",
 					},
-				},
+				}},
 				Services: []Service{
 					{
 						Name:        "web",
@@ -484,18 +922,54 @@ func TestNewFiles(t *testing.T) {
 				},
 				Task: task,
 			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (consul-kv source_input decode)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/consul-kv/terraform_decode.tfvars.tmpl",
+			Input: RootModuleInputData{
+				SourceInputs: []SourceInput{&ConsulKVSourceInput{
+					ConsulKVMonitor{
+						Path:       "key-path",
+						Datacenter: "dc1",
+						Recurse:    true,
+						Decode:     "json",
+					},
+				}},
+				Services: []Service{
+					{
+						Name:        "web",
+						Description: "web service",
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "variables.tf (consul-kv source_input decode)",
+			Func:   newVariablesTF,
+			Golden: "testdata/consul-kv/variables_decode.tf",
+			Input: RootModuleInputData{
+				SourceInputs: []SourceInput{&ConsulKVSourceInput{
+					ConsulKVMonitor{
+						Path:       "key-path",
+						Datacenter: "dc1",
+						Decode:     "yaml",
+					},
+				}},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
 		}, {
 			Name:   "terraform.tfvars.tmpl (consul-kv source_input recurse true)",
 			Func:   newTFVarsTmpl,
 			Golden: "testdata/consul-kv/terraform_recurse_true.tfvars.tmpl",
 			Input: RootModuleInputData{
-				SourceInput: &ConsulKVSourceInput{
+				SourceInputs: []SourceInput{&ConsulKVSourceInput{
 					ConsulKVMonitor{
 						Path:       "key-path",
 						Datacenter: "dc1",
 						Recurse:    true,
 					},
-				},
+				}},
 				Services: []Service{
 					{
 						Name:        "web",
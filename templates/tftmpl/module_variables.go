@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
 	"github.com/hashicorp/hcl/v2"
@@ -57,6 +60,63 @@ func ParseModuleVariables(content []byte, filename string) (hcltmpl.Variables, e
 	return variables, nil
 }
 
+// LoadModuleInputVariables loads the names of the input variables declared
+// by the variable blocks of the Terraform module in the directory.
+func LoadModuleInputVariables(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{
+			Type:       "variable",
+			LabelNames: []string{"name"},
+		}},
+	}
+
+	p := hclparse.NewParser()
+	var names []string
+	var diags hcl.Diagnostics
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, f.Name())
+		var hclFile *hcl.File
+		var diag hcl.Diagnostics
+		switch {
+		case strings.HasSuffix(f.Name(), ".tf"):
+			hclFile, diag = p.ParseHCLFile(path)
+		case strings.HasSuffix(f.Name(), ".tf.json"):
+			hclFile, diag = p.ParseJSONFile(path)
+		default:
+			continue
+		}
+		if diag.HasErrors() {
+			diags = diags.Extend(diag)
+			continue
+		}
+
+		content, _, diag := hclFile.Body.PartialContent(schema)
+		if diag.HasErrors() {
+			diags = diags.Extend(diag)
+			continue
+		}
+		for _, block := range content.Blocks {
+			names = append(names, block.Labels[0])
+		}
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
 // NewModuleVariablesTF writes content used for variables.module.tf of a
 // Terraform root module. These variable defintions correspond to variables
 // that are passed as arguments within the module block.
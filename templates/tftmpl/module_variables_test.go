@@ -1,6 +1,9 @@
 package tftmpl
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,3 +54,47 @@ tup = ["abc", 123, true]`),
 		})
 	}
 }
+
+func TestLoadModuleInputVariables(t *testing.T) {
+	t.Run("happy_path", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "module")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		files := map[string]string{
+			"main.tf": `
+variable "services" {
+  type = map(any)
+}
+resource "null_resource" "example" {}`,
+			"variables.tf.json": `{"variable": {"consul_kv": {"type": "map(string)"}}}`,
+			"README.md":         `variable "ignored" {}`,
+		}
+		for name, content := range files {
+			err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+			require.NoError(t, err)
+		}
+
+		names, err := LoadModuleInputVariables(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"consul_kv", "services"}, names)
+	})
+
+	t.Run("invalid_syntax", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "module")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		err = ioutil.WriteFile(filepath.Join(dir, "main.tf"),
+			[]byte(`variable "services" {`), 0644)
+		require.NoError(t, err)
+
+		_, err = LoadModuleInputVariables(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing_dir", func(t *testing.T) {
+		_, err := LoadModuleInputVariables("dne")
+		assert.Error(t, err)
+	})
+}
@@ -19,6 +19,8 @@ type CatalogServicesMonitor struct {
 	Regexp     string
 	Datacenter string
 	Namespace  string
+	Partition  string
+	Peer       string
 	NodeMeta   map[string]string
 }
 
@@ -74,6 +76,14 @@ func (m CatalogServicesMonitor) hcatQuery() string {
 		opts = append(opts, fmt.Sprintf("ns=%s", m.Namespace))
 	}
 
+	if m.Partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", m.Partition))
+	}
+
+	if m.Peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", m.Peer))
+	}
+
 	for k, v := range m.NodeMeta {
 		opts = append(opts, fmt.Sprintf("node-meta=%s:%s", k, v))
 	}
@@ -0,0 +1,128 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Monitor = (*ConfigEntriesMonitor)(nil)
+)
+
+// ConfigEntriesMonitor handles appending templating for the config-entries
+// run monitor
+type ConfigEntriesMonitor struct {
+	Kinds      []string
+	Regexp     string
+	Datacenter string
+	Namespace  string
+	Partition  string
+}
+
+// ServicesAppended always returns false for config-entries as it doesn't
+// deal with services
+func (m ConfigEntriesMonitor) ServicesAppended() bool {
+	return false
+}
+
+// SourceIncludesVariable returns true if the source variables are to be included in the template.
+// For the case of a config-entries monitor, this always returns true and must be overridden to
+// return based on other conditions.
+func (m ConfigEntriesMonitor) SourceIncludesVariable() bool {
+	return true
+}
+
+func (m ConfigEntriesMonitor) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("config_entries", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "config_entries"},
+	})
+}
+
+// appendTemplate writes the template needed to render the config entries of
+// each kind as the variable config_entries
+func (m ConfigEntriesMonitor) appendTemplate(w io.Writer) error {
+	q := m.hcatQuery()
+	var b strings.Builder
+	for _, kind := range m.Kinds {
+		fmt.Fprintf(&b, configEntriesKindTmpl, kind, q)
+	}
+
+	if _, err := fmt.Fprintf(w, configEntriesIncludesVarTmpl, b.String()); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write config-entries template to include variable", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (m ConfigEntriesMonitor) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableConfigEntries)
+	return err
+}
+
+// hcatQuery returns the query options shared by each config entry kind
+func (m ConfigEntriesMonitor) hcatQuery() string {
+	var opts []string
+
+	if m.Regexp != "" {
+		opts = append(opts, fmt.Sprintf("regexp=%s", m.Regexp))
+	}
+
+	if m.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", m.Datacenter))
+	}
+
+	if m.Namespace != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", m.Namespace))
+	}
+
+	if m.Partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", m.Partition))
+	}
+
+	if len(opts) > 0 {
+		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+	}
+	return ""
+}
+
+const configEntriesIncludesVarTmpl = `
+config_entries = {%s
+}
+`
+
+// configEntriesKindTmpl renders the config entries of a single kind. Entries
+// are keyed by kind, namespace when set, and name.
+const configEntriesKindTmpl = `
+{{- with $entries := configEntries "%s" %s}}
+  {{- range $e := $entries }}
+  "{{ $e.Kind }}/{{ if $e.Namespace }}{{ $e.Namespace }}/{{ end }}{{ $e.Name }}" = {
+{{ HCLConfigEntry $e | indent 4 }}
+  },
+  {{- end}}
+{{- end}}`
+
+// variableConfigEntries is required for modules that include Consul config
+// entries. It is versioned to track compatibility between the generated root
+// module and modules that include config entries.
+var variableConfigEntries = []byte(`
+# Config Entries definition protocol v0
+variable "config_entries" {
+  description = "Consul config entries monitored by Consul Terraform Sync. The config attribute is the JSON encoded entry and can be decoded with jsondecode()"
+  type = map(
+    object({
+      kind      = string
+      name      = string
+      namespace = string
+      meta      = map(string)
+      config    = string
+    })
+  )
+}
+`)
@@ -21,6 +21,11 @@ type ConsulKVMonitor struct {
 	Recurse    bool
 	Datacenter string
 	Namespace  string
+
+	// Decode is the format to decode the values of the KV pairs from, so that
+	// they are rendered as typed objects. Values are rendered as strings when
+	// empty.
+	Decode string
 }
 
 // ServicesAppended always returns false for consul-kv as it doesn't
@@ -53,11 +58,16 @@ func (m ConsulKVMonitor) appendTemplate(w io.Writer) error {
 	logger := logging.Global().Named(logSystemName).Named(tftmplSubsystemName)
 	q := m.hcatQuery()
 
+	value := consulKVValueTmpl
+	if m.Decode != "" {
+		value = fmt.Sprintf(consulKVDecodeValueTmpl, m.Decode)
+	}
+
 	var baseTmpl string
 	if m.Recurse {
-		baseTmpl = fmt.Sprintf(consulKVRecurseBaseTmpl, q)
+		baseTmpl = fmt.Sprintf(consulKVRecurseBaseTmpl, q, value)
 	} else {
-		baseTmpl = fmt.Sprintf(consulKVBaseTmpl, q)
+		baseTmpl = fmt.Sprintf(consulKVBaseTmpl, q, value)
 	}
 	_, err := fmt.Fprintf(w, consulKVIncludesVarTmpl, baseTmpl)
 	if err != nil {
@@ -68,6 +78,10 @@ func (m ConsulKVMonitor) appendTemplate(w io.Writer) error {
 }
 
 func (m ConsulKVMonitor) appendVariable(w io.Writer) error {
+	if m.Decode != "" {
+		_, err := w.Write(variableConsulKVDecoded)
+		return err
+	}
 	_, err := w.Write(variableConsulKV)
 	return err
 }
@@ -98,7 +112,7 @@ consul_kv = {%s}
 const consulKVBaseTmpl = `
 {{- with $kv := keyExistsGet %s }}
   {{- if .Exists }}
-  "{{ .Path }}" = "{{ .Value }}"
+  "{{ .Path }}" = %s
   {{- end}}
 {{- end}}
 `
@@ -106,11 +120,18 @@ const consulKVBaseTmpl = `
 const consulKVRecurseBaseTmpl = `
 {{- with $kv := keys %s }}
   {{- range $k := $kv }}
-  "{{ .Path }}" = "{{ .Value }}"
+  "{{ .Path }}" = %s
   {{- end}}
 {{- end}}
 `
 
+// consulKVValueTmpl renders the value of a KV pair as a string
+const consulKVValueTmpl = `"{{ .Value }}"`
+
+// consulKVDecodeValueTmpl renders the value of a KV pair decoded from the
+// format as a typed object
+const consulKVDecodeValueTmpl = `{{ HCLConsulKVValue %q .Path .Value }}`
+
 // variableConsulKV is required for modules that include Consul KV
 // information. It is versioned to track compatibility between the generated
 // root module and modules that include Consul KV.
@@ -121,3 +142,13 @@ variable "consul_kv" {
   type        = map(string)
 }
 `)
+
+// variableConsulKVDecoded is the variableConsulKV for Consul KV values that
+// are decoded into typed objects
+var variableConsulKVDecoded = []byte(`
+# Consul KV definition protocol v0
+variable "consul_kv" {
+  description = "Consul KV pair with decoded values"
+  type        = any
+}
+`)
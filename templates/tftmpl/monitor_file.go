@@ -0,0 +1,99 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Monitor = (*FileMonitor)(nil)
+)
+
+// FilesVariable is the name of the input variable of the contents of the
+// local files of a file monitor
+const FilesVariable = "files"
+
+// FileMonitor handles appending templating for the file run monitor. The
+// parsed contents of the files are always included as the variable files.
+type FileMonitor struct {
+	// Paths are the paths, directories, or glob patterns of the files
+	Paths []string
+
+	// Format is the format to parse the contents of the files as. The format
+	// is inferred from the extension of each file when empty.
+	Format string
+}
+
+// ServicesAppended always returns false for file as it doesn't deal with
+// services
+func (m FileMonitor) ServicesAppended() bool {
+	return false
+}
+
+// SourceIncludesVariable always returns true for file since the contents of
+// the files are always provided to the module
+func (m FileMonitor) SourceIncludesVariable() bool {
+	return true
+}
+
+func (m FileMonitor) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal(FilesVariable, hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: FilesVariable},
+	})
+}
+
+// appendTemplate writes the template needed to render the parsed contents of
+// the files as the variable files, keyed by the path of each file. The files
+// are read as a single dependency, so adding, removing, or changing any of
+// the files re-renders the template.
+func (m FileMonitor) appendTemplate(w io.Writer) error {
+	baseTmpl := fmt.Sprintf(fileBaseTmpl, m.hcatQuery(), m.Format)
+	if _, err := fmt.Fprintf(w, fileIncludesVarTmpl, baseTmpl); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write file template to include variable", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (m FileMonitor) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableFiles)
+	return err
+}
+
+func (m FileMonitor) hcatQuery() string {
+	opts := make([]string, 0, len(m.Paths))
+	for _, path := range m.Paths {
+		opts = append(opts, fmt.Sprintf("%q", path))
+	}
+	return strings.Join(opts, " ")
+}
+
+const fileIncludesVarTmpl = `
+files = {%s}
+`
+
+const fileBaseTmpl = `
+{{- with $files := localFiles %s }}
+  {{- range $f := $files }}
+  {{ printf "%%q" .Path }} = {{ HCLLocalFile %q .Path .Content }}
+  {{- end}}
+{{- end}}
+`
+
+// variableFiles is required for modules that include local files. It is
+// versioned to track compatibility between the generated root module and
+// modules that include the files.
+var variableFiles = []byte(`
+# Local files definition protocol v0
+variable "files" {
+  description = "Parsed contents of local files by path"
+  type        = any
+}
+`)
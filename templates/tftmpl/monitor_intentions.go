@@ -0,0 +1,127 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Monitor = (*IntentionsMonitor)(nil)
+)
+
+// IntentionsMonitor handles appending templating for the intentions run
+// monitor
+type IntentionsMonitor struct {
+	SourceRegexp      string
+	DestinationRegexp string
+	Datacenter        string
+	Namespace         string
+	Partition         string
+}
+
+// ServicesAppended always returns false for intentions as it doesn't deal
+// with services
+func (m IntentionsMonitor) ServicesAppended() bool {
+	return false
+}
+
+// SourceIncludesVariable returns true if the source variables are to be included in the template.
+// For the case of an intentions monitor, this always returns true and must be overridden to
+// return based on other conditions.
+func (m IntentionsMonitor) SourceIncludesVariable() bool {
+	return true
+}
+
+func (m IntentionsMonitor) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("intentions", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "intentions"},
+	})
+}
+
+// appendTemplate writes the template needed to render the intentions as the
+// variable intentions
+func (m IntentionsMonitor) appendTemplate(w io.Writer) error {
+	q := m.hcatQuery()
+	if _, err := fmt.Fprintf(w, intentionsIncludesVarTmpl, q); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write intentions template to include variable", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (m IntentionsMonitor) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableIntentions)
+	return err
+}
+
+func (m IntentionsMonitor) hcatQuery() string {
+	var opts []string
+
+	if m.SourceRegexp != "" {
+		opts = append(opts, fmt.Sprintf("source=%s", m.SourceRegexp))
+	}
+
+	if m.DestinationRegexp != "" {
+		opts = append(opts, fmt.Sprintf("destination=%s", m.DestinationRegexp))
+	}
+
+	if m.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", m.Datacenter))
+	}
+
+	if m.Namespace != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", m.Namespace))
+	}
+
+	if m.Partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", m.Partition))
+	}
+
+	if len(opts) > 0 {
+		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+	}
+	return ""
+}
+
+const intentionsIncludesVarTmpl = `
+intentions = [
+{{- with $intentions := intentions %s}}
+  {{- range $i := $intentions }}
+  {
+{{ HCLIntention $i | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+]
+`
+
+// variableIntentions is required for modules that include Consul service
+// intentions. It is versioned to track compatibility between the generated
+// root module and modules that include intentions.
+var variableIntentions = []byte(`
+# Intentions definition protocol v0
+variable "intentions" {
+  description = "Consul service intentions monitored by Consul Terraform Sync, ordered by precedence"
+  type = list(
+    object({
+      id                    = string
+      description           = string
+      source_name           = string
+      source_namespace      = string
+      source_type           = string
+      destination_name      = string
+      destination_namespace = string
+      action                = string
+      precedence            = number
+      meta                  = map(string)
+    })
+  )
+}
+`)
@@ -0,0 +1,126 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Monitor = (*NodesMonitor)(nil)
+)
+
+// NodesMonitor handles appending templating for the nodes run monitor
+type NodesMonitor struct {
+	Datacenter string
+	Partition  string
+	Peer       string
+	NodeMeta   map[string]string
+	Filter     string
+}
+
+// ServicesAppended always returns false for nodes as it doesn't deal with
+// services
+func (m NodesMonitor) ServicesAppended() bool {
+	return false
+}
+
+// SourceIncludesVariable returns true if the source variables are to be included in the template.
+// For the case of a nodes monitor, this always returns true and must be overridden to
+// return based on other conditions.
+func (m NodesMonitor) SourceIncludesVariable() bool {
+	return true
+}
+
+func (m NodesMonitor) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("nodes", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "nodes"},
+	})
+}
+
+// appendTemplate writes the template needed to render the nodes as the
+// variable nodes
+func (m NodesMonitor) appendTemplate(w io.Writer) error {
+	logger := logging.Global().Named(logSystemName).Named(tftmplSubsystemName)
+	q := m.hcatQuery()
+	if _, err := fmt.Fprintf(w, nodesIncludesVarTmpl, q); err != nil {
+		logger.Error("unable to write nodes template to include variable", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (m NodesMonitor) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableNodes)
+	return err
+}
+
+func (m NodesMonitor) hcatQuery() string {
+	var opts []string
+
+	if m.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", m.Datacenter))
+	}
+
+	if m.Partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", m.Partition))
+	}
+
+	if m.Peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", m.Peer))
+	}
+
+	var meta []string
+	for k, v := range m.NodeMeta {
+		meta = append(meta, fmt.Sprintf("node-meta=%s:%s", k, v))
+	}
+	sort.Strings(meta)
+	opts = append(opts, meta...)
+
+	if m.Filter != "" {
+		opts = append(opts, strings.ReplaceAll(m.Filter, `"`, `\"`))
+	}
+
+	if len(opts) > 0 {
+		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+	}
+	return ""
+}
+
+const nodesIncludesVarTmpl = `
+nodes = {
+{{- with $nodes := catalogNodes %s}}
+  {{- range $n := $nodes }}
+  "{{ $n.Node }}" = {
+{{ HCLNode $n | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+}
+`
+
+// variableNodes is required for modules that include Consul node information.
+// It is versioned to track compatibility between the generated root module
+// and modules that include nodes.
+var variableNodes = []byte(`
+# Nodes definition protocol v0
+variable "nodes" {
+  description = "Consul nodes monitored by Consul Terraform Sync"
+  type = map(
+    object({
+      id               = string
+      node             = string
+      address          = string
+      datacenter       = string
+      tagged_addresses = map(string)
+      meta             = map(string)
+    })
+  )
+}
+`)
@@ -0,0 +1,100 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Monitor = (*PreparedQueryMonitor)(nil)
+)
+
+// PreparedQueryMonitor handles appending templating for the prepared-query
+// run monitor. The service instances of the prepared query result are always
+// included as the services variable in place of the task's services.
+type PreparedQueryMonitor struct {
+	// Name is the name or ID of the prepared query
+	Name string
+
+	// Datacenter, Near, and Limit are the options of the prepared query
+	// execution
+	Datacenter string
+	Near       string
+	Limit      int
+
+	// IncludeStatuses are the health statuses of the instances to include.
+	// Instances of all statuses are included when empty.
+	IncludeStatuses []string
+
+	// Protocol is the version of the service definition protocol. The service
+	// details are queried for the protocol v1.
+	Protocol int
+}
+
+// ServicesAppended always returns true for prepared-query since the result
+// provides the services variable
+func (m PreparedQueryMonitor) ServicesAppended() bool {
+	return true
+}
+
+// SourceIncludesVariable always returns true for prepared-query since the
+// services variable is always provided to the module
+func (m PreparedQueryMonitor) SourceIncludesVariable() bool {
+	return true
+}
+
+func (m PreparedQueryMonitor) appendModuleAttribute(*hclwrite.Body) {}
+
+// appendTemplate writes the template needed to render the service instances
+// of the prepared query result as the services variable
+func (m PreparedQueryMonitor) appendTemplate(w io.Writer) error {
+	q := m.hcatPipeline()
+	if _, err := fmt.Fprintf(w, servicesRegexIncludesVarTmpl, q); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write prepared-query template", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (m PreparedQueryMonitor) appendVariable(io.Writer) error {
+	return nil
+}
+
+// hcatPipeline returns the template pipeline that executes the prepared query
+func (m PreparedQueryMonitor) hcatPipeline() string {
+	f := "preparedQuery"
+	if m.Protocol >= ServicesProtocolV1 {
+		f = "preparedQueryDetails"
+	}
+	return includeStatusesPipeline(f+" "+m.hcatQuery(), m.IncludeStatuses)
+}
+
+func (m PreparedQueryMonitor) hcatQuery() string {
+	var opts []string
+
+	if m.Name != "" {
+		opts = append(opts, fmt.Sprintf("name=%s", m.Name))
+	}
+
+	if m.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", m.Datacenter))
+	}
+
+	if m.Near != "" {
+		opts = append(opts, fmt.Sprintf("near=%s", m.Near))
+	}
+
+	if m.Limit > 0 {
+		opts = append(opts, fmt.Sprintf("limit=%d", m.Limit))
+	}
+
+	if len(opts) > 0 {
+		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+	}
+	return ""
+}
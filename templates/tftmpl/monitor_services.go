@@ -3,6 +3,7 @@ package tftmpl
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -47,6 +48,24 @@ type Monitor interface {
 // ServicesMonitor handles appending templating for the services run monitor
 type ServicesMonitor struct {
 	Regexp string
+
+	// Datacenter, Datacenters, Namespace, Partition, Peer, NodeMeta, and
+	// Filter are the options of the query for the services matching the Regexp
+	Datacenter  string
+	Datacenters []string
+	Namespace   string
+	Partition   string
+	Peer        string
+	NodeMeta    map[string]string
+	Filter      string
+
+	// IncludeStatuses are the health statuses of the instances to include.
+	// Instances of all statuses are included when empty.
+	IncludeStatuses []string
+
+	// Protocol is the version of the service definition protocol. The service
+	// details are queried for the protocol v1.
+	Protocol int
 }
 
 // ServicesAppended returns true if the services are to be appended
@@ -61,7 +80,7 @@ func (m ServicesMonitor) appendTemplate(w io.Writer) error {
 	if m.Regexp == "" {
 		return nil
 	}
-	q := m.hcatQuery()
+	q := m.hcatPipeline()
 	var err error
 	_, err = fmt.Fprintf(w, servicesRegexIncludesVarTmpl, q)
 
@@ -82,6 +101,15 @@ func (m ServicesMonitor) SourceIncludesVariable() bool {
 	return true
 }
 
+// hcatPipeline returns the template pipeline that queries the services
+func (m ServicesMonitor) hcatPipeline() string {
+	f := "servicesRegex"
+	if m.Protocol >= ServicesProtocolV1 {
+		f = "servicesRegexDetails"
+	}
+	return includeStatusesPipeline(f+" "+m.hcatQuery(), m.IncludeStatuses)
+}
+
 func (m ServicesMonitor) hcatQuery() string {
 	var opts []string
 
@@ -89,6 +117,37 @@ func (m ServicesMonitor) hcatQuery() string {
 		opts = append(opts, fmt.Sprintf("regexp=%s", m.Regexp))
 	}
 
+	if m.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", m.Datacenter))
+	}
+
+	for _, dc := range m.Datacenters {
+		opts = append(opts, fmt.Sprintf("dc=%s", dc))
+	}
+
+	if m.Namespace != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", m.Namespace))
+	}
+
+	if m.Partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", m.Partition))
+	}
+
+	if m.Peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", m.Peer))
+	}
+
+	var meta []string
+	for k, v := range m.NodeMeta {
+		meta = append(meta, fmt.Sprintf("node-meta=%s:%s", k, v))
+	}
+	sort.Strings(meta)
+	opts = append(opts, meta...)
+
+	if m.Filter != "" {
+		opts = append(opts, strings.ReplaceAll(m.Filter, `"`, `\"`))
+	}
+
 	if len(opts) > 0 {
 		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
 	}
@@ -100,7 +159,7 @@ services = {%s}
 `, servicesRegexTmpl)
 
 const servicesRegexTmpl = `
-{{- with $srv := servicesRegex %s }}
+{{- with $srv := %s }}
   {{- range $s := $srv}}
   "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
 {{ HCLService $s | indent 4 }}
@@ -0,0 +1,115 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Monitor = (*VaultSecretMonitor)(nil)
+)
+
+// VaultSecretsVariable is the name of the input variable of the secrets at
+// the Vault paths of a vault-secret monitor
+const VaultSecretsVariable = "vault_secrets"
+
+// VaultSecretMonitor handles appending templating for the vault-secret run
+// monitor. The secrets at the paths are always included as the sensitive
+// variable vault_secrets.
+type VaultSecretMonitor struct {
+	Paths []string
+
+	// Data are the parameters written to each path. The paths are read when
+	// there is no data.
+	Data map[string]string
+}
+
+// ServicesAppended always returns false for vault-secret as it doesn't deal
+// with services
+func (m VaultSecretMonitor) ServicesAppended() bool {
+	return false
+}
+
+// SourceIncludesVariable always returns true for vault-secret since the
+// secrets are always provided to the module
+func (m VaultSecretMonitor) SourceIncludesVariable() bool {
+	return true
+}
+
+func (m VaultSecretMonitor) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal(VaultSecretsVariable, hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: VaultSecretsVariable},
+	})
+}
+
+// appendTemplate writes the template needed to render the secrets as the
+// variable vault_secrets, keyed by the configured paths. Each path is a
+// separate Vault dependency, so a new version or lease of any of the secrets
+// re-renders the template.
+func (m VaultSecretMonitor) appendTemplate(w io.Writer) error {
+	var secrets strings.Builder
+	for _, path := range m.Paths {
+		fmt.Fprintf(&secrets, vaultSecretBaseTmpl, m.hcatQuery(path), path)
+	}
+
+	if _, err := fmt.Fprintf(w, vaultSecretIncludesVarTmpl, secrets.String()); err != nil {
+		logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+			"unable to write vault-secret template to include variable", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (m VaultSecretMonitor) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableVaultSecrets)
+	return err
+}
+
+// hcatQuery returns the arguments of the secret template function for the
+// path. The data is sorted by key so that the query is stable.
+func (m VaultSecretMonitor) hcatQuery(path string) string {
+	opts := []string{fmt.Sprintf("%q", path)}
+
+	keys := make([]string, 0, len(m.Data))
+	for k := range m.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		opts = append(opts, fmt.Sprintf("%q", fmt.Sprintf("%s=%s", k, m.Data[k])))
+	}
+	return strings.Join(opts, " ")
+}
+
+const vaultSecretIncludesVarTmpl = `
+vault_secrets = {%s
+}
+`
+
+const vaultSecretBaseTmpl = `
+{{- with $s := secret %s }}
+  %q = {
+{{ HCLVaultSecret $s | indent 4 }}
+  }
+{{- end}}`
+
+// variableVaultSecrets is required for modules that include Vault secrets. It
+// is versioned to track compatibility between the generated root module and
+// modules that include the secrets. The variable is marked as sensitive, which
+// requires Terraform 0.14+.
+var variableVaultSecrets = []byte(`
+# Vault secrets definition protocol v0
+variable "vault_secrets" {
+  description = "Vault secrets by path with the lease information and data of each secret"
+  type        = any
+  sensitive   = true
+}
+`)
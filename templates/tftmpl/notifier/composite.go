@@ -0,0 +1,239 @@
+package notifier
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat"
+)
+
+const compositeSubsystemName = "composite"
+
+var (
+	_ RunStateNotifier = (*Composite)(nil)
+)
+
+// Composite is a custom notifier expected to be used for a template of a task
+// with an any or all condition. It combines the notifiers of the nested
+// conditions, which each decide whether a dependency change triggers their
+// condition.
+//
+// In any-mode, the composite notifies when any nested condition is triggered.
+// In all-mode, each nested condition is latched when it is triggered and the
+// composite notifies once all nested conditions have been triggered, after
+// which the latches are reset.
+//
+// A nested condition with a value, e.g. a consul-kv condition with a value, is
+// not latched. It is only triggered while its dependency is set to the value.
+// Once the template has been rendered, an all condition does not execute the
+// template while a nested value is not met, so that dependency changes
+// received beforehand do not trigger the task.
+//
+// A scheduled composite, an all condition with a nested schedule condition,
+// is triggered by the schedule instead of the watcher. It does not notify the
+// watcher except to complete once-mode, and only lets the template know that
+// its dependencies have been updated once all nested conditions have been
+// triggered. This way the scheduled task only runs if all of the nested
+// conditions were triggered since the task last ran.
+type Composite struct {
+	templates.Template
+
+	conditions []templates.Template
+	triggered  []bool
+	all        bool
+	scheduled  bool
+
+	mu       sync.Mutex
+	rendered bool
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// ConditionNotifierFunc creates the notifier for a nested condition of a
+// composite condition. The template passed to the function does not notify
+// the watcher, the composite notifier decides whether to notify instead.
+type ConditionNotifierFunc func(templates.Template) templates.Template
+
+// NewComposite creates a new Composite notifier.
+// all parameter: whether all nested conditions need to be triggered, or any
+// scheduled parameter: whether the task is triggered by a nested schedule
+// dependencyCount parameter: the number of dependencies of the template
+// conditions parameter: the notifier funcs of the nested conditions that
+// watch dependencies. Nested schedule conditions are excluded.
+func NewComposite(tmpl templates.Template, all, scheduled bool,
+	dependencyCount int, conditions ...ConditionNotifierFunc) *Composite {
+
+	n := &Composite{
+		Template:  tmpl,
+		all:       all,
+		scheduled: scheduled,
+		depTotal:  dependencyCount,
+		triggered: make([]bool, len(conditions)),
+		logger:    logging.Global().Named(logSystemName).Named(compositeSubsystemName),
+	}
+	n.conditions = make([]templates.Template, len(conditions))
+	for i, f := range conditions {
+		n.conditions[i] = f(nestedTemplate{tmpl})
+	}
+	return n
+}
+
+// Notify notifies when the combined result of the nested conditions is
+// triggered.
+//
+// Notifications are sent when:
+// A. Any nested condition is triggered by the dependency change (any-mode)
+// B. All nested conditions have been triggered since the last notification
+//    (all-mode)
+// C. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// For a scheduled composite, only C notifies the watcher.
+func (n *Composite) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+
+	// nested notifiers are always notified to keep track of their own state
+	triggered := false
+	for i, c := range n.conditions {
+		if c.Notify(d) {
+			n.triggered[i] = true
+			triggered = true
+		}
+		if v, ok := c.(valueCondition); ok && v.hasValue() && !v.conditionMet() {
+			// a nested value is no longer triggered once it is not met
+			n.triggered[i] = false
+		}
+	}
+
+	if !n.once {
+		n.counter++
+		if n.counter < n.depTotal {
+			// nested conditions are not tracked until once-mode completes
+			return false
+		}
+
+		// after all dependencies are received, notify so once-mode can complete
+		n.logger.Debug("notify once-mode complete")
+		n.once = true
+		n.reset()
+		n.Template.Notify(d)
+		return true
+	}
+
+	if n.all {
+		triggered = n.allTriggered()
+	}
+
+	if triggered {
+		n.logger.Debug("notify composite condition triggered", "all", n.all)
+		n.reset()
+		n.Template.Notify(d)
+		// scheduled tasks are not triggered by the watcher
+		return !n.scheduled
+	}
+
+	return false
+}
+
+// Execute executes the template unless it is an all condition with a nested
+// value that is not met. The template is always executed until it is rendered
+// for the first time so that once-mode can complete.
+func (n *Composite) Execute(rec hcat.Recaller) ([]byte, error) {
+	n.mu.Lock()
+	rendered := n.rendered
+	n.mu.Unlock()
+
+	if n.all && rendered && !n.valuesMet() {
+		n.logger.Trace("nested value not met, skip executing template")
+		return nil, hcat.ErrNoNewValues
+	}
+	return n.Template.Execute(rec)
+}
+
+// Render renders the template and keeps track of whether the template has been
+// rendered
+func (n *Composite) Render(content []byte) (hcat.RenderResult, error) {
+	result, err := n.Template.Render(content)
+	if err == nil {
+		n.mu.Lock()
+		n.rendered = true
+		n.mu.Unlock()
+	}
+	return result, err
+}
+
+// Rendered forwards to the nested conditions that keep the state of the
+// dependency changes that triggered the task
+func (n *Composite) Rendered() {
+	for _, c := range n.conditions {
+		if r, ok := c.(RunStateNotifier); ok {
+			r.Rendered()
+		}
+	}
+}
+
+// Applied forwards to the nested conditions that keep the state of the
+// dependency changes that triggered the task
+func (n *Composite) Applied() {
+	for _, c := range n.conditions {
+		if r, ok := c.(RunStateNotifier); ok {
+			r.Applied()
+		}
+	}
+}
+
+// allTriggered returns whether all nested conditions have been triggered
+// since the last reset
+func (n *Composite) allTriggered() bool {
+	for _, t := range n.triggered {
+		if !t {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesMet returns whether the nested conditions with a value are met
+func (n *Composite) valuesMet() bool {
+	for _, c := range n.conditions {
+		if v, ok := c.(valueCondition); ok && !v.conditionMet() {
+			return false
+		}
+	}
+	return true
+}
+
+// reset clears the nested conditions that have been triggered. Nested
+// conditions with a value remain triggered while they are met.
+func (n *Composite) reset() {
+	for i, c := range n.conditions {
+		v, ok := c.(valueCondition)
+		n.triggered[i] = ok && v.hasValue() && v.conditionMet()
+	}
+}
+
+// valueCondition is implemented by the notifiers of nested conditions that can
+// be configured with a value that their dependency needs to be set to
+type valueCondition interface {
+	hasValue() bool
+	conditionMet() bool
+}
+
+// nestedTemplate is passed to the notifiers of nested conditions so that they
+// do not notify the template directly. The composite notifier notifies the
+// template based on the combined result instead.
+type nestedTemplate struct {
+	templates.Template
+}
+
+// Notify does not notify the template
+func (nestedTemplate) Notify(interface{}) bool {
+	return true
+}
@@ -0,0 +1,168 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func servicesNotifierFunc(tmpl templates.Template) templates.Template {
+	return NewServices(tmpl, 1, nil, nil)
+}
+
+func consulKVNotifierFunc(tmpl templates.Template) templates.Template {
+	return NewConsulKV(tmpl, 1, nil)
+}
+
+func Test_Composite_Notify_Any(t *testing.T) {
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	// Notifier has 2 dependencies: 1 services and 1 consul-kv
+	n := NewComposite(tmpl, false, false, 2, servicesNotifierFunc,
+		consulKVNotifierFunc)
+
+	// complete once-mode
+	n.Notify([]*dep.HealthService{})
+	assert.True(t, n.Notify([]*dep.KeyPair{}), "once-mode should have notified")
+	assert.True(t, n.once)
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// either nested condition triggers
+	assert.True(t, n.Notify([]*dep.HealthService{}), "services should have notified")
+	assert.True(t, n.Notify([]*dep.KeyPair{}), "consul-kv should have notified")
+	tmpl.AssertNumberOfCalls(t, "Notify", 3)
+
+	// other dependencies do not trigger
+	assert.False(t, n.Notify([]*dep.CatalogNode{}), "nodes should not have notified")
+	tmpl.AssertNumberOfCalls(t, "Notify", 3)
+}
+
+func Test_Composite_Notify_All(t *testing.T) {
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	// Notifier has 2 dependencies: 1 services and 1 consul-kv
+	n := NewComposite(tmpl, true, false, 2, servicesNotifierFunc,
+		consulKVNotifierFunc)
+
+	// complete once-mode
+	n.Notify([]*dep.KeyPair{})
+	assert.True(t, n.Notify([]*dep.HealthService{}), "once-mode should have notified")
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// services alone does not trigger, even if it changes again
+	assert.False(t, n.Notify([]*dep.HealthService{}))
+	assert.False(t, n.Notify([]*dep.HealthService{}))
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// consul-kv completes the condition
+	assert.True(t, n.Notify([]*dep.KeyPair{}), "all conditions should have notified")
+	tmpl.AssertNumberOfCalls(t, "Notify", 2)
+
+	// conditions are reset after notifying
+	assert.False(t, n.Notify([]*dep.KeyPair{}))
+	tmpl.AssertNumberOfCalls(t, "Notify", 2)
+}
+
+func Test_Composite_Notify_Scheduled(t *testing.T) {
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	// Notifier has 2 dependencies: 1 services and 1 consul-kv. Only the
+	// consul-kv condition is nested with the schedule condition.
+	n := NewComposite(tmpl, true, true, 2, consulKVNotifierFunc)
+
+	// once-mode notifies the watcher
+	n.Notify([]*dep.HealthService{})
+	assert.True(t, n.Notify([]*dep.KeyPair{}), "once-mode should have notified")
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// services does not update the template
+	assert.False(t, n.Notify([]*dep.HealthService{}))
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// consul-kv updates the template for the next scheduled run but does not
+	// notify the watcher
+	assert.False(t, n.Notify([]*dep.KeyPair{}))
+	tmpl.AssertNumberOfCalls(t, "Notify", 2)
+}
+
+func Test_Composite_Notify_Scheduled_Value(t *testing.T) {
+	// Example of running on schedule only while deploy/enabled is true:
+	// an all condition nesting a schedule and a consul-kv condition with a value
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+	tmpl.On("Execute", mock.Anything).Return([]byte("content"), nil)
+	tmpl.On("Render", mock.Anything).Return(hcat.RenderResult{}, nil)
+
+	// Notifier has 2 dependencies: 1 services and 1 consul-kv
+	n := NewComposite(tmpl, true, true, 2,
+		func(tmpl templates.Template) templates.Template {
+			return NewConsulKV(tmpl, 1, config.String("true"))
+		})
+	enabled := &dep.KeyPair{Key: "deploy/enabled", Value: "true", Exists: true}
+	disabled := &dep.KeyPair{Key: "deploy/enabled", Value: "false", Exists: true}
+
+	// once-mode renders regardless of the value
+	n.Notify([]*dep.HealthService{})
+	assert.True(t, n.Notify(disabled), "once-mode should have notified")
+	_, err := n.Execute(nil)
+	assert.NoError(t, err)
+	_, err = n.Render([]byte("content"))
+	assert.NoError(t, err)
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// services does not update the template while disabled
+	assert.False(t, n.Notify([]*dep.HealthService{}))
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// enabling updates the template for the next scheduled run
+	assert.False(t, n.Notify(enabled))
+	tmpl.AssertNumberOfCalls(t, "Notify", 2)
+	_, err = n.Execute(nil)
+	assert.NoError(t, err)
+
+	// services updates the template while enabled
+	assert.False(t, n.Notify([]*dep.HealthService{}))
+	tmpl.AssertNumberOfCalls(t, "Notify", 3)
+
+	// disabling before the scheduled run skips executing the template even
+	// though it was updated
+	assert.False(t, n.Notify(disabled))
+	tmpl.AssertNumberOfCalls(t, "Notify", 3)
+	_, err = n.Execute(nil)
+	assert.Equal(t, hcat.ErrNoNewValues, err)
+	tmpl.AssertNumberOfCalls(t, "Execute", 2)
+}
+
+func Test_Composite_RunState(t *testing.T) {
+	// Test that the run state is forwarded to the nested consul-event
+	// notifier, which stores the event only once the task is applied
+	dir := t.TempDir()
+	var event *ConsulEvent
+	consulEventNotifierFunc := func(tmpl templates.Template) templates.Template {
+		event = NewConsulEvent(tmpl, 1, dir)
+		return event
+	}
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+	n := NewComposite(tmpl, false, false, 2, servicesNotifierFunc,
+		consulEventNotifierFunc)
+
+	n.Notify([]*dep.HealthService{})
+	assert.True(t, n.Notify([]*tmplfunc.ConsulEvent{{ID: "e1", LTime: 1}}))
+	n.Rendered()
+	assert.Equal(t, ConsulEventState{}, event.last)
+
+	n.Applied()
+	assert.Equal(t, ConsulEventState{ID: "e1", LTime: 1}, event.last)
+}
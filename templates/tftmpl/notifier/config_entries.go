@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+)
+
+const configEntriesSubsystemName = "config-entries"
+
+// ConfigEntries is a custom notifier expected to be used for a template that
+// contains the configEntries template function.
+//
+// This notifier only notifies on changes to Consul config entries and
+// once-mode. It suppresses notifications for changes to other tmplfuncs.
+type ConfigEntries struct {
+	templates.Template
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// NewConfigEntries creates a new ConfigEntries notifier.
+// serviceCount parameter: the number of services the task is configured with
+// kindCount parameter: the number of config entry kinds the task monitors
+func NewConfigEntries(tmpl templates.Template, serviceCount, kindCount int) *ConfigEntries {
+	return &ConfigEntries{
+		Template: tmpl,
+		// expect services and a []*tmplfunc.ConfigEntry for each kind
+		depTotal: serviceCount + kindCount,
+		logger:   logging.Global().Named(logSystemName).Named(configEntriesSubsystemName),
+	}
+}
+
+// Notify notifies when Consul config entries are created, changed, or deleted.
+//
+// Notifications are sent when:
+// A. There is a change in a config entries dependency ([]*tmplfunc.ConfigEntry)
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not config entries. For example,
+//    Services ([]*dep.HealthService).
+func (n *ConfigEntries) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after all dependencies are received, notify so once-mode can complete
+		if n.counter >= n.depTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if _, ok := d.([]*tmplfunc.ConfigEntry); ok {
+		n.logger.Debug("notify config entries change")
+		notify = true
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_ConfigEntries_Notify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		dep      interface{}
+		expected bool
+	}{
+		{
+			"don't notify: other type of change",
+			[]*dep.HealthService{},
+			false,
+		},
+		{
+			"notify: config entries",
+			[]*tmplfunc.ConfigEntry{{Kind: "service-defaults", Name: "web"}},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := ConfigEntries{Template: tmpl, once: true, logger: logging.NewNullLogger()}
+			actual := n.Notify(tc.dep)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_ConfigEntries_Notify_Once_Mode(t *testing.T) {
+	// Test that notifier only completes once-mode after receiving the
+	// dependency for each service and each config entry kind.
+
+	// Notifier has 3 dependencies: 1 services and 2 config entry kinds
+	// 1. receive services dependency, no notification
+	// 2. receive first config entries dependency, notify for change
+	// 3. receive second config entries dependency, notify and complete once-mode
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true).Twice()
+	n := NewConfigEntries(tmpl, 1, 2)
+
+	// 1. services dependency does not notify
+	notify := n.Notify([]*dep.HealthService{})
+	assert.False(t, notify, "services dep should not have notified")
+	assert.False(t, n.once, "got 1/3 deps. once-mode should not be completed")
+	assert.Equal(t, 1, n.counter, "services dep should be 1st dep")
+
+	// 2. first config entries notifies
+	notify = n.Notify([]*tmplfunc.ConfigEntry{})
+	assert.True(t, notify, "config entries dep should have notified")
+	assert.False(t, n.once, "got 2/3 deps. once-mode should not be completed")
+	assert.Equal(t, 2, n.counter, "config entries dep should be 2nd dep")
+
+	// 3. second config entries notifies
+	notify = n.Notify([]*tmplfunc.ConfigEntry{})
+	assert.True(t, notify, "config entries dep should have notified")
+	assert.True(t, n.once, "got 3/3 deps. once-mode should be completed")
+	assert.Equal(t, 3, n.counter, "config entries dep should be 3rd dep")
+
+	// check mock template was called twice
+	tmpl.AssertExpectations(t)
+}
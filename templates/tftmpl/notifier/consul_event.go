@@ -0,0 +1,201 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+)
+
+const consulEventSubsystemName = "consul-event"
+
+// ConsulEventStateFilename is the name of the file that stores the last
+// Consul user event that was handled by a successful run of a task with a
+// consul-event condition
+const ConsulEventStateFilename = "consul_event_state.json"
+
+var (
+	_ RunStateNotifier = (*ConsulEvent)(nil)
+)
+
+// ConsulEventState identifies the last Consul user event handled by the task
+type ConsulEventState struct {
+	ID    string `json:"id"`
+	LTime uint64 `json:"ltime"`
+}
+
+// ConsulEvent is a custom notifier expected to be used for a template that
+// contains the consulEvents template function.
+//
+// This notifier only notifies on new Consul user events and once-mode. It
+// suppresses notifications for changes to other tmplfuncs and for changes to
+// the events that are not new, e.g. old events rolling off the agent's list.
+//
+// Events are de-duplicated by ID and Lamport time. Events are coalesced: the
+// task is triggered by the latest event, so several events that are received
+// between runs of the task trigger a single run, which renders the latest
+// event. The last event that was handled by a successful run of the task is
+// stored in the state directory so that the events that already ran the task
+// do not trigger it again after a restart, while an event of a failed or
+// interrupted run triggers the task again.
+type ConsulEvent struct {
+	templates.Template
+
+	// mu guards the events, since the notifier is notified by the watcher
+	// while the task is rendered and applied
+	mu sync.Mutex
+	// last is the last event handled by a successful run of the task
+	last ConsulEventState
+	// notified is the latest event that triggered the task
+	notified *ConsulEventState
+	// rendered is the latest event that triggered the task when the template
+	// was last rendered
+	rendered  *ConsulEventState
+	statePath string
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// NewConsulEvent creates a new ConsulEvent notifier.
+// serviceCount parameter: the number of services the task is configured with
+// stateDir parameter: the directory to store the last event in. The last
+// event is not stored if empty.
+func NewConsulEvent(tmpl templates.Template, serviceCount int, stateDir string) *ConsulEvent {
+	n := &ConsulEvent{
+		Template: tmpl,
+		// expect services and []*tmplfunc.ConsulEvent
+		depTotal: serviceCount + 1,
+		logger:   logging.Global().Named(logSystemName).Named(consulEventSubsystemName),
+	}
+
+	if stateDir != "" {
+		n.statePath = filepath.Join(stateDir, ConsulEventStateFilename)
+		n.loadState()
+	}
+
+	return n
+}
+
+// Notify notifies when there is a new Consul user event.
+//
+// Notifications are sent when:
+// A. The latest event of the events dependency ([]*tmplfunc.ConsulEvent) has
+//    a different ID and an equal or later Lamport time than the latest event
+//    that triggered the task
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not Consul user events. For example,
+//    Services ([]*dep.HealthService).
+//  - The events changed without a new event
+func (n *ConsulEvent) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after all dependencies are received, notify so once-mode can complete
+		if n.counter >= n.depTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if events, ok := d.([]*tmplfunc.ConsulEvent); ok {
+		latest := tmplfunc.LatestConsulEvent(events)
+		n.mu.Lock()
+		if n.isNew(latest) {
+			n.logger.Debug("notify new consul event", "id", latest.ID,
+				"ltime", latest.LTime)
+			n.notified = &ConsulEventState{ID: latest.ID, LTime: latest.LTime}
+			notify = true
+		}
+		n.mu.Unlock()
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
+
+// Rendered marks the latest event that triggered the task as rendered for
+// the next run of the task
+func (n *ConsulEvent) Rendered() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.rendered = n.notified
+}
+
+// Applied stores the event that was rendered for the run of the task as the
+// last event handled by the task, once the task has been applied successfully
+func (n *ConsulEvent) Applied() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.rendered == nil || *n.rendered == n.last {
+		return
+	}
+	n.last = *n.rendered
+	n.saveState()
+}
+
+// isNew returns whether the event has not triggered the task yet
+func (n *ConsulEvent) isNew(e *tmplfunc.ConsulEvent) bool {
+	triggered := n.last
+	if n.notified != nil {
+		triggered = *n.notified
+	}
+
+	if e == nil || e.ID == triggered.ID {
+		return false
+	}
+	return e.LTime >= triggered.LTime
+}
+
+// loadState loads the last event handled by the task from the state file
+func (n *ConsulEvent) loadState() {
+	content, err := ioutil.ReadFile(n.statePath)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err == nil {
+		err = json.Unmarshal(content, &n.last)
+	}
+	if err != nil {
+		n.logger.Warn("unable to load last consul event. events received "+
+			"before the restart may trigger the task", "file_path", n.statePath,
+			"error", err)
+		n.last = ConsulEventState{}
+	}
+}
+
+// saveState stores the last event handled by the task in the state file
+func (n *ConsulEvent) saveState() {
+	if n.statePath == "" {
+		return
+	}
+
+	content, err := json.Marshal(n.last)
+	if err == nil {
+		err = ioutil.WriteFile(n.statePath, content, 0640)
+	}
+	if err != nil {
+		n.logger.Error("unable to store last consul event", "file_path",
+			n.statePath, "error", err)
+	}
+}
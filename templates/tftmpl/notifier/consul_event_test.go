@@ -0,0 +1,198 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_ConsulEvent_Notify(t *testing.T) {
+	t.Parallel()
+
+	last := ConsulEventState{ID: "e2", LTime: 5}
+
+	cases := []struct {
+		name     string
+		dep      interface{}
+		expected bool
+	}{
+		{
+			"don't notify: other type of change",
+			[]*dep.HealthService{},
+			false,
+		},
+		{
+			"don't notify: no events",
+			[]*tmplfunc.ConsulEvent{},
+			false,
+		},
+		{
+			"don't notify: latest event already triggered",
+			[]*tmplfunc.ConsulEvent{
+				{ID: "e1", LTime: 4},
+				{ID: "e2", LTime: 5},
+			},
+			false,
+		},
+		{
+			"don't notify: older event",
+			[]*tmplfunc.ConsulEvent{{ID: "e0", LTime: 3}},
+			false,
+		},
+		{
+			"notify: new event",
+			[]*tmplfunc.ConsulEvent{
+				{ID: "e2", LTime: 5},
+				{ID: "e3", LTime: 6},
+			},
+			true,
+		},
+		{
+			"notify: new event with same ltime",
+			[]*tmplfunc.ConsulEvent{{ID: "e3", LTime: 5}},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := ConsulEvent{Template: tmpl, once: true, last: last,
+				logger: logging.NewNullLogger()}
+			actual := n.Notify(tc.dep)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_ConsulEvent_Notify_Once_Mode(t *testing.T) {
+	t.Run("services-last", func(t *testing.T) {
+		// Notifier has 2 dependencies: 1 services and 1 events
+		// 1. receive events dependency with no events, no notification
+		// 2. receive services dependency, notify for once-mode
+
+		tmpl := new(mocks.Template)
+		tmpl.On("Notify", mock.Anything).Return(true).Once()
+		n := NewConsulEvent(tmpl, 1, "")
+
+		// 1. events dependency without events does not notify
+		notify := n.Notify([]*tmplfunc.ConsulEvent{})
+		assert.False(t, notify, "events dep without events should not have notified")
+		assert.False(t, n.once, "got 1/2 deps. once-mode should not be completed")
+
+		// 2. services notifies
+		notify = n.Notify([]*dep.HealthService{})
+		assert.True(t, notify, "services dep should have notified")
+		assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
+
+		// check mock template was called once
+		tmpl.AssertExpectations(t)
+	})
+
+	t.Run("events-last", func(t *testing.T) {
+		// Notifier has 2 dependencies: 1 services and 1 events
+		// 1. receive services dependency, no notification
+		// 2. receive events dependency, notify
+
+		tmpl := new(mocks.Template)
+		tmpl.On("Notify", mock.Anything).Return(true).Once()
+		n := NewConsulEvent(tmpl, 1, "")
+
+		// 1. services dependency does not notify
+		notify := n.Notify([]*dep.HealthService{})
+		assert.False(t, notify, "services dep should not have notified")
+		assert.False(t, n.once, "got 1/2 deps. once-mode should not be completed")
+
+		// 2. events notifies
+		notify = n.Notify([]*tmplfunc.ConsulEvent{{ID: "e1", LTime: 1}})
+		assert.True(t, notify, "events dep should have notified")
+		assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
+
+		// check mock template was called once
+		tmpl.AssertExpectations(t)
+	})
+}
+
+func Test_ConsulEvent_State(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	events := []*tmplfunc.ConsulEvent{{ID: "e1", LTime: 1}}
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	// first notifier is triggered by the event and stores it once the task
+	// is applied
+	n := NewConsulEvent(tmpl, 0, dir)
+	n.once = true
+	assert.True(t, n.Notify(events), "new event should have notified")
+	assert.Equal(t, ConsulEventState{}, n.last)
+	n.Rendered()
+	n.Applied()
+	assert.Equal(t, ConsulEventState{ID: "e1", LTime: 1}, n.last)
+
+	// restarted notifier loads the event and is not triggered by it again
+	restarted := NewConsulEvent(tmpl, 0, dir)
+	restarted.once = true
+	assert.Equal(t, ConsulEventState{ID: "e1", LTime: 1}, restarted.last)
+	assert.False(t, restarted.Notify(events), "stored event should not have notified")
+
+	events = append(events, &tmplfunc.ConsulEvent{ID: "e2", LTime: 2})
+	assert.True(t, restarted.Notify(events), "new event should have notified")
+	assert.False(t, restarted.Notify(events), "notified event should not have notified again")
+}
+
+func Test_ConsulEvent_State_Failed_Apply(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	events := []*tmplfunc.ConsulEvent{{ID: "e1", LTime: 1}}
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	// the event is rendered, but the task fails to apply
+	n := NewConsulEvent(tmpl, 0, dir)
+	n.once = true
+	assert.True(t, n.Notify(events), "new event should have notified")
+	n.Rendered()
+
+	// restarted notifier is triggered by the event again
+	restarted := NewConsulEvent(tmpl, 0, dir)
+	restarted.once = true
+	assert.Equal(t, ConsulEventState{}, restarted.last)
+	assert.True(t, restarted.Notify(events), "unhandled event should have notified")
+}
+
+func Test_ConsulEvent_State_Event_During_Run(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	n := NewConsulEvent(tmpl, 0, dir)
+	n.once = true
+	assert.True(t, n.Notify([]*tmplfunc.ConsulEvent{{ID: "e1", LTime: 1}}))
+	n.Rendered()
+
+	// an event received while the task is applied is not stored since it
+	// was not rendered for the run
+	assert.True(t, n.Notify([]*tmplfunc.ConsulEvent{{ID: "e2", LTime: 2}}))
+	n.Applied()
+	assert.Equal(t, ConsulEventState{ID: "e1", LTime: 1}, n.last)
+
+	restarted := NewConsulEvent(tmpl, 0, dir)
+	restarted.once = true
+	assert.True(t, restarted.Notify([]*tmplfunc.ConsulEvent{{ID: "e2", LTime: 2}}),
+		"event that was not handled should have notified")
+}
@@ -2,20 +2,37 @@ package notifier
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat"
 	"github.com/hashicorp/hcat/dep"
 )
 
+var (
+	_ valueCondition = (*ConsulKV)(nil)
+)
+
 // ConsulKV is a custom notifier expected to be used for a template that
 // contains consulKVNotifier template function.
 //
 // This notifier only notifies on changes to Consul KV pairs and once-mode.
 // It suppresses notifications for changes to other tmplfuncs.
+//
+// When configured with a value, the notifier only notifies when the Consul KV
+// pair changes to the value. Once the template has been rendered, it is only
+// executed again while the pair is set to the value, so that a change to
+// another value cancels changes that have not been rendered yet.
 type ConsulKV struct {
 	templates.Template
 
+	// value the Consul KV pair needs to be set to, nil for any value
+	value    *string
+	mu       sync.Mutex
+	met      bool
+	rendered bool
+
 	// count all dependencies needed to complete once-mode
 	once     bool
 	depTotal int
@@ -25,9 +42,12 @@ type ConsulKV struct {
 
 // NewConsulKV creates a new ConsulKVNotifier.
 // serviceCount parameter: the number of services the task is configured with
-func NewConsulKV(tmpl templates.Template, serviceCount int) *ConsulKV {
+// value parameter: the value the Consul KV pair needs to be set to, nil for
+// any change to notify
+func NewConsulKV(tmpl templates.Template, serviceCount int, value *string) *ConsulKV {
 	return &ConsulKV{
 		Template: tmpl,
+		value:    value,
 		// expect services and either []*dep.KeyPair or *dep.KeyPair
 		depTotal: serviceCount + 1,
 		logger:   logging.Global().Named(logSystemName).Named(kvSubsystemName),
@@ -38,7 +58,8 @@ func NewConsulKV(tmpl templates.Template, serviceCount int) *ConsulKV {
 //
 // Notifications are sent when:
 // A. There is a change in the Consul KV dependency for a single key
-//    pair (recurse=false) where the pair is returned (*dep.KeyPair)
+//    pair (recurse=false) where the pair is returned (*dep.KeyPair). When
+//    configured with a value, the pair needs to have changed to the value.
 // B. There is a change in the Consul KV dependency for a set of key pairs (recurse=true)
 //    where a list of key pairs is returned ([]*dep.KeyPair)
 // C. All the dependencies have been received for the first time. This is
@@ -62,9 +83,14 @@ func (n *ConsulKV) Notify(d interface{}) (notify bool) {
 		}
 	}
 
-	if _, ok := d.(*dep.KeyPair); ok {
-		n.logger.Debug("notify Consul KV pair change")
-		notify = true
+	if p, ok := d.(*dep.KeyPair); ok {
+		if n.value == nil {
+			n.logger.Debug("notify Consul KV pair change")
+			notify = true
+		} else if n.setMet(p.Exists && p.Value == *n.value) {
+			n.logger.Debug("notify Consul KV pair changed to value")
+			notify = true
+		}
 	}
 
 	if _, ok := d.([]*dep.KeyPair); ok {
@@ -78,3 +104,53 @@ func (n *ConsulKV) Notify(d interface{}) (notify bool) {
 
 	return notify
 }
+
+// Execute executes the template unless the notifier is configured with a
+// value that the Consul KV pair is not set to. The template is always
+// executed until it is rendered for the first time so that once-mode can
+// complete.
+func (n *ConsulKV) Execute(rec hcat.Recaller) ([]byte, error) {
+	n.mu.Lock()
+	skip := n.rendered && n.value != nil && !n.met
+	n.mu.Unlock()
+
+	if skip {
+		n.logger.Trace("Consul KV pair is not set to value, skip executing template")
+		return nil, hcat.ErrNoNewValues
+	}
+	return n.Template.Execute(rec)
+}
+
+// Render renders the template and keeps track of whether the template has been
+// rendered
+func (n *ConsulKV) Render(content []byte) (hcat.RenderResult, error) {
+	result, err := n.Template.Render(content)
+	if err == nil {
+		n.mu.Lock()
+		n.rendered = true
+		n.mu.Unlock()
+	}
+	return result, err
+}
+
+// hasValue returns whether the notifier is configured with a value
+func (n *ConsulKV) hasValue() bool {
+	return n.value != nil
+}
+
+// conditionMet returns whether the Consul KV pair is set to the configured
+// value. It is always met when no value is configured.
+func (n *ConsulKV) conditionMet() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.value == nil || n.met
+}
+
+// setMet sets whether the Consul KV pair is set to the configured value and
+// returns it
+func (n *ConsulKV) setMet(met bool) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.met = met
+	return met
+}
@@ -3,8 +3,10 @@ package notifier
 import (
 	"testing"
 
+	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/hcat"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +49,67 @@ func Test_ConsulKV_Notify(t *testing.T) {
 	}
 }
 
+func Test_ConsulKV_Notify_Value(t *testing.T) {
+	t.Parallel()
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	n := NewConsulKV(tmpl, 0, config.String("true"))
+	n.once = true
+
+	assert.False(t, n.Notify(&dep.KeyPair{Key: "key", Exists: false}),
+		"missing key should not have notified")
+	assert.False(t, n.conditionMet())
+
+	assert.True(t, n.Notify(&dep.KeyPair{Key: "key", Value: "true", Exists: true}),
+		"change to value should have notified")
+	assert.True(t, n.conditionMet())
+
+	assert.False(t, n.Notify(&dep.KeyPair{Key: "key", Value: "false", Exists: true}),
+		"change to other value should not have notified")
+	assert.False(t, n.conditionMet())
+
+	assert.False(t, n.Notify([]*dep.HealthService{}),
+		"other type of change should not have notified")
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+}
+
+func Test_ConsulKV_Execute_Value(t *testing.T) {
+	t.Parallel()
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+	tmpl.On("Execute", mock.Anything).Return([]byte("content"), nil)
+	tmpl.On("Render", mock.Anything).Return(hcat.RenderResult{}, nil)
+
+	n := NewConsulKV(tmpl, 1, config.String("true"))
+
+	// executes until rendered for the first time even if value is not met
+	n.Notify(&dep.KeyPair{Key: "key", Value: "false", Exists: true})
+	_, err := n.Execute(nil)
+	assert.NoError(t, err)
+	_, err = n.Render([]byte("content"))
+	assert.NoError(t, err)
+
+	// value not met
+	_, err = n.Execute(nil)
+	assert.Equal(t, hcat.ErrNoNewValues, err)
+	tmpl.AssertNumberOfCalls(t, "Execute", 1)
+
+	// value met
+	n.Notify(&dep.KeyPair{Key: "key", Value: "true", Exists: true})
+	_, err = n.Execute(nil)
+	assert.NoError(t, err)
+	tmpl.AssertNumberOfCalls(t, "Execute", 2)
+
+	// value deleted
+	n.Notify(&dep.KeyPair{Key: "key", Exists: false})
+	_, err = n.Execute(nil)
+	assert.Equal(t, hcat.ErrNoNewValues, err)
+	tmpl.AssertNumberOfCalls(t, "Execute", 2)
+}
+
 func Test_ConsulKV_Notify_Once_Mode_Key_Pairs(t *testing.T) {
 	t.Run("services-last", func(t *testing.T) {
 		// Test that notifier notifies at the end of once-mode, particularly
@@ -60,7 +123,7 @@ func Test_ConsulKV_Notify_Once_Mode_Key_Pairs(t *testing.T) {
 
 		tmpl := new(mocks.Template)
 		tmpl.On("Notify", mock.Anything).Return(true).Twice()
-		n := NewConsulKV(tmpl, 2)
+		n := NewConsulKV(tmpl, 2, nil)
 
 		// 1. first services dependency does not notify
 		notify := n.Notify([]*dep.HealthService{})
@@ -94,7 +157,7 @@ func Test_ConsulKV_Notify_Once_Mode_Key_Pairs(t *testing.T) {
 
 		tmpl := new(mocks.Template)
 		tmpl.On("Notify", mock.Anything).Return(true).Once()
-		n := NewConsulKV(tmpl, 1)
+		n := NewConsulKV(tmpl, 1, nil)
 
 		// 1. services dependency does not notify
 		notify := n.Notify([]*dep.HealthService{})
@@ -127,7 +190,7 @@ func Test_ConsulKV_Notify_Once_Mode_Single_Key(t *testing.T) {
 
 		tmpl := new(mocks.Template)
 		tmpl.On("Notify", mock.Anything).Return(true).Times(2)
-		n := NewConsulKV(tmpl, 2)
+		n := NewConsulKV(tmpl, 2, nil)
 
 		// 1. first services dependency does not notify
 		notify := n.Notify([]*dep.HealthService{})
@@ -161,7 +224,7 @@ func Test_ConsulKV_Notify_Once_Mode_Single_Key(t *testing.T) {
 
 		tmpl := new(mocks.Template)
 		tmpl.On("Notify", mock.Anything).Return(true).Once()
-		n := NewConsulKV(tmpl, 1)
+		n := NewConsulKV(tmpl, 1, nil)
 
 		// 1. services dependency does not notify
 		notify := n.Notify([]*dep.HealthService{})
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+)
+
+const fileSubsystemName = "file"
+
+// File is a custom notifier expected to be used for a template that contains
+// the localFiles template function.
+//
+// This notifier only notifies on changes to the local files and once-mode. It
+// suppresses notifications for changes to other tmplfuncs.
+type File struct {
+	templates.Template
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// NewFile creates a new File notifier.
+// serviceCount parameter: the number of services the task is configured with
+func NewFile(tmpl templates.Template, serviceCount int) *File {
+	return &File{
+		Template: tmpl,
+		// expect services and the []*tmplfunc.LocalFile of the paths
+		depTotal: serviceCount + 1,
+		logger:   logging.Global().Named(logSystemName).Named(fileSubsystemName),
+	}
+}
+
+// Notify notifies when the local files change. The files are polled, and the
+// template only receives the files when their contents, or the files that
+// match the paths, have changed since the last poll.
+//
+// Notifications are sent when:
+// A. There is a change in the local files dependency ([]*tmplfunc.LocalFile)
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not local files. For example,
+//    Services ([]*dep.HealthService).
+func (n *File) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after all dependencies are received, notify so once-mode can complete
+		if n.counter >= n.depTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if _, ok := d.([]*tmplfunc.LocalFile); ok {
+		n.logger.Debug("notify local files change")
+		notify = true
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
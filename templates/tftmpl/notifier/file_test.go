@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_File_Notify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		dep      interface{}
+		expected bool
+	}{
+		{
+			"don't notify: other type of change",
+			[]*dep.HealthService{},
+			false,
+		},
+		{
+			"notify: local files",
+			[]*tmplfunc.LocalFile{{Path: "inventory/hosts.json", Content: "{}"}},
+			true,
+		},
+		{
+			"notify: no local files",
+			[]*tmplfunc.LocalFile{},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := File{Template: tmpl, once: true, logger: logging.NewNullLogger()}
+			actual := n.Notify(tc.dep)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_File_Notify_Once_Mode(t *testing.T) {
+	// Test that notifier only completes once-mode after receiving the
+	// dependency for each service and the local files.
+
+	// Notifier has 2 dependencies: 1 services and the local files
+	// 1. receive services dependency, no notification
+	// 2. receive local files dependency, notify and complete once-mode
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true).Once()
+	n := NewFile(tmpl, 1)
+
+	// 1. services dependency does not notify
+	notify := n.Notify([]*dep.HealthService{})
+	assert.False(t, notify, "services dep should not have notified")
+	assert.False(t, n.once, "got 1/2 deps. once-mode should not be completed")
+	assert.Equal(t, 1, n.counter, "services dep should be 1st dep")
+
+	// 2. local files notifies
+	notify = n.Notify([]*tmplfunc.LocalFile{})
+	assert.True(t, notify, "local files dep should have notified")
+	assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
+	assert.Equal(t, 2, n.counter, "local files dep should be 2nd dep")
+
+	// check mock template was called once
+	tmpl.AssertExpectations(t)
+}
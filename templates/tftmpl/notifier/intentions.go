@@ -0,0 +1,74 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+)
+
+const intentionsSubsystemName = "intentions"
+
+// Intentions is a custom notifier expected to be used for a template that
+// contains the intentions template function.
+//
+// This notifier only notifies on changes to Consul service intentions and
+// once-mode. It suppresses notifications for changes to other tmplfuncs.
+type Intentions struct {
+	templates.Template
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// NewIntentions creates a new Intentions notifier.
+// serviceCount parameter: the number of services the task is configured with
+func NewIntentions(tmpl templates.Template, serviceCount int) *Intentions {
+	return &Intentions{
+		Template: tmpl,
+		// expect services and []*tmplfunc.Intention
+		depTotal: serviceCount + 1,
+		logger:   logging.Global().Named(logSystemName).Named(intentionsSubsystemName),
+	}
+}
+
+// Notify notifies when Consul service intentions are created, changed, or deleted.
+//
+// Notifications are sent when:
+// A. There is a change in the intentions dependency ([]*tmplfunc.Intention)
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not intentions. For example,
+//    Services ([]*dep.HealthService).
+func (n *Intentions) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after all dependencies are received, notify so once-mode can complete
+		if n.counter >= n.depTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if _, ok := d.([]*tmplfunc.Intention); ok {
+		n.logger.Debug("notify intentions change")
+		notify = true
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
@@ -0,0 +1,74 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat/dep"
+)
+
+const nodesSubsystemName = "nodes"
+
+// Nodes is a custom notifier expected to be used for a template that
+// contains the catalogNodes template function.
+//
+// This notifier only notifies on changes to Consul catalog nodes and
+// once-mode. It suppresses notifications for changes to other tmplfuncs.
+type Nodes struct {
+	templates.Template
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// NewNodes creates a new Nodes notifier.
+// serviceCount parameter: the number of services the task is configured with
+func NewNodes(tmpl templates.Template, serviceCount int) *Nodes {
+	return &Nodes{
+		Template: tmpl,
+		// expect services and []*dep.Node
+		depTotal: serviceCount + 1,
+		logger:   logging.Global().Named(logSystemName).Named(nodesSubsystemName),
+	}
+}
+
+// Notify notifies when the nodes in the Consul catalog change.
+//
+// Notifications are sent when:
+// A. There is a change in the catalog nodes dependency ([]*dep.Node)
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not catalog nodes. For example,
+//    Services ([]*dep.HealthService).
+func (n *Nodes) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after all dependencies are received, notify so once-mode can complete
+		if n.counter >= n.depTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if _, ok := d.([]*dep.Node); ok {
+		n.logger.Debug("notify catalog nodes change")
+		notify = true
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Nodes_Notify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		dep      interface{}
+		expected bool
+	}{
+		{
+			"don't notify: other type of change",
+			[]*dep.HealthService{},
+			false,
+		},
+		{
+			"notify: nodes",
+			[]*dep.Node{{Node: "node", Address: "10.0.0.1"}},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := Nodes{Template: tmpl, once: true, logger: logging.NewNullLogger()}
+			actual := n.Notify(tc.dep)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_Nodes_Notify_Once_Mode(t *testing.T) {
+	t.Run("services-last", func(t *testing.T) {
+		// Test that notifier notifies at the end of once-mode, particularly
+		// for the race-condition when the services dependency (which normally
+		// does not notify) is received after the nodes dependency.
+
+		// Notifier has 3 dependencies: 2 services and 1 nodes
+		// 1. receive first services dependency, no notification
+		// 2. receive nodes dependency, notify for nodes
+		// 3. receive second services dependency, notify for once-mode
+
+		tmpl := new(mocks.Template)
+		tmpl.On("Notify", mock.Anything).Return(true).Twice()
+		n := NewNodes(tmpl, 2)
+
+		// 1. first services dependency does not notify
+		notify := n.Notify([]*dep.HealthService{})
+		assert.False(t, notify, "first services dep should not have notified")
+		assert.False(t, n.once, "got 1/3 deps. once-mode should not be completed")
+		assert.Equal(t, 1, n.counter, "first services dep should be 1st dep")
+
+		// 2. nodes notifies
+		notify = n.Notify([]*dep.Node{})
+		assert.True(t, notify, "nodes dep should have notified")
+		assert.False(t, n.once, "got 2/3 deps. once-mode should not be completed")
+		assert.Equal(t, 2, n.counter, "nodes dep should be 2nd dep")
+
+		// 3. second services notifies
+		notify = n.Notify([]*dep.HealthService{})
+		assert.True(t, notify, "second services dep should have notified")
+		assert.True(t, n.once, "got 3/3 deps. once-mode should be completed")
+		assert.Equal(t, 3, n.counter, "second services should be 3rd dep")
+
+		// check mock template was called twice
+		tmpl.AssertExpectations(t)
+	})
+
+	t.Run("nodes-last", func(t *testing.T) {
+		// Test that notifier notifies at the end of once-mode, particularly for
+		// the case when the nodes dependency is received last.
+
+		// Notifier in test has 2 dependencies: 1 services and 1 nodes
+		// 1. receive services dependency, no notification
+		// 2. receive nodes dependency, notify
+
+		tmpl := new(mocks.Template)
+		tmpl.On("Notify", mock.Anything).Return(true).Once()
+		n := NewNodes(tmpl, 1)
+
+		// 1. services dependency does not notify
+		notify := n.Notify([]*dep.HealthService{})
+		assert.False(t, notify, "services dep should not have notified")
+		assert.False(t, n.once, "got 1/2 deps. once-mode should not be completed")
+		assert.Equal(t, 1, n.counter, "services dep should be 1st dep")
+
+		// 2. nodes notifies
+		notify = n.Notify([]*dep.Node{})
+		assert.True(t, notify, "nodes dep should have notified")
+		assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
+		assert.Equal(t, 2, n.counter, "nodes dep should be 2nd dep")
+
+		// check mock template was called once
+		tmpl.AssertExpectations(t)
+	})
+}
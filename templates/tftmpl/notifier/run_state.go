@@ -0,0 +1,14 @@
+package notifier
+
+// RunStateNotifier is implemented by notifiers that keep state of the
+// dependency changes that triggered the task, which is only committed once
+// the task runs successfully.
+type RunStateNotifier interface {
+	// Rendered is called before the template is rendered for a run of the
+	// task to mark the dependency changes that the run includes
+	Rendered()
+
+	// Applied is called after the task has been applied successfully to
+	// commit the state of the dependency changes that were rendered
+	Applied()
+}
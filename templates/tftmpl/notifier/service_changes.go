@@ -0,0 +1,201 @@
+package notifier
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat/dep"
+)
+
+const (
+	// changeMembership is a change of the instances of a service, when an
+	// instance is registered or deregistered
+	changeMembership = "membership"
+
+	// changeAddress, changePort, changeTags, changeMeta, and changeStatus are
+	// changes to the fields of an existing instance of a service
+	changeAddress = "address"
+	changePort    = "port"
+	changeTags    = "tags"
+	changeMeta    = "meta"
+	changeStatus  = "status"
+)
+
+// serviceChanges tracks the instances of Consul health services dependencies
+// to determine whether an update changes the instances in a way that should
+// trigger a task. Only the instances with one of the included health statuses
+// are tracked, and only changes to the trigger_on fields are compared.
+//
+// A dependency update does not identify the dependency, so instances are
+// tracked in groups of the service name, namespace, and datacenter. The groups
+// received in the same update are recorded so that a group that is missing
+// from a later update of the dependency is known to be deregistered.
+type serviceChanges struct {
+	statuses []string
+	fields   map[string]bool
+	groups   map[string]serviceGroup
+}
+
+// serviceGroup is the instances of a service name, namespace, and datacenter
+type serviceGroup struct {
+	// instances maps the key of each instance to the fingerprint of its
+	// trigger_on fields
+	instances map[string]string
+
+	// siblings are the groups received in the same dependency update
+	siblings []string
+}
+
+// newServiceChanges returns a serviceChanges for the included statuses and
+// trigger_on fields. All fields are compared if trigger_on is empty. Returns
+// nil if neither are configured, meaning every change is a trigger.
+func newServiceChanges(includeStatuses, triggerOn []string) *serviceChanges {
+	if len(includeStatuses) == 0 && len(triggerOn) == 0 {
+		return nil
+	}
+
+	if len(triggerOn) == 0 {
+		triggerOn = []string{changeMembership, changeAddress, changePort,
+			changeTags, changeMeta, changeStatus}
+	}
+
+	fields := make(map[string]bool, len(triggerOn))
+	for _, f := range triggerOn {
+		fields[f] = true
+	}
+
+	return &serviceChanges{
+		statuses: includeStatuses,
+		fields:   fields,
+		groups:   make(map[string]serviceGroup),
+	}
+}
+
+// changed records the instances of a services dependency update and returns
+// true if the instances changed in the trigger_on fields since the previous
+// update.
+func (c *serviceChanges) changed(services []*dep.HealthService) bool {
+	groups := make(map[string]map[string]string)
+	for _, s := range services {
+		// groups without included instances are kept to track that their
+		// instances were excluded
+		if k := groupKey(s); groups[k] == nil {
+			groups[k] = make(map[string]string)
+		}
+	}
+	for _, s := range tmplfunc.IncludeStatuses(services, c.statuses...) {
+		groups[groupKey(s)][instanceKey(s)] = c.fingerprint(s)
+	}
+
+	if len(groups) == 0 {
+		// The services of an empty update cannot be identified. The previous
+		// instances of the dependency, if any, are deregistered, so the tracked
+		// groups are reset and a later registration is compared against no
+		// instances. Tracked groups of other dependencies are then counted as
+		// registered on their next update.
+		deregistered := false
+		for _, g := range c.groups {
+			if len(g.instances) > 0 {
+				deregistered = true
+				break
+			}
+		}
+		c.groups = make(map[string]serviceGroup)
+		return deregistered && c.fields[changeMembership]
+	}
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	changed := false
+	for _, k := range keys {
+		prev, ok := c.groups[k]
+		if !ok {
+			changed = changed || (c.fields[changeMembership] && len(groups[k]) > 0)
+		} else {
+			changed = changed || c.instancesChanged(prev.instances, groups[k])
+
+			for _, sibling := range prev.siblings {
+				if _, ok := groups[sibling]; ok {
+					continue
+				}
+				removed, ok := c.groups[sibling]
+				if !ok {
+					continue
+				}
+				changed = changed || (c.fields[changeMembership] && len(removed.instances) > 0)
+				delete(c.groups, sibling)
+			}
+		}
+
+		c.groups[k] = serviceGroup{
+			instances: groups[k],
+			siblings:  keys,
+		}
+	}
+
+	return changed
+}
+
+// instancesChanged returns true if instances were registered or deregistered
+// and membership is a trigger, or if the fingerprint of an existing instance
+// changed.
+func (c *serviceChanges) instancesChanged(prev, cur map[string]string) bool {
+	if c.fields[changeMembership] && len(prev) != len(cur) {
+		return true
+	}
+
+	for k, fp := range cur {
+		prevFP, ok := prev[k]
+		if !ok {
+			if c.fields[changeMembership] {
+				return true
+			}
+			continue
+		}
+		if prevFP != fp {
+			return true
+		}
+	}
+	return false
+}
+
+// fingerprint returns a string of the trigger_on fields of an instance
+func (c *serviceChanges) fingerprint(s *dep.HealthService) string {
+	f := make(map[string]interface{})
+	if c.fields[changeAddress] {
+		f[changeAddress] = s.Address
+	}
+	if c.fields[changePort] {
+		f[changePort] = s.Port
+	}
+	if c.fields[changeTags] {
+		f[changeTags] = s.Tags
+	}
+	if c.fields[changeMeta] {
+		f[changeMeta] = s.ServiceMeta
+	}
+	if c.fields[changeStatus] {
+		f[changeStatus] = s.Status
+	}
+
+	// map keys are sorted when marshaled
+	b, _ := json.Marshal(f)
+	return string(b)
+}
+
+// groupKey returns the key of the group of an instance
+func groupKey(s *dep.HealthService) string {
+	return strings.Join([]string{s.Name, s.Namespace, s.NodeDatacenter}, "/")
+}
+
+// instanceKey returns the key of an instance, which matches the key of the
+// instance in the services variable
+func instanceKey(s *dep.HealthService) string {
+	return strings.Join([]string{s.ID, s.Node, s.Namespace, s.NodeDatacenter}, ".")
+}
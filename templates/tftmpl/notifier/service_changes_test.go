@@ -0,0 +1,188 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServiceChanges(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newServiceChanges(nil, nil))
+	assert.Nil(t, newServiceChanges([]string{}, []string{}))
+
+	c := newServiceChanges([]string{"passing"}, nil)
+	require.NotNil(t, c)
+	assert.Len(t, c.fields, 6, "all fields should be compared by default")
+
+	c = newServiceChanges(nil, []string{"tags"})
+	require.NotNil(t, c)
+	assert.Equal(t, map[string]bool{"tags": true}, c.fields)
+}
+
+func TestServiceChanges_changed(t *testing.T) {
+	t.Parallel()
+
+	web1 := &dep.HealthService{ID: "web-1", Name: "web", Node: "n1",
+		Address: "10.0.0.1", Port: 80, Status: "passing"}
+	web2 := &dep.HealthService{ID: "web-2", Name: "web", Node: "n2",
+		Address: "10.0.0.2", Port: 80, Status: "passing"}
+	api1 := &dep.HealthService{ID: "api-1", Name: "api", Node: "n1",
+		Address: "10.0.0.1", Port: 90, Status: "passing"}
+
+	with := func(s *dep.HealthService, update func(*dep.HealthService)) *dep.HealthService {
+		c := *s
+		update(&c)
+		return &c
+	}
+
+	cases := []struct {
+		name      string
+		statuses  []string
+		triggerOn []string
+		prev      []*dep.HealthService
+		cur       []*dep.HealthService
+		expected  bool
+	}{
+		{
+			"no change",
+			nil,
+			[]string{"membership", "port"},
+			[]*dep.HealthService{web1, web2},
+			[]*dep.HealthService{web1, web2},
+			false,
+		},
+		{
+			"membership: registered",
+			nil,
+			[]string{"membership"},
+			[]*dep.HealthService{web1},
+			[]*dep.HealthService{web1, web2},
+			true,
+		},
+		{
+			"membership: deregistered",
+			nil,
+			[]string{"membership"},
+			[]*dep.HealthService{web1, web2},
+			[]*dep.HealthService{web1},
+			true,
+		},
+		{
+			"membership: all deregistered",
+			nil,
+			[]string{"membership"},
+			[]*dep.HealthService{web1, web2},
+			[]*dep.HealthService{},
+			true,
+		},
+		{
+			"membership: service deregistered from regexp",
+			nil,
+			[]string{"membership"},
+			[]*dep.HealthService{web1, api1},
+			[]*dep.HealthService{web1},
+			true,
+		},
+		{
+			"membership not a trigger",
+			nil,
+			[]string{"port"},
+			[]*dep.HealthService{web1, api1},
+			[]*dep.HealthService{web1, web2},
+			false,
+		},
+		{
+			"address",
+			nil,
+			[]string{"address"},
+			[]*dep.HealthService{web1},
+			[]*dep.HealthService{with(web1, func(s *dep.HealthService) { s.Address = "10.0.0.9" })},
+			true,
+		},
+		{
+			"tags",
+			nil,
+			[]string{"tags"},
+			[]*dep.HealthService{web1},
+			[]*dep.HealthService{with(web1, func(s *dep.HealthService) { s.Tags = dep.ServiceTags{"v2"} })},
+			true,
+		},
+		{
+			"meta",
+			nil,
+			[]string{"meta"},
+			[]*dep.HealthService{web1},
+			[]*dep.HealthService{with(web1, func(s *dep.HealthService) { s.ServiceMeta = map[string]string{"k": "v"} })},
+			true,
+		},
+		{
+			"field not a trigger",
+			nil,
+			[]string{"membership", "address"},
+			[]*dep.HealthService{web1},
+			[]*dep.HealthService{with(web1, func(s *dep.HealthService) { s.Port = 8080 })},
+			false,
+		},
+		{
+			"status",
+			nil,
+			[]string{"status"},
+			[]*dep.HealthService{web1},
+			[]*dep.HealthService{with(web1, func(s *dep.HealthService) { s.Status = "warning" })},
+			true,
+		},
+		{
+			"status of excluded instance",
+			[]string{"passing"},
+			nil,
+			[]*dep.HealthService{web1, with(web2, func(s *dep.HealthService) { s.Status = "critical" })},
+			[]*dep.HealthService{web1, with(web2, func(s *dep.HealthService) { s.Status = "maintenance" })},
+			false,
+		},
+		{
+			"instance becomes excluded",
+			[]string{"passing"},
+			nil,
+			[]*dep.HealthService{web1, web2},
+			[]*dep.HealthService{web1, with(web2, func(s *dep.HealthService) { s.Status = "critical" })},
+			true,
+		},
+		{
+			"status change between included statuses",
+			[]string{"passing", "warning"},
+			[]string{"membership"},
+			[]*dep.HealthService{web1, web2},
+			[]*dep.HealthService{web1, with(web2, func(s *dep.HealthService) { s.Status = "warning" })},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newServiceChanges(tc.statuses, tc.triggerOn)
+			require.NotNil(t, c)
+			c.changed(tc.prev)
+			assert.Equal(t, tc.expected, c.changed(tc.cur))
+		})
+	}
+}
+
+func TestServiceChanges_changed_Reregister(t *testing.T) {
+	t.Parallel()
+
+	web1 := &dep.HealthService{ID: "web-1", Name: "web", Node: "n1",
+		Address: "10.0.0.1", Port: 80, Status: "passing"}
+
+	c := newServiceChanges(nil, []string{"membership"})
+	require.NotNil(t, c)
+
+	assert.True(t, c.changed([]*dep.HealthService{web1}), "registered")
+	assert.False(t, c.changed([]*dep.HealthService{web1}), "unchanged")
+	assert.True(t, c.changed([]*dep.HealthService{}), "deregistered")
+	assert.False(t, c.changed([]*dep.HealthService{}), "still deregistered")
+	assert.True(t, c.changed([]*dep.HealthService{web1}), "re-registered")
+}
@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat/dep"
+)
+
+const servicesSubsystemName = "services"
+
+// Services is a custom notifier expected to be used for a template that
+// contains the service or servicesRegex template functions alongside other
+// tmplfuncs, for example as a nested condition of a composite condition or
+// with the tmplfuncs of source_inputs.
+//
+// This notifier only notifies on changes to Consul health services and
+// once-mode. It suppresses notifications for changes to other tmplfuncs.
+// When the task configures include_statuses or trigger_on, it also suppresses
+// notifications for changes to services that are not a trigger.
+type Services struct {
+	templates.Template
+
+	// changes is nil when all changes to services notify
+	changes *serviceChanges
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// NewServices creates a new Services notifier.
+// dependencyCount parameter: the number of dependencies of the template
+// includeStatuses parameter: the health statuses of the instances to track
+// triggerOn parameter: the changes to instances that notify
+func NewServices(tmpl templates.Template, dependencyCount int,
+	includeStatuses, triggerOn []string) *Services {
+	return &Services{
+		Template: tmpl,
+		changes:  newServiceChanges(includeStatuses, triggerOn),
+		depTotal: dependencyCount,
+		logger:   logging.Global().Named(logSystemName).Named(servicesSubsystemName),
+	}
+}
+
+// Notify notifies when Consul health services change.
+//
+// Notifications are sent when:
+// A. There is a change in a services dependency ([]*dep.HealthService), or in
+//    a services dependency with service details ([]*tmplfunc.HealthService)
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not services. For example,
+//    Consul KV ([]*dep.KeyPair).
+//  - Changes to services that are not a trigger. For example, a change to an
+//    instance with a status that is not included, or a change to a field that
+//    is not configured for trigger_on.
+func (n *Services) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after all dependencies are received, notify so once-mode can complete
+		if n.counter >= n.depTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if services, ok := healthServices(d); ok {
+		if n.changes == nil || n.changes.changed(services) {
+			n.logger.Debug("notify services change")
+			notify = true
+		} else {
+			n.logger.Debug("suppress services change that is not a trigger")
+		}
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
+
+// healthServices returns the service instances of a services dependency and
+// whether the dependency is a services dependency
+func healthServices(d interface{}) ([]*dep.HealthService, bool) {
+	switch services := d.(type) {
+	case []*dep.HealthService:
+		return services, true
+	case []*tmplfunc.HealthService:
+		instances := make([]*dep.HealthService, 0, len(services))
+		for _, s := range services {
+			instances = append(instances, s.HealthService)
+		}
+		return instances, true
+	}
+	return nil, false
+}
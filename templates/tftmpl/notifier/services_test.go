@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Services_Notify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		dep      interface{}
+		expected bool
+	}{
+		{
+			"don't notify: other type of change",
+			[]*dep.KeyPair{},
+			false,
+		},
+		{
+			"notify: services",
+			[]*dep.HealthService{{Name: "api"}},
+			true,
+		},
+		{
+			"notify: services with details",
+			[]*tmplfunc.HealthService{{HealthService: &dep.HealthService{Name: "api"}}},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := Services{Template: tmpl, once: true, logger: logging.NewNullLogger()}
+			actual := n.Notify(tc.dep)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_Services_Notify_Once_Mode(t *testing.T) {
+	// Notifier has 2 dependencies: 1 services and 1 consul-kv
+	// 1. receive consul-kv dependency, no notification
+	// 2. receive services dependency, notify
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true).Once()
+	n := NewServices(tmpl, 2, nil, nil)
+
+	notify := n.Notify([]*dep.KeyPair{})
+	assert.False(t, notify, "consul-kv dep should not have notified")
+	assert.False(t, n.once, "got 1/2 deps. once-mode should not be completed")
+
+	notify = n.Notify([]*dep.HealthService{})
+	assert.True(t, notify, "services dep should have notified")
+	assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
+
+	tmpl.AssertExpectations(t)
+}
+
+func Test_Services_Notify_TriggerOn(t *testing.T) {
+	// Notifier has 1 services dependency and only triggers on membership
+	// 1. receive services dependency, notify once-mode
+	// 2. receive port change of the instance, no notification
+	// 3. receive new instance, notify
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true).Twice()
+	n := NewServices(tmpl, 1, nil, []string{"membership"})
+
+	web1 := &dep.HealthService{ID: "web-1", Name: "web", Port: 80}
+	notify := n.Notify([]*dep.HealthService{web1})
+	assert.True(t, notify, "once-mode should have notified")
+
+	web1Port := &dep.HealthService{ID: "web-1", Name: "web", Port: 8080}
+	notify = n.Notify([]*dep.HealthService{web1Port})
+	assert.False(t, notify, "port change should not have notified")
+
+	web2 := &dep.HealthService{ID: "web-2", Name: "web", Port: 80}
+	notify = n.Notify([]*dep.HealthService{web1Port, web2})
+	assert.True(t, notify, "new instance should have notified")
+
+	tmpl.AssertExpectations(t)
+}
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat/dep"
+)
+
+const vaultSecretSubsystemName = "vault-secret"
+
+// VaultSecret is a custom notifier expected to be used for a template that
+// contains the secret template function.
+//
+// This notifier only notifies on changes to Vault secrets and once-mode. It
+// suppresses notifications for changes to other tmplfuncs.
+type VaultSecret struct {
+	templates.Template
+
+	// count all dependencies needed to complete once-mode
+	once     bool
+	depTotal int
+	counter  int
+	logger   logging.Logger
+}
+
+// NewVaultSecret creates a new VaultSecret notifier.
+// serviceCount parameter: the number of services the task is configured with
+// pathCount parameter: the number of Vault paths the task monitors
+func NewVaultSecret(tmpl templates.Template, serviceCount, pathCount int) *VaultSecret {
+	return &VaultSecret{
+		Template: tmpl,
+		// expect services and a *dep.Secret for each path
+		depTotal: serviceCount + pathCount,
+		logger:   logging.Global().Named(logSystemName).Named(vaultSecretSubsystemName),
+	}
+}
+
+// Notify notifies when a Vault secret changes, e.g. a new version of a KV v2
+// secret or a new lease of a PKI certificate.
+//
+// Notifications are sent when:
+// A. There is a change in a Vault secret dependency (*dep.Secret)
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not Vault secrets. For example,
+//    Services ([]*dep.HealthService).
+func (n *VaultSecret) Notify(d interface{}) (notify bool) {
+	n.logger.Debug("received dependency change", "dependency_type", fmt.Sprintf("%T", d))
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after all dependencies are received, notify so once-mode can complete
+		if n.counter >= n.depTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if _, ok := d.(*dep.Secret); ok {
+		n.logger.Debug("notify Vault secret change")
+		notify = true
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_VaultSecret_Notify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		dep      interface{}
+		expected bool
+	}{
+		{
+			"don't notify: other type of change",
+			[]*dep.HealthService{},
+			false,
+		},
+		{
+			"notify: secret",
+			&dep.Secret{LeaseID: "pki/issue/web/5a0a1d6b"},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := VaultSecret{Template: tmpl, once: true, logger: logging.NewNullLogger()}
+			actual := n.Notify(tc.dep)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_VaultSecret_Notify_Once_Mode(t *testing.T) {
+	// Test that notifier only completes once-mode after receiving the
+	// dependency for each service and each Vault path.
+
+	// Notifier has 3 dependencies: 1 services and 2 Vault paths
+	// 1. receive services dependency, no notification
+	// 2. receive first secret dependency, notify for change
+	// 3. receive second secret dependency, notify and complete once-mode
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true).Twice()
+	n := NewVaultSecret(tmpl, 1, 2)
+
+	// 1. services dependency does not notify
+	notify := n.Notify([]*dep.HealthService{})
+	assert.False(t, notify, "services dep should not have notified")
+	assert.False(t, n.once, "got 1/3 deps. once-mode should not be completed")
+	assert.Equal(t, 1, n.counter, "services dep should be 1st dep")
+
+	// 2. first secret notifies
+	notify = n.Notify(&dep.Secret{})
+	assert.True(t, notify, "secret dep should have notified")
+	assert.False(t, n.once, "got 2/3 deps. once-mode should not be completed")
+	assert.Equal(t, 2, n.counter, "secret dep should be 2nd dep")
+
+	// 3. second secret notifies
+	notify = n.Notify(&dep.Secret{})
+	assert.True(t, notify, "secret dep should have notified")
+	assert.True(t, n.once, "got 3/3 deps. once-mode should be completed")
+	assert.Equal(t, 3, n.counter, "secret dep should be 3rd dep")
+
+	// check mock template was called twice
+	tmpl.AssertExpectations(t)
+}
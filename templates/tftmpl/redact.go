@@ -0,0 +1,167 @@
+package tftmpl
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// redactedValue replaces the values of sensitive attributes
+const redactedValue = "(redacted)"
+
+// sensitiveNameRegexp matches the names of attributes and object keys that
+// are likely to hold secrets
+var sensitiveNameRegexp = regexp.MustCompile(
+	`(?i)(token|password|passwd|secret|private_key|credential|api_key|access_key)`)
+
+// RedactTFVars redacts the string values of attributes and object keys with
+// names that are likely to hold secrets, e.g. "token" or "db_password", from
+// the content of a rendered .tfvars file. The whole value of the sensitive
+// variables is redacted, including the values nested in objects, e.g. the
+// secrets of vault_secrets. Formatting and all other values are preserved.
+func RedactTFVars(content []byte, sensitiveVars []string) ([]byte, error) {
+	f, diags := hclwrite.ParseConfig(content, TFVarsFilename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	body := f.Body()
+	for _, name := range sensitiveVars {
+		if body.GetAttribute(name) == nil {
+			continue
+		}
+		attr := body.SetAttributeValue(name, cty.StringVal(redactedValue))
+		if expr := attr.Expr().BuildTokens(nil); len(expr) > 0 {
+			expr[0].SpacesBefore = 1
+		}
+	}
+
+	tokens := f.BuildTokens(nil)
+	for i, t := range tokens {
+		if t.Type != hclsyntax.TokenEqual || i+1 == len(tokens) {
+			continue
+		}
+		if !sensitiveNameRegexp.MatchString(tokenKeyName(tokens[:i])) {
+			continue
+		}
+		redactValue(tokens[i+1:])
+	}
+
+	return tokens.Bytes(), nil
+}
+
+// RedactBackend redacts the values of all the attributes of the backend block,
+// including nested blocks, from the content of a rendered main.tf file. The
+// backend configuration is completely redacted since backends have varying
+// arguments containing secrets, e.g. access_token or secret_key.
+func RedactBackend(content []byte) ([]byte, error) {
+	f, diags := hclwrite.ParseConfig(content, RootFilename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	for _, tf := range f.Body().Blocks() {
+		if tf.Type() != "terraform" {
+			continue
+		}
+		for _, b := range tf.Body().Blocks() {
+			if b.Type() == "backend" {
+				redactBody(b.Body())
+			}
+		}
+	}
+
+	return f.Bytes(), nil
+}
+
+// redactBody replaces the values of all the attributes of the body and its
+// nested blocks
+func redactBody(body *hclwrite.Body) {
+	for name := range body.Attributes() {
+		attr := body.SetAttributeValue(name, cty.StringVal(redactedValue))
+		if expr := attr.Expr().BuildTokens(nil); len(expr) > 0 {
+			expr[0].SpacesBefore = 1
+		}
+	}
+	for _, b := range body.Blocks() {
+		redactBody(b.Body())
+	}
+}
+
+// SensitiveVariables returns the names of the variables that are declared
+// with sensitive = true in the content of a variables.tf file
+func SensitiveVariables(content []byte) ([]string, error) {
+	f, diags := hclwrite.ParseConfig(content, VarsFilename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var names []string
+	for _, b := range f.Body().Blocks() {
+		if b.Type() != "variable" || len(b.Labels()) != 1 {
+			continue
+		}
+		attr := b.Body().GetAttribute("sensitive")
+		if attr == nil {
+			continue
+		}
+		v := strings.TrimSpace(string(attr.Expr().BuildTokens(nil).Bytes()))
+		if v == "true" {
+			names = append(names, b.Labels()[0])
+		}
+	}
+	return names, nil
+}
+
+// tokenKeyName returns the name of the attribute or object key that ends the
+// tokens. Keys can be identifiers or quoted strings.
+func tokenKeyName(tokens hclwrite.Tokens) string {
+	n := len(tokens)
+	if n == 0 {
+		return ""
+	}
+
+	switch last := tokens[n-1]; last.Type {
+	case hclsyntax.TokenIdent:
+		return string(last.Bytes)
+	case hclsyntax.TokenCQuote:
+		if n >= 2 && tokens[n-2].Type == hclsyntax.TokenQuotedLit {
+			return string(tokens[n-2].Bytes)
+		}
+	}
+	return ""
+}
+
+// redactValue replaces the string value that begins the tokens. Values that
+// are not strings, like numbers and objects, are not modified.
+func redactValue(tokens hclwrite.Tokens) {
+	var end hclsyntax.TokenType
+	switch tokens[0].Type {
+	case hclsyntax.TokenOQuote:
+		end = hclsyntax.TokenCQuote
+	case hclsyntax.TokenOHeredoc:
+		end = hclsyntax.TokenCHeredoc
+	default:
+		return
+	}
+
+	redacted := false
+	for _, t := range tokens[1:] {
+		if t.Type == end {
+			return
+		}
+		if redacted {
+			t.Bytes = nil
+			continue
+		}
+		t.Bytes = []byte(redactedValue)
+		if tokens[0].Type == hclsyntax.TokenOHeredoc {
+			t.Bytes = append(t.Bytes, '\n')
+		}
+		redacted = true
+	}
+}
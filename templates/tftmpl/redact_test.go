@@ -0,0 +1,192 @@
+package tftmpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactTFVars(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		content   string
+		sensitive []string
+		expected  string
+	}{
+		{
+			"no sensitive values",
+			`services = {
+  "api.node" = {
+    id   = "api"
+    port = 80
+  }
+}
+`,
+			nil,
+			`services = {
+  "api.node" = {
+    id   = "api"
+    port = 80
+  }
+}
+`,
+		},
+		{
+			"attributes",
+			`token       = "abc"
+db_password = "p@ss$${x}"
+name        = "web"
+`,
+			nil,
+			`token       = "(redacted)"
+db_password = "(redacted)"
+name        = "web"
+`,
+		},
+		{
+			"object keys",
+			`services = {
+  "api.node" = {
+    cts_user_defined_meta = {
+      "api_key" = "abc"
+      API_TOKEN = "def"
+      empty_secret = ""
+      region    = "us-east-1"
+    }
+  }
+}
+`,
+			nil,
+			`services = {
+  "api.node" = {
+    cts_user_defined_meta = {
+      "api_key" = "(redacted)"
+      API_TOKEN = "(redacted)"
+      empty_secret = ""
+      region    = "us-east-1"
+    }
+  }
+}
+`,
+		},
+		{
+			"heredoc",
+			`secret = <<EOT
+line 1
+line 2
+EOT
+`,
+			nil,
+			`secret = <<EOT
+(redacted)
+EOT
+`,
+		},
+		{
+			"non-string values",
+			`secret_count = 3
+`,
+			nil,
+			`secret_count = 3
+`,
+		},
+		{
+			"sensitive variables",
+			`services = {}
+vault_secrets = {
+  "pki/issue/web" = {
+    lease_id = "pki/issue/web/5a0a1d6b"
+    data = {
+      key = "supersecretkeymaterial"
+    }
+  }
+}
+`,
+			[]string{"vault_secrets", "dne"},
+			`services = {}
+vault_secrets = "(redacted)"
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := RedactTFVars([]byte(tc.content), tc.sensitive)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := RedactTFVars([]byte(`services = {`), nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestRedactBackend(t *testing.T) {
+	t.Parallel()
+
+	content := `terraform {
+  required_version = ">= 0.13.0, < 1.2.0"
+  backend "s3" {
+    access_key = "AKIAEXAMPLE"
+    bucket     = "tfstate"
+    secret_key = "secret123"
+    assume_role {
+      role_arn = "arn:aws:iam::123:role/cts"
+    }
+  }
+}
+
+provider "null" {
+  token = var.null.token
+}
+`
+	expected := `terraform {
+  required_version = ">= 0.13.0, < 1.2.0"
+  backend "s3" {
+    access_key = "(redacted)"
+    bucket     = "(redacted)"
+    secret_key = "(redacted)"
+    assume_role {
+      role_arn = "(redacted)"
+    }
+  }
+}
+
+provider "null" {
+  token = var.null.token
+}
+`
+
+	actual, err := RedactBackend([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestSensitiveVariables(t *testing.T) {
+	t.Parallel()
+
+	actual, err := SensitiveVariables([]byte(`
+variable "services" {
+  type = any
+}
+
+variable "vault_secrets" {
+  type      = any
+  sensitive = true
+}
+
+variable "not_sensitive" {
+  sensitive = false
+}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vault_secrets"}, actual)
+
+	_, err = SensitiveVariables([]byte(`variable "services" {`))
+	assert.Error(t, err)
+}
@@ -50,6 +50,11 @@ const (
 	// written in a separate file from terraform.tfvars because it may contain
 	// sensitive or secret values.
 	ProvidersTFVarsFilename = "providers.tfvars"
+
+	// WebhookPayloadFilename is the file name where the JSON payload of the
+	// webhook request that triggered a task with a webhook condition is
+	// written to. Terraform automatically loads files with this suffix.
+	WebhookPayloadFilename = "webhook_payload.auto.tfvars.json"
 )
 
 var (
@@ -99,6 +104,18 @@ type Service struct {
 	Namespace   string
 	Filter      string
 
+	// Datacenters are the datacenters to query for the service instead of a
+	// single Datacenter. The wildcard "*" queries all datacenters.
+	Datacenters []string
+
+	// IncludeStatuses are the health statuses of the instances to include.
+	// The default of the query is used when empty.
+	IncludeStatuses []string
+
+	// Protocol is the version of the service definition protocol. The service
+	// details are queried for the protocol v1.
+	Protocol int
+
 	// CTSUserDefinedMeta is user defined metadata that is configured by
 	// operators for CTS to append to Consul service information to be used for
 	// network infrastructure automation.
@@ -107,6 +124,24 @@ type Service struct {
 
 type tfFileFunc func(io.Writer, string, *RootModuleInputData) error
 
+// hcatFunc returns the name of the template function to query the service.
+// Services across multiple datacenters are queried with serviceDatacenters,
+// and the details of services are queried with serviceDetails.
+func (s Service) hcatFunc() string {
+	if s.Protocol >= ServicesProtocolV1 {
+		return "serviceDetails"
+	}
+	if len(s.Datacenters) > 0 {
+		return "serviceDatacenters"
+	}
+	return "service"
+}
+
+// hcatPipeline returns the template pipeline that queries the service
+func (s Service) hcatPipeline() string {
+	return includeStatusesPipeline(s.hcatFunc()+" "+s.hcatQuery(), s.IncludeStatuses)
+}
+
 // hcatQuery prepares formatted parameters that satisfies hcat
 // query syntax to make Consul requests to /v1/health/service/:service
 func (s Service) hcatQuery() string {
@@ -116,6 +151,10 @@ func (s Service) hcatQuery() string {
 		opts = append(opts, fmt.Sprintf("dc=%s", s.Datacenter))
 	}
 
+	for _, dc := range s.Datacenters {
+		opts = append(opts, fmt.Sprintf("dc=%s", dc))
+	}
+
 	if s.Namespace != "" {
 		opts = append(opts, fmt.Sprintf("ns=%s", s.Namespace))
 	}
@@ -126,6 +165,19 @@ func (s Service) hcatQuery() string {
 		opts = append(opts, filter)
 	}
 
+	if len(s.IncludeStatuses) > 0 {
+		// The service query only returns passing instances unless it is
+		// filtered by check status. The checks are filtered in the query and
+		// the instances are filtered by their aggregated status in the template.
+		filter := strings.ReplaceAll(checksStatusFilter(s.IncludeStatuses), `"`, `\"`)
+		opts = append(opts, filter)
+	} else if s.Protocol >= ServicesProtocolV1 && len(s.Datacenters) == 0 &&
+		!strings.Contains(s.Filter, "Checks.Status") {
+		// Unlike the service query, the service details query does not default
+		// to passing instances
+		opts = append(opts, `Checks.Status == \"passing\"`)
+	}
+
 	query := fmt.Sprintf("%q", s.Name)
 	if len(opts) > 0 {
 		query = query + ` "` + strings.Join(opts, `" "`) + `"`
@@ -133,6 +185,39 @@ func (s Service) hcatQuery() string {
 	return query
 }
 
+// includeStatusesPipeline wraps the template pipeline of a services query to
+// only include the instances with one of the health statuses.
+func includeStatusesPipeline(pipeline string, statuses []string) string {
+	if len(statuses) == 0 {
+		return pipeline
+	}
+
+	quoted := make([]string, len(statuses))
+	for i, status := range statuses {
+		quoted[i] = fmt.Sprintf("%q", status)
+	}
+	return fmt.Sprintf("includeStatuses (%s) %s", pipeline, strings.Join(quoted, " "))
+}
+
+// checksStatusFilter returns the filter expression for instances with a
+// health check of one of the statuses. Instances in maintenance have a
+// critical maintenance check.
+func checksStatusFilter(statuses []string) string {
+	seen := make(map[string]bool)
+	var exprs []string
+	for _, status := range statuses {
+		if status == "maintenance" {
+			status = "critical"
+		}
+		if seen[status] {
+			continue
+		}
+		seen[status] = true
+		exprs = append(exprs, fmt.Sprintf("Checks.Status == %q", status))
+	}
+	return "(" + strings.Join(exprs, " or ") + ")"
+}
+
 // RootModuleInputData is the input data used to generate the root module
 type RootModuleInputData struct {
 	TerraformVersion *goVersion.Version
@@ -143,7 +228,11 @@ type RootModuleInputData struct {
 	Task             Task
 	Variables        hcltmpl.Variables
 	Condition        Condition
-	SourceInput      SourceInput
+	SourceInputs     []SourceInput
+
+	// ServicesProtocol is the version of the service definition protocol of
+	// the services variable
+	ServicesProtocol int
 
 	Path      string
 	FilePerms os.FileMode
@@ -248,7 +337,12 @@ func newMainTF(w io.Writer, filename string, input *RootModuleInputData) error {
 	rootBody.AppendNewline()
 	appendRootProviderBlocks(rootBody, input.Providers)
 	rootBody.AppendNewline()
-	appendRootModuleBlock(rootBody, input.Task, input.Variables.Keys(), input.Condition, input.SourceInput)
+	monitors := make([]Monitor, 0, len(input.SourceInputs)+1)
+	monitors = append(monitors, input.Condition)
+	for _, si := range input.SourceInputs {
+		monitors = append(monitors, si)
+	}
+	appendRootModuleBlock(rootBody, input.Task, input.Variables.Keys(), monitors...)
 
 	// Format the file before writing
 	content := hclFile.Bytes()
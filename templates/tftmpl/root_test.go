@@ -298,6 +298,35 @@ func TestService_hcatQuery(t *testing.T) {
 				Datacenter: "dc1",
 			},
 			`"app" "dc=dc1"`,
+		}, {
+			"datacenters",
+			Service{
+				Name:        "app",
+				Datacenters: []string{"dc1", "dc2"},
+			},
+			`"app" "dc=dc1" "dc=dc2"`,
+		}, {
+			"include statuses",
+			Service{
+				Name:            "app",
+				IncludeStatuses: []string{"warning", "maintenance", "critical"},
+			},
+			`"app" "(Checks.Status == \"warning\" or Checks.Status == \"critical\")"`,
+		}, {
+			"protocol v1",
+			Service{
+				Name:     "app",
+				Protocol: ServicesProtocolV1,
+			},
+			`"app" "Checks.Status == \"passing\""`,
+		}, {
+			"protocol v1 with checks status filter",
+			Service{
+				Name:     "app",
+				Filter:   `Checks.Status != "critical"`,
+				Protocol: ServicesProtocolV1,
+			},
+			`"app" "Checks.Status != \"critical\""`,
 		}, {
 			"namespace",
 			Service{
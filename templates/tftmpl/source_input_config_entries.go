@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ SourceInput = (*ConfigEntriesSourceInput)(nil)
+)
+
+// ConfigEntriesSourceInput handles appending a run source variable's relevant templating for Terraform
+// generated files
+type ConfigEntriesSourceInput struct {
+	ConfigEntriesMonitor
+}
@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ SourceInput = (*FileSourceInput)(nil)
+)
+
+// FileSourceInput handles appending a run source variable's relevant templating for Terraform
+// generated files
+type FileSourceInput struct {
+	FileMonitor
+}
@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ SourceInput = (*IntentionsSourceInput)(nil)
+)
+
+// IntentionsSourceInput handles appending a run source variable's relevant templating for Terraform
+// generated files
+type IntentionsSourceInput struct {
+	IntentionsMonitor
+}
@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ SourceInput = (*NodesSourceInput)(nil)
+)
+
+// NodesSourceInput handles appending a run source variable's relevant templating for Terraform
+// generated files
+type NodesSourceInput struct {
+	NodesMonitor
+}
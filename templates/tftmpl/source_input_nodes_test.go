@@ -0,0 +1,32 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodesSourceInput_appendTemplate(t *testing.T) {
+	si := &NodesSourceInput{
+		NodesMonitor{
+			NodeMeta: map[string]string{"env": "prod"},
+		},
+	}
+
+	w := new(strings.Builder)
+	err := si.appendTemplate(w)
+	require.NoError(t, err)
+	assert.Equal(t, `
+nodes = {
+{{- with $nodes := catalogNodes "node-meta=env:prod" }}
+  {{- range $n := $nodes }}
+  "{{ $n.Node }}" = {
+{{ HCLNode $n | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+}
+`, w.String())
+}
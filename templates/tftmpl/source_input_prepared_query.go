@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ SourceInput = (*PreparedQuerySourceInput)(nil)
+)
+
+// PreparedQuerySourceInput handles appending a run source variable's relevant templating for Terraform
+// generated files
+type PreparedQuerySourceInput struct {
+	PreparedQueryMonitor
+}
@@ -0,0 +1,11 @@
+package tftmpl
+
+var (
+	_ SourceInput = (*VaultSecretSourceInput)(nil)
+)
+
+// VaultSecretSourceInput handles appending a run source variable's relevant templating for Terraform
+// generated files
+type VaultSecretSourceInput struct {
+	VaultSecretMonitor
+}
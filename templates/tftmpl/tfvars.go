@@ -16,18 +16,18 @@ func newTFVarsTmpl(w io.Writer, filename string, input *RootModuleInputData) err
 		return err
 	}
 
-	// First append the SourceInput templating
+	// First append the templating of each SourceInput
 	areServicesAppended := false
-	if input.SourceInput != nil {
-		if err := input.SourceInput.appendTemplate(w); err != nil {
+	for _, si := range input.SourceInputs {
+		if err := si.appendTemplate(w); err != nil {
 			return err
 		}
-		areServicesAppended = input.SourceInput.ServicesAppended()
+		areServicesAppended = areServicesAppended || si.ServicesAppended()
 	}
 
 	// Next append the condition templating
 	// SourceInput services take precedence over Condition services, so only append Condition services if services
-	// weren't appended by SourceInput
+	// weren't appended by any SourceInput
 	if input.Condition != nil {
 		if input.Condition.ServicesAppended() {
 			if !areServicesAppended {
@@ -96,7 +96,7 @@ func appendRawServiceTemplateValues(body *hclwrite.Body, services []Service) {
 	})
 
 	for _, s := range services {
-		rawService := fmt.Sprintf(serviceBaseTmpl, s.hcatQuery())
+		rawService := fmt.Sprintf(serviceBaseTmpl, s.hcatPipeline())
 		token := hclwrite.Token{
 			Type:  hclsyntax.TokenNil,
 			Bytes: []byte(rawService),
@@ -114,7 +114,7 @@ func appendRawServiceTemplateValues(body *hclwrite.Body, services []Service) {
 // serviceBaseTmpl is the raw template following hcat syntax for addresses of
 // Consul services.
 const serviceBaseTmpl = `
-{{- with $srv := service %s }}
+{{- with $srv := %s }}
   {{- range $s := $srv}}
   "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
 {{ HCLService $s | indent 4 }}
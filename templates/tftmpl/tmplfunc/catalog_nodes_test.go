@@ -0,0 +1,249 @@
+package tmplfunc
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCatalogNodesQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *catalogNodesQuery
+		err  bool
+	}{
+		{
+			"no opts",
+			[]string{},
+			&catalogNodesQuery{},
+			false,
+		},
+		{
+			"dc",
+			[]string{"dc=dc1"},
+			&catalogNodesQuery{
+				dc: "dc1",
+			},
+			false,
+		},
+		{
+			"partition and peer",
+			[]string{"partition=ap1", "peer=peer1"},
+			&catalogNodesQuery{
+				partition: "ap1",
+				peer:      "peer1",
+			},
+			false,
+		},
+		{
+			"node-meta",
+			[]string{"node-meta=k:v", "node-meta=foo:bar"},
+			&catalogNodesQuery{
+				nodeMeta: map[string]string{"k": "v", "foo": "bar"},
+			},
+			false,
+		},
+		{
+			"filter",
+			[]string{`Meta.rack == "a"`, `Node != "web"`},
+			&catalogNodesQuery{
+				filter: `Meta.rack == "a" and Node != "web"`,
+			},
+			false,
+		},
+		{
+			"multiple",
+			[]string{"node-meta=k:v", "dc=dc1", `Node == "web"`},
+			&catalogNodesQuery{
+				dc:       "dc1",
+				nodeMeta: map[string]string{"k": "v"},
+				filter:   `Node == "web"`,
+			},
+			false,
+		},
+		{
+			"invalid node-meta",
+			[]string{"node-meta=k"},
+			nil,
+			true,
+		},
+		{
+			"invalid filter",
+			[]string{"invalid=true"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newCatalogNodesQuery(tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestCatalogNodesQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"empty",
+			[]string{},
+			"catalog.nodes",
+		},
+		{
+			"datacenter",
+			[]string{"dc=dc1"},
+			"catalog.nodes(@dc1)",
+		},
+		{
+			"partition and peer",
+			[]string{"partition=ap1", "peer=peer1"},
+			"catalog.nodes(partition=ap1&peer=peer1)",
+		},
+		{
+			"node-meta",
+			[]string{"node-meta=k:v", "node-meta=foo:bar"},
+			"catalog.nodes(node-meta=foo:bar&node-meta=k:v)",
+		},
+		{
+			"filter",
+			[]string{`Meta.rack == "a"`},
+			`catalog.nodes(filter=Meta.rack == "a")`,
+		},
+		{
+			"multiple",
+			[]string{"node-meta=k:v", "dc=dc1", `Meta.rack == "a"`},
+			`catalog.nodes(@dc1&filter=Meta.rack == "a"&node-meta=k:v)`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newCatalogNodesQuery(tc.i)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestCatalogNodesQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	// Test is fetching nodes from a Consul cluster set-up as:
+	// dc1: (wan joined with dc2)
+	//   - node: srv1 (no node-meta) (lan joined with srv2)
+	//   - node: srv2 (with node-meta)
+	// dc2:
+	//   - node: srv3 (no node-meta)
+
+	srv1 := testutils.NewTestConsulServer(t, testutils.TestConsulServerConfig{})
+	defer srv1.Stop()
+
+	tb := &testutils.TestingTB{}
+	srv2, err := testutil.NewTestServerConfigT(tb,
+		func(c *testutil.TestServerConfig) {
+			c.NodeName = "srv2"
+			c.Bootstrap = false
+			c.LogLevel = "warn"
+			c.Stdout = ioutil.Discard
+			c.Stderr = ioutil.Discard
+			c.NodeMeta = map[string]string{"k": "v"}
+		})
+	require.NoError(t, err, "failed to start consul server 2")
+	defer srv2.Stop()
+
+	srv3, err := testutil.NewTestServerConfigT(tb,
+		func(c *testutil.TestServerConfig) {
+			c.NodeName = "srv3"
+			c.Datacenter = "dc2"
+			c.Bootstrap = true
+			c.LogLevel = "warn"
+			c.Stdout = ioutil.Discard
+			c.Stderr = ioutil.Discard
+		})
+	require.NoError(t, err, "failed to start consul server 3")
+	defer srv3.Stop()
+
+	srv1.JoinLAN(t, srv2.LANAddr)
+	srv1.JoinWAN(t, srv3.WANAddr)
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv1.HTTPAddr
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err, "failed to make consul client")
+
+	WaitForCatalogRegistration(t, client, &consulapi.QueryOptions{Datacenter: "dc2"},
+		"consul", 8*time.Second)
+
+	nodeNames := func(nodes interface{}) []string {
+		var names []string
+		for _, n := range nodes.([]*dep.Node) {
+			names = append(names, n.Node)
+		}
+		return names
+	}
+
+	cases := []struct {
+		name     string
+		i        []string
+		expected []string
+	}{
+		{
+			"no filtering (in dc1)",
+			[]string{},
+			[]string{srv1.Config.NodeName, "srv2"},
+		},
+		{
+			"node-meta",
+			[]string{"node-meta=k:v"},
+			[]string{"srv2"},
+		},
+		{
+			"filter",
+			[]string{`Meta.k == "v"`},
+			[]string{"srv2"},
+		},
+		{
+			"dc",
+			[]string{"dc=dc2"},
+			[]string{"srv3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newCatalogNodesQuery(tc.i)
+			require.NoError(t, err)
+
+			actual, _, err := d.Fetch(&testClient{consul: client})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expected, nodeNames(actual))
+		})
+	}
+}
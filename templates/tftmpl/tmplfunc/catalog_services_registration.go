@@ -15,8 +15,8 @@ var _ hcatQuery = (*catalogServicesRegistrationQuery)(nil)
 
 // catalogServicesRegistrationFunc returns information on registered Consul
 // services. It queries the Catalog List Services API and supports the query
-// parameters dc, ns, and node-meta. It also adds an additional layer of
-// custom functionality on the API response:
+// parameters dc, ns, partition, peer, and node-meta. It also adds an
+// additional layer of custom functionality on the API response:
 //  - Adds regex filtering on service name option e.g. "regexp=api"
 //
 // Endpoint: /v1/catalog/services
@@ -44,11 +44,13 @@ type catalogServicesRegistrationQuery struct {
 	isConsul
 	stopCh chan struct{}
 
-	regexp   *regexp.Regexp // custom
-	dc       string
-	ns       string
-	nodeMeta map[string]string
-	opts     hcat.QueryOptions
+	regexp    *regexp.Regexp // custom
+	dc        string
+	ns        string
+	partition string
+	peer      string
+	nodeMeta  map[string]string
+	opts      hcat.QueryOptions
 }
 
 // newCatalogServicesRegistrationQuery processes options in the format of
@@ -79,6 +81,10 @@ func newCatalogServicesRegistrationQuery(opts []string) (*catalogServicesRegistr
 			query.dc = value
 		case "ns", "namespace":
 			query.ns = value
+		case "partition":
+			query.partition = value
+		case "peer":
+			query.peer = value
 		case "node-meta":
 			if query.nodeMeta == nil {
 				query.nodeMeta = make(map[string]string)
@@ -113,6 +119,8 @@ func (d *catalogServicesRegistrationQuery) Fetch(clients dep.Clients) (interface
 		Namespace:  d.ns,
 	}
 	opts := hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+	opts.Peer = d.peer
 	if len(d.nodeMeta) != 0 {
 		opts.NodeMeta = d.nodeMeta
 	}
@@ -161,6 +169,12 @@ func (d *catalogServicesRegistrationQuery) String() string {
 	if d.ns != "" {
 		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
 	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", d.peer))
+	}
 	for k, v := range d.nodeMeta {
 		opts = append(opts, fmt.Sprintf("node-meta=%s:%s", k, v))
 	}
@@ -54,6 +54,15 @@ func TestNewCatalogServicesRegistrationQuery(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"partition and peer",
+			[]string{"partition=ap1", "peer=peer1"},
+			&catalogServicesRegistrationQuery{
+				partition: "ap1",
+				peer:      "peer1",
+			},
+			false,
+		},
 		{
 			"node-meta",
 			[]string{"node-meta=k:v", "node-meta=foo:bar"},
@@ -133,6 +142,11 @@ func TestCatalogServicesRegistrationQuery_String(t *testing.T) {
 			[]string{"ns=namespace"},
 			"catalog.services.registration(ns=namespace)",
 		},
+		{
+			"partition and peer",
+			[]string{"partition=ap1", "peer=peer1"},
+			"catalog.services.registration(partition=ap1&peer=peer1)",
+		},
 		{
 			"node-meta",
 			[]string{"node-meta=k:v", "node-meta=foo:bar"},
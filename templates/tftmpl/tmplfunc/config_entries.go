@@ -0,0 +1,230 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+var _ hcatQuery = (*configEntriesQuery)(nil)
+
+// ConfigEntry is a Consul config entry. The kind-specific configuration of the
+// entry is JSON encoded since its structure varies between kinds.
+type ConfigEntry struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Meta      map[string]string
+
+	// Config is the JSON encoding of the config entry as returned by the Consul
+	// API. The Raft indexes of the entry are omitted so that rewriting an entry
+	// without changing it does not change the config.
+	Config string
+}
+
+// configEntriesFunc returns the Consul config entries of a kind. It queries
+// the List Configurations API and supports the query parameters dc, ns, and
+// partition. It also adds an additional layer of custom functionality on the
+// API response:
+//  - Adds regex filtering on the config entry name e.g. "regexp=api"
+//
+// Endpoint: /v1/config/:kind
+// Template: {{ configEntries "<kind>" <filter options> ... }}
+func configEntriesFunc(recall hcat.Recaller) interface{} {
+	return func(kind string, opts ...string) ([]*ConfigEntry, error) {
+		result := []*ConfigEntry{}
+
+		d, err := newConfigEntriesQuery(kind, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*ConfigEntry), nil
+		}
+
+		return result, nil
+	}
+}
+
+// configEntriesQuery is the representation of a requested config entries
+// query from inside a template.
+type configEntriesQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	kind      string
+	regexp    *regexp.Regexp // custom
+	dc        string
+	ns        string
+	partition string
+	opts      hcat.QueryOptions
+}
+
+// newConfigEntriesQuery processes the kind of config entries and options in
+// the format of "key=value" e.g. "dc=dc1"
+func newConfigEntriesQuery(kind string, opts []string) (*configEntriesQuery, error) {
+	if strings.TrimSpace(kind) == "" {
+		return nil, fmt.Errorf("config.entries: kind is required")
+	}
+
+	query := configEntriesQuery{
+		stopCh: make(chan struct{}, 1),
+		kind:   kind,
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		param, value, err := stringsSplit2(opt, "=")
+		if err != nil {
+			return nil, fmt.Errorf("config.entries: invalid "+
+				"query parameter format: %q", opt)
+		}
+		switch param {
+		case "regexp":
+			r, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("config.entries: invalid regexp")
+			}
+			query.regexp = r
+		case "dc", "datacenter":
+			query.dc = value
+		case "ns", "namespace":
+			query.ns = value
+		case "partition":
+			query.partition = value
+		default:
+			return nil, fmt.Errorf(
+				"config.entries: invalid query parameter: %q", opt)
+		}
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of ConfigEntry objects with names that match the set regex.
+func (d *configEntriesQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	hcatOpts := d.opts.Merge(&hcat.QueryOptions{
+		Datacenter: d.dc,
+		Namespace:  d.ns,
+	})
+	opts := hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+
+	entries, qm, err := clients.Consul().ConfigEntries().List(d.kind, opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	configEntries := make([]*ConfigEntry, 0, len(entries))
+	for _, e := range entries {
+		if d.regexp != nil && !d.regexp.MatchString(e.GetName()) {
+			continue
+		}
+		config, err := encodeConfigEntry(e)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, d.String())
+		}
+		configEntries = append(configEntries, &ConfigEntry{
+			Kind:      e.GetKind(),
+			Name:      e.GetName(),
+			Namespace: e.GetNamespace(),
+			Meta:      e.GetMeta(),
+			Config:    config,
+		})
+	}
+
+	sort.Stable(ByKindThenName(configEntries))
+
+	rm := &dep.ResponseMetadata{
+		LastIndex:   qm.LastIndex,
+		LastContact: qm.LastContact,
+	}
+
+	return configEntries, rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *configEntriesQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// String returns the human-friendly version of this query.
+func (d *configEntriesQuery) String() string {
+	var opts []string
+	if d.dc != "" {
+		opts = append(opts, fmt.Sprintf("@%s", d.dc))
+	}
+	if d.ns != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
+	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.regexp != nil {
+		opts = append(opts, fmt.Sprintf("regexp=%s", d.regexp.String()))
+	}
+	if len(opts) > 0 {
+		return fmt.Sprintf("config.entries(%s|%s)", d.kind, strings.Join(opts, "&"))
+	}
+	return fmt.Sprintf("config.entries(%s)", d.kind)
+}
+
+// Stop halts the query's fetch function.
+func (d *configEntriesQuery) Stop() {
+	close(d.stopCh)
+}
+
+// encodeConfigEntry JSON encodes the config entry without its Raft indexes
+func encodeConfigEntry(e interface{}) (string, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return "", err
+	}
+	delete(m, "CreateIndex")
+	delete(m, "ModifyIndex")
+
+	// map keys are sorted when encoded, so the encoding is stable
+	b, err = json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ByKindThenName is a sortable slice of ConfigEntry structs.
+type ByKindThenName []*ConfigEntry
+
+func (s ByKindThenName) Len() int      { return len(s) }
+func (s ByKindThenName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ByKindThenName) Less(i, j int) bool {
+	if s[i].Kind != s[j].Kind {
+		return s[i].Kind < s[j].Kind
+	}
+	if s[i].Namespace != s[j].Namespace {
+		return s[i].Namespace < s[j].Namespace
+	}
+	return s[i].Name < s[j].Name
+}
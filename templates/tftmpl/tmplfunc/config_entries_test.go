@@ -0,0 +1,185 @@
+package tmplfunc
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigEntriesQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		kind string
+		opts []string
+		exp  *configEntriesQuery
+		err  bool
+	}{
+		{
+			"kind only",
+			"service-defaults",
+			[]string{},
+			&configEntriesQuery{
+				kind: "service-defaults",
+			},
+			false,
+		},
+		{
+			"all opts",
+			"ingress-gateway",
+			[]string{"regexp=^lb", "dc=dc1", "ns=ns1", "partition=ap1"},
+			&configEntriesQuery{
+				kind:      "ingress-gateway",
+				regexp:    regexp.MustCompile("^lb"),
+				dc:        "dc1",
+				ns:        "ns1",
+				partition: "ap1",
+			},
+			false,
+		},
+		{
+			"missing kind",
+			"",
+			[]string{},
+			nil,
+			true,
+		},
+		{
+			"invalid regexp",
+			"service-defaults",
+			[]string{"regexp=*"},
+			nil,
+			true,
+		},
+		{
+			"invalid query parameter",
+			"service-defaults",
+			[]string{"node-meta=k:v"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newConfigEntriesQuery(tc.kind, tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestConfigEntriesQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"kind only",
+			[]string{},
+			"config.entries(service-defaults)",
+		},
+		{
+			"multiple",
+			[]string{"regexp=^web", "ns=ns1", "dc=dc1", "partition=ap1"},
+			"config.entries(service-defaults|@dc1&ns=ns1&partition=ap1&regexp=^web)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newConfigEntriesQuery("service-defaults", tc.i)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestEncodeConfigEntry(t *testing.T) {
+	t.Parallel()
+
+	e := &consulapi.ServiceConfigEntry{
+		Kind:        consulapi.ServiceDefaults,
+		Name:        "web",
+		Protocol:    "http",
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	actual, err := encodeConfigEntry(e)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Expose":{},"Kind":"service-defaults",`+
+		`"MeshGateway":{},"Name":"web","Protocol":"http"}`, actual)
+}
+
+func TestConfigEntriesQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	srv := testutils.NewTestConsulServer(t, testutils.TestConsulServerConfig{})
+	defer srv.Stop()
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv.HTTPAddr
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err, "failed to make consul client")
+
+	for _, name := range []string{"web", "api"} {
+		_, _, err := client.ConfigEntries().Set(&consulapi.ServiceConfigEntry{
+			Kind:     consulapi.ServiceDefaults,
+			Name:     name,
+			Protocol: "http",
+		}, nil)
+		require.NoError(t, err)
+	}
+
+	cases := []struct {
+		name     string
+		i        []string
+		expected []string
+	}{
+		{
+			"no filtering",
+			[]string{},
+			[]string{"api", "web"},
+		},
+		{
+			"regexp",
+			[]string{"regexp=^web$"},
+			[]string{"web"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newConfigEntriesQuery(consulapi.ServiceDefaults, tc.i)
+			require.NoError(t, err)
+
+			actual, _, err := d.Fetch(&testClient{consul: client})
+			require.NoError(t, err)
+
+			var names []string
+			for _, e := range actual.([]*ConfigEntry) {
+				assert.Equal(t, consulapi.ServiceDefaults, e.Kind)
+				assert.Contains(t, e.Config, `"Protocol":"http"`)
+				names = append(names, e.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+var _ hcatQuery = (*consulEventsQuery)(nil)
+
+// ConsulEvent is a Consul user event. The payload of the event is converted
+// to a string.
+type ConsulEvent struct {
+	ID            string
+	Name          string
+	Payload       string
+	NodeFilter    string
+	ServiceFilter string
+	TagFilter     string
+	Version       int
+	LTime         uint64
+}
+
+// consulEventsFunc returns the most recent Consul user events that the agent
+// has received. It queries the List Events API and supports the query
+// parameters name and dc. The events are ordered by their Lamport time, from
+// oldest to latest.
+//
+// Endpoint: /v1/event/list
+// Template: {{ consulEvents <filter options> ... }}
+func consulEventsFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) ([]*ConsulEvent, error) {
+		result := []*ConsulEvent{}
+
+		d, err := newConsulEventsQuery(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*ConsulEvent), nil
+		}
+
+		return result, nil
+	}
+}
+
+// consulEventsQuery is the representation of a requested Consul user events
+// query from inside a template.
+type consulEventsQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	name string
+	dc   string
+	opts hcat.QueryOptions
+}
+
+// newConsulEventsQuery processes options in the format of "key=value"
+// e.g. "name=deploy"
+func newConsulEventsQuery(opts []string) (*consulEventsQuery, error) {
+	query := consulEventsQuery{
+		stopCh: make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		param, value, err := stringsSplit2(opt, "=")
+		if err != nil {
+			return nil, fmt.Errorf("consul.events: invalid "+
+				"query parameter format: %q", opt)
+		}
+		switch param {
+		case "name":
+			query.name = value
+		case "dc", "datacenter":
+			query.dc = value
+		default:
+			return nil, fmt.Errorf(
+				"consul.events: invalid query parameter: %q", opt)
+		}
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of ConsulEvent objects ordered by Lamport time.
+//
+// The List Events API only supports quasi-blocking queries. The index of the
+// response is a hash of the latest event and is not monotonic.
+func (d *consulEventsQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	hcatOpts := d.opts.Merge(&hcat.QueryOptions{
+		Datacenter: d.dc,
+	})
+	opts := hcatOpts.ToConsulOpts()
+
+	entries, qm, err := clients.Consul().Event().List(d.name, opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	events := make([]*ConsulEvent, 0, len(entries))
+	for _, e := range entries {
+		events = append(events, &ConsulEvent{
+			ID:            e.ID,
+			Name:          e.Name,
+			Payload:       string(e.Payload),
+			NodeFilter:    e.NodeFilter,
+			ServiceFilter: e.ServiceFilter,
+			TagFilter:     e.TagFilter,
+			Version:       e.Version,
+			LTime:         e.LTime,
+		})
+	}
+
+	sort.Stable(ByLTime(events))
+
+	rm := &dep.ResponseMetadata{
+		LastIndex:   qm.LastIndex,
+		LastContact: qm.LastContact,
+	}
+
+	return events, rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *consulEventsQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// String returns the human-friendly version of this query.
+func (d *consulEventsQuery) String() string {
+	var opts []string
+	if d.dc != "" {
+		opts = append(opts, fmt.Sprintf("@%s", d.dc))
+	}
+	if d.name != "" {
+		opts = append(opts, fmt.Sprintf("name=%s", d.name))
+	}
+	if len(opts) > 0 {
+		return fmt.Sprintf("consul.events(%s)", strings.Join(opts, "&"))
+	}
+	return "consul.events"
+}
+
+// Stop halts the query's fetch function.
+func (d *consulEventsQuery) Stop() {
+	close(d.stopCh)
+}
+
+// LatestConsulEvent returns the event with the latest Lamport time. Returns
+// nil if there are no events.
+func LatestConsulEvent(events []*ConsulEvent) *ConsulEvent {
+	var latest *ConsulEvent
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		if latest == nil || e.LTime >= latest.LTime {
+			latest = e
+		}
+	}
+	return latest
+}
+
+// ByLTime is a sortable slice of ConsulEvent structs. Events are sorted by
+// their Lamport time, from oldest to latest.
+type ByLTime []*ConsulEvent
+
+func (s ByLTime) Len() int      { return len(s) }
+func (s ByLTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ByLTime) Less(i, j int) bool {
+	return s[i].LTime < s[j].LTime
+}
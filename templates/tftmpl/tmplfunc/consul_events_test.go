@@ -0,0 +1,211 @@
+package tmplfunc
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsulEventsQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *consulEventsQuery
+		err  bool
+	}{
+		{
+			"no opts",
+			[]string{},
+			&consulEventsQuery{},
+			false,
+		},
+		{
+			"all opts",
+			[]string{"name=deploy", "dc=dc1"},
+			&consulEventsQuery{
+				name: "deploy",
+				dc:   "dc1",
+			},
+			false,
+		},
+		{
+			"invalid query parameter",
+			[]string{"node=web"},
+			nil,
+			true,
+		},
+		{
+			"invalid format",
+			[]string{"name"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newConsulEventsQuery(tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestConsulEventsQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"empty",
+			[]string{},
+			"consul.events",
+		},
+		{
+			"name",
+			[]string{"name=deploy"},
+			"consul.events(name=deploy)",
+		},
+		{
+			"multiple",
+			[]string{"name=deploy", "dc=dc1"},
+			"consul.events(@dc1&name=deploy)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newConsulEventsQuery(tc.i)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestConsulEventsQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	srv := testutils.NewTestConsulServer(t, testutils.TestConsulServerConfig{})
+	defer srv.Stop()
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv.HTTPAddr
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err, "failed to make consul client")
+
+	for _, e := range []*consulapi.UserEvent{
+		{Name: "deploy", Payload: []byte("v1")},
+		{Name: "restart"},
+		{Name: "deploy", Payload: []byte("v2")},
+	} {
+		_, _, err := client.Event().Fire(e, nil)
+		require.NoError(t, err)
+	}
+
+	payloads := func(events interface{}) []string {
+		var payloads []string
+		for _, e := range events.([]*ConsulEvent) {
+			payloads = append(payloads, e.Name+":"+e.Payload)
+		}
+		return payloads
+	}
+
+	cases := []struct {
+		name     string
+		i        []string
+		expected []string
+	}{
+		{
+			"no filtering",
+			[]string{},
+			[]string{"deploy:v1", "restart:", "deploy:v2"},
+		},
+		{
+			"name",
+			[]string{"name=deploy"},
+			[]string{"deploy:v1", "deploy:v2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newConsulEventsQuery(tc.i)
+			require.NoError(t, err)
+
+			actual, _, err := d.Fetch(&testClient{consul: client})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, payloads(actual))
+		})
+	}
+}
+
+func TestLatestConsulEvent(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		events   []*ConsulEvent
+		expected *ConsulEvent
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			[]*ConsulEvent{},
+			nil,
+		},
+		{
+			"latest",
+			[]*ConsulEvent{
+				{ID: "1", LTime: 3},
+				{ID: "3", LTime: 7},
+				{ID: "2", LTime: 5},
+			},
+			&ConsulEvent{ID: "3", LTime: 7},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, LatestConsulEvent(tc.events))
+		})
+	}
+}
+
+func TestByLTime(t *testing.T) {
+	t.Parallel()
+
+	events := []*ConsulEvent{
+		{ID: "c", LTime: 9},
+		{ID: "a", LTime: 2},
+		{ID: "b", LTime: 4},
+	}
+	sort.Stable(ByLTime(events))
+
+	var order []string
+	for _, e := range events {
+		order = append(order, e.ID)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+}
@@ -0,0 +1,440 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+)
+
+// Fixture is Consul data used to render templates without querying Consul.
+// Services are health service instances, which are also used for the catalog
+// services when CatalogServices is not set. ConsulKV is a map of key paths to
+// values. Nodes are the nodes in the catalog. Intentions are the service
+// intentions. ConfigEntries are the config entries of all kinds. ConsulEvents
+// are the Consul user events received by the agent. PreparedQueries is a map
+// of prepared query names to the service instances of their result.
+// VaultSecrets is a map of Vault paths to the data of their secrets, which is
+// returned for any version of the secret and for reads and writes alike.
+// Files is a map of local file paths to their contents.
+//
+// Query options that are evaluated by Consul, like filter expressions, are not
+// applied to fixture data. Queries for service details return the services
+// without the details that are not included in Services, like tagged
+// addresses, proxy, and connect information.
+type Fixture struct {
+	Services        []*dep.HealthService              `json:"services"`
+	CatalogServices map[string][]string               `json:"catalog_services"`
+	ConsulKV        map[string]string                 `json:"consul_kv"`
+	Nodes           []*dep.Node                       `json:"nodes"`
+	Intentions      []*Intention                      `json:"intentions"`
+	ConfigEntries   []*ConfigEntry                    `json:"config_entries"`
+	ConsulEvents    []*ConsulEvent                    `json:"consul_events"`
+	PreparedQueries map[string][]*dep.HealthService   `json:"prepared_queries"`
+	VaultSecrets    map[string]map[string]interface{} `json:"vault_secrets"`
+	Files           map[string]string                 `json:"files"`
+}
+
+// LoadFixture loads a fixture from a JSON file
+func LoadFixture(path string) (*Fixture, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f Fixture
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("unable to decode fixture %s: %s", path, err)
+	}
+	return &f, nil
+}
+
+// Recaller returns a recaller that looks up the values of template
+// dependencies from the fixture data.
+func (f *Fixture) Recaller() hcat.Recaller {
+	return func(d dep.Dependency) (interface{}, bool) {
+		switch q := d.(type) {
+		case *servicesRegexQuery:
+			if q.details {
+				return withDetails(f.servicesRegex(q)), true
+			}
+			return f.servicesRegex(q), true
+		case *serviceDatacentersQuery:
+			if q.details {
+				return withDetails(f.serviceDatacenters(q)), true
+			}
+			return f.serviceDatacenters(q), true
+		case *catalogServicesRegistrationQuery:
+			return f.catalogServices(q), true
+		case *catalogNodesQuery:
+			return f.catalogNodes(q), true
+		case *intentionsQuery:
+			return f.intentions(q), true
+		case *configEntriesQuery:
+			return f.configEntries(q), true
+		case *consulEventsQuery:
+			return f.consulEvents(q), true
+		case *preparedQueryQuery:
+			if q.details {
+				return withDetails(f.preparedQuery(q)), true
+			}
+			return f.preparedQuery(q), true
+		case *localFilesQuery:
+			return f.localFiles(q), true
+		}
+
+		// Dependencies of the hcat template functions are internal to hcat, so
+		// they are identified by their string representation
+		s := d.String()
+		switch {
+		case strings.HasPrefix(s, "health.service("):
+			return f.healthService(depArg(s, "health.service(")), true
+		case strings.HasPrefix(s, "kv.list("):
+			return f.kvList(depKey(depArg(s, "kv.list("))), true
+		case strings.HasPrefix(s, "kv.exists.get("):
+			return f.kvExistsGet(depKey(depArg(s, "kv.exists.get("))), true
+		case strings.HasPrefix(s, "kv.exists("):
+			_, ok := f.ConsulKV[depKey(depArg(s, "kv.exists("))]
+			return dep.KVExists(ok), true
+		case strings.HasPrefix(s, "kv.get("):
+			v, ok := f.ConsulKV[depKey(depArg(s, "kv.get("))]
+			if !ok {
+				return nil, false
+			}
+			return dep.KvValue(v), true
+		case strings.HasPrefix(s, "vault.read("):
+			return f.vaultSecret(depArg(s, "vault.read("))
+		case strings.HasPrefix(s, "vault.write("):
+			path := depArg(s, "vault.write(")
+			if i := strings.Index(path, " -> "); i != -1 {
+				path = path[:i]
+			}
+			return f.vaultSecret(path)
+		}
+
+		return nil, false
+	}
+}
+
+// healthService returns the service instances for a health service query in
+// the format <name>[@dc][~near][|status][?ns=namespace&filter=expression]
+func (f *Fixture) healthService(query string) []*dep.HealthService {
+	name, params := splitQuery(query)
+	var dc string
+	if i := strings.IndexAny(name, "|~"); i != -1 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, "@"); i != -1 {
+		name, dc = name[:i], name[i+1:]
+	}
+
+	var services []*dep.HealthService
+	for _, s := range f.Services {
+		if s.Name != name || !matchLocation(s, dc, params["ns"], nil) {
+			continue
+		}
+		services = append(services, s)
+	}
+	sort.Stable(ByNodeThenID(services))
+	return services
+}
+
+// servicesRegex returns the service instances for a servicesRegex query
+func (f *Fixture) servicesRegex(q *servicesRegexQuery) []*dep.HealthService {
+	var services []*dep.HealthService
+	for _, s := range f.Services {
+		if q.regexp != nil && !q.regexp.MatchString(s.Name) {
+			continue
+		}
+		if !matchDatacenters(s, q.dcs) || !matchLocation(s, "", q.ns, q.nodeMeta) {
+			continue
+		}
+		services = append(services, s)
+	}
+	sort.Stable(ByNodeThenID(services))
+	return services
+}
+
+// preparedQuery returns the service instances of the result of a prepared
+// query, up to the limit of the query
+func (f *Fixture) preparedQuery(q *preparedQueryQuery) []*dep.HealthService {
+	services := append([]*dep.HealthService{}, f.PreparedQueries[q.name]...)
+	if q.limit > 0 && len(services) > q.limit {
+		services = services[:q.limit]
+	}
+	sort.Stable(ByNodeThenID(services))
+	return services
+}
+
+// serviceDatacenters returns the service instances for a serviceDatacenters
+// query
+func (f *Fixture) serviceDatacenters(q *serviceDatacentersQuery) []*dep.HealthService {
+	var services []*dep.HealthService
+	for _, s := range f.Services {
+		if s.Name != q.name {
+			continue
+		}
+		if !matchDatacenters(s, q.dcs) || !matchLocation(s, "", q.ns, nil) {
+			continue
+		}
+		services = append(services, s)
+	}
+	sort.Stable(ByNodeThenID(services))
+	return services
+}
+
+// catalogServices returns the catalog services for a
+// catalogServicesRegistration query
+func (f *Fixture) catalogServices(q *catalogServicesRegistrationQuery) []*dep.CatalogSnippet {
+	catalog := f.CatalogServices
+	if catalog == nil {
+		catalog = make(map[string][]string)
+		for _, s := range f.Services {
+			if !matchLocation(s, q.dc, q.ns, q.nodeMeta) {
+				continue
+			}
+			catalog[s.Name] = append(catalog[s.Name], s.Tags...)
+		}
+	}
+
+	var snippets []*dep.CatalogSnippet
+	for name, tags := range catalog {
+		if q.regexp != nil && !q.regexp.MatchString(name) {
+			continue
+		}
+		snippets = append(snippets, &dep.CatalogSnippet{
+			Name: name,
+			Tags: dep.ServiceTags(uniqueSortedTags(tags)),
+		})
+	}
+	sort.Stable(ByName(snippets))
+	return snippets
+}
+
+// catalogNodes returns the nodes for a catalogNodes query
+func (f *Fixture) catalogNodes(q *catalogNodesQuery) []*dep.Node {
+	nodes := make([]*dep.Node, 0, len(f.Nodes))
+	for _, n := range f.Nodes {
+		if q.dc != "" && n.Datacenter != "" && n.Datacenter != q.dc {
+			continue
+		}
+		match := true
+		for k, v := range q.nodeMeta {
+			if n.Meta[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.Stable(ByNode(nodes))
+	return nodes
+}
+
+// intentions returns the intentions for an intentions query
+func (f *Fixture) intentions(q *intentionsQuery) []*Intention {
+	intentions := make([]*Intention, 0, len(f.Intentions))
+	for _, i := range f.Intentions {
+		if q.source != nil && !q.source.MatchString(i.SourceName) {
+			continue
+		}
+		if q.destination != nil && !q.destination.MatchString(i.DestinationName) {
+			continue
+		}
+		intentions = append(intentions, i)
+	}
+	sort.Stable(ByPrecedence(intentions))
+	return intentions
+}
+
+// configEntries returns the config entries for a configEntries query
+func (f *Fixture) configEntries(q *configEntriesQuery) []*ConfigEntry {
+	entries := make([]*ConfigEntry, 0)
+	for _, e := range f.ConfigEntries {
+		if e.Kind != q.kind {
+			continue
+		}
+		if q.ns != "" && e.Namespace != "" && e.Namespace != q.ns {
+			continue
+		}
+		if q.regexp != nil && !q.regexp.MatchString(e.Name) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Stable(ByKindThenName(entries))
+	return entries
+}
+
+// consulEvents returns the events for a consulEvents query
+func (f *Fixture) consulEvents(q *consulEventsQuery) []*ConsulEvent {
+	events := make([]*ConsulEvent, 0, len(f.ConsulEvents))
+	for _, e := range f.ConsulEvents {
+		if q.name != "" && e.Name != q.name {
+			continue
+		}
+		events = append(events, e)
+	}
+	sort.Stable(ByLTime(events))
+	return events
+}
+
+// kvList returns the key-value pairs under the prefix
+func (f *Fixture) kvList(prefix string) []*dep.KeyPair {
+	pairs := make([]*dep.KeyPair, 0)
+	for _, path := range f.sortedKeys() {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		key := strings.TrimPrefix(path, prefix)
+		pairs = append(pairs, &dep.KeyPair{
+			Path:   path,
+			Key:    strings.TrimLeft(key, "/"),
+			Value:  f.ConsulKV[path],
+			Exists: true,
+		})
+	}
+	return pairs
+}
+
+// kvExistsGet returns the key-value pair for the key
+func (f *Fixture) kvExistsGet(key string) *dep.KeyPair {
+	v, ok := f.ConsulKV[key]
+	return &dep.KeyPair{
+		Path:   key,
+		Key:    key,
+		Value:  v,
+		Exists: ok,
+	}
+}
+
+// vaultSecret returns the secret at the Vault path in the format
+// <path>[.v<version>]. The version is ignored if there is no secret for the
+// path including the version.
+func (f *Fixture) vaultSecret(path string) (interface{}, bool) {
+	data, ok := f.VaultSecrets[path]
+	if !ok {
+		if i := strings.LastIndex(path, ".v"); i != -1 {
+			data, ok = f.VaultSecrets[path[:i]]
+		}
+	}
+	if !ok {
+		return nil, false
+	}
+	return &dep.Secret{Data: data}, true
+}
+
+// localFiles returns the files matching the paths of a localFiles query. A
+// path matches the files directly within it, like a directory, or the files
+// matching it as a glob pattern.
+func (f *Fixture) localFiles(q *localFilesQuery) []*LocalFile {
+	paths := make([]string, 0, len(f.Files))
+	for path := range f.Files {
+		for _, p := range q.paths {
+			if ok, _ := filepath.Match(p, path); ok || filepath.Dir(path) == filepath.Clean(p) {
+				paths = append(paths, path)
+				break
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	files := make([]*LocalFile, 0, len(paths))
+	for _, path := range paths {
+		files = append(files, &LocalFile{Path: path, Content: f.Files[path]})
+	}
+	return files
+}
+
+func (f *Fixture) sortedKeys() []string {
+	keys := make([]string, 0, len(f.ConsulKV))
+	for k := range f.ConsulKV {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// matchLocation checks if the service instance is in the datacenter and
+// namespace and has the node meta. Empty values match any instance.
+func matchLocation(s *dep.HealthService, dc, ns string, nodeMeta map[string]string) bool {
+	if dc != "" && s.NodeDatacenter != "" && s.NodeDatacenter != dc {
+		return false
+	}
+	if ns != "" && s.Namespace != "" && s.Namespace != ns {
+		return false
+	}
+	for k, v := range nodeMeta {
+		if s.NodeMeta[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// matchDatacenters returns whether a service instance is in one of the
+// datacenters. No datacenters or the wildcard "*" matches all datacenters.
+func matchDatacenters(s *dep.HealthService, dcs []string) bool {
+	if len(dcs) == 0 {
+		return true
+	}
+	for _, dc := range dcs {
+		if dc == allDatacenters || matchLocation(s, dc, "", nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// depArg returns the argument of a dependency string in the format
+// <prefix><argument>)
+func depArg(s, prefix string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(s, prefix), ")")
+}
+
+// depKey returns the key of a KV dependency argument in the format
+// <key>[@dc] or <key>[?dc=dc&ns=namespace]
+func depKey(arg string) string {
+	key, _ := splitQuery(arg)
+	if i := strings.Index(key, "@"); i != -1 {
+		key = key[:i]
+	}
+	return key
+}
+
+// splitQuery splits a dependency argument into the value and the query
+// parameters that follow '?'
+func splitQuery(arg string) (string, map[string]string) {
+	params := make(map[string]string)
+	i := strings.Index(arg, "?")
+	if i == -1 {
+		return arg, params
+	}
+
+	for _, p := range strings.Split(arg[i+1:], "&") {
+		if k, v, err := stringsSplit2(p, "="); err == nil {
+			params[k] = v
+		}
+	}
+	return arg[:i], params
+}
+
+func uniqueSortedTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	unique := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,264 @@
+package tmplfunc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixture_Recaller(t *testing.T) {
+	t.Parallel()
+
+	fixture := &Fixture{
+		Services: []*dep.HealthService{
+			{Node: "node2", ID: "web-2", Name: "web", Tags: dep.ServiceTags{"b"}, NodeDatacenter: "dc1", Status: "critical"},
+			{Node: "node1", ID: "web-1", Name: "web", Tags: dep.ServiceTags{"a"}, NodeDatacenter: "dc1", Status: "passing"},
+			{Node: "node1", ID: "api-1", Name: "api", NodeDatacenter: "dc2"},
+		},
+		ConsulKV: map[string]string{
+			"path/a":   "1",
+			"path/b/c": "2",
+			"other":    "3",
+		},
+		Nodes: []*dep.Node{
+			{Node: "node2", Datacenter: "dc1", Meta: map[string]string{"env": "prod"}},
+			{Node: "node1", Datacenter: "dc1", Meta: map[string]string{"env": "dev"}},
+			{Node: "node3", Datacenter: "dc2", Meta: map[string]string{"env": "prod"}},
+		},
+		Intentions: []*Intention{
+			{SourceName: "*", DestinationName: "db", Action: "deny", Precedence: 8},
+			{SourceName: "api", DestinationName: "db", Action: "allow", Precedence: 9},
+			{SourceName: "web", DestinationName: "api", Action: "allow", Precedence: 9},
+		},
+		ConfigEntries: []*ConfigEntry{
+			{Kind: "service-defaults", Name: "web"},
+			{Kind: "service-defaults", Name: "api"},
+			{Kind: "ingress-gateway", Name: "lb"},
+		},
+		ConsulEvents: []*ConsulEvent{
+			{ID: "2", Name: "deploy", Payload: "v2", LTime: 5},
+			{ID: "1", Name: "deploy", Payload: "v1", LTime: 3},
+			{ID: "3", Name: "restart", LTime: 4},
+		},
+		PreparedQueries: map[string][]*dep.HealthService{
+			"web-failover": {
+				{Node: "node3", ID: "web-3", Name: "web", NodeDatacenter: "dc2"},
+				{Node: "node2", ID: "web-4", Name: "web", NodeDatacenter: "dc2"},
+			},
+		},
+		VaultSecrets: map[string]map[string]interface{}{
+			"kv/data/app":   {"data": map[string]interface{}{"password": "s3cr3t"}},
+			"pki/issue/web": {"serial_number": "1a:2b"},
+		},
+		Files: map[string]string{
+			"inventory/b.yaml":   "b: 2",
+			"inventory/a.json":   `{"a": 1}`,
+			"inventory/x/c.yaml": "c: 3",
+			"racks.hcl":          "racks = 2",
+		},
+	}
+
+	cases := []struct {
+		name     string
+		tmpl     string
+		expected string
+	}{
+		{
+			"service",
+			`{{ range service "web" }}{{ .ID }},{{ end }}`,
+			"web-1,web-2,",
+		},
+		{
+			"service datacenter",
+			`{{ range service "web" "dc=dc2" }}{{ .ID }},{{ end }}`,
+			"",
+		},
+		{
+			"services regex",
+			`{{ range servicesRegex "regexp=^(api|web)$" }}{{ .ID }},{{ end }}`,
+			"api-1,web-1,web-2,",
+		},
+		{
+			"services regex datacenters",
+			`{{ range servicesRegex "regexp=.*" "dc=dc2" "dc=dc3" }}{{ .ID }},{{ end }}`,
+			"api-1,",
+		},
+		{
+			"service datacenters",
+			`{{ range serviceDatacenters "web" "dc=*" }}{{ .ID }},{{ end }}`,
+			"web-1,web-2,",
+		},
+		{
+			"service datacenters no match",
+			`{{ range serviceDatacenters "api" "dc=dc1" }}{{ .ID }},{{ end }}`,
+			"",
+		},
+		{
+			"include statuses",
+			`{{ range includeStatuses (service "web" "(Checks.Status == \"passing\")") "passing" }}{{ .ID }},{{ end }}`,
+			"web-1,",
+		},
+		{
+			"include statuses service datacenters",
+			`{{ range includeStatuses (serviceDatacenters "web" "dc=*") "critical" "warning" }}{{ .ID }},{{ end }}`,
+			"web-2,",
+		},
+		{
+			"service details",
+			`{{ range serviceDetails "web" }}{{ .ID }}:{{ .Status }},{{ end }}`,
+			"web-1:passing,web-2:critical,",
+		},
+		{
+			"services regex details",
+			`{{ range includeStatuses (servicesRegexDetails "regexp=^(api|web)$") "passing" }}{{ .ID }},{{ end }}`,
+			"web-1,",
+		},
+		{
+			"include statuses service details",
+			`{{ with $srv := includeStatuses (serviceDetails "web" "dc=dc1") "passing" }}` +
+				`{{ range $s := $srv }}{{ joinStrings "." .ID .Node }}={{ .TaggedAddresses }},{{ end }}{{ end }}`,
+			"web-1.node1=map[],",
+		},
+		{
+			"catalog services registration",
+			`{{ range catalogServicesRegistration "regexp=.*" }}{{ .Name }}{{ .Tags }},{{ end }}`,
+			"api[],web[a b],",
+		},
+		{
+			"catalog nodes",
+			`{{ range catalogNodes }}{{ .Node }},{{ end }}`,
+			"node1,node2,node3,",
+		},
+		{
+			"catalog nodes datacenter and node meta",
+			`{{ range catalogNodes "dc=dc1" "node-meta=env:prod" }}{{ .Node }},{{ end }}`,
+			"node2,",
+		},
+		{
+			"intentions",
+			`{{ range intentions "destination=^db$" }}{{ .SourceName }}:{{ .Action }},{{ end }}`,
+			"api:allow,*:deny,",
+		},
+		{
+			"config entries",
+			`{{ range configEntries "service-defaults" }}{{ .Name }},{{ end }}`,
+			"api,web,",
+		},
+		{
+			"prepared query",
+			`{{ range preparedQuery "name=web-failover" }}{{ .ID }},{{ end }}`,
+			"web-4,web-3,",
+		},
+		{
+			"prepared query limit details",
+			`{{ range preparedQueryDetails "name=web-failover" "limit=1" }}{{ .ID }},{{ end }}`,
+			"web-3,",
+		},
+		{
+			"prepared query unknown",
+			`{{ range preparedQuery "name=db-failover" }}{{ .ID }},{{ end }}`,
+			"",
+		},
+		{
+			"consul events",
+			`{{ range consulEvents "name=deploy" }}{{ .ID }}={{ .Payload }},{{ end }}`,
+			"1=v1,2=v2,",
+		},
+		{
+			"latest consul event",
+			`{{ with latestConsulEvent (consulEvents "name=deploy") }}{{ .ID }}{{ end }}`,
+			"2",
+		},
+		{
+			"vault secret",
+			`{{ with secret "kv/data/app" }}{{ .Data.data.password }}{{ end }}`,
+			"s3cr3t",
+		},
+		{
+			"vault secret version",
+			`{{ with secret "kv/data/app?version=2" }}{{ .Data.data.password }}{{ end }}`,
+			"s3cr3t",
+		},
+		{
+			"vault secret write",
+			`{{ with secret "pki/issue/web" "common_name=web.example.com" }}{{ .Data.serial_number }}{{ end }}`,
+			"1a:2b",
+		},
+		{
+			"local files directory",
+			`{{ range localFiles "inventory" }}{{ .Path }}={{ .Content }},{{ end }}`,
+			`inventory/a.json={"a": 1},inventory/b.yaml=b: 2,`,
+		},
+		{
+			"local files glob patterns",
+			`{{ range localFiles "inventory/*/*.yaml" "*.hcl" }}{{ .Path }},{{ end }}`,
+			"inventory/x/c.yaml,racks.hcl,",
+		},
+		{
+			"keys",
+			`{{ range keys "path" }}{{ .Path }}={{ .Value }},{{ end }}`,
+			"path/a=1,path/b/c=2,",
+		},
+		{
+			"key",
+			`{{ key "other" }}{{ key "dne" }}`,
+			"3",
+		},
+		{
+			"key exists",
+			`{{ keyExists "other" }},{{ keyExists "dne" }}`,
+			"true,false",
+		},
+		{
+			"key exists get",
+			`{{ with keyExistsGet "path/a" }}{{ .Exists }}={{ .Value }}{{ end }},` +
+				`{{ with keyExistsGet "dne" }}{{ .Exists }}{{ end }}`,
+			"true=1,false",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := hcat.NewTemplate(hcat.TemplateInput{
+				Contents:     tc.tmpl,
+				FuncMapMerge: HCLMap(nil),
+			})
+			content, err := tmpl.Execute(fixture.Recaller())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(content))
+		})
+	}
+}
+
+func TestLoadFixture(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "fixture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fixture.json")
+	err = ioutil.WriteFile(path, []byte(`{
+  "services": [{"ID": "web-1", "Name": "web", "Port": 80}],
+  "consul_kv": {"key": "value"}
+}`), 0644)
+	require.NoError(t, err)
+
+	fixture, err := LoadFixture(path)
+	require.NoError(t, err)
+	assert.Equal(t, &Fixture{
+		Services: []*dep.HealthService{{ID: "web-1", Name: "web", Port: 80}},
+		ConsulKV: map[string]string{"key": "value"},
+	}, fixture)
+
+	err = ioutil.WriteFile(path, []byte(`{"services": {}}`), 0644)
+	require.NoError(t, err)
+	_, err = LoadFixture(path)
+	assert.Error(t, err)
+}
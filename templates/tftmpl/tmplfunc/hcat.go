@@ -7,6 +7,7 @@ package tmplfunc
 import (
 	"sort"
 
+	consulapi "github.com/hashicorp/consul/api"
 	"github.com/hashicorp/hcat"
 	"github.com/hashicorp/hcat/dep"
 )
@@ -31,3 +32,32 @@ func deepCopyAndSortTags(tags []string) []string {
 	sort.Strings(newTags)
 	return newTags
 }
+
+// healthServiceFromEntry converts a Consul health service entry to a HealthService
+// object.
+func healthServiceFromEntry(entry *consulapi.ServiceEntry) *dep.HealthService {
+	address := entry.Service.Address
+	if address == "" {
+		address = entry.Node.Address
+	}
+	return &dep.HealthService{
+		Node:                entry.Node.Node,
+		NodeID:              entry.Node.ID,
+		Kind:                string(entry.Service.Kind),
+		NodeAddress:         entry.Node.Address,
+		NodeDatacenter:      entry.Node.Datacenter,
+		NodeTaggedAddresses: entry.Node.TaggedAddresses,
+		NodeMeta:            entry.Node.Meta,
+		ServiceMeta:         entry.Service.Meta,
+		Address:             address,
+		ID:                  entry.Service.ID,
+		Name:                entry.Service.Service,
+		Tags: dep.ServiceTags(
+			deepCopyAndSortTags(entry.Service.Tags)),
+		Status:    entry.Checks.AggregatedStatus(),
+		Checks:    entry.Checks,
+		Port:      entry.Service.Port,
+		Weights:   entry.Service.Weights,
+		Namespace: entry.Service.Namespace,
+	}
+}
@@ -0,0 +1,45 @@
+package tmplfunc
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// hclConfigEntryFunc is a template function to marshal Consul config entry
+// information into HCL.
+func hclConfigEntryFunc(e *ConfigEntry) string {
+	if e == nil {
+		return ""
+	}
+
+	// Convert to an HCL marshal-able object
+	entry := newConfigEntry(e)
+
+	f := hclwrite.NewEmptyFile()
+	gohcl.EncodeIntoBody(entry, f.Body())
+	return strings.TrimSpace(string(f.Bytes()))
+}
+
+type configEntry struct {
+	Kind      string            `hcl:"kind"`
+	Name      string            `hcl:"name"`
+	Namespace string            `hcl:"namespace"`
+	Meta      map[string]string `hcl:"meta"`
+	Config    string            `hcl:"config"`
+}
+
+func newConfigEntry(e *ConfigEntry) configEntry {
+	if e == nil {
+		return configEntry{}
+	}
+
+	return configEntry{
+		Kind:      e.Kind,
+		Name:      e.Name,
+		Namespace: e.Namespace,
+		Meta:      nonNullMap(e.Meta),
+		Config:    e.Config,
+	}
+}
@@ -0,0 +1,51 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLConfigEntryFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *ConfigEntry
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&ConfigEntry{},
+			`kind      = ""
+name      = ""
+namespace = ""
+meta      = {}
+config    = ""`,
+		}, {
+			"basic",
+			&ConfigEntry{
+				Kind:   "service-defaults",
+				Name:   "web",
+				Meta:   map[string]string{"team": "web"},
+				Config: `{"Kind":"service-defaults","Name":"web","Protocol":"http"}`,
+			},
+			`kind      = "service-defaults"
+name      = "web"
+namespace = ""
+meta = {
+  team = "web"
+}
+config = "{\"Kind\":\"service-defaults\",\"Name\":\"web\",\"Protocol\":\"http\"}"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := hclConfigEntryFunc(tc.content)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
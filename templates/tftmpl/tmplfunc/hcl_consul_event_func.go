@@ -0,0 +1,51 @@
+package tmplfunc
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// hclConsulEventFunc is a template function to marshal Consul user event
+// information into HCL.
+func hclConsulEventFunc(e *ConsulEvent) string {
+	if e == nil {
+		return ""
+	}
+
+	// Convert to an HCL marshal-able object
+	ev := newConsulEvent(e)
+
+	f := hclwrite.NewEmptyFile()
+	gohcl.EncodeIntoBody(ev, f.Body())
+	return strings.TrimSpace(string(f.Bytes()))
+}
+
+type consulEvent struct {
+	ID            string `hcl:"id"`
+	Name          string `hcl:"name"`
+	Payload       string `hcl:"payload"`
+	NodeFilter    string `hcl:"node_filter"`
+	ServiceFilter string `hcl:"service_filter"`
+	TagFilter     string `hcl:"tag_filter"`
+	Version       int    `hcl:"version"`
+	LTime         uint64 `hcl:"ltime"`
+}
+
+func newConsulEvent(e *ConsulEvent) consulEvent {
+	if e == nil {
+		return consulEvent{}
+	}
+
+	return consulEvent{
+		ID:            e.ID,
+		Name:          e.Name,
+		Payload:       e.Payload,
+		NodeFilter:    e.NodeFilter,
+		ServiceFilter: e.ServiceFilter,
+		TagFilter:     e.TagFilter,
+		Version:       e.Version,
+		LTime:         e.LTime,
+	}
+}
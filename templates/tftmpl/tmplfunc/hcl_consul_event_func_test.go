@@ -0,0 +1,57 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLConsulEventFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *ConsulEvent
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&ConsulEvent{},
+			`id             = ""
+name           = ""
+payload        = ""
+node_filter    = ""
+service_filter = ""
+tag_filter     = ""
+version        = 0
+ltime          = 0`,
+		}, {
+			"basic",
+			&ConsulEvent{
+				ID:            "b54fe110-7af5-cafc-d1fb-afc8ba432b1c",
+				Name:          "deploy",
+				Payload:       `{"version": "v1.2.0"}`,
+				ServiceFilter: "api",
+				Version:       1,
+				LTime:         19,
+			},
+			`id             = "b54fe110-7af5-cafc-d1fb-afc8ba432b1c"
+name           = "deploy"
+payload        = "{\"version\": \"v1.2.0\"}"
+node_filter    = ""
+service_filter = "api"
+tag_filter     = ""
+version        = 1
+ltime          = 19`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := hclConsulEventFunc(tc.content)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
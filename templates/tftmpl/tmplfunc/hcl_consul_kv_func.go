@@ -0,0 +1,87 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"gopkg.in/yaml.v3"
+)
+
+// hclConsulKVValueFunc is a template function to decode the value of a Consul
+// KV pair of the format, "json", "yaml", or "hcl", and marshal it into an HCL
+// expression. Empty values are marshalled as null. The error names the path of
+// the KV pair so that a value that fails to decode can be found.
+func hclConsulKVValueFunc(format, path, value string) (string, error) {
+	val, err := decodeValue(format, value)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode value of consul-kv key %q "+
+			"as %s: %s", path, format, err)
+	}
+	return string(hclwrite.TokensForValue(val).Bytes()), nil
+}
+
+// decodeValue decodes a value of the format, e.g. the value of a Consul KV pair
+// or the contents of a local file, into a cty value
+func decodeValue(format, value string) (cty.Value, error) {
+	if strings.TrimSpace(value) == "" {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+
+	switch format {
+	case "json":
+		return decodeJSON([]byte(value))
+	case "yaml":
+		var v interface{}
+		if err := yaml.Unmarshal([]byte(value), &v); err != nil {
+			return cty.NilVal, err
+		}
+		// Convert through JSON to reuse the type inference of JSON values
+		b, err := json.Marshal(v)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		return decodeJSON(b)
+	case "hcl":
+		return decodeHCL(value)
+	}
+	return cty.NilVal, fmt.Errorf("unsupported format")
+}
+
+// decodeJSON decodes a JSON document into a cty value of its implied type
+func decodeJSON(b []byte) (cty.Value, error) {
+	ty, err := ctyjson.ImpliedType(b)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(b, ty)
+}
+
+// decodeHCL decodes an HCL document of attributes into a cty object. The
+// attribute expressions cannot reference variables or call functions.
+func decodeHCL(value string) (cty.Value, error) {
+	f, diags := hclsyntax.ParseConfig([]byte(value), "value", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+
+	attrs, diags := f.Body.JustAttributes()
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+
+	obj := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+		obj[name] = v
+	}
+	return cty.ObjectVal(obj), nil
+}
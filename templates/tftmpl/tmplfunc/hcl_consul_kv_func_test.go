@@ -0,0 +1,86 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHCLConsulKVValueFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   string
+		value    string
+		expected string
+	}{
+		{
+			"empty",
+			"json",
+			"",
+			"null",
+		}, {
+			"json object",
+			"json",
+			`{"replicas": 3, "enabled": true, "zones": ["a", "b"]}`,
+			`{
+  enabled  = true
+  replicas = 3
+  zones    = ["a", "b"]
+}`,
+		}, {
+			"json string",
+			"json",
+			`"v1.2.0"`,
+			`"v1.2.0"`,
+		}, {
+			"yaml",
+			"yaml",
+			"replicas: 3\nlabels:\n  team: web\n",
+			`{
+  labels = {
+    team = "web"
+  }
+  replicas = 3
+}`,
+		}, {
+			"hcl",
+			"hcl",
+			"replicas = 3\nversion = \"v1.2.0\"\n",
+			`{
+  replicas = 3
+  version  = "v1.2.0"
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := hclConsulKVValueFunc(tc.format, "path", tc.value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	errCases := []struct {
+		name   string
+		format string
+		value  string
+	}{
+		{"invalid json", "json", `{"replicas": `},
+		{"invalid yaml", "yaml", "replicas: [3"},
+		// panics with gopkg.in/yaml.v3 before v3.0.1, CVE-2022-28948
+		{"malformed yaml", "yaml", "0: [:!00 \xef"},
+		{"invalid hcl", "hcl", "replicas = "},
+		{"hcl variables", "hcl", "replicas = var.replicas"},
+		{"unsupported format", "xml", "<replicas>3</replicas>"},
+	}
+
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := hclConsulKVValueFunc(tc.format, "app/config", tc.value)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `consul-kv key "app/config"`)
+		})
+	}
+}
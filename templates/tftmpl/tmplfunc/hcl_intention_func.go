@@ -0,0 +1,55 @@
+package tmplfunc
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// hclIntentionFunc is a template function to marshal Consul intention
+// information into HCL.
+func hclIntentionFunc(i *Intention) string {
+	if i == nil {
+		return ""
+	}
+
+	// Convert to an HCL marshal-able object
+	ixn := newIntention(i)
+
+	f := hclwrite.NewEmptyFile()
+	gohcl.EncodeIntoBody(ixn, f.Body())
+	return strings.TrimSpace(string(f.Bytes()))
+}
+
+type intention struct {
+	ID                   string            `hcl:"id"`
+	Description          string            `hcl:"description"`
+	SourceName           string            `hcl:"source_name"`
+	SourceNamespace      string            `hcl:"source_namespace"`
+	SourceType           string            `hcl:"source_type"`
+	DestinationName      string            `hcl:"destination_name"`
+	DestinationNamespace string            `hcl:"destination_namespace"`
+	Action               string            `hcl:"action"`
+	Precedence           int               `hcl:"precedence"`
+	Meta                 map[string]string `hcl:"meta"`
+}
+
+func newIntention(i *Intention) intention {
+	if i == nil {
+		return intention{}
+	}
+
+	return intention{
+		ID:                   i.ID,
+		Description:          i.Description,
+		SourceName:           i.SourceName,
+		SourceNamespace:      i.SourceNamespace,
+		SourceType:           i.SourceType,
+		DestinationName:      i.DestinationName,
+		DestinationNamespace: i.DestinationNamespace,
+		Action:               i.Action,
+		Precedence:           i.Precedence,
+		Meta:                 nonNullMap(i.Meta),
+	}
+}
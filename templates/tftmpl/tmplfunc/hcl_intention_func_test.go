@@ -0,0 +1,67 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLIntentionFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *Intention
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&Intention{},
+			`id                    = ""
+description           = ""
+source_name           = ""
+source_namespace      = ""
+source_type           = ""
+destination_name      = ""
+destination_namespace = ""
+action                = ""
+precedence            = 0
+meta                  = {}`,
+		}, {
+			"basic",
+			&Intention{
+				ID:                   "b8dc0f6c-1ea4-8a5c-6f3d-8c5e3c0a5a2f",
+				Description:          "web to api",
+				SourceName:           "web",
+				SourceNamespace:      "default",
+				SourceType:           "consul",
+				DestinationName:      "api",
+				DestinationNamespace: "default",
+				Action:               "allow",
+				Precedence:           9,
+				Meta:                 map[string]string{"team": "web"},
+			},
+			`id                    = "b8dc0f6c-1ea4-8a5c-6f3d-8c5e3c0a5a2f"
+description           = "web to api"
+source_name           = "web"
+source_namespace      = "default"
+source_type           = "consul"
+destination_name      = "api"
+destination_namespace = "default"
+action                = "allow"
+precedence            = 9
+meta = {
+  team = "web"
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := hclIntentionFunc(tc.content)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
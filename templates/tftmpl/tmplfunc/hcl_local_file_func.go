@@ -0,0 +1,40 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// localFileFormatExtensions are the file extensions that the format of a local
+// file is inferred from when the format is not configured
+var localFileFormatExtensions = map[string]string{
+	".json": "json",
+	".yaml": "yaml",
+	".yml":  "yaml",
+	".hcl":  "hcl",
+}
+
+// hclLocalFileFunc is a template function to decode the contents of a local
+// file of the format, "json", "yaml", or "hcl", and marshal it into an HCL
+// expression. The format is inferred from the file extension when empty. Empty
+// files are marshalled as null. The error names the path of the file so that
+// a file that fails to decode can be found.
+func hclLocalFileFunc(format, path, content string) (string, error) {
+	if format == "" {
+		f, ok := localFileFormatExtensions[filepath.Ext(path)]
+		if !ok {
+			return "", fmt.Errorf("unable to infer the format of file %q from "+
+				"its extension", path)
+		}
+		format = f
+	}
+
+	val, err := decodeValue(format, content)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode file %q as %s: %s",
+			path, format, err)
+	}
+	return string(hclwrite.TokensForValue(val).Bytes()), nil
+}
@@ -0,0 +1,77 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHCLLocalFileFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   string
+		path     string
+		content  string
+		expected string
+	}{
+		{
+			"empty",
+			"",
+			"hosts.json",
+			"",
+			"null",
+		}, {
+			"json extension",
+			"",
+			"hosts.json",
+			`{"web": ["10.0.0.1"]}`,
+			`{
+  web = ["10.0.0.1"]
+}`,
+		}, {
+			"yml extension",
+			"",
+			"inventory/racks.yml",
+			"rack: a\nslots: 42\n",
+			`{
+  rack  = "a"
+  slots = 42
+}`,
+		}, {
+			"format overrides extension",
+			"hcl",
+			"inventory/hosts",
+			"web = \"10.0.0.1\"\n",
+			`{
+  web = "10.0.0.1"
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := hclLocalFileFunc(tc.format, tc.path, tc.content)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	errCases := []struct {
+		name    string
+		format  string
+		path    string
+		content string
+	}{
+		{"invalid json", "", "inventory/hosts.json", `{"web": `},
+		{"unknown extension", "", "inventory/hosts.txt", "web"},
+	}
+
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := hclLocalFileFunc(tc.format, tc.path, tc.content)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `file "inventory/hosts.`)
+		})
+	}
+}
@@ -1,18 +1,35 @@
 package tmplfunc
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/hcat/dep"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty/gocty"
 )
 
 // hclServiceFunc is a wrapper of the template function to marshal Consul
 // service information into HCL. The function accepts a map representing
 // metadata for services in scope of a task.
-func hclServiceFunc(meta ServicesMeta) func(sDep *dep.HealthService) string {
-	return func(sDep *dep.HealthService) string {
+//
+// The template function accepts an hcat HealthService, which is marshalled
+// following the services variable protocol v0, or a HealthService with the
+// service details, which is marshalled following the protocol v1.
+func hclServiceFunc(meta ServicesMeta) func(service interface{}) string {
+	return func(service interface{}) string {
+		var sDep *dep.HealthService
+		var details *HealthService
+		switch s := service.(type) {
+		case *dep.HealthService:
+			sDep = s
+		case *HealthService:
+			if s != nil {
+				sDep = s.HealthService
+				details = s
+			}
+		}
 		if sDep == nil {
 			return ""
 		}
@@ -29,6 +46,9 @@ func hclServiceFunc(meta ServicesMeta) func(sDep *dep.HealthService) string {
 
 		f := hclwrite.NewEmptyFile()
 		gohcl.EncodeIntoBody(s, f.Body())
+		if details != nil {
+			appendHealthServiceDetails(f.Body(), newHealthServiceDetails(details))
+		}
 		return strings.TrimSpace(string(f.Bytes()))
 	}
 }
@@ -90,6 +110,154 @@ func newHealthService(s *dep.HealthService, ctsUserDefinedMeta map[string]string
 	}
 }
 
+// healthServiceDetails are the service details appended to a service for the
+// services variable protocol v1
+type healthServiceDetails struct {
+	Partition       string
+	Peer            string
+	Checks          []healthCheck
+	Weights         serviceWeights
+	TaggedAddresses map[string]serviceAddress
+	Proxy           *serviceProxy
+	Connect         serviceConnect
+}
+
+type healthCheck struct {
+	Node        string `cty:"node"`
+	CheckID     string `cty:"check_id"`
+	Name        string `cty:"name"`
+	Status      string `cty:"status"`
+	Notes       string `cty:"notes"`
+	Output      string `cty:"output"`
+	ServiceID   string `cty:"service_id"`
+	ServiceName string `cty:"service_name"`
+	Type        string `cty:"type"`
+	Namespace   string `cty:"namespace"`
+}
+
+type serviceWeights struct {
+	Passing int `cty:"passing"`
+	Warning int `cty:"warning"`
+}
+
+type serviceAddress struct {
+	Address string `cty:"address"`
+	Port    int    `cty:"port"`
+}
+
+type serviceProxy struct {
+	DestinationServiceName string          `cty:"destination_service_name"`
+	DestinationServiceID   string          `cty:"destination_service_id"`
+	LocalServiceAddress    string          `cty:"local_service_address"`
+	LocalServicePort       int             `cty:"local_service_port"`
+	Upstreams              []proxyUpstream `cty:"upstreams"`
+}
+
+type proxyUpstream struct {
+	DestinationType      string `cty:"destination_type"`
+	DestinationNamespace string `cty:"destination_namespace"`
+	DestinationName      string `cty:"destination_name"`
+	Datacenter           string `cty:"datacenter"`
+	LocalBindAddress     string `cty:"local_bind_address"`
+	LocalBindPort        int    `cty:"local_bind_port"`
+}
+
+type serviceConnect struct {
+	Native bool `cty:"native"`
+}
+
+func newHealthServiceDetails(s *HealthService) healthServiceDetails {
+	// Default to empty collections instead of null
+	checks := make([]healthCheck, 0, len(s.Checks))
+	for _, c := range s.Checks {
+		checks = append(checks, healthCheck{
+			Node:        c.Node,
+			CheckID:     c.CheckID,
+			Name:        c.Name,
+			Status:      c.Status,
+			Notes:       c.Notes,
+			Output:      c.Output,
+			ServiceID:   c.ServiceID,
+			ServiceName: c.ServiceName,
+			Type:        c.Type,
+			Namespace:   c.Namespace,
+		})
+	}
+
+	addresses := make(map[string]serviceAddress, len(s.TaggedAddresses))
+	for k, a := range s.TaggedAddresses {
+		addresses[k] = serviceAddress{Address: a.Address, Port: a.Port}
+	}
+
+	// The proxy is null for services that are not a proxy
+	var proxy *serviceProxy
+	if s.Proxy != nil {
+		upstreams := make([]proxyUpstream, 0, len(s.Proxy.Upstreams))
+		for _, u := range s.Proxy.Upstreams {
+			upstreams = append(upstreams, proxyUpstream{
+				DestinationType:      string(u.DestinationType),
+				DestinationNamespace: u.DestinationNamespace,
+				DestinationName:      u.DestinationName,
+				Datacenter:           u.Datacenter,
+				LocalBindAddress:     u.LocalBindAddress,
+				LocalBindPort:        u.LocalBindPort,
+			})
+		}
+		proxy = &serviceProxy{
+			DestinationServiceName: s.Proxy.DestinationServiceName,
+			DestinationServiceID:   s.Proxy.DestinationServiceID,
+			LocalServiceAddress:    s.Proxy.LocalServiceAddress,
+			LocalServicePort:       s.Proxy.LocalServicePort,
+			Upstreams:              upstreams,
+		}
+	}
+
+	var connect serviceConnect
+	if s.Connect != nil {
+		connect.Native = s.Connect.Native
+	}
+
+	return healthServiceDetails{
+		Partition: s.Partition,
+		Peer:      s.PeerName,
+		Checks:    checks,
+		Weights: serviceWeights{
+			Passing: s.Weights.Passing,
+			Warning: s.Weights.Warning,
+		},
+		TaggedAddresses: addresses,
+		Proxy:           proxy,
+		Connect:         connect,
+	}
+}
+
+// appendHealthServiceDetails appends the service details as attributes to the
+// body of a service. Unlike gohcl, null attributes are not omitted since they
+// are required by the object type of the services variable.
+func appendHealthServiceDetails(body *hclwrite.Body, d healthServiceDetails) {
+	setAttributeGoValue(body, "partition", d.Partition)
+	setAttributeGoValue(body, "peer", d.Peer)
+	setAttributeGoValue(body, "checks", d.Checks)
+	setAttributeGoValue(body, "weights", d.Weights)
+	setAttributeGoValue(body, "tagged_addresses", d.TaggedAddresses)
+	setAttributeGoValue(body, "proxy", d.Proxy)
+	setAttributeGoValue(body, "connect", d.Connect)
+}
+
+// setAttributeGoValue sets an attribute to the cty value of a Go value with
+// cty struct tags. Like gohcl, it panics if the value cannot be converted.
+func setAttributeGoValue(body *hclwrite.Body, name string, v interface{}) {
+	ty, err := gocty.ImpliedType(v)
+	if err != nil {
+		panic(fmt.Sprintf("cannot encode %s: %s", name, err))
+	}
+	val, err := gocty.ToCtyValue(v, ty)
+	if err != nil {
+		panic(fmt.Sprintf("cannot encode %s: %s", name, err))
+	}
+	body.SetAttributeValue(name, val)
+}
+
 func nonNullMap(m map[string]string) map[string]string {
 	if m == nil {
 		return map[string]string{}
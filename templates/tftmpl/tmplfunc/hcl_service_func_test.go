@@ -3,6 +3,7 @@ package tmplfunc
 import (
 	"testing"
 
+	consulapi "github.com/hashicorp/consul/api"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 )
@@ -156,3 +157,147 @@ cts_user_defined_meta = {
 	actual := hclServiceFunc(meta)(content)
 	assert.Equal(t, expected, actual)
 }
+
+func TestHCLServiceFunc_details(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *HealthService
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&HealthService{HealthService: &dep.HealthService{ID: "api"}},
+			`id                    = "api"
+name                  = ""
+kind                  = ""
+address               = ""
+port                  = 0
+meta                  = {}
+tags                  = []
+namespace             = ""
+status                = ""
+node                  = ""
+node_id               = ""
+node_address          = ""
+node_datacenter       = ""
+node_tagged_addresses = {}
+node_meta             = {}
+cts_user_defined_meta = {}
+partition             = ""
+peer                  = ""
+checks                = []
+weights = {
+  passing = 0
+  warning = 0
+}
+tagged_addresses = {}
+proxy            = null
+connect = {
+  native = false
+}`,
+		}, {
+			"proxy",
+			&HealthService{
+				HealthService: &dep.HealthService{
+					ID:   "api-sidecar-proxy",
+					Kind: "connect-proxy",
+					Checks: consulapi.HealthChecks{{
+						Node:        "worker-01",
+						CheckID:     "service:api-sidecar-proxy",
+						Name:        "Connect Sidecar Listening",
+						Status:      "passing",
+						Output:      "TCP connect 127.0.0.1:21000: Success",
+						ServiceID:   "api-sidecar-proxy",
+						ServiceName: "api-sidecar-proxy",
+						Type:        "tcp",
+					}},
+					Weights: consulapi.AgentWeights{Passing: 10, Warning: 1},
+				},
+				Partition: "ap1",
+				PeerName:  "peer1",
+				TaggedAddresses: map[string]consulapi.ServiceAddress{
+					"lan": {Address: "127.0.0.1", Port: 21000},
+				},
+				Proxy: &consulapi.AgentServiceConnectProxyConfig{
+					DestinationServiceName: "api",
+					DestinationServiceID:   "api",
+					LocalServiceAddress:    "127.0.0.1",
+					LocalServicePort:       8080,
+					Upstreams: []consulapi.Upstream{{
+						DestinationType: consulapi.UpstreamDestTypeService,
+						DestinationName: "db",
+						LocalBindPort:   9191,
+					}},
+				},
+			},
+			`id                    = "api-sidecar-proxy"
+name                  = ""
+kind                  = "connect-proxy"
+address               = ""
+port                  = 0
+meta                  = {}
+tags                  = []
+namespace             = ""
+status                = ""
+node                  = ""
+node_id               = ""
+node_address          = ""
+node_datacenter       = ""
+node_tagged_addresses = {}
+node_meta             = {}
+cts_user_defined_meta = {}
+partition             = "ap1"
+peer                  = "peer1"
+checks = [{
+  check_id     = "service:api-sidecar-proxy"
+  name         = "Connect Sidecar Listening"
+  namespace    = ""
+  node         = "worker-01"
+  notes        = ""
+  output       = "TCP connect 127.0.0.1:21000: Success"
+  service_id   = "api-sidecar-proxy"
+  service_name = "api-sidecar-proxy"
+  status       = "passing"
+  type         = "tcp"
+}]
+weights = {
+  passing = 10
+  warning = 1
+}
+tagged_addresses = {
+  lan = {
+    address = "127.0.0.1"
+    port    = 21000
+  }
+}
+proxy = {
+  destination_service_id   = "api"
+  destination_service_name = "api"
+  local_service_address    = "127.0.0.1"
+  local_service_port       = 8080
+  upstreams = [{
+    datacenter            = ""
+    destination_name      = "db"
+    destination_namespace = ""
+    destination_type      = "service"
+    local_bind_address    = ""
+    local_bind_port       = 9191
+  }]
+}
+connect = {
+  native = false
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := hclServiceFunc(nil)(tc.content)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// hclVaultSecretFunc is a template function to marshal a Vault secret into
+// HCL. The data of the secret is marshalled as an object of its implied type,
+// since the data differs by secrets engine, e.g. KV v2 secrets nest the values
+// and metadata under "data" and "metadata".
+func hclVaultSecretFunc(s *dep.Secret) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+
+	data := cty.EmptyObjectVal
+	if len(s.Data) > 0 {
+		b, err := json.Marshal(s.Data)
+		if err != nil {
+			return "", fmt.Errorf("unable to marshal vault secret data: %s", err)
+		}
+		data, err = decodeJSON(b)
+		if err != nil {
+			return "", fmt.Errorf("unable to decode vault secret data: %s", err)
+		}
+	}
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	body.SetAttributeValue("lease_id", cty.StringVal(s.LeaseID))
+	body.SetAttributeValue("lease_duration", cty.NumberIntVal(int64(s.LeaseDuration)))
+	body.SetAttributeValue("renewable", cty.BoolVal(s.Renewable))
+	body.SetAttributeValue("data", data)
+	return strings.TrimSpace(string(f.Bytes())), nil
+}
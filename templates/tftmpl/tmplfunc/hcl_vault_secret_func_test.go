@@ -0,0 +1,81 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHCLVaultSecretFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *dep.Secret
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&dep.Secret{},
+			`lease_id       = ""
+lease_duration = 0
+renewable      = false
+data           = {}`,
+		}, {
+			"kv v2",
+			&dep.Secret{
+				Data: map[string]interface{}{
+					"data": map[string]interface{}{
+						"password": "s3cr3t",
+					},
+					"metadata": map[string]interface{}{
+						"version": 2,
+					},
+				},
+			},
+			`lease_id       = ""
+lease_duration = 0
+renewable      = false
+data = {
+  data = {
+    password = "s3cr3t"
+  }
+  metadata = {
+    version = 2
+  }
+}`,
+		}, {
+			"pki",
+			&dep.Secret{
+				LeaseID:       "pki/issue/web/5a0a1d6b",
+				LeaseDuration: 86400,
+				Renewable:     false,
+				Data: map[string]interface{}{
+					"certificate":   "-----BEGIN CERTIFICATE-----",
+					"ca_chain":      []interface{}{"-----BEGIN CERTIFICATE-----"},
+					"serial_number": "1a:2b",
+				},
+			},
+			`lease_id       = "pki/issue/web/5a0a1d6b"
+lease_duration = 86400
+renewable      = false
+data = {
+  ca_chain      = ["-----BEGIN CERTIFICATE-----"]
+  certificate   = "-----BEGIN CERTIFICATE-----"
+  serial_number = "1a:2b"
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := hclVaultSecretFunc(tc.content)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package tmplfunc
+
+import (
+	"sort"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
+)
+
+// HealthService is a Consul service instance with the details of the service
+// registration that are not included in the hcat HealthService. It is returned
+// by the template functions that query service details, which are used for the
+// services variable protocol v1.
+type HealthService struct {
+	*dep.HealthService
+
+	Partition       string
+	PeerName        string
+	TaggedAddresses map[string]consulapi.ServiceAddress
+	Proxy           *consulapi.AgentServiceConnectProxyConfig
+	Connect         *consulapi.AgentServiceConnect
+}
+
+// healthServiceDetailsFromEntry converts a Consul health service entry to a
+// HealthService object with the service details.
+func healthServiceDetailsFromEntry(entry *consulapi.ServiceEntry) *HealthService {
+	return &HealthService{
+		HealthService:   healthServiceFromEntry(entry),
+		Partition:       entry.Service.Partition,
+		PeerName:        entry.Service.PeerName,
+		TaggedAddresses: entry.Service.TaggedAddresses,
+		Proxy:           entry.Service.Proxy,
+		Connect:         entry.Service.Connect,
+	}
+}
+
+// healthServicesResult converts the service entries of a query to the result
+// of the query sorted by node and then ID. Queries for service details return
+// HealthService objects, otherwise hcat HealthService objects are returned.
+func healthServicesResult(entries []*consulapi.ServiceEntry, details bool) interface{} {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Node.Node == entries[j].Node.Node {
+			return entries[i].Service.ID <= entries[j].Service.ID
+		}
+		return entries[i].Node.Node < entries[j].Node.Node
+	})
+
+	if details {
+		services := make([]*HealthService, 0, len(entries))
+		for _, entry := range entries {
+			services = append(services, healthServiceDetailsFromEntry(entry))
+		}
+		return services
+	}
+
+	services := make([]*dep.HealthService, 0, len(entries))
+	for _, entry := range entries {
+		services = append(services, healthServiceFromEntry(entry))
+	}
+	return services
+}
+
+// withDetails wraps hcat HealthService objects as HealthService objects
+// without service details.
+func withDetails(services []*dep.HealthService) []*HealthService {
+	wrapped := make([]*HealthService, 0, len(services))
+	for _, s := range services {
+		wrapped = append(wrapped, &HealthService{HealthService: s})
+	}
+	return wrapped
+}
@@ -0,0 +1,61 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthServicesResult(t *testing.T) {
+	entries := func() []*consulapi.ServiceEntry {
+		return []*consulapi.ServiceEntry{
+			{
+				Node: &consulapi.Node{Node: "node-b"},
+				Service: &consulapi.AgentService{
+					ID:      "web-1",
+					Service: "web",
+				},
+			},
+			{
+				Node: &consulapi.Node{Node: "node-a", Address: "10.0.0.1"},
+				Service: &consulapi.AgentService{
+					ID:        "web-2",
+					Service:   "web",
+					Partition: "ap1",
+					PeerName:  "peer1",
+					TaggedAddresses: map[string]consulapi.ServiceAddress{
+						"lan": {Address: "10.0.0.1", Port: 8080},
+					},
+					Connect: &consulapi.AgentServiceConnect{Native: true},
+				},
+			},
+		}
+	}
+
+	t.Run("health services", func(t *testing.T) {
+		result := healthServicesResult(entries(), false)
+		services, ok := result.([]*dep.HealthService)
+		require.True(t, ok)
+		require.Len(t, services, 2)
+		assert.Equal(t, "web-2", services[0].ID)
+		assert.Equal(t, "10.0.0.1", services[0].Address)
+		assert.Equal(t, "web-1", services[1].ID)
+	})
+
+	t.Run("details", func(t *testing.T) {
+		result := healthServicesResult(entries(), true)
+		services, ok := result.([]*HealthService)
+		require.True(t, ok)
+		require.Len(t, services, 2)
+		assert.Equal(t, "web-2", services[0].ID)
+		assert.Equal(t, "10.0.0.1", services[0].TaggedAddresses["lan"].Address)
+		assert.Equal(t, "ap1", services[0].Partition)
+		assert.Equal(t, "peer1", services[0].PeerName)
+		assert.True(t, services[0].Connect.Native)
+		assert.Equal(t, "web-1", services[1].ID)
+		assert.Nil(t, services[1].Proxy)
+	})
+}
@@ -0,0 +1,216 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+var _ hcatQuery = (*intentionsQuery)(nil)
+
+// Intention is a Consul service intention. It is a subset of the Consul API
+// intention that includes the information to authorize traffic between
+// services.
+type Intention struct {
+	ID                   string
+	Description          string
+	SourceName           string
+	SourceNamespace      string
+	SourceType           string
+	DestinationName      string
+	DestinationNamespace string
+	Action               string
+	Precedence           int
+	Meta                 map[string]string
+}
+
+// intentionsFunc returns the Consul service intentions. It queries the List
+// Intentions API and supports the query parameters dc, ns, and partition. It
+// also adds an additional layer of custom functionality on the API response:
+//  - Adds regex filtering on the source service name e.g. "source=api"
+//  - Adds regex filtering on the destination service name e.g. "destination=db"
+//
+// Endpoint: /v1/connect/intentions
+// Template: {{ intentions <filter options> ... }}
+func intentionsFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) ([]*Intention, error) {
+		result := []*Intention{}
+
+		d, err := newIntentionsQuery(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*Intention), nil
+		}
+
+		return result, nil
+	}
+}
+
+// intentionsQuery is the representation of a requested intentions query from
+// inside a template.
+type intentionsQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	source      *regexp.Regexp // custom
+	destination *regexp.Regexp // custom
+	dc          string
+	ns          string
+	partition   string
+	opts        hcat.QueryOptions
+}
+
+// newIntentionsQuery processes options in the format of "key=value"
+// e.g. "dc=dc1"
+func newIntentionsQuery(opts []string) (*intentionsQuery, error) {
+	query := intentionsQuery{
+		stopCh: make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		param, value, err := stringsSplit2(opt, "=")
+		if err != nil {
+			return nil, fmt.Errorf("connect.intentions: invalid "+
+				"query parameter format: %q", opt)
+		}
+		switch param {
+		case "source", "destination":
+			r, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("connect.intentions: invalid %s regexp", param)
+			}
+			if param == "source" {
+				query.source = r
+			} else {
+				query.destination = r
+			}
+		case "dc", "datacenter":
+			query.dc = value
+		case "ns", "namespace":
+			query.ns = value
+		case "partition":
+			query.partition = value
+		default:
+			return nil, fmt.Errorf(
+				"connect.intentions: invalid query parameter: %q", opt)
+		}
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of Intention objects that match the source and destination regexes.
+func (d *intentionsQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	hcatOpts := d.opts.Merge(&hcat.QueryOptions{
+		Datacenter: d.dc,
+		Namespace:  d.ns,
+	})
+	opts := hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+
+	entries, qm, err := clients.Consul().Connect().Intentions(opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	intentions := make([]*Intention, 0, len(entries))
+	for _, i := range entries {
+		if d.source != nil && !d.source.MatchString(i.SourceName) {
+			continue
+		}
+		if d.destination != nil && !d.destination.MatchString(i.DestinationName) {
+			continue
+		}
+		intentions = append(intentions, &Intention{
+			ID:                   i.ID,
+			Description:          i.Description,
+			SourceName:           i.SourceName,
+			SourceNamespace:      i.SourceNS,
+			SourceType:           string(i.SourceType),
+			DestinationName:      i.DestinationName,
+			DestinationNamespace: i.DestinationNS,
+			Action:               string(i.Action),
+			Precedence:           i.Precedence,
+			Meta:                 i.Meta,
+		})
+	}
+
+	sort.Stable(ByPrecedence(intentions))
+
+	rm := &dep.ResponseMetadata{
+		LastIndex:   qm.LastIndex,
+		LastContact: qm.LastContact,
+	}
+
+	return intentions, rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *intentionsQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// String returns the human-friendly version of this query.
+func (d *intentionsQuery) String() string {
+	var opts []string
+	if d.dc != "" {
+		opts = append(opts, fmt.Sprintf("@%s", d.dc))
+	}
+	if d.destination != nil {
+		opts = append(opts, fmt.Sprintf("destination=%s", d.destination.String()))
+	}
+	if d.ns != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
+	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.source != nil {
+		opts = append(opts, fmt.Sprintf("source=%s", d.source.String()))
+	}
+	if len(opts) > 0 {
+		return fmt.Sprintf("connect.intentions(%s)", strings.Join(opts, "&"))
+	}
+	return "connect.intentions"
+}
+
+// Stop halts the query's fetch function.
+func (d *intentionsQuery) Stop() {
+	close(d.stopCh)
+}
+
+// ByPrecedence is a sortable slice of Intention structs. Intentions are sorted
+// in the order they are evaluated by Consul, by highest precedence first.
+type ByPrecedence []*Intention
+
+func (s ByPrecedence) Len() int      { return len(s) }
+func (s ByPrecedence) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ByPrecedence) Less(i, j int) bool {
+	if s[i].Precedence != s[j].Precedence {
+		return s[i].Precedence > s[j].Precedence
+	}
+	if s[i].SourceName != s[j].SourceName {
+		return s[i].SourceName < s[j].SourceName
+	}
+	return s[i].DestinationName < s[j].DestinationName
+}
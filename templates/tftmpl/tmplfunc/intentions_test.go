@@ -0,0 +1,199 @@
+package tmplfunc
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIntentionsQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *intentionsQuery
+		err  bool
+	}{
+		{
+			"no opts",
+			[]string{},
+			&intentionsQuery{},
+			false,
+		},
+		{
+			"all opts",
+			[]string{"source=^api$", "destination=db", "dc=dc1", "ns=ns1",
+				"partition=ap1"},
+			&intentionsQuery{
+				source:      regexp.MustCompile("^api$"),
+				destination: regexp.MustCompile("db"),
+				dc:          "dc1",
+				ns:          "ns1",
+				partition:   "ap1",
+			},
+			false,
+		},
+		{
+			"invalid source regexp",
+			[]string{"source=*"},
+			nil,
+			true,
+		},
+		{
+			"peer not supported",
+			[]string{"peer=peer1"},
+			nil,
+			true,
+		},
+		{
+			"invalid query parameter",
+			[]string{"node-meta=k:v"},
+			nil,
+			true,
+		},
+		{
+			"invalid format",
+			[]string{"source"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newIntentionsQuery(tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestIntentionsQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"empty",
+			[]string{},
+			"connect.intentions",
+		},
+		{
+			"source",
+			[]string{"source=^api$"},
+			"connect.intentions(source=^api$)",
+		},
+		{
+			"multiple",
+			[]string{"source=api", "ns=ns1", "destination=db", "dc=dc1",
+				"partition=ap1"},
+			"connect.intentions(@dc1&destination=db&ns=ns1&partition=ap1&source=api)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newIntentionsQuery(tc.i)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestIntentionsQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	srv := testutils.NewTestConsulServer(t, testutils.TestConsulServerConfig{})
+	defer srv.Stop()
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv.HTTPAddr
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err, "failed to make consul client")
+
+	for _, ixn := range []*consulapi.Intention{
+		{SourceName: "web", DestinationName: "api", Action: consulapi.IntentionActionAllow},
+		{SourceName: "api", DestinationName: "db", Action: consulapi.IntentionActionAllow},
+		{SourceName: "*", DestinationName: "db", Action: consulapi.IntentionActionDeny},
+	} {
+		_, err := client.Connect().IntentionUpsert(ixn, nil)
+		require.NoError(t, err)
+	}
+
+	names := func(intentions interface{}) []string {
+		var names []string
+		for _, i := range intentions.([]*Intention) {
+			names = append(names, i.SourceName+"=>"+i.DestinationName+":"+i.Action)
+		}
+		return names
+	}
+
+	cases := []struct {
+		name     string
+		i        []string
+		expected []string
+	}{
+		{
+			"no filtering",
+			[]string{},
+			[]string{"api=>db:allow", "web=>api:allow", "*=>db:deny"},
+		},
+		{
+			"source",
+			[]string{"source=^web$"},
+			[]string{"web=>api:allow"},
+		},
+		{
+			"destination",
+			[]string{"destination=^db$"},
+			[]string{"api=>db:allow", "*=>db:deny"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newIntentionsQuery(tc.i)
+			require.NoError(t, err)
+
+			actual, _, err := d.Fetch(&testClient{consul: client})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, names(actual))
+		})
+	}
+}
+
+func TestByPrecedence(t *testing.T) {
+	t.Parallel()
+
+	intentions := []*Intention{
+		{SourceName: "*", DestinationName: "db", Precedence: 8},
+		{SourceName: "web", DestinationName: "db", Precedence: 9},
+		{SourceName: "api", DestinationName: "db", Precedence: 9},
+		{SourceName: "*", DestinationName: "*", Precedence: 5},
+	}
+	sort.Stable(ByPrecedence(intentions))
+
+	var order []string
+	for _, i := range intentions {
+		order = append(order, i.SourceName+"=>"+i.DestinationName)
+	}
+	assert.Equal(t, []string{"api=>db", "web=>db", "*=>db", "*=>*"}, order)
+}
@@ -0,0 +1,234 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+const (
+	logSystemName         = "templates"
+	tmplfuncSubsystemName = "tmplfunc"
+)
+
+var (
+	// localFilesPollInterval is the interval between reads of the local files.
+	// The files are polled for changes since file system notifications are not
+	// supported on all platforms.
+	localFilesPollInterval = 2 * time.Second
+
+	_ dep.Dependency          = (*localFilesQuery)(nil)
+	_ hcat.QueryOptionsSetter = (*localFilesQuery)(nil)
+)
+
+// LocalFile is a local file matching the paths of a localFiles query
+type LocalFile struct {
+	Path    string
+	Content string
+}
+
+// localFilesFunc reads the local files matching the paths and returns the
+// contents of each file, sorted by path. A path can be a file, a directory of
+// files, or a glob pattern. Files matched by more than one path are only
+// returned once.
+//
+// Template: {{ localFiles <path> ... }}
+func localFilesFunc(recall hcat.Recaller) interface{} {
+	return func(paths ...string) ([]*LocalFile, error) {
+		result := []*LocalFile{}
+
+		d, err := newLocalFilesQuery(paths)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*LocalFile), nil
+		}
+
+		return result, nil
+	}
+}
+
+// localFilesQuery is the representation of a requested read of local files
+// from inside a template.
+type localFilesQuery struct {
+	stopCh chan struct{}
+
+	paths []string
+	opts  hcat.QueryOptions
+
+	// skipped are the files that could not be read by the last fetch
+	skipped map[string]error
+	logger  logging.Logger
+}
+
+// newLocalFilesQuery processes the paths of the local files
+func newLocalFilesQuery(paths []string) (*localFilesQuery, error) {
+	query := localFilesQuery{
+		stopCh: make(chan struct{}, 1),
+		logger: logging.Global().Named(logSystemName).Named(tmplfuncSubsystemName),
+	}
+
+	for _, path := range paths {
+		if strings.TrimSpace(path) == "" {
+			continue
+		}
+		if _, err := filepath.Match(path, ""); err != nil {
+			return nil, fmt.Errorf("local.files: invalid path %q: %s", path, err)
+		}
+		query.paths = append(query.paths, path)
+	}
+
+	if len(query.paths) == 0 {
+		return nil, fmt.Errorf("local.files: path required")
+	}
+
+	return &query, nil
+}
+
+// Fetch reads the local files matching the paths of the query and returns a
+// slice of LocalFile objects.
+//
+// The first read returns right away. Following reads wait for the poll
+// interval to pass. The index of the response increases with each read, and
+// the view only updates when the contents of the files change.
+//
+// Files that cannot be read, e.g. due to permissions, are skipped and logged
+// once until they can be read again, so that one unreadable file does not
+// stop the watcher.
+func (d *localFilesQuery) Fetch(dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	waitIndex := d.opts.WaitIndex
+	if waitIndex > 0 {
+		// the context of the query options is only exposed by the Consul options
+		ctx := d.opts.ToConsulOpts().Context()
+		select {
+		case <-time.After(localFilesPollInterval):
+		case <-d.stopCh:
+			return nil, nil, dep.ErrStopped
+		case <-ctx.Done():
+			return nil, nil, errors.Wrap(ctx.Err(), d.String())
+		}
+	}
+
+	files, skipped := readLocalFiles(d.paths)
+	for path, err := range skipped {
+		if _, ok := d.skipped[path]; !ok {
+			d.logger.Warn("skipping local file that cannot be read",
+				"query", d.String(), "path", path, "error", err)
+		}
+	}
+	d.skipped = skipped
+
+	rm := &dep.ResponseMetadata{
+		LastIndex: waitIndex + 1,
+	}
+
+	return files, rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// polling for changes.
+func (d *localFilesQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// String returns the human-friendly version of this query.
+func (d *localFilesQuery) String() string {
+	return fmt.Sprintf("local.files(%s)", strings.Join(d.paths, ","))
+}
+
+// Stop halts the query's fetch function.
+func (d *localFilesQuery) Stop() {
+	close(d.stopCh)
+}
+
+// readLocalFiles reads the files matching the paths. Directories are expanded
+// to the files directly within them. Files that are removed while being read
+// are skipped, and the errors of the other files that cannot be read are
+// returned by path.
+func readLocalFiles(paths []string) ([]*LocalFile, map[string]error) {
+	seen := make(map[string]bool)
+	skipped := make(map[string]error)
+	var matches []string
+	for _, path := range paths {
+		// patterns are validated when the query is created, so glob does
+		// not return an error
+		m, _ := filepath.Glob(path)
+
+		for _, match := range m {
+			info, err := os.Stat(match)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					skipped[match] = err
+				}
+				continue
+			}
+
+			names := []string{match}
+			if info.IsDir() {
+				names, err = dirFiles(match)
+				if err != nil {
+					skipped[match] = err
+					continue
+				}
+			}
+
+			for _, name := range names {
+				if !seen[name] {
+					seen[name] = true
+					matches = append(matches, name)
+				}
+			}
+		}
+	}
+	sort.Strings(matches)
+
+	files := make([]*LocalFile, 0, len(matches))
+	for _, path := range matches {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				skipped[path] = err
+			}
+			continue
+		}
+		files = append(files, &LocalFile{
+			Path:    path,
+			Content: string(content),
+		})
+	}
+	return files, skipped
+}
+
+// dirFiles returns the paths of the regular files directly within the
+// directory
+func dirFiles(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			names = append(names, filepath.Join(dir, info.Name()))
+		}
+	}
+	return names, nil
+}
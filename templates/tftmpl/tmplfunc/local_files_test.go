@@ -0,0 +1,223 @@
+package tmplfunc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalFilesQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		paths []string
+		exp   *localFilesQuery
+		err   bool
+	}{
+		{
+			"path",
+			[]string{"inventory/hosts.json"},
+			&localFilesQuery{
+				paths: []string{"inventory/hosts.json"},
+			},
+			false,
+		},
+		{
+			"empty paths are ignored",
+			[]string{"inventory/*.yaml", " ", "racks.hcl"},
+			&localFilesQuery{
+				paths: []string{"inventory/*.yaml", "racks.hcl"},
+			},
+			false,
+		},
+		{
+			"path required",
+			[]string{},
+			nil,
+			true,
+		},
+		{
+			"invalid pattern",
+			[]string{"inventory/[.json"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newLocalFilesQuery(tc.paths)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+				act.logger = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestLocalFilesQuery_String(t *testing.T) {
+	t.Parallel()
+
+	d, err := newLocalFilesQuery([]string{"inventory/*.yaml", "racks.hcl"})
+	require.NoError(t, err)
+	assert.Equal(t, "local.files(inventory/*.yaml,racks.hcl)", d.String())
+}
+
+func TestLocalFilesQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "local-files")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	inventory := filepath.Join(dir, "inventory")
+	require.NoError(t, os.Mkdir(inventory, 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(inventory, "nested"), 0755))
+
+	files := map[string]string{
+		filepath.Join(inventory, "b.yaml"):              "b: 2\n",
+		filepath.Join(inventory, "a.json"):              `{"a": 1}`,
+		filepath.Join(inventory, "nested", "c.hcl"):     "c = 3\n",
+		filepath.Join(dir, "racks.hcl"):                 "racks = 2\n",
+		filepath.Join(inventory, "nested", "d.yaml"):    "d: 4\n",
+		filepath.Join(inventory, "nested", "e.unknown"): "",
+	}
+	for path, content := range files {
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	paths := func(a interface{}) []string {
+		var actual []string
+		for _, f := range a.([]*LocalFile) {
+			actual = append(actual, f.Path)
+		}
+		return actual
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		d, err := newLocalFilesQuery([]string{inventory})
+		require.NoError(t, err)
+
+		a, rm, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), rm.LastIndex)
+		assert.Equal(t, []*LocalFile{
+			{Path: filepath.Join(inventory, "a.json"), Content: `{"a": 1}`},
+			{Path: filepath.Join(inventory, "b.yaml"), Content: "b: 2\n"},
+		}, a)
+	})
+
+	t.Run("glob patterns", func(t *testing.T) {
+		d, err := newLocalFilesQuery([]string{
+			filepath.Join(inventory, "*", "*.yaml"),
+			filepath.Join(dir, "*.hcl"),
+			filepath.Join(dir, "racks.hcl"),
+		})
+		require.NoError(t, err)
+
+		a, _, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(inventory, "nested", "d.yaml"),
+			filepath.Join(dir, "racks.hcl"),
+		}, paths(a))
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		d, err := newLocalFilesQuery([]string{filepath.Join(dir, "dne.json")})
+		require.NoError(t, err)
+
+		a, _, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Empty(t, a)
+	})
+
+	t.Run("unreadable files are skipped", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("file permissions are not enforced for root")
+		}
+
+		unreadable := filepath.Join(dir, "unreadable")
+		require.NoError(t, os.Mkdir(unreadable, 0755))
+		path := filepath.Join(unreadable, "hosts.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte("{}"), 0000))
+		readable := filepath.Join(unreadable, "racks.json")
+		require.NoError(t, ioutil.WriteFile(readable, []byte("{}"), 0644))
+
+		d, err := newLocalFilesQuery([]string{filepath.Join(unreadable, "*.json")})
+		require.NoError(t, err)
+
+		a, _, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{readable}, paths(a))
+		assert.Contains(t, d.skipped, path)
+	})
+
+	t.Run("files that cannot be read are skipped", func(t *testing.T) {
+		// a symlink loop cannot be read regardless of the permissions of the
+		// user running the test
+		loop := filepath.Join(dir, "loop")
+		require.NoError(t, os.Mkdir(loop, 0755))
+		path := filepath.Join(loop, "hosts.json")
+		require.NoError(t, os.Symlink(path, path))
+		readable := filepath.Join(loop, "racks.json")
+		require.NoError(t, ioutil.WriteFile(readable, []byte("{}"), 0644))
+
+		d, err := newLocalFilesQuery([]string{filepath.Join(loop, "*.json"), loop})
+		require.NoError(t, err)
+
+		a, _, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{readable}, paths(a))
+		assert.Contains(t, d.skipped, path)
+
+		// the file is read once it can be read again
+		require.NoError(t, os.Remove(path))
+		require.NoError(t, ioutil.WriteFile(path, []byte("{}"), 0644))
+		a, _, err = d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{path, readable}, paths(a))
+		assert.Empty(t, d.skipped)
+	})
+
+	t.Run("polls after first execution", func(t *testing.T) {
+		interval := localFilesPollInterval
+		localFilesPollInterval = 100 * time.Millisecond
+		defer func() { localFilesPollInterval = interval }()
+
+		d, err := newLocalFilesQuery([]string{filepath.Join(dir, "racks.hcl")})
+		require.NoError(t, err)
+		d.SetOptions(hcat.QueryOptions{WaitIndex: 1})
+
+		start := time.Now()
+		a, rm, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.True(t, time.Since(start) >= localFilesPollInterval)
+		assert.Equal(t, uint64(2), rm.LastIndex)
+		assert.Len(t, a.([]*LocalFile), 1)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		d, err := newLocalFilesQuery([]string{inventory})
+		require.NoError(t, err)
+		d.Stop()
+		_, _, err = d.Fetch(nil)
+		assert.Equal(t, dep.ErrStopped, err)
+	})
+}
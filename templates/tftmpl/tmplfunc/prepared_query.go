@@ -0,0 +1,219 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+var (
+	// preparedQueryPollInterval is the interval between executions of a
+	// prepared query. The Execute Prepared Query API does not support blocking
+	// queries, so the result of the query is polled for changes.
+	preparedQueryPollInterval = 30 * time.Second
+
+	_ hcatQuery = (*preparedQueryQuery)(nil)
+)
+
+// preparedQueryFunc executes a Consul prepared query and returns the
+// service instances of the result. It supports the query parameters name, dc,
+// near, and limit. The name is the name or ID of the prepared query and is
+// required. The result is sorted by node and then ID after the limit is
+// applied, so near only affects which instances are within the limit.
+//
+// Endpoint: /v1/query/:name/execute
+// Template: {{ preparedQuery name=<name> <options> ... }}
+func preparedQueryFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) ([]*dep.HealthService, error) {
+		result := []*dep.HealthService{}
+
+		d, err := newPreparedQueryQuery(opts, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*dep.HealthService), nil
+		}
+
+		return result, nil
+	}
+}
+
+// preparedQueryDetailsFunc executes a Consul prepared query and returns the
+// service instances of the result with the details of the service
+// registrations. It supports the same parameters as preparedQueryFunc.
+//
+// Endpoint: /v1/query/:name/execute
+// Template: {{ preparedQueryDetails name=<name> <options> ... }}
+func preparedQueryDetailsFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) ([]*HealthService, error) {
+		result := []*HealthService{}
+
+		d, err := newPreparedQueryQuery(opts, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*HealthService), nil
+		}
+
+		return result, nil
+	}
+}
+
+// preparedQueryQuery is the representation of a requested prepared query
+// execution from inside a template.
+type preparedQueryQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	name  string
+	dc    string
+	near  string
+	limit int
+	opts  hcat.QueryOptions
+
+	// details is whether the query returns the service details
+	details bool
+}
+
+// newPreparedQueryQuery processes options in the format of "key=value"
+// e.g. "name=web-failover"
+func newPreparedQueryQuery(opts []string, details bool) (*preparedQueryQuery, error) {
+	query := preparedQueryQuery{
+		stopCh:  make(chan struct{}, 1),
+		details: details,
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		param, value, err := stringsSplit2(opt, "=")
+		if err != nil {
+			return nil, fmt.Errorf("prepared.query: invalid "+
+				"query parameter format: %q", opt)
+		}
+		switch param {
+		case "name":
+			query.name = value
+		case "dc", "datacenter":
+			query.dc = value
+		case "near":
+			query.near = value
+		case "limit":
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 0 {
+				return nil, fmt.Errorf("prepared.query: invalid limit: %q", value)
+			}
+			query.limit = limit
+		default:
+			return nil, fmt.Errorf(
+				"prepared.query: invalid query parameter: %q", opt)
+		}
+	}
+
+	if query.name == "" {
+		return nil, fmt.Errorf("prepared.query: name option required")
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of HealthService objects of the prepared query result. The objects include
+// the service details if the query is for the details.
+//
+// The first execution of the query returns right away. Following executions
+// wait for the poll interval to pass. The index of the response increases with
+// each execution, and the view only updates when the result changes.
+func (d *preparedQueryQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	waitIndex := d.opts.WaitIndex
+	hcatOpts := d.opts.Merge(&hcat.QueryOptions{
+		Datacenter: d.dc,
+		Near:       d.near,
+	})
+	opts := hcatOpts.ToConsulOpts()
+	// The API does not support blocking queries
+	opts.WaitIndex = 0
+	opts.WaitTime = 0
+
+	if waitIndex > 0 {
+		select {
+		case <-time.After(preparedQueryPollInterval):
+		case <-d.stopCh:
+			return nil, nil, dep.ErrStopped
+		case <-opts.Context().Done():
+			return nil, nil, errors.Wrap(opts.Context().Err(), d.String())
+		}
+	}
+
+	resp, qm, err := clients.Consul().PreparedQuery().Execute(d.name, opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	// The nodes are sorted by the RTT to the near node, so the limit keeps the
+	// nearest instances
+	nodes := resp.Nodes
+	if d.limit > 0 && len(nodes) > d.limit {
+		nodes = nodes[:d.limit]
+	}
+	entries := make([]*consulapi.ServiceEntry, 0, len(nodes))
+	for i := range nodes {
+		entries = append(entries, &nodes[i])
+	}
+
+	rm := &dep.ResponseMetadata{
+		LastIndex:   waitIndex + 1,
+		LastContact: qm.LastContact,
+	}
+
+	return healthServicesResult(entries, d.details), rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *preparedQueryQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// String returns the human-friendly version of this query.
+func (d *preparedQueryQuery) String() string {
+	opts := []string{fmt.Sprintf("name=%s", d.name)}
+	if d.dc != "" {
+		opts = append(opts, fmt.Sprintf("@%s", d.dc))
+	}
+	if d.near != "" {
+		opts = append(opts, fmt.Sprintf("near=%s", d.near))
+	}
+	if d.limit > 0 {
+		opts = append(opts, fmt.Sprintf("limit=%d", d.limit))
+	}
+
+	prefix := "prepared.query"
+	if d.details {
+		prefix = "prepared.query.details"
+	}
+	return fmt.Sprintf("%s(%s)", prefix, strings.Join(opts, "&"))
+}
+
+// Stop halts the query's fetch function.
+func (d *preparedQueryQuery) Stop() {
+	close(d.stopCh)
+}
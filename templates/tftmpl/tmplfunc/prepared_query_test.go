@@ -0,0 +1,196 @@
+package tmplfunc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPreparedQueryQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *preparedQueryQuery
+		err  bool
+	}{
+		{
+			"name",
+			[]string{"name=web-failover"},
+			&preparedQueryQuery{
+				name: "web-failover",
+			},
+			false,
+		},
+		{
+			"all opts",
+			[]string{"name=web-failover", "dc=dc1", "near=_agent", "limit=3"},
+			&preparedQueryQuery{
+				name:  "web-failover",
+				dc:    "dc1",
+				near:  "_agent",
+				limit: 3,
+			},
+			false,
+		},
+		{
+			"name required",
+			[]string{"dc=dc1"},
+			nil,
+			true,
+		},
+		{
+			"invalid limit",
+			[]string{"name=web-failover", "limit=-1"},
+			nil,
+			true,
+		},
+		{
+			"invalid query parameter",
+			[]string{"name=web-failover", "ns=default"},
+			nil,
+			true,
+		},
+		{
+			"invalid format",
+			[]string{"name"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newPreparedQueryQuery(tc.opts, false)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestPreparedQueryQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       []string
+		details bool
+		exp     string
+	}{
+		{
+			"name",
+			[]string{"name=web-failover"},
+			false,
+			"prepared.query(name=web-failover)",
+		},
+		{
+			"all opts",
+			[]string{"name=web-failover", "dc=dc1", "near=_agent", "limit=3"},
+			false,
+			"prepared.query(name=web-failover&@dc1&near=_agent&limit=3)",
+		},
+		{
+			"details",
+			[]string{"name=web-failover"},
+			true,
+			"prepared.query.details(name=web-failover)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newPreparedQueryQuery(tc.i, tc.details)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestPreparedQueryQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	srv := testutils.NewTestConsulServer(t, testutils.TestConsulServerConfig{})
+	defer srv.Stop()
+
+	for _, id := range []string{"web-1", "web-2", "web-3"} {
+		service := testutil.TestService{ID: id, Name: "web"}
+		testutils.RegisterConsulServiceHealth(t, srv, service, 8*time.Second, testutil.HealthPassing)
+	}
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv.HTTPAddr
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err, "failed to make consul client")
+
+	_, _, err = client.PreparedQuery().Create(&consulapi.PreparedQueryDefinition{
+		Name:    "web-failover",
+		Service: consulapi.ServiceQuery{Service: "web"},
+	}, nil)
+	require.NoError(t, err)
+
+	t.Run("name", func(t *testing.T) {
+		d, err := newPreparedQueryQuery([]string{"name=web-failover"}, false)
+		require.NoError(t, err)
+
+		a, rm, err := d.Fetch(&testClient{consul: client})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), rm.LastIndex)
+
+		var actual []string
+		for _, s := range a.([]*dep.HealthService) {
+			actual = append(actual, s.ID)
+		}
+		assert.Equal(t, []string{"web-1", "web-2", "web-3"}, actual)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		d, err := newPreparedQueryQuery([]string{"name=web-failover", "limit=2"}, false)
+		require.NoError(t, err)
+
+		a, _, err := d.Fetch(&testClient{consul: client})
+		require.NoError(t, err)
+		assert.Len(t, a.([]*dep.HealthService), 2)
+	})
+
+	t.Run("polls after first execution", func(t *testing.T) {
+		interval := preparedQueryPollInterval
+		preparedQueryPollInterval = 100 * time.Millisecond
+		defer func() { preparedQueryPollInterval = interval }()
+
+		d, err := newPreparedQueryQuery([]string{"name=web-failover"}, true)
+		require.NoError(t, err)
+		d.SetOptions(hcat.QueryOptions{WaitIndex: 1})
+
+		start := time.Now()
+		a, rm, err := d.Fetch(&testClient{consul: client})
+		require.NoError(t, err)
+		assert.True(t, time.Since(start) >= preparedQueryPollInterval)
+		assert.Equal(t, uint64(2), rm.LastIndex)
+		assert.Len(t, a.([]*HealthService), 3)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		d, err := newPreparedQueryQuery([]string{"name=web-failover"}, false)
+		require.NoError(t, err)
+		d.Stop()
+		_, _, err = d.Fetch(&testClient{consul: client})
+		assert.Equal(t, dep.ErrStopped, err)
+	})
+}
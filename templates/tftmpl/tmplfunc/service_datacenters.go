@@ -0,0 +1,402 @@
+package tmplfunc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+// allDatacenters is the value of the dc query parameter to query all the
+// datacenters known to Consul
+const allDatacenters = "*"
+
+// datacentersCacheTTL is how long the datacenters known to Consul are cached
+// by the queries of all the datacenters
+const datacentersCacheTTL = time.Minute
+
+var _ hcatQuery = (*serviceDatacentersQuery)(nil)
+
+// serviceDatacentersFunc returns the instances of a Consul service in multiple
+// datacenters. It queries the Health API for the service in each of the
+// datacenters and merges the instances. It supports the parameters dc, ns,
+// partition, and filter. The dc parameter can be set multiple times, or set to "*" to query
+// all the datacenters known to Consul.
+//
+// Endpoints:
+//   /v1/catalog/datacenters (dc=*)
+//   /v1/health/service/:service
+// Template: {{ serviceDatacenters "<name>" "dc=<dc>" ... <options> ... }}
+func serviceDatacentersFunc(recall hcat.Recaller) interface{} {
+	return func(name string, opts ...string) ([]*dep.HealthService, error) {
+		result := []*dep.HealthService{}
+
+		d, err := newServiceDatacentersQuery(name, opts, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*dep.HealthService), nil
+		}
+
+		return result, nil
+	}
+}
+
+// serviceDetailsFunc returns the instances of a Consul service with the
+// details of the service registration. It supports the same parameters as
+// serviceDatacentersFunc, except the dc parameter is optional and defaults to
+// the datacenter of the agent. A query of a single datacenter is a blocking
+// query like the service query.
+//
+// Endpoints:
+//   /v1/catalog/datacenters (dc=*)
+//   /v1/health/service/:service
+// Template: {{ serviceDetails "<name>" <options> ... }}
+func serviceDetailsFunc(recall hcat.Recaller) interface{} {
+	return func(name string, opts ...string) ([]*HealthService, error) {
+		result := []*HealthService{}
+
+		d, err := newServiceDatacentersQuery(name, opts, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*HealthService), nil
+		}
+
+		return result, nil
+	}
+}
+
+// serviceDatacentersQuery is the representation of a requested service query
+// across datacenters from inside a template.
+type serviceDatacentersQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	name      string
+	dcs       []string
+	ns        string
+	partition string
+	filter    string
+	opts      hcat.QueryOptions
+
+	// details is whether the query returns the service details
+	details bool
+
+	// indexes and entries are the index and the entries of the last response
+	// of each datacenter
+	indexes datacenterIndexes
+	entries map[string][]*consulapi.ServiceEntry
+}
+
+// newServiceDatacentersQuery processes options in the format of "key=value"
+// (e.g. "dc=dc1") with the exception of filters. Any option that is not a
+// key/value pair is assumed to be a filter. The dc option is required unless
+// the query is for the service details.
+func newServiceDatacentersQuery(name string, opts []string, details bool) (*serviceDatacentersQuery, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("service.datacenters: service name required")
+	}
+
+	query := serviceDatacentersQuery{
+		stopCh:  make(chan struct{}, 1),
+		name:    name,
+		details: details,
+	}
+
+	var filters []string
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		if queryParamOptRe.MatchString(opt) || isAllDatacentersOpt(opt) {
+			param, value, err := stringsSplit2(opt, "=")
+			if err != nil {
+				return nil, fmt.Errorf("service.datacenters: invalid "+
+					"query parameter format: %q", opt)
+			}
+			switch param {
+			case "dc", "datacenter":
+				query.dcs = append(query.dcs, value)
+				continue
+			case "ns", "namespace":
+				query.ns = value
+				continue
+			case "partition":
+				query.partition = value
+				continue
+			}
+		}
+
+		// Any option that was not already parsed is assumed to be a filter.
+		// Evaluate the grammar of the filter before attempting to query Consul.
+		if _, err := bexpr.CreateFilter(opt); err != nil {
+			return nil, fmt.Errorf(
+				"service.datacenters: invalid filter: %q: %s", opt, err)
+		}
+		filters = append(filters, opt)
+	}
+
+	if len(filters) > 0 {
+		query.filter = strings.Join(filters, " and ")
+	}
+
+	if len(query.dcs) == 0 && !details {
+		return nil, fmt.Errorf("service.datacenters: dc option required")
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of HealthService objects of the service in all the datacenters. The objects
+// include the service details if the query is for the details.
+//
+// A query of a single datacenter is a blocking query of the Health API. The
+// Health API only supports blocking queries for a single datacenter, so for
+// multiple datacenters each datacenter is queried with its own blocking query.
+// Fetch returns when the instances in any of the datacenters change, or when
+// the queries of all the datacenters time out.
+func (d *serviceDatacentersQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	if len(d.dcs) <= 1 && !isAllDatacenters(d.dcs) {
+		var dc string
+		if len(d.dcs) == 1 {
+			dc = d.dcs[0]
+		}
+
+		ctx := d.opts.ToConsulOpts().Context()
+		entries, qm, err := d.fetchDatacenter(ctx, clients, dc, d.opts.WaitIndex)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, d.String())
+		}
+
+		rm := &dep.ResponseMetadata{
+			LastIndex:   qm.LastIndex,
+			LastContact: qm.LastContact,
+		}
+		return healthServicesResult(entries, d.details), rm, nil
+	}
+
+	dcs, err := d.indexes.datacenters(clients, d.dcs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	ctx, cancel := context.WithCancel(d.opts.ToConsulOpts().Context())
+	defer cancel()
+
+	type result struct {
+		dc      string
+		entries []*consulapi.ServiceEntry
+		qm      *consulapi.QueryMeta
+		err     error
+	}
+	resultCh := make(chan result, len(dcs))
+	for _, dc := range dcs {
+		go func(dc string, index uint64) {
+			entries, qm, err := d.fetchDatacenter(ctx, clients, dc, index)
+			resultCh <- result{dc: dc, entries: entries, qm: qm, err: err}
+		}(dc, d.indexes.indexes[dc])
+	}
+
+	if d.entries == nil {
+		d.entries = make(map[string][]*consulapi.ServiceEntry)
+	}
+	indexes := make(map[string]uint64, len(dcs))
+	for _, dc := range dcs {
+		if index, ok := d.indexes.indexes[dc]; ok {
+			indexes[dc] = index
+		}
+	}
+
+	rm := &dep.ResponseMetadata{}
+	canceled := false
+	for range dcs {
+		var r result
+		select {
+		case r = <-resultCh:
+		case <-d.stopCh:
+			return nil, nil, dep.ErrStopped
+		}
+
+		if r.err != nil {
+			if canceled {
+				continue
+			}
+			return nil, nil, errors.Wrap(r.err, d.String())
+		}
+
+		prev, ok := indexes[r.dc]
+		indexes[r.dc] = r.qm.LastIndex
+		d.entries[r.dc] = r.entries
+		if r.qm.LastContact > rm.LastContact {
+			rm.LastContact = r.qm.LastContact
+		}
+
+		// Once every datacenter has been queried, the queries that are still
+		// blocking are canceled when the instances of a datacenter change.
+		if (!ok || prev != r.qm.LastIndex) && len(indexes) == len(dcs) {
+			canceled = true
+			cancel()
+		}
+	}
+
+	var entries []*consulapi.ServiceEntry
+	for _, dc := range dcs {
+		entries = append(entries, d.entries[dc]...)
+	}
+	for dc := range d.entries {
+		if _, ok := indexes[dc]; !ok {
+			delete(d.entries, dc)
+		}
+	}
+
+	rm.LastIndex = d.indexes.update(indexes)
+	return healthServicesResult(entries, d.details), rm, nil
+}
+
+// fetchDatacenter fetches the health service entries of the service in a
+// datacenter. The query blocks until the index of the datacenter changes from
+// the given index.
+func (d *serviceDatacentersQuery) fetchDatacenter(ctx context.Context, clients dep.Clients,
+	dc string, index uint64) ([]*consulapi.ServiceEntry, *consulapi.QueryMeta, error) {
+
+	hcatOpts := d.opts
+	hcatOpts.Datacenter = dc
+	hcatOpts.Namespace = d.ns
+	hcatOpts.WaitIndex = index
+	opts := hcatOpts.ToConsulOpts().WithContext(ctx)
+	opts.Partition = d.partition
+	opts.Filter = d.filter
+
+	return clients.Consul().Health().Service(d.name, "", false, opts)
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *serviceDatacentersQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// String returns the human-friendly version of this query.
+func (d *serviceDatacentersQuery) String() string {
+	var opts []string
+	for _, dc := range d.dcs {
+		opts = append(opts, fmt.Sprintf("dc=%s", dc))
+	}
+	if d.ns != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
+	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.filter != "" {
+		opts = append(opts, fmt.Sprintf("filter=%s", d.filter))
+	}
+
+	sort.Strings(opts)
+	prefix := "service.datacenters"
+	if d.details {
+		prefix = "service.details"
+	}
+	return fmt.Sprintf("%s(%s|%s)", prefix, d.name, strings.Join(opts, "&"))
+}
+
+// Stop halts the query's fetch function.
+func (d *serviceDatacentersQuery) Stop() {
+	close(d.stopCh)
+}
+
+// datacenterIndexes tracks the datacenters of a query across datacenters and
+// the index of each datacenter. The indexes of different datacenters cannot be
+// compared, so the index of the query is a counter that is incremented when the
+// index of any of the datacenters changes.
+type datacenterIndexes struct {
+	indexes map[string]uint64
+	index   uint64
+
+	// allDCs and allDCsTime are the datacenters known to Consul and when they
+	// were last queried
+	allDCs     []string
+	allDCsTime time.Time
+}
+
+// datacenters returns the datacenters to query. If the datacenters are the
+// wildcard "*", all the datacenters known to Consul are returned, which are
+// cached for datacentersCacheTTL. If there are no datacenters, only the
+// default datacenter of the agent is queried.
+func (i *datacenterIndexes) datacenters(clients dep.Clients, dcs []string) ([]string, error) {
+	if len(dcs) == 0 {
+		return []string{""}, nil
+	}
+
+	if isAllDatacenters(dcs) {
+		if i.allDCs == nil || time.Since(i.allDCsTime) > datacentersCacheTTL {
+			allDCs, err := clients.Consul().Catalog().Datacenters()
+			if err != nil {
+				return nil, err
+			}
+			i.allDCs = allDCs
+			i.allDCsTime = time.Now()
+		}
+		return i.allDCs, nil
+	}
+
+	return dcs, nil
+}
+
+// update records the index of each datacenter queried and returns the index
+// of the query.
+func (i *datacenterIndexes) update(indexes map[string]uint64) uint64 {
+	changed := len(indexes) != len(i.indexes)
+	for dc, index := range indexes {
+		if prev, ok := i.indexes[dc]; !ok || prev != index {
+			changed = true
+		}
+	}
+
+	i.indexes = indexes
+	if changed {
+		i.index++
+	}
+	return i.index
+}
+
+// isAllDatacenters returns whether the datacenters include the wildcard "*"
+// for all the datacenters known to Consul
+func isAllDatacenters(dcs []string) bool {
+	for _, dc := range dcs {
+		if dc == allDatacenters {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllDatacentersOpt returns whether the option queries all datacenters. The
+// wildcard value is not matched by the query parameter regex.
+func isAllDatacentersOpt(opt string) bool {
+	param, value, err := stringsSplit2(opt, "=")
+	if err != nil {
+		return false
+	}
+	return (param == "dc" || param == "datacenter") && value == allDatacenters
+}
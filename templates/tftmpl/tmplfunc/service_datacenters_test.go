@@ -0,0 +1,364 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServiceDatacentersQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		service string
+		opts    []string
+		exp     *serviceDatacentersQuery
+		err     bool
+	}{
+		{
+			"no name",
+			"",
+			[]string{"dc=dc1"},
+			nil,
+			true,
+		},
+		{
+			"no dc",
+			"web",
+			[]string{},
+			nil,
+			true,
+		},
+		{
+			"multiple dcs",
+			"web",
+			[]string{"dc=dc1", "datacenter=dc2"},
+			&serviceDatacentersQuery{
+				name: "web",
+				dcs:  []string{"dc1", "dc2"},
+			},
+			false,
+		},
+		{
+			"all dcs",
+			"web",
+			[]string{"dc=*"},
+			&serviceDatacentersQuery{
+				name: "web",
+				dcs:  []string{"*"},
+			},
+			false,
+		},
+		{
+			"all opts",
+			"web",
+			[]string{"dc=dc1", "ns=namespace", "partition=ap1",
+				"\"my-tag\" in Service.Tags"},
+			&serviceDatacentersQuery{
+				name:      "web",
+				dcs:       []string{"dc1"},
+				ns:        "namespace",
+				partition: "ap1",
+				filter:    "\"my-tag\" in Service.Tags",
+			},
+			false,
+		},
+		{
+			"invalid filter",
+			"web",
+			[]string{"dc=dc1", "invalid"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newServiceDatacentersQuery(tc.service, tc.opts, false)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestNewServiceDatacentersQuery_Details(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no dc", func(t *testing.T) {
+		d, err := newServiceDatacentersQuery("web", []string{"ns=namespace"}, true)
+		require.NoError(t, err)
+		assert.True(t, d.details)
+		assert.Empty(t, d.dcs)
+		assert.Equal(t, "service.details(web|ns=namespace)", d.String())
+	})
+
+	t.Run("dc", func(t *testing.T) {
+		d, err := newServiceDatacentersQuery("web", []string{"dc=dc1"}, true)
+		require.NoError(t, err)
+		assert.Equal(t, "service.details(web|dc=dc1)", d.String())
+	})
+}
+
+func TestServiceDatacentersQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"dc",
+			[]string{"dc=dc1"},
+			"service.datacenters(web|dc=dc1)",
+		},
+		{
+			"multiple",
+			[]string{"ns=namespace", "dc=dc2", "dc=dc1", "partition=ap1",
+				"\"my-tag\" in Service.Tags"},
+			`service.datacenters(web|dc=dc1&dc=dc2&filter="my-tag" in Service.Tags&ns=namespace&partition=ap1)`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newServiceDatacentersQuery("web", tc.i, false)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestServiceDatacentersQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	srv := testutils.NewTestConsulServer(t, testutils.TestConsulServerConfig{})
+	defer srv.Stop()
+
+	service := testutil.TestService{ID: "web-1", Name: "web"}
+	testutils.RegisterConsulServiceHealth(t, srv, service, 8*time.Second, testutil.HealthPassing)
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv.HTTPAddr
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err, "failed to make consul client")
+
+	cases := []struct {
+		name     string
+		i        []string
+		expected []string
+	}{
+		{
+			"dc",
+			[]string{"dc=dc1"},
+			[]string{"web-1"},
+		},
+		{
+			"all dcs",
+			[]string{"dc=*"},
+			[]string{"web-1"},
+		},
+		{
+			"filter",
+			[]string{"dc=*", "\"my-tag\" in Service.Tags"},
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newServiceDatacentersQuery("web", tc.i, false)
+			require.NoError(t, err)
+
+			a, _, err := d.Fetch(&testClient{consul: client})
+			require.NoError(t, err)
+
+			var actual []string
+			for _, s := range a.([]*dep.HealthService) {
+				actual = append(actual, s.ID)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestServiceDatacentersQuery_Fetch_Blocking(t *testing.T) {
+	t.Parallel()
+
+	// The fake Consul server blocks the health query of a datacenter until the
+	// index of the datacenter changes from the requested index
+	var mu sync.Mutex
+	dcsRequests := 0
+	indexes := map[string]uint64{"dc1": 10, "dc2": 20}
+	instances := map[string][]string{"dc1": {"web-1"}, "dc2": {"web-2"}}
+	var requested []string
+	changeCh := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/catalog/datacenters":
+			mu.Lock()
+			dcsRequests++
+			mu.Unlock()
+			json.NewEncoder(w).Encode([]string{"dc1", "dc2"})
+		case "/v1/health/service/web":
+			dc := r.URL.Query().Get("dc")
+			index, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64)
+
+			mu.Lock()
+			requested = append(requested, fmt.Sprintf("%s:%d", dc, index))
+			current := indexes[dc]
+			mu.Unlock()
+			if index == current {
+				select {
+				case <-changeCh:
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+			mu.Lock()
+			var entries []*consulapi.ServiceEntry
+			for _, id := range instances[dc] {
+				entries = append(entries, &consulapi.ServiceEntry{
+					Node:    &consulapi.Node{Node: id, Datacenter: dc},
+					Service: &consulapi.AgentService{ID: id, Service: "web"},
+				})
+			}
+			w.Header().Set("X-Consul-Index", strconv.FormatUint(indexes[dc], 10))
+			mu.Unlock()
+			json.NewEncoder(w).Encode(entries)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv.URL
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err)
+	clients := &testClient{consul: client}
+
+	d, err := newServiceDatacentersQuery("web", []string{"dc=*"}, false)
+	require.NoError(t, err)
+	d.SetOptions(hcat.QueryOptions{WaitTime: 10 * time.Second})
+
+	ids := func(a interface{}) []string {
+		var ids []string
+		for _, s := range a.([]*dep.HealthService) {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	// The first fetch does not block
+	a, rm, err := d.Fetch(clients)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web-1", "web-2"}, ids(a))
+	assert.Equal(t, uint64(1), rm.LastIndex)
+
+	// The next fetch blocks on the index of each datacenter until the
+	// instances of dc2 change
+	type result struct {
+		data interface{}
+		rm   *dep.ResponseMetadata
+		err  error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		a, rm, err := d.Fetch(clients)
+		resultCh <- result{a, rm, err}
+	}()
+
+	select {
+	case <-resultCh:
+		t.Fatal("expected fetch to block")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	mu.Lock()
+	indexes["dc2"] = 21
+	instances["dc2"] = []string{"web-2", "web-3"}
+	mu.Unlock()
+	close(changeCh)
+
+	select {
+	case r := <-resultCh:
+		require.NoError(t, r.err)
+		assert.Equal(t, []string{"web-1", "web-2", "web-3"}, ids(r.data))
+		assert.Equal(t, uint64(2), r.rm.LastIndex)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected fetch to return after the instances changed")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, dcsRequests, "datacenters should be cached")
+	assert.Subset(t, requested, []string{"dc1:0", "dc2:0", "dc1:10", "dc2:20"})
+}
+
+func TestServiceDatacentersQuery_Fetch_SingleDatacenter(t *testing.T) {
+	t.Parallel()
+
+	// A query of a single datacenter is a blocking query with the index from
+	// the query options, and returns the index of the datacenter
+	requestCh := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/health/service/web" {
+			http.NotFound(w, r)
+			return
+		}
+		requestCh <- r.URL.RawQuery
+		w.Header().Set("X-Consul-Index", "43")
+		json.NewEncoder(w).Encode([]*consulapi.ServiceEntry{{
+			Node:    &consulapi.Node{Node: "n1", Datacenter: "dc1"},
+			Service: &consulapi.AgentService{ID: "web-1", Service: "web"},
+		}})
+	}))
+	defer srv.Close()
+
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = srv.URL
+	client, err := consulapi.NewClient(consulConfig)
+	require.NoError(t, err)
+
+	d, err := newServiceDatacentersQuery("web", nil, true)
+	require.NoError(t, err)
+	d.SetOptions(hcat.QueryOptions{WaitIndex: 42, WaitTime: time.Second})
+
+	a, rm, err := d.Fetch(&testClient{consul: client})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(43), rm.LastIndex)
+
+	services := a.([]*HealthService)
+	require.Len(t, services, 1)
+	assert.Equal(t, "web-1", services[0].ID)
+
+	query := <-requestCh
+	assert.Contains(t, query, "index=42")
+	assert.NotContains(t, query, "dc=")
+}
@@ -26,10 +26,13 @@ var (
 // services that have a name that match a given regex. It queries
 // the Catalog List Services API initially to get all the services
 // and then queries the Health API for each matching service.
-// It supports parameters filter, dc, ns, and node-meta on the
-// Health API query only.
+// It supports parameters filter, dc, ns, partition, peer, and node-meta on
+// the Health API query only. The dc parameter can be set multiple times
+// to query the services in each of the datacenters, or set to "*" to
+// query the services in all the datacenters known to Consul.
 //
 // Endpoints:
+//   /v1/catalog/datacenters (dc=*)
 //   /v1/catalog/services
 //   /v1/health/service/:service
 // Template: {{ servicesRegex regexp=<regex> <options> ... }}
@@ -37,7 +40,7 @@ func servicesRegexFunc(recall hcat.Recaller) interface{} {
 	return func(opts ...string) ([]*dep.HealthService, error) {
 		result := []*dep.HealthService{}
 
-		d, err := newServicesRegexQuery(opts)
+		d, err := newServicesRegexQuery(opts, false)
 		if err != nil {
 			return nil, err
 		}
@@ -50,6 +53,32 @@ func servicesRegexFunc(recall hcat.Recaller) interface{} {
 	}
 }
 
+// servicesRegexDetailsFunc returns information on registered Consul services
+// that have a name that match a given regex with the details of the service
+// registrations. It supports the same parameters as servicesRegexFunc.
+//
+// Endpoints:
+//   /v1/catalog/datacenters (dc=*)
+//   /v1/catalog/services
+//   /v1/health/service/:service
+// Template: {{ servicesRegexDetails regexp=<regex> <options> ... }}
+func servicesRegexDetailsFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) ([]*HealthService, error) {
+		result := []*HealthService{}
+
+		d, err := newServicesRegexQuery(opts, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*HealthService), nil
+		}
+
+		return result, nil
+	}
+}
+
 // servicesRegexQuery is the representation of the regex service
 // query from inside a template.
 type servicesRegexQuery struct {
@@ -58,19 +87,28 @@ type servicesRegexQuery struct {
 
 	regexp *regexp.Regexp
 
-	filter   string
-	dc       string
-	ns       string
-	nodeMeta map[string]string
-	opts     hcat.QueryOptions
+	filter    string
+	dcs       []string
+	ns        string
+	partition string
+	peer      string
+	nodeMeta  map[string]string
+	opts      hcat.QueryOptions
+
+	// details is whether the query returns the service details
+	details bool
+
+	// indexes is the index of the last response of each datacenter
+	indexes datacenterIndexes
 }
 
 // newServicesRegexQuery processes options in the format of
 // "key=value" (e.g. "regexp=^web.*") with the exception of filters.
 // Any option that is not a key/value pair is assumed to be a filter.
-func newServicesRegexQuery(opts []string) (*servicesRegexQuery, error) {
+func newServicesRegexQuery(opts []string, details bool) (*servicesRegexQuery, error) {
 	servicesRegexQuery := servicesRegexQuery{
-		stopCh: make(chan struct{}, 1),
+		stopCh:  make(chan struct{}, 1),
+		details: details,
 	}
 	var filters []string
 	for _, opt := range opts {
@@ -79,7 +117,8 @@ func newServicesRegexQuery(opts []string) (*servicesRegexQuery, error) {
 		}
 
 		// Parse query paramters, excluding the filter which is not set as a parameter
-		if queryParamOptRe.MatchString(opt) || strings.Contains(opt, "regexp=") {
+		if queryParamOptRe.MatchString(opt) || strings.Contains(opt, "regexp=") ||
+			isAllDatacentersOpt(opt) {
 			queryParam := strings.SplitN(opt, "=", 2)
 			query := strings.TrimSpace(queryParam[0])
 			value := strings.TrimSpace(queryParam[1])
@@ -92,11 +131,17 @@ func newServicesRegexQuery(opts []string) (*servicesRegexQuery, error) {
 				servicesRegexQuery.regexp = r
 				continue
 			case "dc", "datacenter":
-				servicesRegexQuery.dc = value
+				servicesRegexQuery.dcs = append(servicesRegexQuery.dcs, value)
 				continue
 			case "ns", "namespace":
 				servicesRegexQuery.ns = value
 				continue
+			case "partition":
+				servicesRegexQuery.partition = value
+				continue
+			case "peer":
+				servicesRegexQuery.peer = value
+				continue
 			case "node-meta":
 				if servicesRegexQuery.nodeMeta == nil {
 					servicesRegexQuery.nodeMeta = make(map[string]string)
@@ -135,7 +180,8 @@ func newServicesRegexQuery(opts []string) (*servicesRegexQuery, error) {
 }
 
 // Fetch queries the Consul API defined by the given client and returns a slice
-// of HealthService objects for services that match the set regex.
+// of HealthService objects for services that match the set regex. The objects
+// include the service details if the query is for the details.
 func (d *servicesRegexQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
 	select {
 	case <-d.stopCh:
@@ -143,18 +189,51 @@ func (d *servicesRegexQuery) Fetch(clients dep.Clients) (interface{}, *dep.Respo
 	default:
 	}
 
+	dcs, err := d.indexes.datacenters(clients, d.dcs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	// The services of each datacenter are merged. The index of the response is
+	// incremented when the index of any of the datacenters changes.
+	var entries []*consulapi.ServiceEntry
+	indexes := make(map[string]uint64, len(dcs))
+	rm := &dep.ResponseMetadata{}
+	for _, dc := range dcs {
+		dcEntries, qm, err := d.fetchDatacenter(clients, dc)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, d.String())
+		}
+		entries = append(entries, dcEntries...)
+		indexes[dc] = qm.LastIndex
+		if qm.LastContact > rm.LastContact {
+			rm.LastContact = qm.LastContact
+		}
+	}
+
+	rm.LastIndex = d.indexes.update(indexes)
+	return healthServicesResult(entries, d.details), rm, nil
+}
+
+// fetchDatacenter fetches the health service entries of the services matching
+// the regex in a datacenter
+func (d *servicesRegexQuery) fetchDatacenter(clients dep.Clients, dc string) (
+	[]*consulapi.ServiceEntry, *consulapi.QueryMeta, error) {
+
 	// Fetch all services via catalog services
 	hcatOpts := &hcat.QueryOptions{
-		Datacenter: d.dc,
+		Datacenter: dc,
 		Namespace:  d.ns,
 	}
 	opts := hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+	opts.Peer = d.peer
 	if len(d.nodeMeta) != 0 {
 		opts.NodeMeta = d.nodeMeta
 	}
 	catalog, qm, err := clients.Consul().Catalog().Services(opts)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, d.String())
+		return nil, nil, err
 	}
 
 	// Filter out only the services that match the regex
@@ -170,49 +249,17 @@ func (d *servicesRegexQuery) Fetch(clients dep.Clients) (interface{}, *dep.Respo
 	if d.filter != "" {
 		opts.Filter = d.filter
 	}
-	var services []*dep.HealthService
+	var entries []*consulapi.ServiceEntry
 	for _, s := range matchServices {
-		var entries []*consulapi.ServiceEntry
-		entries, qm, err = clients.Consul().Health().Service(s, "", false, opts)
+		var serviceEntries []*consulapi.ServiceEntry
+		serviceEntries, qm, err = clients.Consul().Health().Service(s, "", false, opts)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, d.String())
+			return nil, nil, err
 		}
-		for _, entry := range entries {
-			address := entry.Service.Address
-			if address == "" {
-				address = entry.Node.Address
-			}
-			services = append(services, &dep.HealthService{
-				Node:                entry.Node.Node,
-				NodeID:              entry.Node.ID,
-				Kind:                string(entry.Service.Kind),
-				NodeAddress:         entry.Node.Address,
-				NodeDatacenter:      entry.Node.Datacenter,
-				NodeTaggedAddresses: entry.Node.TaggedAddresses,
-				NodeMeta:            entry.Node.Meta,
-				ServiceMeta:         entry.Service.Meta,
-				Address:             address,
-				ID:                  entry.Service.ID,
-				Name:                entry.Service.Service,
-				Tags: dep.ServiceTags(
-					deepCopyAndSortTags(entry.Service.Tags)),
-				Status:    entry.Checks.AggregatedStatus(),
-				Checks:    entry.Checks,
-				Port:      entry.Service.Port,
-				Weights:   entry.Service.Weights,
-				Namespace: entry.Service.Namespace,
-			})
-		}
-	}
-
-	sort.Stable(ByNodeThenID(services))
-
-	rm := &dep.ResponseMetadata{
-		LastIndex:   qm.LastIndex,
-		LastContact: qm.LastContact,
+		entries = append(entries, serviceEntries...)
 	}
 
-	return services, rm, nil
+	return entries, qm, nil
 }
 
 // SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
@@ -226,12 +273,18 @@ func (d *servicesRegexQuery) String() string {
 	var opts []string
 	opts = append(opts, fmt.Sprintf("regexp=%s", d.regexp.String()))
 
-	if d.dc != "" {
-		opts = append(opts, fmt.Sprintf("dc=%s", d.dc))
+	for _, dc := range d.dcs {
+		opts = append(opts, fmt.Sprintf("dc=%s", dc))
 	}
 	if d.ns != "" {
 		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
 	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", d.peer))
+	}
 	for k, v := range d.nodeMeta {
 		opts = append(opts, fmt.Sprintf("node-meta=%s:%s", k, v))
 	}
@@ -240,8 +293,11 @@ func (d *servicesRegexQuery) String() string {
 	}
 
 	sort.Strings(opts)
-	return fmt.Sprintf("service.regex(%s)",
-		strings.Join(opts, "&"))
+	prefix := "service.regex"
+	if d.details {
+		prefix = "service.regex.details"
+	}
+	return fmt.Sprintf("%s(%s)", prefix, strings.Join(opts, "&"))
 }
 
 // Stop halts the query's fetch function.
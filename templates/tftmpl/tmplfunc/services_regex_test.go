@@ -44,13 +44,41 @@ func TestNewServicesRegexQuery(t *testing.T) {
 			[]string{"regexp=.*", "\"my-tag\" in Service.Tags", "node-meta=k:v", "ns=namespace", "dc=dc1"},
 			&servicesRegexQuery{
 				regexp:   regexp.MustCompile(".*"),
-				dc:       "dc1",
+				dcs:      []string{"dc1"},
 				ns:       "namespace",
 				nodeMeta: map[string]string{"k": "v"},
 				filter:   "\"my-tag\" in Service.Tags",
 			},
 			false,
 		},
+		{
+			"partition and peer",
+			[]string{"regexp=.*", "partition=ap1", "peer=peer1"},
+			&servicesRegexQuery{
+				regexp:    regexp.MustCompile(".*"),
+				partition: "ap1",
+				peer:      "peer1",
+			},
+			false,
+		},
+		{
+			"multiple datacenters",
+			[]string{"regexp=.*", "dc=dc1", "dc=dc2"},
+			&servicesRegexQuery{
+				regexp: regexp.MustCompile(".*"),
+				dcs:    []string{"dc1", "dc2"},
+			},
+			false,
+		},
+		{
+			"all datacenters",
+			[]string{"regexp=.*", "dc=*"},
+			&servicesRegexQuery{
+				regexp: regexp.MustCompile(".*"),
+				dcs:    []string{"*"},
+			},
+			false,
+		},
 		{
 			"invalid query",
 			[]string{"regexp=.*", "invalid=true"},
@@ -67,7 +95,7 @@ func TestNewServicesRegexQuery(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			act, err := newServicesRegexQuery(tc.opts)
+			act, err := newServicesRegexQuery(tc.opts, false)
 			if tc.err {
 				assert.Error(t, err)
 				return
@@ -101,17 +129,35 @@ func TestServicesRegexQuery_String(t *testing.T) {
 			[]string{"node-meta=k:v", "dc=dc1", "ns=namespace", "regexp=web", "\"my-tag\" in Service.Tags"},
 			`service.regex(dc=dc1&filter="my-tag" in Service.Tags&node-meta=k:v&ns=namespace&regexp=web)`,
 		},
+		{
+			"partition and peer",
+			[]string{"regexp=web", "peer=peer1", "partition=ap1"},
+			"service.regex(partition=ap1&peer=peer1&regexp=web)",
+		},
+		{
+			"multiple dcs",
+			[]string{"regexp=web", "dc=dc2", "dc=dc1"},
+			"service.regex(dc=dc1&dc=dc2&regexp=web)",
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			d, err := newServicesRegexQuery(tc.i)
+			d, err := newServicesRegexQuery(tc.i, false)
 			if err != nil {
 				t.Fatal(err)
 			}
 			assert.Equal(t, tc.exp, d.String())
 		})
 	}
+
+	t.Run("details", func(t *testing.T) {
+		d, err := newServicesRegexQuery([]string{"regexp=web", "dc=dc1"}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "service.regex.details(dc=dc1&regexp=web)", d.String())
+	})
 }
 
 func TestServicesRegexQuery_Fetch(t *testing.T) {
@@ -232,6 +278,24 @@ func TestServicesRegexQuery_Fetch(t *testing.T) {
 				dbSrv,
 			},
 		},
+		{
+			"multiple dcs",
+			[]string{"regexp=api", "dc=dc1", "dc=dc2"},
+			[]*dep.HealthService{
+				apiSrv,
+				apiWebSrv,
+				dbSrv,
+			},
+		},
+		{
+			"all dcs",
+			[]string{"regexp=api", "dc=*"},
+			[]*dep.HealthService{
+				apiSrv,
+				apiWebSrv,
+				dbSrv,
+			},
+		},
 		{
 			"filter",
 			[]string{"regexp=.*", "\"tag1\" in Service.Tags"},
@@ -260,7 +324,7 @@ func TestServicesRegexQuery_Fetch(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			d, err := newServicesRegexQuery(tc.i)
+			d, err := newServicesRegexQuery(tc.i, false)
 			require.NoError(t, err)
 
 			a, _, err := d.Fetch(&testClient{consul: client})
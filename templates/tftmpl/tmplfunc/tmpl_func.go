@@ -20,12 +20,31 @@ type ServicesMeta map[string]map[string]string
 func HCLMap(meta ServicesMeta) template.FuncMap {
 	tmplFuncs := hcat.FuncMapConsulV1()
 	tmplFuncs["catalogServicesRegistration"] = catalogServicesRegistrationFunc
+	tmplFuncs["catalogNodes"] = catalogNodesFunc
+	tmplFuncs["intentions"] = intentionsFunc
+	tmplFuncs["configEntries"] = configEntriesFunc
+	tmplFuncs["consulEvents"] = consulEventsFunc
+	tmplFuncs["latestConsulEvent"] = LatestConsulEvent
 	tmplFuncs["servicesRegex"] = servicesRegexFunc
+	tmplFuncs["serviceDatacenters"] = serviceDatacentersFunc
+	tmplFuncs["serviceDetails"] = serviceDetailsFunc
+	tmplFuncs["servicesRegexDetails"] = servicesRegexDetailsFunc
+	tmplFuncs["preparedQuery"] = preparedQueryFunc
+	tmplFuncs["preparedQueryDetails"] = preparedQueryDetailsFunc
+	tmplFuncs["localFiles"] = localFilesFunc
 	tmplFuncs["indent"] = tfunc.Helpers()["indent"]
 	tmplFuncs["subtract"] = tfunc.Math()["subtract"]
 	tmplFuncs["joinStrings"] = joinStringsFunc
+	tmplFuncs["includeStatuses"] = includeStatusesFunc
 	tmplFuncs["HCLService"] = hclServiceFunc(meta)
 	tmplFuncs["HCLServiceTags"] = hclServiceTagsFunc()
+	tmplFuncs["HCLNode"] = hclNodeFunc
+	tmplFuncs["HCLIntention"] = hclIntentionFunc
+	tmplFuncs["HCLConfigEntry"] = hclConfigEntryFunc
+	tmplFuncs["HCLConsulEvent"] = hclConsulEventFunc
+	tmplFuncs["HCLConsulKVValue"] = hclConsulKVValueFunc
+	tmplFuncs["HCLVaultSecret"] = hclVaultSecretFunc
+	tmplFuncs["HCLLocalFile"] = hclLocalFileFunc
 	return tmplFuncs
 }
 
@@ -47,6 +66,54 @@ func joinStringsFunc(sep string, values ...string) string {
 	return strings.Join(cleaned, sep)
 }
 
+// includeStatusesFunc is the template function of IncludeStatuses, which also
+// accepts service instances with the service details.
+func includeStatusesFunc(services interface{}, statuses ...string) (interface{}, error) {
+	switch s := services.(type) {
+	case []*dep.HealthService:
+		return IncludeStatuses(s, statuses...), nil
+	case []*HealthService:
+		if len(statuses) == 0 {
+			return s, nil
+		}
+		included := make([]*HealthService, 0, len(s))
+		for _, service := range s {
+			if hasStatus(service.HealthService, statuses) {
+				included = append(included, service)
+			}
+		}
+		return included, nil
+	}
+	return nil, fmt.Errorf("includeStatuses: unsupported services type %T", services)
+}
+
+// IncludeStatuses returns the service instances with one of the aggregated
+// health statuses, e.g. "passing" or "warning". All instances are returned if
+// no statuses are given.
+func IncludeStatuses(services []*dep.HealthService, statuses ...string) []*dep.HealthService {
+	if len(statuses) == 0 {
+		return services
+	}
+
+	included := make([]*dep.HealthService, 0, len(services))
+	for _, s := range services {
+		if hasStatus(s, statuses) {
+			included = append(included, s)
+		}
+	}
+	return included
+}
+
+// hasStatus returns whether the service instance has one of the statuses
+func hasStatus(s *dep.HealthService, statuses []string) bool {
+	for _, status := range statuses {
+		if s.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
 // hclServiceTagsFunc is a wrapper of the template function to marshal Consul
 // catalog service tag information into HCL. It returns the list of tags with
 // formatted like: "["tag1", "tag2"]". It returns an empty array string "[]"
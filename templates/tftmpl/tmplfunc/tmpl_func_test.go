@@ -3,6 +3,7 @@ package tmplfunc
 import (
 	"testing"
 
+	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,3 +39,65 @@ func TestJoinStringsFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestIncludeStatuses(t *testing.T) {
+	passing := &dep.HealthService{ID: "web-1", Status: "passing"}
+	warning := &dep.HealthService{ID: "web-2", Status: "warning"}
+	critical := &dep.HealthService{ID: "web-3", Status: "critical"}
+	services := []*dep.HealthService{passing, warning, critical}
+
+	testCases := []struct {
+		name     string
+		statuses []string
+		expected []*dep.HealthService
+	}{
+		{
+			"no statuses",
+			[]string{},
+			services,
+		}, {
+			"single status",
+			[]string{"critical"},
+			[]*dep.HealthService{critical},
+		}, {
+			"multiple statuses",
+			[]string{"passing", "warning"},
+			[]*dep.HealthService{passing, warning},
+		}, {
+			"none included",
+			[]string{"maintenance"},
+			[]*dep.HealthService{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := IncludeStatuses(services, tc.statuses...)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestIncludeStatusesFunc(t *testing.T) {
+	passing := &dep.HealthService{ID: "web-1", Status: "passing"}
+	critical := &dep.HealthService{ID: "web-2", Status: "critical"}
+
+	t.Run("health services", func(t *testing.T) {
+		actual, err := includeStatusesFunc(
+			[]*dep.HealthService{passing, critical}, "critical")
+		assert.NoError(t, err)
+		assert.Equal(t, []*dep.HealthService{critical}, actual)
+	})
+
+	t.Run("health services with details", func(t *testing.T) {
+		services := withDetails([]*dep.HealthService{passing, critical})
+		actual, err := includeStatusesFunc(services, "passing")
+		assert.NoError(t, err)
+		assert.Equal(t, []*HealthService{services[0]}, actual)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := includeStatusesFunc([]string{"web"}, "passing")
+		assert.Error(t, err)
+	})
+}
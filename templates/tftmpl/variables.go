@@ -17,6 +17,16 @@ import (
 // for variables
 var tfVersionSensitive = goVersion.Must(goVersion.NewSemver("0.14.0"))
 
+const (
+	// ServicesProtocolV0 is the default version of the service definition
+	// protocol of the services variable
+	ServicesProtocolV0 = 0
+
+	// ServicesProtocolV1 is the version of the service definition protocol
+	// that includes the service details
+	ServicesProtocolV1 = 1
+)
+
 // VariableServices is versioned to track compatibility with the generated
 // root module with modules.
 var VariableServices = []byte(`
@@ -48,6 +58,89 @@ variable "services" {
 }
 `)
 
+// VariableServicesV1 is the services variable for the service definition
+// protocol v1, which adds the admin partition, cluster peer, health checks,
+// weights, tagged addresses, and proxy and connect information to the services
+// of the protocol v0.
+var VariableServicesV1 = []byte(`
+# Service definition protocol v1
+variable "services" {
+  description = "Consul services monitored by Consul Terraform Sync"
+  type = map(
+    object({
+      id        = string
+      name      = string
+      kind      = string
+      address   = string
+      port      = number
+      meta      = map(string)
+      tags      = list(string)
+      namespace = string
+      status    = string
+
+      node                  = string
+      node_id               = string
+      node_address          = string
+      node_datacenter       = string
+      node_tagged_addresses = map(string)
+      node_meta             = map(string)
+
+      cts_user_defined_meta = map(string)
+
+      partition = string
+      peer      = string
+
+      checks = list(object({
+        node         = string
+        check_id     = string
+        name         = string
+        status       = string
+        notes        = string
+        output       = string
+        service_id   = string
+        service_name = string
+        type         = string
+        namespace    = string
+      }))
+      weights = object({
+        passing = number
+        warning = number
+      })
+      tagged_addresses = map(object({
+        address = string
+        port    = number
+      }))
+      proxy = object({
+        destination_service_name = string
+        destination_service_id   = string
+        local_service_address    = string
+        local_service_port       = number
+        upstreams = list(object({
+          destination_type      = string
+          destination_namespace = string
+          destination_name      = string
+          datacenter            = string
+          local_bind_address    = string
+          local_bind_port       = number
+        }))
+      })
+      connect = object({
+        native = bool
+      })
+    })
+  )
+}
+`)
+
+// variableServices returns the services variable for the version of the
+// service definition protocol
+func variableServices(protocol int) []byte {
+	if protocol >= ServicesProtocolV1 {
+		return VariableServicesV1
+	}
+	return VariableServices
+}
+
 // newVariablesTF writes variable definitions to a file. This includes the
 // required services variable and generated provider variables based on CTS
 // user configuration for the task.
@@ -57,7 +150,7 @@ func newVariablesTF(w io.Writer, filename string, input *RootModuleInputData) er
 		return err
 	}
 
-	if _, err = w.Write(VariableServices); err != nil {
+	if _, err = w.Write(variableServices(input.ServicesProtocol)); err != nil {
 		return err
 	}
 
@@ -67,9 +160,9 @@ func newVariablesTF(w io.Writer, filename string, input *RootModuleInputData) er
 		}
 	}
 
-	if input.SourceInput != nil {
-		if input.SourceInput.SourceIncludesVariable() {
-			if err = input.SourceInput.appendVariable(w); err != nil {
+	for _, si := range input.SourceInputs {
+		if si.SourceIncludesVariable() {
+			if err = si.appendVariable(w); err != nil {
 				return err
 			}
 		} else {
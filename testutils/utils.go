@@ -147,6 +147,15 @@ func (*TestingTB) Logf(string, ...interface{}) {}
 // Name implements Consul's testutil.TestingTB's Name()
 func (*TestingTB) Name() string { return "TestingTB" }
 
+// Fatalf implements Consul's testutil.TestingTB's Fatalf(). There is no test
+// to fail, so it panics instead.
+func (*TestingTB) Fatalf(format string, args ...interface{}) {
+	panic(fmt.Sprintf(format, args...))
+}
+
+// Helper implements Consul's testutil.TestingTB's Helper()
+func (*TestingTB) Helper() {}
+
 // Cleanup implements Consul's testutil.TestingTB's Cleanup()
 func (t *TestingTB) Cleanup(f func()) {
 	t.Lock()